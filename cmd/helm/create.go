@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
@@ -52,6 +53,12 @@ something like this:
 do not exist, Helm will attempt to create them as it goes. If the given
 destination exists and there are files in that directory, conflicting files
 will be overwritten, but other files will be left alone.
+
+If '--schema' is given, the chart's values.yaml is generated from the provided
+JSON schema. Schema defaults are used where present, and example values of the
+declared types are used otherwise. The schema is copied into the chart as
+values.schema.json. Scaffold templates that read values the schema does not
+describe, such as the service and ingress, are left out of the chart.
 `
 
 type createCmd struct {
@@ -59,6 +66,7 @@ type createCmd struct {
 	name    string
 	out     io.Writer
 	starter string
+	schema  string
 }
 
 func newCreateCmd(out io.Writer) *cobra.Command {
@@ -79,6 +87,7 @@ func newCreateCmd(out io.Writer) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&cc.starter, "starter", "p", "", "the named Helm starter scaffold")
+	cmd.Flags().StringVar(&cc.schema, "schema", "", "generate values.yaml from the given values.schema.json file")
 	return cmd
 }
 
@@ -100,6 +109,15 @@ func (c *createCmd) run() error {
 		return chartutil.CreateFrom(cfile, filepath.Dir(c.name), lstarter)
 	}
 
+	if c.schema != "" {
+		schema, err := ioutil.ReadFile(c.schema)
+		if err != nil {
+			return err
+		}
+		_, err = chartutil.CreateFromSchema(cfile, filepath.Dir(c.name), schema)
+		return err
+	}
+
 	_, err := chartutil.Create(cfile, filepath.Dir(c.name))
 	return err
 }
@@ -32,6 +32,22 @@ It removes all of the resources associated with the last release of the chart.
 
 Use the '--dry-run' flag to see which releases will be deleted without actually
 deleting them.
+
+Use '--cascade' to choose how dependents of the deleted resources are removed:
+'Foreground', 'Background' or 'Orphan'. Orphan leaves dependents, such as the
+pods of a StatefulSet, running. '--delete-grace-period' sets the number of
+seconds each resource is given to terminate; set it to 1 for immediate shutdown.
+
+Pre-delete hooks run before any resource is deleted. Hook Jobs are always
+awaited; with '--wait', hook Pods are awaited too, as are post-delete hooks.
+If a pre-delete hook fails the release is not deleted, unless
+'--continue-on-hook-failure' is set.
+
+Use '--selector' to also delete every release whose last revision is stored
+with the given labels, for example '--selector STATUS=FAILED'. The labels are
+NAME, STATUS and VERSION, as well as COMMIT when Tiller records it. A summary
+of the deleted releases is printed. Without a release name or a selector
+nothing is deleted.
 `
 
 type deleteCmd struct {
@@ -40,6 +56,11 @@ type deleteCmd struct {
 	disableHooks bool
 	purge        bool
 	timeout      int64
+	cascade      string
+	gracePeriod  int64
+	wait         bool
+	continueHook bool
+	selector     string
 
 	out    io.Writer
 	client helm.Interface
@@ -59,10 +80,13 @@ func newDeleteCmd(c helm.Interface, out io.Writer) *cobra.Command {
 		Long:       deleteDesc,
 		PreRunE:    setupConnection,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) == 0 {
-				return errors.New("command 'delete' requires a release name")
+			if len(args) == 0 && del.selector == "" {
+				return errors.New("command 'delete' requires a release name or --selector")
 			}
 			del.client = ensureHelmClient(del.client)
+			if del.selector != "" {
+				return del.runSelector(args)
+			}
 
 			for i := 0; i < len(args); i++ {
 				del.name = args[i]
@@ -80,22 +104,70 @@ func newDeleteCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&del.dryRun, "dry-run", false, "simulate a delete")
 	f.BoolVar(&del.disableHooks, "no-hooks", false, "prevent hooks from running during deletion")
 	f.BoolVar(&del.purge, "purge", false, "remove the release from the store and make its name free for later use")
-	f.Int64Var(&del.timeout, "timeout", 300, "time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
+	f.Var(newTimeoutValue(defaultTimeout, &del.timeout), "timeout", timeoutUsage)
+	f.StringVar(&del.cascade, "cascade", "", "propagation policy for dependents of deleted resources: Foreground, Background or Orphan")
+	f.Int64Var(&del.gracePeriod, "delete-grace-period", 0, "seconds each resource is given to terminate. 0 uses the default of each resource")
+	f.BoolVar(&del.wait, "wait", false, "if set, will wait until pre-delete and post-delete hook Pods and Jobs have completed. It will wait for as long as --timeout")
+	f.BoolVar(&del.continueHook, "continue-on-hook-failure", false, "delete the release even if a pre-delete hook fails")
+	f.StringVarP(&del.selector, "selector", "l", "", "also delete the releases whose storage labels match, e.g. STATUS=FAILED,VERSION=1")
 
 	return cmd
 }
 
-func (d *deleteCmd) run() error {
-	opts := []helm.DeleteOption{
+func (d *deleteCmd) options() []helm.DeleteOption {
+	return []helm.DeleteOption{
 		helm.DeleteDryRun(d.dryRun),
 		helm.DeleteDisableHooks(d.disableHooks),
 		helm.DeletePurge(d.purge),
 		helm.DeleteTimeout(d.timeout),
+		helm.DeleteCascade(d.cascade),
+		helm.DeleteGracePeriod(d.gracePeriod),
+		helm.DeleteWait(d.wait),
+		helm.DeleteContinueOnHookFailure(d.continueHook),
 	}
-	res, err := d.client.DeleteRelease(d.name, opts...)
+}
+
+func (d *deleteCmd) run() error {
+	res, err := d.client.DeleteRelease(d.name, d.options()...)
 	if res != nil && res.Info != "" {
 		fmt.Fprintln(d.out, res.Info)
 	}
 
 	return prettyError(err)
 }
+
+// runSelector deletes the named releases and those matching the selector in
+// one call, and prints a summary of the result.
+func (d *deleteCmd) runSelector(names []string) error {
+	opts := append(d.options(), helm.DeleteReleasesSelector(d.selector))
+	res, err := d.client.DeleteReleases(names, opts...)
+	if err != nil {
+		return prettyError(err)
+	}
+	if len(res.Results) == 0 {
+		fmt.Fprintf(d.out, "no releases match selector %q\n", d.selector)
+		return nil
+	}
+
+	action := "deleted"
+	if d.dryRun {
+		action = "would be deleted"
+	}
+	var failed int
+	for _, r := range res.Results {
+		if r.Error != "" {
+			failed++
+			fmt.Fprintf(d.out, "release \"%s\" failed: %s\n", r.Name, r.Error)
+			continue
+		}
+		fmt.Fprintf(d.out, "release \"%s\" %s\n", r.Name, action)
+		if r.Response != nil && r.Response.Info != "" {
+			fmt.Fprintln(d.out, r.Response.Info)
+		}
+	}
+	fmt.Fprintf(d.out, "%d of %d releases %s\n", len(res.Results)-failed, len(res.Results), action)
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d releases", failed)
+	}
+	return nil
+}
@@ -61,11 +61,36 @@ func TestDelete(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
 		},
+		{
+			name:     "delete by selector",
+			args:     []string{},
+			flags:    []string{"--selector", "STATUS=FAILED"},
+			expected: "release \"ci-1\" deleted\nrelease \"ci-2\" deleted\n2 of 2 releases deleted\n",
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "ci-1", StatusCode: release.Status_FAILED}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "ci-2", StatusCode: release.Status_FAILED}),
+			},
+		},
+		{
+			name:     "delete by selector without match",
+			args:     []string{},
+			flags:    []string{"-l", "STATUS=FAILED"},
+			expected: "no releases match selector \"STATUS=FAILED\"\n",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
 		{
 			name: "delete without release",
 			args: []string{},
 			err:  true,
 		},
+		{
+			name:  "delete without release or selector",
+			args:  []string{},
+			flags: []string{"--purge"},
+			err:   true,
+			rels:  []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
 	}
 	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
 		return newDeleteCmd(c, out)
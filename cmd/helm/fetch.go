@@ -54,8 +54,10 @@ type fetchCmd struct {
 	repoURL  string
 
 	verify      bool
+	noCache     bool
 	verifyLater bool
 	keyring     string
+	logURL      string
 
 	certFile string
 	keyFile  string
@@ -98,8 +100,10 @@ func newFetchCmd(out io.Writer) *cobra.Command {
 	f.StringVar(&fch.untardir, "untardir", ".", "if untar is specified, this flag specifies the name of the directory into which the chart is expanded")
 	f.BoolVar(&fch.verify, "verify", false, "verify the package against its signature")
 	f.BoolVar(&fch.verifyLater, "prov", false, "fetch the provenance file, but don't perform verification")
+	f.BoolVar(&fch.noCache, "no-cache", false, "download the chart even if it is in the chart cache")
 	f.StringVar(&fch.version, "version", "", "specific version of a chart. Without this, the latest version is fetched")
 	f.StringVar(&fch.keyring, "keyring", defaultKeyring(), "keyring containing public keys")
+	f.StringVar(&fch.logURL, "transparency-log", "", "URL of a transparency log that must record the signature of the chart. Only used with --verify")
 	f.StringVarP(&fch.destdir, "destination", "d", ".", "location to write the chart. If this and tardir are specified, tardir is appended to this")
 	f.StringVar(&fch.repoURL, "repo", "", "chart repository url where to locate the requested chart")
 	f.StringVar(&fch.certFile, "cert-file", "", "identify HTTPS client using this SSL certificate file")
@@ -117,6 +121,9 @@ func (f *fetchCmd) run() error {
 		Keyring:  f.keyring,
 		Verify:   downloader.VerifyNever,
 		Getters:  getter.All(settings),
+		NoCache:  f.noCache,
+
+		TransparencyLog: f.logURL,
 	}
 
 	if f.verify {
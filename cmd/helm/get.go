@@ -75,6 +75,9 @@ func newGetCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	cmd.AddCommand(addFlagsTLS(newGetValuesCmd(nil, out)))
 	cmd.AddCommand(addFlagsTLS(newGetManifestCmd(nil, out)))
 	cmd.AddCommand(addFlagsTLS(newGetHooksCmd(nil, out)))
+	cmd.AddCommand(addFlagsTLS(newGetValuesDiffCmd(nil, out)))
+	cmd.AddCommand(addFlagsTLS(newGetRollbackDiffCmd(nil, out)))
+	cmd.AddCommand(addFlagsTLS(newGetAllCmd(nil, out)))
 
 	return cmd
 }
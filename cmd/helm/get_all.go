@@ -0,0 +1,124 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+const getAllHelp = `
+This command downloads everything known about a named release in a single
+request to Tiller: its metadata, the user-supplied and computed values, the
+hooks, the manifest and the notes.
+
+The manifest, hooks and notes can be large. Use --skip-manifest, --skip-hooks
+and --skip-notes to leave them out.
+`
+
+var getAllTemplate = `NAME: {{.Release.Name}}
+NAMESPACE: {{.Release.Namespace}}
+REVISION: {{.Release.Version}}
+STATUS: {{.Release.Info.Status.Code}}
+RELEASED: {{.ReleaseDate}}
+CHART: {{.Release.Chart.Name}}-{{.Release.Chart.Version}}
+USER-SUPPLIED VALUES:
+{{.Release.Config.Raw}}
+COMPUTED VALUES:
+{{.Release.ComputedValues.Raw}}
+{{- if not .SkipHooks}}
+HOOKS:
+{{- range .Release.Hooks }}
+---
+# {{.Name}}
+{{.Manifest}}
+{{- end }}
+{{- end }}
+{{- if not .SkipManifest}}
+MANIFEST:
+{{.Release.Manifest}}
+{{- end }}
+{{- if not .SkipNotes}}
+NOTES:
+{{.Release.Notes}}
+{{- end }}
+`
+
+type getAllCmd struct {
+	release      string
+	out          io.Writer
+	client       helm.Interface
+	version      int32
+	skipManifest bool
+	skipHooks    bool
+	skipNotes    bool
+}
+
+func newGetAllCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	get := &getAllCmd{
+		out:    out,
+		client: client,
+	}
+	cmd := &cobra.Command{
+		Use:     "all [flags] RELEASE_NAME",
+		Short:   "download all information for a named release",
+		Long:    getAllHelp,
+		PreRunE: setupConnection,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errReleaseRequired
+			}
+			get.release = args[0]
+			get.client = ensureHelmClient(get.client)
+			return get.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.Int32Var(&get.version, "revision", 0, "get the named release with revision")
+	f.BoolVar(&get.skipManifest, "skip-manifest", false, "do not download the manifest")
+	f.BoolVar(&get.skipHooks, "skip-hooks", false, "do not download the hooks")
+	f.BoolVar(&get.skipNotes, "skip-notes", false, "do not download the notes")
+	return cmd
+}
+
+// run implements 'helm get all'
+func (g *getAllCmd) run() error {
+	res, err := g.client.ReleaseAll(g.release,
+		helm.AllReleaseVersion(g.version),
+		helm.AllSkipManifest(g.skipManifest),
+		helm.AllSkipHooks(g.skipHooks),
+		helm.AllSkipNotes(g.skipNotes),
+	)
+	if err != nil {
+		return prettyError(err)
+	}
+
+	data := map[string]interface{}{
+		"Release":      res,
+		"ReleaseDate":  timeconv.Format(res.Info.LastDeployed, time.ANSIC),
+		"SkipManifest": g.skipManifest,
+		"SkipHooks":    g.skipHooks,
+		"SkipNotes":    g.skipNotes,
+	}
+	return tpl(getAllTemplate, data, g.out)
+}
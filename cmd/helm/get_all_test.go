@@ -0,0 +1,70 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestGetAllCmd(t *testing.T) {
+	rels := []*release.Release{
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"}),
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide", Version: 2, Namespace: "maps"}),
+	}
+	rels[0].Info.Status.Notes = "Take the first left."
+
+	tests := []releaseCase{
+		{
+			name: "get all with a release",
+			args: []string{"thomas-guide"},
+			expected: "NAME: thomas-guide\nNAMESPACE: default\nREVISION: 1\nSTATUS: DEPLOYED\nRELEASED: (.*)\nCHART: foo-0.1.0-beta.1\n" +
+				"USER-SUPPLIED VALUES:\nname: \"value\"\nCOMPUTED VALUES:\nname: value\n\n" +
+				"HOOKS:\n---\n# pre-install-hook\n" + helm.MockHookTemplate + "\n" +
+				"MANIFEST:\n" + helm.MockManifest + "\nNOTES:\nTake the first left.\n$",
+			rels: rels,
+		},
+		{
+			name:     "get all with a revision",
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--revision", "2"},
+			expected: "NAMESPACE: maps\nREVISION: 2\n",
+			rels:     rels,
+		},
+		{
+			name:     "get all without manifest, hooks and notes",
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--skip-manifest", "--skip-hooks", "--skip-notes"},
+			expected: "COMPUTED VALUES:\nname: value\n\n$",
+			rels:     rels,
+		},
+		{
+			name: "get all requires release name arg",
+			err:  true,
+		},
+	}
+
+	cmd := func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newGetAllCmd(c, out)
+	}
+	runReleaseCases(t, tests, cmd)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/releaseutil"
 )
 
 var getManifestHelp = `
@@ -31,6 +32,10 @@ This command fetches the generated manifest for a given release.
 A manifest is a YAML-encoded representation of the Kubernetes resources that
 were generated from this release's chart(s). If a chart is dependent on other
 charts, those resources will also be included in the manifest.
+
+Use '--revision' to fetch the manifest of an earlier revision of the release,
+and '--kind' to print only the resources of the given kinds. Nothing is printed
+if no resource matches.
 `
 
 type getManifestCmd struct {
@@ -38,6 +43,7 @@ type getManifestCmd struct {
 	out     io.Writer
 	client  helm.Interface
 	version int32
+	kinds   []string
 }
 
 func newGetManifestCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -63,6 +69,7 @@ func newGetManifestCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	}
 
 	cmd.Flags().Int32Var(&get.version, "revision", 0, "get the named release with revision")
+	cmd.Flags().StringSliceVar(&get.kinds, "kind", []string{}, "only print the resources of the given kind (can specify multiple or separate values with commas: Deployment,Service)")
 	return cmd
 }
 
@@ -72,6 +79,12 @@ func (g *getManifestCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
-	fmt.Fprintln(g.out, res.Release.Manifest)
+	if len(g.kinds) == 0 {
+		fmt.Fprintln(g.out, res.Release.Manifest)
+		return nil
+	}
+	for _, m := range releaseutil.FilterManifestsByKind(res.Release.Manifest, g.kinds...) {
+		fmt.Fprintf(g.out, "---\n%s\n", m.Content)
+	}
 	return nil
 }
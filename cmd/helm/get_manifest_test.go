@@ -26,7 +26,34 @@ import (
 	"k8s.io/helm/pkg/proto/hapi/release"
 )
 
+func manifestReleaseMock(version int32, manifest string) *release.Release {
+	rel := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno", Version: version})
+	rel.Manifest = manifest
+	return rel
+}
+
 func TestGetManifest(t *testing.T) {
+	v1 := manifestReleaseMock(1, `---
+# Source: juno/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: juno-v1
+---
+# Source: juno/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: juno
+`)
+	v2 := manifestReleaseMock(2, `---
+# Source: juno/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: juno-v2
+`)
+
 	tests := []releaseCase{
 		{
 			name:     "get manifest with release",
@@ -35,6 +62,27 @@ func TestGetManifest(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"}),
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"})},
 		},
+		{
+			name:     "get manifest of a revision filtered by kind",
+			args:     []string{"juno"},
+			flags:    []string{"--revision", "1", "--kind", "deployment"},
+			expected: "^---\n# Source: juno/templates/deployment.yaml\napiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: juno-v1\n$",
+			rels:     []*release.Release{v2, v1},
+		},
+		{
+			name:     "get manifest filtered by several kinds",
+			args:     []string{"juno"},
+			flags:    []string{"--revision", "1", "--kind", "Service,Deployment"},
+			expected: "^---\n# Source: juno/templates/deployment.yaml\n(?s:.*)  name: juno-v1\n---\n# Source: juno/templates/service.yaml\n(?s:.*)  name: juno\n$",
+			rels:     []*release.Release{v2, v1},
+		},
+		{
+			name:     "get manifest with no resource of the kind",
+			args:     []string{"juno"},
+			flags:    []string{"--revision", "2", "--kind", "Service"},
+			expected: "^$",
+			rels:     []*release.Release{v2, v1},
+		},
 		{
 			name: "get manifest without args",
 			args: []string{},
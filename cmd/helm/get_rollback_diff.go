@@ -0,0 +1,98 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+var getRollbackDiffHelp = `
+This command shows what rolling back a release to a previous revision would
+change, without rolling it back.
+
+The manifest stored for the given revision is compared with the manifest of
+the current revision of the release. Each changed resource is printed with its
+kind and name, followed by a line diff. Resources that the rollback would
+create are marked as added, and resources it would delete as removed.
+`
+
+type getRollbackDiffCmd struct {
+	release  string
+	revision int32
+	out      io.Writer
+	client   helm.Interface
+}
+
+func newGetRollbackDiffCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	diff := &getRollbackDiffCmd{
+		out:    out,
+		client: client,
+	}
+	cmd := &cobra.Command{
+		Use:     "rollback-diff [flags] RELEASE_NAME REVISION",
+		Short:   "preview the changes of rolling back a named release to a previous revision",
+		Long:    getRollbackDiffHelp,
+		PreRunE: setupConnection,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "release name", "revision number"); err != nil {
+				return err
+			}
+			diff.release = args[0]
+			v64, err := strconv.ParseInt(args[1], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid revision number '%q': %s", args[1], err)
+			}
+			diff.revision = int32(v64)
+			diff.client = ensureHelmClient(diff.client)
+			return diff.run()
+		},
+	}
+	return cmd
+}
+
+// run implements 'helm get rollback-diff'
+func (d *getRollbackDiffCmd) run() error {
+	current, err := d.client.ReleaseContent(d.release)
+	if err != nil {
+		return prettyError(err)
+	}
+	target, err := d.client.ReleaseContent(d.release, helm.ContentReleaseVersion(d.revision))
+	if err != nil {
+		return prettyError(err)
+	}
+	printManifestDiffs(d.out, current.Release.Manifest, target.Release.Manifest)
+	return nil
+}
+
+// printManifestDiffs writes the per-resource differences between two manifests.
+func printManifestDiffs(out io.Writer, from, to string) {
+	diffs := releaseutil.DiffManifests(from, to)
+	if len(diffs) == 0 {
+		fmt.Fprintln(out, "No changes.")
+		return
+	}
+	for _, d := range diffs {
+		fmt.Fprintf(out, "%s (%s)\n%s", d.Name, d.Type, d.Diff)
+	}
+}
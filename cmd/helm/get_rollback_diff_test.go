@@ -0,0 +1,73 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestGetRollbackDiffCmd(t *testing.T) {
+	const service = "apiVersion: v1\nkind: Service\nmetadata:\n  name: web\n"
+	const worker = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: worker\n"
+
+	releaseWithManifest := func(version int32, manifest string) *release.Release {
+		rel := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-honey", Version: version})
+		rel.Manifest = manifest
+		return rel
+	}
+	// The current revision is listed first.
+	rels := []*release.Release{
+		releaseWithManifest(3, "---\n"+service+"---\n"+worker),
+		releaseWithManifest(2, "---\n"+service),
+	}
+
+	tests := []releaseCase{
+		{
+			name:     "rollback removes a resource added in a later revision",
+			args:     []string{"funny-honey", "2"},
+			expected: "ConfigMap/worker \\(removed\\)\n-apiVersion: v1\n-kind: ConfigMap\n-metadata:\n-  name: worker\n$",
+			rels:     rels,
+		},
+		{
+			name:     "rollback to the current revision",
+			args:     []string{"funny-honey", "3"},
+			expected: "No changes.",
+			rels:     rels,
+		},
+		{
+			name: "rollback requires a revision",
+			args: []string{"funny-honey"},
+			err:  true,
+		},
+		{
+			name: "rollback to unknown revision",
+			args: []string{"funny-honey", "1"},
+			rels: rels,
+			err:  true,
+		},
+	}
+	cmd := func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newGetRollbackDiffCmd(c, out)
+	}
+	runReleaseCases(t, tests, cmd)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+var getValuesDiffHelp = `
+This command shows how the values of a release changed between two revisions.
+
+Each changed value is printed with its path. Added values are prefixed with
+'+', removed values with '-', and changed values with '~'.
+
+By default, the user-supplied values are compared. Use '--all' to compare the
+computed values, including the chart's defaults.
+`
+
+type getValuesDiffCmd struct {
+	release   string
+	revisions [2]int32
+	allValues bool
+	out       io.Writer
+	client    helm.Interface
+}
+
+func newGetValuesDiffCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	diff := &getValuesDiffCmd{
+		out:    out,
+		client: client,
+	}
+	cmd := &cobra.Command{
+		Use:     "values-diff [flags] RELEASE_NAME REVISION1 REVISION2",
+		Short:   "show how the values of a named release changed between two revisions",
+		Long:    getValuesDiffHelp,
+		PreRunE: setupConnection,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "release name", "revision number", "revision number"); err != nil {
+				return err
+			}
+			diff.release = args[0]
+			for i, arg := range args[1:] {
+				v64, err := strconv.ParseInt(arg, 10, 32)
+				if err != nil {
+					return fmt.Errorf("invalid revision number '%q': %s", arg, err)
+				}
+				diff.revisions[i] = int32(v64)
+			}
+			diff.client = ensureHelmClient(diff.client)
+			return diff.run()
+		},
+	}
+
+	cmd.Flags().BoolVarP(&diff.allValues, "all", "a", false, "compare all (computed) values")
+	return cmd
+}
+
+// run implements 'helm get values-diff'
+func (d *getValuesDiffCmd) run() error {
+	var vals [2]chartutil.Values
+	for i, revision := range d.revisions {
+		res, err := d.client.ReleaseContent(d.release, helm.ContentReleaseVersion(revision))
+		if err != nil {
+			return prettyError(err)
+		}
+		if vals[i], err = d.values(res.Release); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range chartutil.DiffValues(vals[0], vals[1]) {
+		switch c.Type {
+		case chartutil.ValueAdded:
+			fmt.Fprintf(d.out, "+ %s: %s\n", c.Path, formatValue(c.New))
+		case chartutil.ValueRemoved:
+			fmt.Fprintf(d.out, "- %s: %s\n", c.Path, formatValue(c.Old))
+		case chartutil.ValueChanged:
+			fmt.Fprintf(d.out, "~ %s: %s -> %s\n", c.Path, formatValue(c.Old), formatValue(c.New))
+		}
+	}
+	return nil
+}
+
+// values returns the values of a release revision.
+func (d *getValuesDiffCmd) values(rel *release.Release) (chartutil.Values, error) {
+	if d.allValues {
+		return chartutil.CoalesceValues(rel.Chart, rel.Config)
+	}
+	return chartutil.ReadValues([]byte(rel.Config.GetRaw()))
+}
+
+// formatValue renders a value on a single line.
+func formatValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestGetValuesDiffCmd(t *testing.T) {
+	releaseWithValues := func(version int32, raw string) *release.Release {
+		rel := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide", Version: version})
+		rel.Config = &chart.Config{Raw: raw}
+		return rel
+	}
+	rels := []*release.Release{
+		releaseWithValues(4, "image:\n  tag: \"1.13\"\ndebug: true\n"),
+		releaseWithValues(5, "image:\n  tag: \"1.14\"\nreplicaCount: 3\n"),
+	}
+
+	tests := []releaseCase{
+		{
+			name:     "diff values between revisions",
+			args:     []string{"thomas-guide", "4", "5"},
+			expected: "- debug: true\n~ image.tag: \"1.13\" -> \"1.14\"\n\\+ replicaCount: 3\n",
+			rels:     rels,
+		},
+		{
+			name: "diff values requires two revisions",
+			args: []string{"thomas-guide", "4"},
+			err:  true,
+		},
+		{
+			name: "diff values with unknown revision",
+			args: []string{"thomas-guide", "4", "6"},
+			rels: rels,
+			err:  true,
+		},
+	}
+	cmd := func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newGetValuesDiffCmd(c, out)
+	}
+	runReleaseCases(t, tests, cmd)
+}
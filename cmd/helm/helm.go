@@ -42,6 +42,7 @@ var (
 	tlsCaCertFile string // path to TLS CA certificate file
 	tlsCertFile   string // path to TLS certificate file
 	tlsKeyFile    string // path to TLS key file
+	tlsServerName string // server name used to verify the hostname on the returned certificates
 	tlsVerify     bool   // enable TLS and verify remote certificates
 	tlsEnable     bool   // enable TLS
 
@@ -70,11 +71,17 @@ Common actions from this point include:
 - helm list:      list releases of charts
 
 Environment:
-  $HELM_HOME          set an alternative location for Helm files. By default, these are stored in ~/.helm
-  $HELM_HOST          set an alternative Tiller host. The format is host:port
-  $HELM_NO_PLUGINS    disable plugins. Set HELM_NO_PLUGINS=1 to disable plugins.
-  $TILLER_NAMESPACE   set an alternative Tiller namespace (default "kube-system")
-  $KUBECONFIG         set an alternative Kubernetes configuration file (default "~/.kube/config")
+  $HELM_DEFAULT_NAMESPACE   namespace releases are installed in when --namespace is not given, instead of the namespace of the kubeconfig context
+  $HELM_DEFAULT_REPO        repository searched first for chart names given without a repository, e.g. "stable"
+  $HELM_HOME                set an alternative location for Helm files. By default, these are stored in ~/.helm
+  $HELM_HOST                set an alternative Tiller host. The format is host:port
+  $HELM_HTTP_PROXY          route requests to chart repositories through this proxy instead of $HTTP_PROXY and $HTTPS_PROXY
+  $HELM_HTTP_RETRIES        retry failed requests to chart repositories this many times, with exponential backoff
+  $HELM_NO_PROXY            comma-separated hosts that are reached without $HELM_HTTP_PROXY
+  $HELM_NO_PLUGINS          disable plugins. Set HELM_NO_PLUGINS=1 to disable plugins.
+  $HELM_USER_AGENT_SUFFIX   append to the User-Agent sent to chart repositories, e.g. "my-ci/1.0"
+  $TILLER_NAMESPACE         set an alternative Tiller namespace (default "kube-system")
+  $KUBECONFIG               set an alternative Kubernetes configuration file (default "~/.kube/config")
 `
 
 func newRootCmd(args []string) *cobra.Command {
@@ -109,6 +116,7 @@ func newRootCmd(args []string) *cobra.Command {
 		newRepoCmd(out),
 		newSearchCmd(out),
 		newServeCmd(out),
+		newValuesCmd(out),
 		newVerifyCmd(out),
 
 		// release commands
@@ -117,6 +125,8 @@ func newRootCmd(args []string) *cobra.Command {
 		addFlagsTLS(newHistoryCmd(nil, out)),
 		addFlagsTLS(newInstallCmd(nil, out)),
 		addFlagsTLS(newListCmd(nil, out)),
+		addFlagsTLS(newPauseCmd(nil, out)),
+		addFlagsTLS(newResumeCmd(nil, out)),
 		addFlagsTLS(newRollbackCmd(nil, out)),
 		addFlagsTLS(newStatusCmd(nil, out)),
 		addFlagsTLS(newUpgradeCmd(nil, out)),
@@ -277,7 +287,7 @@ func newClient() helm.Interface {
 			tlsKeyFile = settings.Home.TLSKey()
 		}
 		debug("Key=%q, Cert=%q, CA=%q\n", tlsKeyFile, tlsCertFile, tlsCaCertFile)
-		tlsopts := tlsutil.Options{KeyFile: tlsKeyFile, CertFile: tlsCertFile, InsecureSkipVerify: true}
+		tlsopts := tlsutil.Options{KeyFile: tlsKeyFile, CertFile: tlsCertFile, ServerName: tlsServerName, InsecureSkipVerify: true}
 		if tlsVerify {
 			tlsopts.CaCertFile = tlsCaCertFile
 			tlsopts.InsecureSkipVerify = false
@@ -300,6 +310,7 @@ func addFlagsTLS(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().StringVar(&tlsCaCertFile, "tls-ca-cert", tlsCaCertDefault, "path to TLS CA certificate file")
 	cmd.Flags().StringVar(&tlsCertFile, "tls-cert", tlsCertDefault, "path to TLS certificate file")
 	cmd.Flags().StringVar(&tlsKeyFile, "tls-key", tlsKeyDefault, "path to TLS key file")
+	cmd.Flags().StringVar(&tlsServerName, "tls-hostname", "", "the server name used to verify the hostname on the returned certificates from the server")
 	cmd.Flags().BoolVar(&tlsVerify, "tls-verify", false, "enable TLS for request and verify remote")
 	cmd.Flags().BoolVar(&tlsEnable, "tls", false, "enable TLS for request")
 	return cmd
@@ -19,6 +19,7 @@ package main
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
@@ -43,6 +44,12 @@ The historical release set is printed as a formatted table, e.g:
     2           Mon Oct 3 10:15:13 2016     SUPERSEDED      alpine-0.1.0  Upgraded successfully
     3           Mon Oct 3 10:15:13 2016     SUPERSEDED      alpine-0.1.0  Rolled back to 2
     4           Mon Oct 3 10:15:13 2016     DEPLOYED        alpine-0.1.0  Upgraded successfully
+
+With '--output json' or '--output yaml' the revisions are printed as a list.
+'--output jsonl' prints each revision as a JSON object on a line of its own,
+which suits tools that read one record per line:
+
+    $ helm history angry-bird --output jsonl | jq -r .status
 `
 
 type historyCmd struct {
@@ -51,6 +58,7 @@ type historyCmd struct {
 	out      io.Writer
 	helmc    helm.Interface
 	colWidth uint
+	output   string
 }
 
 func newHistoryCmd(c helm.Interface, w io.Writer) *cobra.Command {
@@ -77,6 +85,7 @@ func newHistoryCmd(c helm.Interface, w io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	f.Int32Var(&his.max, "max", 256, "maximum number of revision to include in history")
 	f.UintVar(&his.colWidth, "col-width", 60, "specifies the max column width of output")
+	f.StringVarP(&his.output, "output", "o", "table", outputFormatUsage)
 
 	return cmd
 }
@@ -86,6 +95,9 @@ func (cmd *historyCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
+	if cmd.output != "table" {
+		return printStructured(cmd.out, cmd.output, historyItems(r.Releases))
+	}
 	if len(r.Releases) == 0 {
 		return nil
 	}
@@ -94,6 +106,31 @@ func (cmd *historyCmd) run() error {
 	return nil
 }
 
+// historyItem is a revision as printed by the structured output formats.
+type historyItem struct {
+	Revision    int32  `json:"revision"`
+	Updated     string `json:"updated"`
+	Status      string `json:"status"`
+	Chart       string `json:"chart"`
+	Description string `json:"description"`
+}
+
+// historyItems lists the revisions oldest first, like the table does.
+func historyItems(rls []*release.Release) []interface{} {
+	items := make([]interface{}, 0, len(rls))
+	for i := len(rls) - 1; i >= 0; i-- {
+		r := rls[i]
+		items = append(items, historyItem{
+			Revision:    r.Version,
+			Updated:     timeconv.Format(r.Info.LastDeployed, time.RFC3339),
+			Status:      r.Info.Status.Code.String(),
+			Chart:       formatChartname(r.Chart),
+			Description: r.Info.Description,
+		})
+	}
+	return items
+}
+
 func formatHistory(rls []*release.Release, colWidth uint) string {
 	tbl := uitable.New()
 
@@ -18,7 +18,9 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"regexp"
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/helm"
@@ -63,6 +65,16 @@ func TestHistoryCmd(t *testing.T) {
 			},
 			xout: "REVISION\tUPDATED                 \tSTATUS    \tCHART           \tDESCRIPTION \n3       \t(.*)\tSUPERSEDED\tfoo-0.1.0-beta.1\tRelease mock\n4       \t(.*)\tDEPLOYED  \tfoo-0.1.0-beta.1\tRelease mock\n",
 		},
+		{
+			cmds: "helm history --output=json RELEASE_NAME",
+			desc: "get history as json",
+			args: []string{"--output=json", "angry-bird"},
+			resp: []*rpb.Release{
+				mk("angry-bird", 2, rpb.Status_DEPLOYED),
+				mk("angry-bird", 1, rpb.Status_SUPERSEDED),
+			},
+			xout: `^\[\{"revision":1,"updated":"[^"]+","status":"SUPERSEDED","chart":"foo-0.1.0-beta.1","description":"Release mock"\},\{"revision":2,"updated":"[^"]+","status":"DEPLOYED","chart":"foo-0.1.0-beta.1","description":"Release mock"\}\]\n$`,
+		},
 	}
 
 	var buf bytes.Buffer
@@ -81,3 +93,32 @@ func TestHistoryCmd(t *testing.T) {
 		buf.Reset()
 	}
 }
+
+func TestHistoryCmdJSONLines(t *testing.T) {
+	rels := []*rpb.Release{
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "angry-bird", Version: 3, StatusCode: rpb.Status_DEPLOYED}),
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "angry-bird", Version: 2, StatusCode: rpb.Status_SUPERSEDED}),
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "angry-bird", Version: 1, StatusCode: rpb.Status_SUPERSEDED}),
+	}
+	var buf bytes.Buffer
+	cmd := newHistoryCmd(&helm.FakeClient{Rels: rels}, &buf)
+	args := []string{"--output", "jsonl", "angry-bird"}
+	cmd.ParseFlags(args)
+	if err := cmd.RunE(cmd, args); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(rels) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(rels), len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var item historyItem
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			t.Fatalf("line %d is not a JSON object: %s\n%s", i+1, err, line)
+		}
+		if item.Revision != int32(i+1) {
+			t.Errorf("line %d: expected revision %d, got %d", i+1, i+1, item.Revision)
+		}
+	}
+}
@@ -342,6 +342,7 @@ func ensureDirectories(home helmpath.Home, out io.Writer) error {
 		home.Plugins(),
 		home.Starters(),
 		home.Archive(),
+		home.ChartCache(),
 	}
 	for _, p := range configDirectories {
 		if fi, err := os.Stat(p); err != nil {
@@ -372,7 +373,7 @@ func ensureDefaultRepos(home helmpath.Home, out io.Writer, skipRefresh bool) err
 		}
 		f.Add(sr)
 		f.Add(lr)
-		if err := f.WriteFile(repoFile, 0644); err != nil {
+		if err := f.WriteFile(repoFile, 0600); err != nil {
 			return err
 		}
 	} else if fi.IsDir() {
@@ -431,7 +432,7 @@ func ensureRepoFileFormat(file string, out io.Writer) error {
 	r, err := repo.LoadRepositoriesFile(file)
 	if err == repo.ErrRepoOutOfDate {
 		fmt.Fprintln(out, "Updating repository file format...")
-		if err := r.WriteFile(file, 0644); err != nil {
+		if err := r.WriteFile(file, 0600); err != nil {
 			return err
 		}
 	}
@@ -18,6 +18,7 @@ package main
 
 import (
 	"bytes"
+	"compress/gzip"
 	"errors"
 	"fmt"
 	"io"
@@ -30,15 +31,19 @@ import (
 
 	"github.com/Masterminds/sprig"
 	"github.com/ghodss/yaml"
+	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/decrypt"
 	"k8s.io/helm/pkg/downloader"
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/strvals"
 )
@@ -64,27 +69,137 @@ contained a key called 'Test', the value set in override.yaml would take precede
 
 	$ helm install -f myvalues.yaml -f override.yaml ./redis
 
+Values files compressed with gzip are decompressed automatically:
+
+	$ helm install -f myvalues.yaml.gz ./redis
+
+Passing a directory to '--values' merges the YAML files in it in lexical order,
+so that later files take precedence. Files that do not end in .yaml or .yml are
+skipped with a warning:
+
+	$ helm install -f ./overrides/ ./redis
+
+A values file can also be fetched from a URL. When the URL is in a repository
+added with 'helm repo add', the credentials and TLS files of the repository
+are used to fetch it:
+
+	$ helm install -f https://config.example.com/env/prod.yaml ./redis
+
 You can specify the '--set' flag multiple times. The priority will be given to the
 last (right-most) set specified. For example, if both 'bar' and 'newbar' values are
 set for a key called 'foo', the 'newbar' value would take precedence:
 
 	$ helm install --set foo=bar --set foo=newbar ./redis
 
+A '--set' value is converted to the type that the chart's values.schema.json
+declares for its key, so that 'image.tag=1.10' stays a string when the schema
+says so. The schema of a subchart declares the types of the values under the
+subchart's name. Values whose key has no type in a schema are guessed from the
+value: true and false are booleans, and numbers without a leading zero are
+integers.
+
+Structured values are easier to give as JSON with the '--set-json' flag. The
+JSON is assigned at the key, and '--set' values are applied on top of it:
+
+	$ helm install --set-json 'ingress={"enabled":true,"hosts":["a","b"]}' ./redis
+
+By default '--set-json' and '--set' override the values files. Pass
+'--values-precedence set-first' to merge the values files last instead, so a
+file can override a value given with '--set':
+
+	$ helm install --values-precedence set-first --set replicas=3 -f prod.yaml ./redis
+
+Values can also be read from environment variables with the '--values-env-prefix'
+flag. Every variable starting with the prefix sets a value, with '__' separating
+nested keys. These values have the lowest priority, so '--values' and '--set'
+override them:
+
+	$ HELM_VAL_image__tag=1.2 helm install --values-env-prefix HELM_VAL_ ./redis
+
+Organization-wide defaults, such as a registry mirror, can be kept in a values
+file passed with '--default-values'. It is applied to the chart and each of its
+subcharts, beneath their own default values, so both the charts and the user
+can override it. Use '--default-values-precedence chart-first' to let it
+override the default values of the charts instead; the user's values still win.
+
+Values can be kept in a ConfigMap in the namespace of the release and
+referenced with '--values-from configmap/NAMESPACE/NAME[:KEY]'. Tiller reads the
+key, which defaults to values.yaml, and merges it beneath the values given with
+'-f' and '--set', so those still win:
+
+	$ helm install --namespace cache --values-from configmap/cache/redis:prod --set replicas=3 ./redis
+
+A single rendered resource can be adjusted without editing the chart with
+'--patch KIND/NAME:PATH=VALUE'. PATH is a dot separated list of fields and VALUE
+is converted like a '--set' value, or may be a JSON object or list. A patch
+that matches no rendered resource fails the release:
+
+	$ helm install --patch deployment/my-app:spec.replicas=5 ./redis
 
 To check the generated manifests of a release without installing the chart,
 the '--debug' and '--dry-run' flags can be combined. This will still require a
 round-trip to the Tiller server.
 
+A dry run also prints the hooks the install would run, in the order it would
+run them, with their weights and delete policies. Malformed hook annotations
+are reported as warnings.
+
+With '--quota-check', the resource requests of the pods the release creates are
+compared with what the ResourceQuotas of the namespace have left before
+installing. Insufficient quota is reported as a warning with 'warn', and fails
+the install with 'fail'. Namespaces without a quota are not checked. Tiller reads
+the quotas with its own service account, the identity that creates the
+resources; if it may not list them, the check is skipped with a warning with
+'warn', and fails the install with 'fail'.
+
+With '--api-check', the apiVersions of the rendered resources are compared with
+the APIs deprecated or removed in the Kubernetes version of the cluster, such
+as 'extensions/v1beta1' Deployments. Each use is reported along with the API to
+use instead, as a warning with 'warn', or failing the install with 'fail'.
+
+Pods that crash soon after reporting ready can pass '--wait'. With
+'--post-wait-settle 30s', Tiller waits another 30 seconds once the release is
+ready and checks it again, failing the release if a resource is no longer
+ready or a pod restarted in the meantime.
+
+Deleting a release without '--purge' keeps its record, so installing another
+release of the same name fails. With '--reuse-name', the name of a deleted or
+failed release is reused instead, and the new release continues its revision
+history. Installing over a release that is still deployed remains an error.
+
+Resources are applied in the order of their kinds, so that a ConfigMap exists
+before the Deployment that mounts it. With '--ordered-apply', they are applied
+in the order of their template files instead, and in the order they appear
+within each file. Namespaces and CustomResourceDefinitions still go first.
+
+Tiller labels each resource of a release, apart from hooks, with the name of
+the release and the revision that applied it, as 'helm.sh/release' and
+'helm.sh/revision', unless the chart sets these labels itself. This lets
+'kubectl get -l helm.sh/release=NAME' find them.
+
+A resource that already exists in the cluster fails the install. If it is
+labeled as owned by another release, through the 'helm.sh/release' label or
+the 'release' and 'heritage' labels of charts made with 'helm create', the
+error names that release. With '--take-ownership', existing
+resources are adopted instead: they are patched to the rendered resources,
+whichever release owned them.
+
+With '--progress', each step of the install is printed as Tiller starts it:
+rendering the chart, running hooks, applying each resource and waiting for
+them to be ready. This requires a Tiller that supports the "install-stream"
+feature.
+
 If --verify is set, the chart MUST have a provenance file, and the provenance
 file MUST pass all verification steps.
 
-There are five different ways you can express the chart you want to install:
+There are six different ways you can express the chart you want to install:
 
 1. By chart reference: helm install stable/mariadb
 2. By path to a packaged chart: helm install ./nginx-1.2.3.tgz
 3. By path to an unpacked chart directory: helm install ./nginx
 4. By absolute URL: helm install https://example.com/charts/nginx-1.2.3.tgz
 5. By chart reference and repo url: helm install --repo https://example.com/charts/ nginx
+6. By a packaged chart read from stdin: cat nginx-1.2.3.tgz | helm install -
 
 CHART REFERENCES
 
@@ -96,6 +211,12 @@ chart in that repository whose name is 'mariadb'. It will install the latest
 version of that chart unless you also supply a version number with the
 '--version' flag.
 
+A chart name without a repo prefix ('mariadb') is looked up in the cached
+indexes of all configured repositories. If $HELM_DEFAULT_REPO names a
+repository that has the chart, that repository is used. Otherwise the chart
+must be in exactly one repository; if several have it, the candidates are
+listed and you must choose one with a prefix.
+
 To see the list of chart repositories, use 'helm repo list'. To search for
 charts in a repository, use 'helm search'.
 `
@@ -109,18 +230,43 @@ type installCmd struct {
 	disableHooks bool
 	replace      bool
 	verify       bool
+	noCache      bool
 	keyring      string
 	out          io.Writer
 	client       helm.Interface
 	values       []string
+	jsonValues   []string
+	envPrefix    string
+	precedence   string
+	defaultVals  string
+	defaultsPrec string
+	valuesFrom   string
+	patches      []string
+	contOnError  bool
+	subNotes     bool
 	nameTemplate string
 	version      string
 	timeout      int64
 	wait         bool
+	postWait     int64
+	atomic       bool
+	description  string
+	commonLabels bool
+	skipCRDs     bool
+	quotaCheck   string
+	apiCheck     string
+	failOnEmpty  bool
+	progress     bool
+	orderedApply bool
+	takeOwner    bool
 	repoURL      string
 	devel        bool
 	depUp        bool
 
+	buildPipeline string
+	buildCommit   string
+	buildActor    string
+
 	certFile string
 	keyFile  string
 	caFile   string
@@ -143,6 +289,16 @@ func (v *valueFiles) Set(value string) error {
 	return nil
 }
 
+// hasStdin reports whether values are read from stdin.
+func (v valueFiles) hasStdin() bool {
+	for _, f := range v {
+		if strings.TrimSpace(f) == "-" {
+			return true
+		}
+	}
+	return false
+}
+
 func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	inst := &installCmd{
 		out:    out,
@@ -165,12 +321,27 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 				inst.version = ">0.0.0-0"
 			}
 
-			cp, err := locateChartPath(inst.repoURL, args[0], inst.version, inst.verify, inst.keyring,
-				inst.certFile, inst.keyFile, inst.caFile)
-			if err != nil {
-				return err
+			if args[0] == stdinChart {
+				if inst.verify {
+					return errors.New("a chart read from stdin cannot be verified")
+				}
+				if inst.valueFiles.hasStdin() {
+					return errors.New("stdin cannot hold both the chart and values")
+				}
+				cp, cleanup, err := bufferStdinChart(os.Stdin)
+				if err != nil {
+					return err
+				}
+				defer cleanup()
+				inst.chartPath = cp
+			} else {
+				cp, err := locateChartPath(inst.repoURL, args[0], inst.version, inst.verify, inst.noCache, inst.keyring,
+					inst.certFile, inst.keyFile, inst.caFile)
+				if err != nil {
+					return err
+				}
+				inst.chartPath = cp
 			}
-			inst.chartPath = cp
 			inst.client = ensureHelmClient(inst.client)
 			return inst.run()
 		},
@@ -183,19 +354,46 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&inst.dryRun, "dry-run", false, "simulate an install")
 	f.BoolVar(&inst.disableHooks, "no-hooks", false, "prevent hooks from running during install")
 	f.BoolVar(&inst.replace, "replace", false, "re-use the given name, even if that name is already used. This is unsafe in production")
+	f.BoolVar(&inst.replace, "reuse-name", false, "re-use the name of a deleted or failed release, continuing its revision history. A release of that name that is still deployed is an error")
 	f.StringArrayVar(&inst.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&inst.jsonValues, "set-json", []string{}, "set JSON values on the command line (can specify multiple or separate values with commas: key1=jsonval1,key2=jsonval2)")
+	f.StringVar(&inst.envPrefix, "values-env-prefix", "", "set values from environment variables with this prefix, using __ to separate nested keys (e.g. HELM_VAL_image__tag=1.2)")
+	f.StringVar(&inst.precedence, "values-precedence", "file-first", "order in which values are merged: file-first lets --set and --set-json override -f files, set-first lets -f files override them")
+	f.StringVar(&inst.defaultVals, "default-values", "", "values file applied to the chart and its subcharts, beneath the user's values")
+	f.StringVar(&inst.defaultsPrec, "default-values-precedence", defaultsFirst, "order in which --default-values and the default values of the charts are merged: defaults-first lets the charts override the file, chart-first lets the file override the charts")
+	f.StringVar(&inst.valuesFrom, "values-from", "", "read values from a ConfigMap key in the namespace of the release, as configmap/NAMESPACE/NAME[:KEY] (KEY defaults to values.yaml). Values from -f and --set take precedence")
+	f.StringArrayVar(&inst.patches, "patch", []string{}, "set a field of one rendered resource before it is applied, as KIND/NAME:PATH=VALUE (can specify multiple)")
+	f.BoolVar(&inst.contOnError, "continue-on-error", false, "attempt to create every resource even if some fail, reporting all failures. The release is still marked as failed")
+	f.BoolVar(&inst.takeOwner, "take-ownership", false, "adopt resources that already exist in the cluster, even if another release owns them")
+	f.BoolVar(&inst.subNotes, "render-subchart-notes", false, "render the NOTES.txt of enabled subcharts beneath the notes of the chart")
 	f.StringVar(&inst.nameTemplate, "name-template", "", "specify template used to name the release")
 	f.BoolVar(&inst.verify, "verify", false, "verify the package before installing it")
+	f.BoolVar(&inst.noCache, "no-cache", false, "download the chart even if it is in the chart cache")
 	f.StringVar(&inst.keyring, "keyring", defaultKeyring(), "location of public keys used for verification")
 	f.StringVar(&inst.version, "version", "", "specify the exact chart version to install. If this is not specified, the latest version is installed")
-	f.Int64Var(&inst.timeout, "timeout", 300, "time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
+	f.Var(newTimeoutValue(defaultTimeout, &inst.timeout), "timeout", timeoutUsage)
 	f.BoolVar(&inst.wait, "wait", false, "if set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
+	f.Var(newTimeoutValue(0, &inst.postWait), "post-wait-settle", "wait this long after --wait finds the release ready, then fail the release if any of its resources is no longer ready or any of its pods restarted. The --wait flag will be set automatically if this is set")
+	f.BoolVar(&inst.atomic, "atomic", false, "if set, installation process purges the release and its hook resources on failure. The --wait flag will be set automatically if --atomic is used")
 	f.StringVar(&inst.repoURL, "repo", "", "chart repository url where to locate the requested chart")
 	f.StringVar(&inst.certFile, "cert-file", "", "identify HTTPS client using this SSL certificate file")
 	f.StringVar(&inst.keyFile, "key-file", "", "identify HTTPS client using this SSL key file")
 	f.StringVar(&inst.caFile, "ca-file", "", "verify certificates of HTTPS-enabled servers using this CA bundle")
 	f.BoolVar(&inst.devel, "devel", false, "use development versions, too. Equivalent to version '>0.0.0-0'. If --version is set, this is ignored.")
+	f.BoolVar(&inst.depUp, "dependency-update", false, "run helm dependency update before installing the chart")
 	f.BoolVar(&inst.depUp, "dep-up", false, "run helm dependency update before installing the chart")
+	f.MarkDeprecated("dep-up", "use --dependency-update instead")
+	f.BoolVar(&inst.commonLabels, "common-labels", false, "add the recommended app.kubernetes.io and helm.sh/chart labels to every resource that does not already set them")
+	f.StringVar(&inst.description, "description", "", "specify a description for the release, shown in 'helm history'")
+	f.BoolVar(&inst.skipCRDs, "skip-crds", false, "do not install the CustomResourceDefinitions rendered by the chart")
+	f.StringVar(&inst.quotaCheck, "quota-check", "", "check the resource requests of the release against the ResourceQuota of the namespace before installing: \"warn\" or \"fail\" on insufficient quota")
+	f.StringVar(&inst.apiCheck, "api-check", "", "check the apiVersions of the release against the APIs deprecated in the cluster's Kubernetes version before installing: \"warn\" or \"fail\" on deprecated APIs")
+	f.BoolVar(&inst.orderedApply, "ordered-apply", false, "apply the resources in the order of their template files instead of by kind. Namespaces and CustomResourceDefinitions are still applied first")
+	f.BoolVar(&inst.progress, "progress", false, "print the steps of the install as Tiller performs them")
+	f.BoolVar(&inst.failOnEmpty, "fail-on-empty", false, "fail if a template renders to nothing but whitespace and comments, unless it contains the comment \"# helm.sh/allow-empty\"")
+	f.StringVar(&inst.buildPipeline, "build-pipeline", "", "record the CI pipeline that built the release")
+	f.StringVar(&inst.buildCommit, "build-commit", "", "record the commit the release was built from")
+	f.StringVar(&inst.buildActor, "build-actor", "", "record who triggered the build of the release")
 
 	return cmd
 }
@@ -207,12 +405,8 @@ func (i *installCmd) run() error {
 		i.namespace = defaultNamespace()
 	}
 
-	rawVals, err := vals(i.valueFiles, i.values)
-	if err != nil {
-		return err
-	}
-
 	// If template is specified, try to run the template.
+	var err error
 	if i.nameTemplate != "" {
 		i.name, err = generateName(i.nameTemplate)
 		if err != nil {
@@ -233,27 +427,54 @@ func (i *installCmd) run() error {
 		// As of Helm 2.4.0, this is treated as a stopping condition:
 		// https://github.com/kubernetes/helm/issues/2209
 		if err := checkDependencies(chartRequested, req); err != nil {
-			if i.depUp {
-				man := &downloader.Manager{
-					Out:        i.out,
-					ChartPath:  i.chartPath,
-					HelmHome:   settings.Home,
-					Keyring:    defaultKeyring(),
-					SkipUpdate: false,
-					Getters:    getter.All(settings),
-				}
-				if err := man.Update(); err != nil {
-					return prettyError(err)
-				}
-			} else {
+			if !i.depUp {
+				return prettyError(err)
+			}
+			man := &downloader.Manager{
+				Out:        i.out,
+				ChartPath:  i.chartPath,
+				HelmHome:   settings.Home,
+				Keyring:    defaultKeyring(),
+				SkipUpdate: false,
+				Getters:    getter.All(settings),
+				Debug:      settings.Debug,
+			}
+			if err := man.Update(); err != nil {
 				return prettyError(err)
 			}
 
+			// Load the chart again, so that it includes the dependencies that
+			// were just fetched.
+			if chartRequested, err = chartutil.Load(i.chartPath); err != nil {
+				return prettyError(err)
+			}
 		}
 	} else if err != chartutil.ErrRequirementsNotFound {
 		return fmt.Errorf("cannot load requirements: %v", err)
 	}
 
+	types, err := chartutil.SchemaTypes(chartRequested)
+	if err != nil {
+		return err
+	}
+	rawVals, err := vals(i.out, i.valueFiles, i.values, i.jsonValues, i.envPrefix, i.precedence, types)
+	if err != nil {
+		return err
+	}
+
+	rawVals, err = applyDefaultValues(chartRequested, rawVals, i.defaultVals, i.defaultsPrec)
+	if err != nil {
+		return err
+	}
+	printTypeWarnings(i.out, chartRequested, rawVals)
+
+	var progress func(*services.InstallReleaseEvent)
+	if i.progress {
+		progress = func(e *services.InstallReleaseEvent) {
+			fmt.Fprintf(i.out, "%s: %s\n", e.Stage, e.Message)
+		}
+	}
+
 	res, err := i.client.InstallReleaseFromChart(
 		chartRequested,
 		i.namespace,
@@ -263,7 +484,23 @@ func (i *installCmd) run() error {
 		helm.InstallReuseName(i.replace),
 		helm.InstallDisableHooks(i.disableHooks),
 		helm.InstallTimeout(i.timeout),
-		helm.InstallWait(i.wait))
+		helm.InstallWait(i.wait || i.atomic || i.postWait > 0),
+		helm.InstallPostWaitSettle(i.postWait),
+		helm.InstallAtomic(i.atomic),
+		helm.InstallDescription(i.description),
+		helm.InstallCommonLabels(i.commonLabels),
+		helm.InstallSkipCRDs(i.skipCRDs),
+		helm.InstallValuesFrom(i.valuesFrom),
+		helm.InstallPatches(i.patches),
+		helm.InstallContinueOnError(i.contOnError),
+		helm.InstallSubchartNotes(i.subNotes),
+		helm.InstallBuildInfo(buildInfo(i.buildPipeline, i.buildCommit, i.buildActor)),
+		helm.InstallQuotaCheck(i.quotaCheck),
+		helm.InstallAPICheck(i.apiCheck),
+		helm.InstallProgress(progress),
+		helm.InstallOrderedApply(i.orderedApply),
+		helm.InstallFailOnEmpty(i.failOnEmpty),
+		helm.InstallTakeOwnership(i.takeOwner))
 	if err != nil {
 		return prettyError(err)
 	}
@@ -273,9 +510,13 @@ func (i *installCmd) run() error {
 		return nil
 	}
 	i.printRelease(rel)
+	for _, w := range res.Warnings {
+		fmt.Fprintf(i.out, "WARNING: %s\n", w)
+	}
 
 	// If this is a dry run, we can't display status.
 	if i.dryRun {
+		printHookPlan(i.out, res.HookPlan)
 		return nil
 	}
 
@@ -320,13 +561,87 @@ func mergeValues(dest map[string]interface{}, src map[string]interface{}) map[st
 	return dest
 }
 
+// Orders accepted by --values-precedence. With valuesFileFirst the values
+// files are merged first and --set-json and --set override them; with
+// valuesSetFirst it is the other way around.
+const (
+	valuesFileFirst = "file-first"
+	valuesSetFirst  = "set-first"
+)
+
 // vals merges values from files specified via -f/--values and
-// directly via --set, marshaling them to YAML
-func vals(valueFiles valueFiles, values []string) ([]byte, error) {
+// directly via --set-json and --set, marshaling them to YAML. The
+// precedence, file-first or set-first, decides which of them wins.
+// If types is set, it decides the types of the --set values. Warnings
+// about skipped files are written to out.
+func vals(out io.Writer, valueFiles valueFiles, values, jsonValues []string, envPrefix, precedence string, types strvals.TypeFunc) ([]byte, error) {
 	base := map[string]interface{}{}
 
-	// User specified a values files via -f/--values
+	// User specified values via environment variables with --values-env-prefix
+	if envPrefix != "" {
+		base = envValues(envPrefix, os.Environ())
+	}
+
+	var err error
+	switch precedence {
+	case "", valuesFileFirst:
+		if base, err = mergeValueFiles(out, base, valueFiles); err != nil {
+			return []byte{}, err
+		}
+		if err := mergeSetValues(base, values, jsonValues, types); err != nil {
+			return []byte{}, err
+		}
+	case valuesSetFirst:
+		if err := mergeSetValues(base, values, jsonValues, types); err != nil {
+			return []byte{}, err
+		}
+		if base, err = mergeValueFiles(out, base, valueFiles); err != nil {
+			return []byte{}, err
+		}
+	default:
+		return []byte{}, fmt.Errorf("unknown values precedence %q (must be %s or %s)", precedence, valuesSetFirst, valuesFileFirst)
+	}
+
+	return yaml.Marshal(base)
+}
+
+// expandValueDirs replaces each directory given with -f/--values by the YAML
+// files in it, in lexical order. Other files in the directory are skipped
+// with a warning written to out.
+func expandValueDirs(out io.Writer, valueFiles valueFiles) ([]string, error) {
+	var paths []string
 	for _, filePath := range valueFiles {
+		fi, err := os.Stat(filePath)
+		if err != nil || !fi.IsDir() {
+			// Let reading the file report a missing file, stdin or a URL.
+			paths = append(paths, filePath)
+			continue
+		}
+		files, err := ioutil.ReadDir(filePath)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			name := filepath.Join(filePath, f.Name())
+			ext := filepath.Ext(strings.TrimSuffix(f.Name(), ".gz"))
+			if f.IsDir() || (ext != ".yaml" && ext != ".yml") {
+				fmt.Fprintf(out, "WARNING: skipping %s: not a YAML file\n", name)
+				continue
+			}
+			paths = append(paths, name)
+		}
+	}
+	return paths, nil
+}
+
+// mergeValueFiles merges the files given with -f/--values into base, in order.
+// The YAML files of a directory are merged in lexical order.
+func mergeValueFiles(out io.Writer, base map[string]interface{}, valueFiles valueFiles) (map[string]interface{}, error) {
+	paths, err := expandValueDirs(out, valueFiles)
+	if err != nil {
+		return nil, err
+	}
+	for _, filePath := range paths {
 		currentMap := map[string]interface{}{}
 
 		var bytes []byte
@@ -338,24 +653,196 @@ func vals(valueFiles valueFiles, values []string) ([]byte, error) {
 		}
 
 		if err != nil {
-			return []byte{}, err
+			return nil, err
+		}
+
+		if bytes, err = decrypt.All(settings).Decrypt(filePath, bytes); err != nil {
+			return nil, err
+		}
+
+		if bytes, err = gunzipValues(bytes); err != nil {
+			return nil, fmt.Errorf("failed to decompress %s: %s", filePath, err)
 		}
 
 		if err := yaml.Unmarshal(bytes, &currentMap); err != nil {
-			return []byte{}, fmt.Errorf("failed to parse %s: %s", filePath, err)
+			return nil, fmt.Errorf("failed to parse %s: %s", filePath, err)
 		}
 		// Merge with the previous map
 		base = mergeValues(base, currentMap)
 	}
+	return base, nil
+}
+
+// mergeSetValues applies the values given with --set-json and then --set to base.
+func mergeSetValues(base map[string]interface{}, values, jsonValues []string, types strvals.TypeFunc) error {
+	// User specified a JSON value via --set-json
+	for _, value := range jsonValues {
+		if err := strvals.ParseJSON(value, base); err != nil {
+			return fmt.Errorf("failed parsing --set-json data: %s", err)
+		}
+	}
 
 	// User specified a value via --set
 	for _, value := range values {
-		if err := strvals.ParseInto(value, base); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set data: %s", err)
+		if err := strvals.ParseIntoTyped(value, base, types); err != nil {
+			return fmt.Errorf("failed parsing --set data: %s", err)
 		}
 	}
+	return nil
+}
 
-	return yaml.Marshal(base)
+// gunzipValues decompresses the contents of a values file if they are
+// gzipped, and returns them unchanged otherwise.
+func gunzipValues(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// envValues builds a values map from the environment variables in environ
+// whose names start with prefix. The rest of the name is the path of the
+// value, with "__" separating nested keys, so HELM_VAL_image__tag=1.2 sets
+// image.tag to "1.2" for the prefix HELM_VAL_. Values are always strings.
+func envValues(prefix string, environ []string) map[string]interface{} {
+	base := map[string]interface{}{}
+	for _, kv := range environ {
+		if !strings.HasPrefix(kv, prefix) {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(kv, prefix), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		keys := strings.Split(parts[0], "__")
+		if !validKeys(keys) {
+			continue
+		}
+		current := base
+		for _, k := range keys[:len(keys)-1] {
+			next, ok := current[k].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				current[k] = next
+			}
+			current = next
+		}
+		current[keys[len(keys)-1]] = parts[1]
+	}
+	return base
+}
+
+func validKeys(keys []string) bool {
+	for _, k := range keys {
+		if k == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Orders accepted by --default-values-precedence. With defaultsFirst the
+// default values file is merged first, beneath the default values of each
+// chart; with chartFirst it overrides them. The user's values override both.
+const (
+	defaultsFirst = "defaults-first"
+	chartFirst    = "chart-first"
+)
+
+// applyDefaultValues merges the values file at path beneath the user's values
+// in rawVals and returns the result. The file is applied to the chart and to
+// each of its subcharts, beneath or above their own default values as
+// precedence decides. The chart itself is left untouched.
+func applyDefaultValues(ch *chart.Chart, rawVals []byte, path, precedence string) ([]byte, error) {
+	if path == "" {
+		return rawVals, nil
+	}
+	switch precedence {
+	case "", defaultsFirst, chartFirst:
+	default:
+		return nil, fmt.Errorf("unknown default values precedence %q (must be %s or %s)", precedence, defaultsFirst, chartFirst)
+	}
+
+	data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defaults := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &defaults); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+
+	chartVals, err := chartutil.CoalesceValues(ch, &chart.Config{})
+	if err != nil {
+		return nil, err
+	}
+	userVals := map[string]interface{}{}
+	if err := yaml.Unmarshal(rawVals, &userVals); err != nil {
+		return nil, err
+	}
+
+	layered := layerDefaultValues(ch, defaults, chartVals, precedence == chartFirst)
+	return yaml.Marshal(mergeValues(layered, userVals))
+}
+
+// layerDefaultValues returns the defaults to apply to ch and its subcharts,
+// given the values chartVals they already have. Unless override is set, the
+// keys set in chartVals are left out, so that the chart's values win.
+func layerDefaultValues(ch *chart.Chart, defaults, chartVals map[string]interface{}, override bool) map[string]interface{} {
+	var layered map[string]interface{}
+	if override {
+		layered = copyValues(defaults)
+	} else {
+		layered = fillValues(defaults, chartVals)
+	}
+	for _, sub := range ch.Dependencies {
+		name := sub.Metadata.GetName()
+		subVals, _ := chartVals[name].(map[string]interface{})
+		subLayered := layerDefaultValues(sub, defaults, subVals, override)
+		if dest, ok := layered[name].(map[string]interface{}); ok {
+			subLayered = mergeValues(subLayered, dest)
+		}
+		layered[name] = subLayered
+	}
+	return layered
+}
+
+// fillValues returns the values of defaults for the keys not set in vals.
+func fillValues(defaults, vals map[string]interface{}) map[string]interface{} {
+	filled := map[string]interface{}{}
+	for k, v := range defaults {
+		cur, ok := vals[k]
+		if !ok || cur == nil {
+			filled[k] = v
+			continue
+		}
+		dm, isMap := v.(map[string]interface{})
+		cm, curIsMap := cur.(map[string]interface{})
+		if isMap && curIsMap {
+			if f := fillValues(dm, cm); len(f) > 0 {
+				filled[k] = f
+			}
+		}
+	}
+	return filled
+}
+
+// copyValues returns a deep copy of the tables in vals, so that merging into
+// the copy leaves vals untouched.
+func copyValues(vals map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(vals))
+	for k, v := range vals {
+		if m, ok := v.(map[string]interface{}); ok {
+			v = copyValues(m)
+		}
+		c[k] = v
+	}
+	return c
 }
 
 // printRelease prints info about a release if the Debug is true.
@@ -370,6 +857,82 @@ func (i *installCmd) printRelease(rel *release.Release) {
 	}
 }
 
+// printHookPlan prints the hooks an install would run, in the order it would
+// run them, followed by warnings about malformed hook annotations.
+func printHookPlan(out io.Writer, plan *services.HookPlan) {
+	if plan == nil {
+		return
+	}
+	if len(plan.Steps) > 0 {
+		table := uitable.New()
+		table.AddRow("EVENT", "WEIGHT", "KIND", "NAME", "DELETE POLICIES")
+		for _, s := range plan.Steps {
+			policies := make([]string, len(s.DeletePolicies))
+			for i, p := range s.DeletePolicies {
+				policies[i] = p.String()
+			}
+			table.AddRow(s.Event, s.Weight, s.Kind, s.Name, strings.Join(policies, ","))
+		}
+		fmt.Fprintf(out, "HOOK PLAN:\n%s\n", table)
+	}
+	for _, w := range plan.Warnings {
+		fmt.Fprintf(out, "WARNING: %s\n", w)
+	}
+}
+
+// printTypeWarnings prints a warning for each of the overrides in rawVals that
+// changes the type of the chart default it overrides. Errors are left for the
+// render to report.
+func printTypeWarnings(out io.Writer, ch *chart.Chart, rawVals []byte) {
+	_, warnings, err := chartutil.CoalesceValuesWithWarnings(ch, &chart.Config{Raw: string(rawVals)})
+	if err != nil {
+		return
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(out, "WARNING: %s\n", w)
+	}
+}
+
+// buildInfo returns the build info given by the --build-* flags, or nil if none
+// of them is set.
+func buildInfo(pipeline, commit, actor string) *release.BuildInfo {
+	if pipeline == "" && commit == "" && actor == "" {
+		return nil
+	}
+	return &release.BuildInfo{Pipeline: pipeline, Commit: commit, Actor: actor}
+}
+
+// stdinChart is the chart argument that reads the chart archive from stdin.
+const stdinChart = "-"
+
+// bufferStdinChart writes the chart archive read from in to a temporary file,
+// so that it can be loaded like any other chart archive. The returned function
+// removes the file.
+func bufferStdinChart(in io.Reader) (string, func(), error) {
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot read chart from stdin: %s", err)
+	}
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return "", nil, errors.New("stdin is not a chart archive: expected a gzipped tar file, such as one made by 'helm package'")
+	}
+	if _, err := chartutil.LoadArchive(bytes.NewReader(data)); err != nil {
+		return "", nil, fmt.Errorf("stdin is not a valid chart archive: %s", err)
+	}
+
+	dir, err := ioutil.TempDir("", "helm-stdin-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	path := filepath.Join(dir, "chart.tgz")
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return path, cleanup, nil
+}
+
 // locateChartPath looks for a chart directory in known places, and returns either the full path or an error.
 //
 // This does not ensure that the chart is well-formed; only that the requested filename exists.
@@ -381,7 +944,9 @@ func (i *installCmd) printRelease(rel *release.Release) {
 // - URL
 //
 // If 'verify' is true, this will attempt to also verify the chart.
-func locateChartPath(repoURL, name, version string, verify bool, keyring,
+//
+// If 'noCache' is true, the chart is downloaded even if it is in the chart cache.
+func locateChartPath(repoURL, name, version string, verify, noCache bool, keyring,
 	certFile, keyFile, caFile string) (string, error) {
 	name = strings.TrimSpace(name)
 	version = strings.TrimSpace(version)
@@ -409,11 +974,20 @@ func locateChartPath(repoURL, name, version string, verify bool, keyring,
 		return filepath.Abs(crepo)
 	}
 
+	if repoURL == "" && !strings.Contains(name, "/") {
+		qualified, err := resolveChartName(settings.Home, settings.DefaultRepo, name)
+		if err != nil {
+			return "", err
+		}
+		name = qualified
+	}
+
 	dl := downloader.ChartDownloader{
 		HelmHome: settings.Home,
 		Out:      os.Stdout,
 		Keyring:  keyring,
 		Getters:  getter.All(settings),
+		NoCache:  noCache,
 	}
 	if verify {
 		dl.Verify = downloader.VerifyAlways
@@ -446,6 +1020,34 @@ func locateChartPath(repoURL, name, version string, verify bool, keyring,
 	return filename, fmt.Errorf("failed to download %q", name)
 }
 
+// resolveChartName qualifies a chart name given without a repository with the
+// repository that has it. The default repository is preferred; otherwise the
+// chart must be in exactly one repository. Names that no repository has are
+// returned unchanged.
+func resolveChartName(home helmpath.Home, defaultRepo, name string) (string, error) {
+	rf, err := repo.LoadRepositoriesFile(home.RepositoryFile())
+	if err != nil {
+		return name, nil
+	}
+	repos := rf.FindChart(name, home.CacheIndex)
+	for _, r := range repos {
+		if r == defaultRepo {
+			return r + "/" + name, nil
+		}
+	}
+	switch len(repos) {
+	case 0:
+		return name, nil
+	case 1:
+		return repos[0] + "/" + name, nil
+	}
+	candidates := make([]string, len(repos))
+	for i, r := range repos {
+		candidates[i] = r + "/" + name
+	}
+	return "", fmt.Errorf("chart %q is in several repositories, use one of: %s", name, strings.Join(candidates, ", "))
+}
+
 func generateName(nameTemplate string) (string, error) {
 	t, err := template.New("name-template").Funcs(sprig.TxtFuncMap()).Parse(nameTemplate)
 	if err != nil {
@@ -459,7 +1061,13 @@ func generateName(nameTemplate string) (string, error) {
 	return b.String(), nil
 }
 
+// defaultNamespace returns the namespace to install in when --namespace is not
+// given: $HELM_DEFAULT_NAMESPACE, or else the namespace of the kubeconfig
+// context.
 func defaultNamespace() string {
+	if settings.DefaultNamespace != "" {
+		return settings.DefaultNamespace
+	}
 	if ns, _, err := kube.GetConfig(settings.KubeContext, settings.KubeConfig).Namespace(); err == nil {
 		return ns
 	}
@@ -489,22 +1097,55 @@ func checkDependencies(ch *chart.Chart, reqs *chartutil.Requirements) error {
 	return nil
 }
 
-//readFile load a file from the local directory or a remote file with a url.
+// readFile loads a file from the local directory or a remote file with a URL.
+//
+// A remote file is fetched with the getter of its scheme. When the URL is in a
+// repository added with 'helm repo add', the TLS files and credentials of the
+// repository are used to fetch it.
 func readFile(filePath string) ([]byte, error) {
-	u, _ := url.Parse(filePath)
+	u, err := url.Parse(filePath)
+	if err != nil {
+		return ioutil.ReadFile(filePath)
+	}
 	p := getter.All(settings)
 
 	// FIXME: maybe someone handle other protocols like ftp.
 	getterConstructor, err := p.ByScheme(u.Scheme)
-
 	if err != nil {
 		return ioutil.ReadFile(filePath)
-	} else {
-		getter, err := getterConstructor(filePath, "", "", "")
-		if err != nil {
-			return []byte{}, err
+	}
+
+	cfg := repoEntryForURL(settings.Home, filePath)
+	g, err := getterConstructor(filePath, cfg.CertFile, cfg.KeyFile, cfg.CAFile)
+	if err != nil {
+		return []byte{}, err
+	}
+	if a, ok := g.(getter.Authenticator); ok {
+		a.SetCredentials(cfg.Username, cfg.Password)
+		a.SetBearerToken(cfg.Token)
+	}
+	data, err := g.Get(filePath)
+	if err != nil {
+		return []byte{}, err
+	}
+	return data.Bytes(), nil
+}
+
+// repoEntryForURL returns the repository whose URL is the longest prefix of
+// href. An empty entry is returned if href is in no repository.
+func repoEntryForURL(home helmpath.Home, href string) *repo.Entry {
+	entry := &repo.Entry{}
+	rf, err := repo.LoadRepositoriesFile(home.RepositoryFile())
+	if err != nil {
+		return entry
+	}
+	for _, r := range rf.Repositories {
+		if r.URL == "" || len(r.URL) <= len(entry.URL) {
+			continue
+		}
+		if strings.HasPrefix(href, strings.TrimSuffix(r.URL, "/")+"/") {
+			entry = r
 		}
-		data, err := getter.Get(filePath)
-		return data.Bytes(), err
 	}
+	return entry
 }
@@ -17,14 +17,30 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/decrypt"
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/repo"
+	"k8s.io/helm/pkg/repo/repotest"
 )
 
 func TestInstall(t *testing.T) {
@@ -91,6 +107,14 @@ func TestInstall(t *testing.T) {
 			expected: "aeneas",
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
 		},
+		// Install, re-use the name of a deleted release
+		{
+			name:     "install and reuse name",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--name aeneas --reuse-name", " "),
+			expected: "aeneas",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+		},
 		// Install, with timeout
 		{
 			name:     "install with a timeout",
@@ -158,6 +182,117 @@ type nameTemplateTestCase struct {
 	expectedErrorStr string
 }
 
+// chartRecordingClient records the chart it is asked to install.
+type chartRecordingClient struct {
+	*helm.FakeClient
+	chart *chart.Chart
+}
+
+func (c *chartRecordingClient) InstallReleaseFromChart(ch *chart.Chart, ns string, opts ...helm.InstallOption) (*services.InstallReleaseResponse, error) {
+	c.chart = ch
+	return c.FakeClient.InstallReleaseFromChart(ch, ns, opts...)
+}
+
+func TestInstallDependencyUpdate(t *testing.T) {
+	hh, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cleanup := resetEnv()
+	defer func() {
+		os.RemoveAll(hh.String())
+		cleanup()
+	}()
+
+	settings.Home = hh
+
+	srv := repotest.NewServer(hh.String())
+	defer srv.Stop()
+	if _, err := srv.CopyCharts("testdata/testcharts/*.tgz"); err != nil {
+		t.Fatal(err)
+	}
+
+	chartname := "depup"
+	if err := createTestingChart(hh.String(), chartname, srv.URL()); err != nil {
+		t.Fatal(err)
+	}
+	chartpath := filepath.Join(hh.String(), chartname)
+
+	out := bytes.NewBuffer(nil)
+	c := &chartRecordingClient{FakeClient: &helm.FakeClient{}}
+
+	// Without the flag, the missing dependencies stop the install.
+	cmd := newInstallCmd(c, out)
+	cmd.ParseFlags([]string{"--name", "aeneas", "--namespace", "default"})
+	if err := cmd.RunE(cmd, []string{chartpath}); err == nil || !strings.Contains(err.Error(), "found in requirements.yaml, but missing in charts/ directory") {
+		t.Fatalf("Expected missing dependencies to fail the install, got %v", err)
+	}
+
+	cmd = newInstallCmd(c, out)
+	cmd.ParseFlags([]string{"--name", "aeneas", "--namespace", "default", "--dependency-update"})
+	if err := cmd.RunE(cmd, []string{chartpath}); err != nil {
+		t.Logf("Output: %s", out.String())
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(chartpath, "charts/reqtest-0.1.0.tgz")); err != nil {
+		t.Errorf("Expected the dependency to be fetched: %s", err)
+	}
+	if c.chart == nil {
+		t.Fatal("Expected the chart to be installed")
+	}
+	var deps []string
+	for _, dep := range c.chart.Dependencies {
+		deps = append(deps, dep.Metadata.Name)
+	}
+	sort.Strings(deps)
+	if expect := []string{"compressedchart", "reqtest"}; !reflect.DeepEqual(deps, expect) {
+		t.Errorf("Expected the installed chart to have dependencies %v, got %v", expect, deps)
+	}
+}
+
+// withStdin runs fn with the contents of the file as stdin.
+func withStdin(t *testing.T, file string, fn func()) {
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	stdin := os.Stdin
+	os.Stdin = f
+	defer func() { os.Stdin = stdin }()
+	fn()
+}
+
+func TestInstallFromStdin(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	c := &chartRecordingClient{FakeClient: &helm.FakeClient{}}
+
+	withStdin(t, "testdata/testcharts/compressedchart-0.1.0.tgz", func() {
+		cmd := newInstallCmd(c, out)
+		cmd.ParseFlags([]string{"--name", "foo", "--namespace", "default"})
+		if err := cmd.RunE(cmd, []string{"-"}); err != nil {
+			t.Fatalf("Failed install: %s", err)
+		}
+	})
+	if c.chart == nil || c.chart.Metadata.Name != "compressedchart" || c.chart.Metadata.Version != "0.1.0" {
+		t.Fatalf("Expected compressedchart-0.1.0 to be installed, got %v", c.chart)
+	}
+	if !strings.Contains(out.String(), "foo") {
+		t.Errorf("Expected the release name in the output, got %q", out.String())
+	}
+
+	withStdin(t, "testdata/testcharts/alpine/Chart.yaml", func() {
+		cmd := newInstallCmd(c, out)
+		cmd.ParseFlags([]string{"--name", "bar", "--namespace", "default"})
+		err := cmd.RunE(cmd, []string{"-"})
+		if err == nil || !strings.Contains(err.Error(), "stdin is not a chart archive") {
+			t.Errorf("Expected stdin that is not an archive to fail, got %v", err)
+		}
+	})
+}
+
 func TestNameTemplate(t *testing.T) {
 	testCases := []nameTemplateTestCase{
 		// Just a straight up nop please
@@ -281,3 +416,543 @@ func TestMergeValues(t *testing.T) {
 		t.Errorf("Expected a map with different keys to merge properly with another map. Expected: %v, got %v", expectedMap, testMap)
 	}
 }
+
+func TestEnvValues(t *testing.T) {
+	environ := []string{
+		"HELM_VAL_image__tag=1.2",
+		"HELM_VAL_image__repository=quay.io/whaler",
+		"HELM_VAL_replicas=3",
+		"HELM_VAL_url=http://example.com/?a=b",
+		"HELM_VAL_bad____key=ignored",
+		"HELM_VAL_=ignored",
+		"HOME=/root",
+	}
+	expect := map[string]interface{}{
+		"image": map[string]interface{}{
+			"tag":        "1.2",
+			"repository": "quay.io/whaler",
+		},
+		"replicas": "3",
+		"url":      "http://example.com/?a=b",
+	}
+	if got := envValues("HELM_VAL_", environ); !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected %v, got %v", expect, got)
+	}
+}
+
+func TestValsEnvPrefix(t *testing.T) {
+	defer os.Unsetenv("HELM_TEST_VAL_image__tag")
+	defer os.Unsetenv("HELM_TEST_VAL_image__pullPolicy")
+	defer os.Unsetenv("HELM_TEST_VAL_name")
+	os.Setenv("HELM_TEST_VAL_image__tag", "1.2")
+	os.Setenv("HELM_TEST_VAL_image__pullPolicy", "Always")
+	os.Setenv("HELM_TEST_VAL_name", "env")
+
+	f, err := ioutil.TempFile("", "helm-values")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString("name: file\nimage:\n  pullPolicy: IfNotPresent\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	b, err := vals(ioutil.Discard, valueFiles{f.Name()}, []string{"image.tag=1.3"}, []string{}, "HELM_TEST_VAL_", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := "image:\n  pullPolicy: IfNotPresent\n  tag: \"1.3\"\nname: file\n"
+	if string(b) != expect {
+		t.Errorf("Expected values:\n%s\ngot:\n%s", expect, b)
+	}
+
+	b, err = vals(ioutil.Discard, valueFiles{}, []string{}, []string{}, "", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "{}\n"; string(b) != expect {
+		t.Errorf("Expected no values without a prefix, got %q", b)
+	}
+
+	b, err = vals(ioutil.Discard, valueFiles{}, []string{"ingress.enabled=false"}, []string{`ingress={"enabled":true,"hosts":["a","b"]}`}, "", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "ingress:\n  enabled: false\n  hosts:\n  - a\n  - b\n"; string(b) != expect {
+		t.Errorf("Expected --set to apply over --set-json, got %q", b)
+	}
+
+	if _, err := vals(ioutil.Discard, valueFiles{}, []string{}, []string{`ingress={"enabled":}`}, "", "", nil); err == nil || !strings.Contains(err.Error(), "failed parsing --set-json data") {
+		t.Errorf("Expected --set-json parse error, got %v", err)
+	}
+}
+
+func TestValsPrecedence(t *testing.T) {
+	f, err := ioutil.TempFile("", "helm-values")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString("replicas: 2\nname: file\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	tests := []struct {
+		precedence string
+		expect     string
+	}{
+		{"", "name: file\nreplicas: 3\n"},
+		{"file-first", "name: file\nreplicas: 3\n"},
+		{"set-first", "name: file\nreplicas: 2\n"},
+	}
+	for _, tt := range tests {
+		b, err := vals(ioutil.Discard, valueFiles{f.Name()}, []string{"replicas=3"}, []string{}, "", tt.precedence, nil)
+		if err != nil {
+			t.Fatalf("%q: %s", tt.precedence, err)
+		}
+		if string(b) != tt.expect {
+			t.Errorf("%q: expected values:\n%s\ngot:\n%s", tt.precedence, tt.expect, b)
+		}
+	}
+
+	if _, err := vals(ioutil.Discard, valueFiles{}, []string{}, []string{}, "", "set-last", nil); err == nil || !strings.Contains(err.Error(), "unknown values precedence") {
+		t.Errorf("Expected unknown precedence error, got %v", err)
+	}
+}
+
+func TestValsSchemaTypes(t *testing.T) {
+	types := func(path []string) string {
+		if len(path) == 2 && path[0] == "image" && path[1] == "tag" {
+			return "string"
+		}
+		return ""
+	}
+	b, err := vals(ioutil.Discard, valueFiles{}, []string{"image.tag=1234,replicas=3"}, []string{}, "", "", types)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "image:\n  tag: \"1234\"\nreplicas: 3\n"; string(b) != expect {
+		t.Errorf("Expected %q, got %q", expect, string(b))
+	}
+
+	if _, err := vals(ioutil.Discard, valueFiles{}, []string{"replicas=three"}, []string{}, "", "", func([]string) string { return "integer" }); err == nil || !strings.Contains(err.Error(), "is not of type integer") {
+		t.Errorf("Expected a type error, got %v", err)
+	}
+}
+
+func TestValsDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-values")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"20-env.yml":   "name: env\nimage:\n  tag: \"2.0\"\n",
+		"10-base.yaml": "name: base\nreplicas: 1\nimage:\n  tag: \"1.0\"\n",
+		"30-team.yaml": "replicas: 3\n",
+		"README.md":    "not: [valid yaml\n",
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var warnings bytes.Buffer
+	b, err := vals(&warnings, valueFiles{dir}, []string{}, []string{}, "", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := "image:\n  tag: \"2.0\"\nname: env\nreplicas: 3\n"
+	if string(b) != expect {
+		t.Errorf("Expected values:\n%s\ngot:\n%s", expect, b)
+	}
+	if expect := "WARNING: skipping " + filepath.Join(dir, "README.md") + ": not a YAML file\n"; warnings.String() != expect {
+		t.Errorf("Expected warning %q, got %q", expect, warnings.String())
+	}
+}
+
+func TestValsGzip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-values")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	data := []byte("image:\n  tag: \"1.2\"\nname: whaler\n")
+	plain := filepath.Join(dir, "values.yaml")
+	if err := ioutil.WriteFile(plain, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	gzipped := filepath.Join(dir, "values.yaml.gz")
+	if err := ioutil.WriteFile(gzipped, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []string{plain, gzipped} {
+		b, err := vals(ioutil.Discard, valueFiles{f}, []string{}, []string{}, "", "", nil)
+		if err != nil {
+			t.Fatalf("%s: %s", f, err)
+		}
+		if !bytes.Equal(b, data) {
+			t.Errorf("%s: expected values:\n%s\ngot:\n%s", f, data, b)
+		}
+	}
+
+	// A truncated gzip file is an error rather than garbage values.
+	broken := filepath.Join(dir, "broken.yaml.gz")
+	if err := ioutil.WriteFile(broken, buf.Bytes()[:10], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vals(ioutil.Discard, valueFiles{broken}, []string{}, []string{}, "", "", nil); err == nil || !strings.Contains(err.Error(), "failed to decompress") {
+		t.Errorf("Expected a decompression error, got %v", err)
+	}
+}
+
+func TestValsURL(t *testing.T) {
+	hh, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(hh.String())
+	oldhome := settings.Home
+	defer func() { settings.Home = oldhome }()
+	settings.Home = hh
+
+	data := []byte("image:\n  tag: \"1.3\"\nreplicas: 3\n")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/private/") {
+			if user, pass, ok := r.BasicAuth(); !ok || user != "ci" || pass != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		w.Write(data)
+	}))
+	defer srv.Close()
+
+	b, err := vals(ioutil.Discard, valueFiles{srv.URL + "/env/prod.yaml"}, []string{}, []string{}, "", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, data) {
+		t.Errorf("Expected values:\n%s\ngot:\n%s", data, b)
+	}
+
+	// The credentials of a repository are only used for the URLs in it.
+	private := srv.URL + "/private/env/prod.yaml"
+	if _, err := vals(ioutil.Discard, valueFiles{private}, []string{}, []string{}, "", "", nil); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected an unauthorized error without credentials, got %v", err)
+	}
+
+	rf := repo.NewRepoFile()
+	rf.Add(&repo.Entry{Name: "config", URL: srv.URL + "/private", Username: "ci", Password: "secret"})
+	if err := rf.WriteFile(hh.RepositoryFile(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	b, err = vals(ioutil.Discard, valueFiles{private}, []string{"replicas=5"}, []string{}, "", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "image:\n  tag: \"1.3\"\nreplicas: 5\n"; string(b) != expected {
+		t.Errorf("Expected values:\n%s\ngot:\n%s", expected, b)
+	}
+}
+
+const namespacedKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: test
+  context:
+    cluster: test
+    namespace: from-kubeconfig
+current-context: test
+`
+
+func TestInstallNamespacePrecedence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-kubeconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	kubeconfig := filepath.Join(dir, "config")
+	if err := ioutil.WriteFile(kubeconfig, []byte(namespacedKubeconfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldKubeConfig, oldKubeContext, oldDefault := settings.KubeConfig, settings.KubeContext, settings.DefaultNamespace
+	defer func() {
+		settings.KubeConfig, settings.KubeContext, settings.DefaultNamespace = oldKubeConfig, oldKubeContext, oldDefault
+	}()
+	settings.KubeConfig, settings.KubeContext = kubeconfig, ""
+
+	tests := []struct {
+		name             string
+		flags            []string
+		defaultNamespace string
+		expect           string
+	}{
+		{"kubeconfig", nil, "", "from-kubeconfig"},
+		{"client default", nil, "from-client", "from-client"},
+		{"explicit flag", []string{"--namespace", "from-flag"}, "from-client", "from-flag"},
+	}
+	for _, tt := range tests {
+		settings.DefaultNamespace = tt.defaultNamespace
+
+		c := &helm.FakeClient{}
+		cmd := newInstallCmd(c, ioutil.Discard)
+		cmd.ParseFlags(append([]string{"--name", "aeneas"}, tt.flags...))
+		if err := cmd.RunE(cmd, []string{"testdata/testcharts/alpine"}); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if len(c.Rels) != 1 || c.Rels[0].Namespace != tt.expect {
+			t.Errorf("%s: expected the release in namespace %q, got %v", tt.name, tt.expect, c.Rels)
+		}
+	}
+}
+
+func TestValsDecrypt(t *testing.T) {
+	// The values files matching secrets.*.yaml are "encrypted" with base64.
+	err := decrypt.Register(decrypt.Provider{
+		Patterns: []string{"secrets.*.yaml"},
+		Decryptor: decrypt.DecryptorFunc(func(name string, data []byte) ([]byte, error) {
+			return base64.StdEncoding.DecodeString(string(data))
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "helm-values")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	plain := filepath.Join(dir, "values.yaml")
+	if err := ioutil.WriteFile(plain, []byte("name: whaler\npassword: changeme\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	secrets := filepath.Join(dir, "secrets.prod.yaml")
+	ciphertext := base64.StdEncoding.EncodeToString([]byte("password: hunter2\n"))
+	if err := ioutil.WriteFile(secrets, []byte(ciphertext), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := vals(ioutil.Discard, valueFiles{plain, secrets}, []string{}, []string{}, "", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "name: whaler\npassword: hunter2\n"; string(b) != expect {
+		t.Errorf("Expected values:\n%s\ngot:\n%s", expect, b)
+	}
+
+	broken := filepath.Join(dir, "secrets.dev.yaml")
+	if err := ioutil.WriteFile(broken, []byte("password: hunter2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vals(ioutil.Discard, valueFiles{broken}, []string{}, []string{}, "", "", nil); err == nil || !strings.Contains(err.Error(), "failed to decrypt "+broken) {
+		t.Errorf("Expected a decryption error, got %v", err)
+	}
+}
+
+func TestApplyDefaultValues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-default-values")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	defaults := []byte("image:\n  registry: mirror.example.com\n  tag: latest\nname: default\nresources:\n  cpu: 100m\n")
+	defaultsFile := filepath.Join(dir, "defaults.yaml")
+	if err := ioutil.WriteFile(defaultsFile, defaults, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	const chartValues = "# The image of the whaler.\nimage:\n  tag: stable\nname: chart\n"
+	ch := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "whaler"},
+		Values:   &chart.Config{Raw: chartValues},
+		Dependencies: []*chart.Chart{{
+			Metadata: &chart.Metadata{Name: "harpoon"},
+			Values:   &chart.Config{Raw: "resources:\n  cpu: 50m\n"},
+		}},
+	}
+
+	tests := []struct {
+		precedence string
+		expect     map[string]interface{}
+	}{
+		{
+			precedence: defaultsFirst,
+			expect: map[string]interface{}{
+				"image":     map[string]interface{}{"registry": "mirror.example.com", "tag": "stable"},
+				"name":      "user",
+				"resources": map[string]interface{}{"cpu": "100m"},
+				"harpoon": map[string]interface{}{
+					"image":     map[string]interface{}{"registry": "mirror.example.com", "tag": "latest"},
+					"name":      "default",
+					"resources": map[string]interface{}{"cpu": "50m"},
+				},
+			},
+		},
+		{
+			precedence: chartFirst,
+			expect: map[string]interface{}{
+				"image":     map[string]interface{}{"registry": "mirror.example.com", "tag": "latest"},
+				"name":      "user",
+				"resources": map[string]interface{}{"cpu": "100m"},
+				"harpoon": map[string]interface{}{
+					"image":     map[string]interface{}{"registry": "mirror.example.com", "tag": "latest"},
+					"name":      "default",
+					"resources": map[string]interface{}{"cpu": "100m"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		rawVals, err := applyDefaultValues(ch, []byte("name: user\n"), defaultsFile, tt.precedence)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.precedence, err)
+		}
+		vals, err := chartutil.CoalesceValues(ch, &chart.Config{Raw: string(rawVals)})
+		if err != nil {
+			t.Fatalf("%s: %s", tt.precedence, err)
+		}
+		delete(vals["harpoon"].(map[string]interface{}), "global")
+		if !reflect.DeepEqual(map[string]interface{}(vals), tt.expect) {
+			t.Errorf("%s: expected %v, got %v", tt.precedence, tt.expect, vals)
+		}
+		if ch.Values.Raw != chartValues {
+			t.Errorf("%s: expected the chart values to be unchanged, got %q", tt.precedence, ch.Values.Raw)
+		}
+	}
+
+	if rawVals, err := applyDefaultValues(ch, []byte("name: user\n"), "", defaultsFirst); err != nil || string(rawVals) != "name: user\n" {
+		t.Errorf("Expected the values to be unchanged without a file, got %q, %v", rawVals, err)
+	}
+	if _, err := applyDefaultValues(ch, nil, filepath.Join(dir, "missing.yaml"), defaultsFirst); err == nil {
+		t.Error("Expected an error for a missing default values file")
+	}
+	if _, err := applyDefaultValues(ch, nil, defaultsFile, "user-first"); err == nil {
+		t.Error("Expected an error for an unknown precedence")
+	}
+}
+
+func TestResolveChartName(t *testing.T) {
+	hh, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(hh.String())
+
+	rf := repo.NewRepoFile()
+	rf.Add(&repo.Entry{Name: "stable"}, &repo.Entry{Name: "incubator"})
+	if err := rf.WriteFile(hh.RepositoryFile(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for r, charts := range map[string][]string{
+		"stable":    {"mysql", "nginx"},
+		"incubator": {"nginx"},
+	} {
+		i := repo.NewIndexFile()
+		for _, name := range charts {
+			i.Add(&chart.Metadata{Name: name, Version: "0.1.0"}, name+"-0.1.0.tgz", "http://example.com/"+r, "sha256:1234")
+		}
+		if err := i.WriteFile(hh.CacheIndex(r), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		defaultRepo string
+		expect      string
+		err         string
+	}{
+		{name: "mysql", expect: "stable/mysql"},
+		{name: "mysql", defaultRepo: "incubator", expect: "stable/mysql"},
+		{name: "nginx", defaultRepo: "incubator", expect: "incubator/nginx"},
+		{name: "nginx", err: `chart "nginx" is in several repositories, use one of: stable/nginx, incubator/nginx`},
+		{name: "redis", expect: "redis"},
+	}
+	for _, tt := range tests {
+		got, err := resolveChartName(hh, tt.defaultRepo, tt.name)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("%s: expected error %q, got %v", tt.name, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+		}
+		if got != tt.expect {
+			t.Errorf("%s (default %q): expected %q, got %q", tt.name, tt.defaultRepo, tt.expect, got)
+		}
+	}
+}
+
+func TestPrintHookPlan(t *testing.T) {
+	plan := &services.HookPlan{
+		Steps: []*services.HookPlanStep{
+			{Event: release.Hook_PRE_INSTALL, Name: "backup", Kind: "Job", Weight: -5},
+			{Event: release.Hook_POST_INSTALL, Name: "notify", Kind: "Job", DeletePolicies: []release.Hook_DeletePolicy{release.Hook_SUCCEEDED, release.Hook_FAILED}},
+		},
+		Warnings: []string{`templates/typo.yaml: unknown hook "pre-instal", the resource is skipped`},
+	}
+
+	var buf bytes.Buffer
+	printHookPlan(&buf, plan)
+	expect := "HOOK PLAN:\n" +
+		"EVENT       \tWEIGHT\tKIND\tNAME  \tDELETE POLICIES \n" +
+		"PRE_INSTALL \t-5    \tJob \tbackup\t                \n" +
+		"POST_INSTALL\t0     \tJob \tnotify\tSUCCEEDED,FAILED\n" +
+		"WARNING: templates/typo.yaml: unknown hook \"pre-instal\", the resource is skipped\n"
+	if buf.String() != expect {
+		t.Errorf("Expected\n%q\ngot\n%q", expect, buf.String())
+	}
+
+	buf.Reset()
+	printHookPlan(&buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output without a plan, got %q", buf.String())
+	}
+}
+
+func TestPrintTypeWarnings(t *testing.T) {
+	ch := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "warned"},
+		Values:   &chart.Config{Raw: "image:\n  tag: stable\nports: [80]\n"},
+	}
+
+	var buf bytes.Buffer
+	printTypeWarnings(&buf, ch, []byte("image: nginx\nports: [80, 443]\n"))
+	expect := "WARNING: image: a scalar overrides a table in the chart defaults\n"
+	if buf.String() != expect {
+		t.Errorf("Expected %q, got %q", expect, buf.String())
+	}
+
+	buf.Reset()
+	printTypeWarnings(&buf, ch, []byte("image:\n  tag: latest\n"))
+	if buf.Len() != 0 {
+		t.Errorf("Expected no warnings, got %q", buf.String())
+	}
+}
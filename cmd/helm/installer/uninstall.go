@@ -29,6 +29,9 @@ const (
 	deploymentName = "tiller-deploy"
 	serviceName    = "tiller-deploy"
 	secretName     = "tiller-secret"
+
+	// releaseRecordSelector selects the objects Tiller stores releases in.
+	releaseRecordSelector = "OWNER=TILLER"
 )
 
 // Uninstall uses Kubernetes client to uninstall Tiller.
@@ -63,6 +66,33 @@ func deleteSecret(client coreclient.SecretsGetter, namespace string) error {
 	return ingoreNotFound(err)
 }
 
+// DeleteReleaseRecords deletes the ConfigMaps and Secrets in which Tiller
+// stores the releases, and so the history of every release, from namespace.
+func DeleteReleaseRecords(client internalclientset.Interface, namespace string) error {
+	opts := metav1.ListOptions{LabelSelector: releaseRecordSelector}
+	cms, err := client.Core().ConfigMaps(namespace).List(opts)
+	if err != nil {
+		return err
+	}
+	for _, cm := range cms.Items {
+		err := client.Core().ConfigMaps(namespace).Delete(cm.Name, &metav1.DeleteOptions{})
+		if err := ingoreNotFound(err); err != nil {
+			return err
+		}
+	}
+	secrets, err := client.Core().Secrets(namespace).List(opts)
+	if err != nil {
+		return err
+	}
+	for _, s := range secrets.Items {
+		err := client.Core().Secrets(namespace).Delete(s.Name, &metav1.DeleteOptions{})
+		if err := ingoreNotFound(err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func ingoreNotFound(err error) error {
 	if apierrors.IsNotFound(err) {
 		return nil
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	testcore "k8s.io/client-go/testing"
@@ -86,3 +87,28 @@ func TestUninstall_secretNotFound(t *testing.T) {
 		t.Errorf("unexpected actions: %v, expect 7 actions got %d", actions, len(actions))
 	}
 }
+
+func TestDeleteReleaseRecords(t *testing.T) {
+	fc := fake.NewSimpleClientset(
+		&core.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      "atlas-guide.v1",
+			Namespace: core.NamespaceDefault,
+			Labels:    map[string]string{"OWNER": "TILLER"},
+		}},
+		&core.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      "atlas-guide.v1",
+			Namespace: "other",
+			Labels:    map[string]string{"OWNER": "TILLER"},
+		}},
+	)
+	if err := DeleteReleaseRecords(fc, core.NamespaceDefault); err != nil {
+		t.Fatalf("unexpected error: %#+v", err)
+	}
+
+	if cms, _ := fc.Core().ConfigMaps(core.NamespaceDefault).List(metav1.ListOptions{}); len(cms.Items) != 0 {
+		t.Errorf("expected the release records to be deleted, got %v", cms.Items)
+	}
+	if cms, _ := fc.Core().ConfigMaps("other").List(metav1.ListOptions{}); len(cms.Items) != 1 {
+		t.Errorf("expected the release records of other namespaces to be kept, got %v", cms.Items)
+	}
+}
@@ -41,6 +41,10 @@ the chart is well-formed.
 If the linter encounters things that will cause the chart to fail installation,
 it will emit [ERROR] messages. If it encounters issues that break with convention
 or recommendation, it will emit [WARNING] messages.
+
+With '--with-subcharts', the charts in the charts/ directory are linted too,
+recursively. Their messages are prefixed with the path of the subchart, and
+errors in a subchart fail the lint of the chart.
 `
 
 type lintCmd struct {
@@ -48,6 +52,7 @@ type lintCmd struct {
 	values     []string
 	namespace  string
 	strict     bool
+	subcharts  bool
 	paths      []string
 	out        io.Writer
 }
@@ -73,6 +78,7 @@ func newLintCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringArrayVar(&l.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	cmd.Flags().StringVar(&l.namespace, "namespace", "default", "namespace to install the release into (only used if --install is set)")
 	cmd.Flags().BoolVar(&l.strict, "strict", false, "fail on lint warnings")
+	cmd.Flags().BoolVar(&l.subcharts, "with-subcharts", false, "lint the subcharts of the chart too")
 
 	return cmd
 }
@@ -96,7 +102,7 @@ func (l *lintCmd) run() error {
 	var total int
 	var failures int
 	for _, path := range l.paths {
-		if linter, err := lintChart(path, rvals, l.namespace, l.strict); err != nil {
+		if linter, err := lintChart(path, rvals, l.namespace, l.strict, l.subcharts); err != nil {
 			fmt.Println("==> Skipping", path)
 			fmt.Println(err)
 		} else {
@@ -128,7 +134,7 @@ func (l *lintCmd) run() error {
 	return nil
 }
 
-func lintChart(path string, vals []byte, namespace string, strict bool) (support.Linter, error) {
+func lintChart(path string, vals []byte, namespace string, strict, subcharts bool) (support.Linter, error) {
 	var chartPath string
 	linter := support.Linter{}
 
@@ -160,6 +166,9 @@ func lintChart(path string, vals []byte, namespace string, strict bool) (support
 		return linter, errLintNoChart
 	}
 
+	if subcharts {
+		return lint.AllWithSubcharts(chartPath, vals, namespace, strict), nil
+	}
 	return lint.All(chartPath, vals, namespace, strict), nil
 }
 
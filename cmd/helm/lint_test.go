@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -26,15 +27,30 @@ var (
 	strict            = false
 	archivedChartPath = "testdata/testcharts/compressedchart-0.1.0.tgz"
 	chartDirPath      = "testdata/testcharts/decompressedchart/"
+	subchartsDirPath  = "testdata/testcharts/reqtest"
 )
 
 func TestLintChart(t *testing.T) {
-	if _, err := lintChart(chartDirPath, values, namespace, strict); err != nil {
+	if _, err := lintChart(chartDirPath, values, namespace, strict, false); err != nil {
 		t.Errorf("%s", err)
 	}
 
-	if _, err := lintChart(archivedChartPath, values, namespace, strict); err != nil {
+	if _, err := lintChart(archivedChartPath, values, namespace, strict, false); err != nil {
 		t.Errorf("%s", err)
 	}
 
+	linter, err := lintChart(subchartsDirPath, values, namespace, strict, true)
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+	var linted bool
+	for _, msg := range linter.Messages {
+		if strings.HasPrefix(msg.Path, "charts/reqsubchart3-0.2.0.tgz/") {
+			linted = true
+		}
+	}
+	if !linted {
+		t.Errorf("expected the packaged subchart to be linted, got %v", linter.Messages)
+	}
+
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
@@ -40,6 +41,19 @@ By default, it lists only releases that are deployed or failed. Flags like
 By default, items are sorted alphabetically. Use the '-d' flag to sort by
 release date.
 
+To sort by several keys, use the '--sort' flag with a comma-separated list of
+keys, each optionally followed by ':asc' or ':desc'. Releases are sorted by the
+first key, then by the next one where the previous keys are equal. The keys
+are name, updated, namespace, chart and revision:
+
+	$ helm list --sort namespace,updated:desc
+
+Releases can also be filtered by the name of their chart and by the time they
+were last deployed. Times are given as dates or in RFC 3339 format, and all
+filters must match for a release to be listed:
+
+	$ helm list --chart-name mariadb --deployed-before 2018-01-01
+
 If an argument is provided, it will be treated as a filter. Filters are
 regular expressions (Perl compatible) that are applied to the list of releases.
 Only items that match the filter will be returned.
@@ -55,6 +69,15 @@ By default, up to 256 items may be returned. To limit this, use the '--max' flag
 Setting '--max' to 0 will not return all results. Rather, it will return the
 server's default, which may be much higher than 256. Pairing the '--max'
 flag with the '--offset' flag allows you to page through results.
+
+With '--output json' or '--output yaml' the releases are printed as a list.
+'--output jsonl' prints each release as a JSON object on a line of its own,
+which suits tools that read one record per line:
+
+	$ helm list --output jsonl | jq -r 'select(.status == "FAILED") | .name'
+
+The structured formats leave out the name to pass to '--offset' for the next
+page, so use them with a '--max' that is large enough.
 `
 
 type listCmd struct {
@@ -63,6 +86,12 @@ type listCmd struct {
 	limit      int
 	offset     string
 	byDate     bool
+	sortKeys   []string
+	chartName  string
+	commit     string
+	showBuild  bool
+	before     string
+	after      string
 	sortDesc   bool
 	out        io.Writer
 	all        bool
@@ -75,6 +104,7 @@ type listCmd struct {
 	pending    bool
 	client     helm.Interface
 	colWidth   uint
+	output     string
 }
 
 func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -104,6 +134,7 @@ func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVarP(&list.short, "short", "q", false, "output short (quiet) listing format")
 	f.BoolVarP(&list.byDate, "date", "d", false, "sort by release date")
 	f.BoolVarP(&list.sortDesc, "reverse", "r", false, "reverse the sort order")
+	f.StringSliceVar(&list.sortKeys, "sort", []string{}, "sort by these keys, each optionally followed by :asc or :desc (name, updated, namespace, chart, revision). Overrides --date and --reverse")
 	f.IntVarP(&list.limit, "max", "m", 256, "maximum number of releases to fetch")
 	f.StringVarP(&list.offset, "offset", "o", "", "next release name in the list, used to offset from start value")
 	f.BoolVarP(&list.all, "all", "a", false, "show all releases, not just the ones marked DEPLOYED")
@@ -114,6 +145,12 @@ func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&list.pending, "pending", false, "show pending releases")
 	f.StringVar(&list.namespace, "namespace", "", "show releases within a specific namespace")
 	f.UintVar(&list.colWidth, "col-width", 60, "specifies the max column width of output")
+	f.StringVar(&list.output, "output", "table", outputFormatUsage)
+	f.StringVar(&list.chartName, "chart-name", "", "show releases of the chart with this name")
+	f.StringVar(&list.commit, "build-commit", "", "show releases built from this commit")
+	f.BoolVar(&list.showBuild, "show-build", false, "show the CI pipeline, commit and actor that built each release")
+	f.StringVar(&list.before, "deployed-before", "", "show releases last deployed before this date or RFC 3339 time")
+	f.StringVar(&list.after, "deployed-after", "", "show releases last deployed after this date or RFC 3339 time")
 
 	// TODO: Do we want this as a feature of 'helm list'?
 	//f.BoolVar(&list.superseded, "history", true, "show historical releases")
@@ -134,7 +171,7 @@ func (l *listCmd) run() error {
 
 	stats := l.statusCodes()
 
-	res, err := l.client.ListReleases(
+	opts := []helm.ReleaseListOption{
 		helm.ReleaseListLimit(l.limit),
 		helm.ReleaseListOffset(l.offset),
 		helm.ReleaseListFilter(l.filter),
@@ -142,12 +179,41 @@ func (l *listCmd) run() error {
 		helm.ReleaseListOrder(int32(sortOrder)),
 		helm.ReleaseListStatuses(stats),
 		helm.ReleaseListNamespace(l.namespace),
-	)
+		helm.ReleaseListChartName(l.chartName),
+		helm.ReleaseListBuildCommit(l.commit),
+	}
+	if l.before != "" {
+		t, err := parseListTime(l.before)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, helm.ReleaseListDeployedBefore(t))
+	}
+	if l.after != "" {
+		t, err := parseListTime(l.after)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, helm.ReleaseListDeployedAfter(t))
+	}
+	for _, key := range l.sortKeys {
+		opt, err := sortKeyOption(key)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, opt)
+	}
+
+	res, err := l.client.ListReleases(opts...)
 
 	if err != nil {
 		return prettyError(err)
 	}
 
+	if l.output != "table" && !l.short {
+		return printStructured(l.out, l.output, listItems(filterList(res.Releases)))
+	}
+
 	if len(res.Releases) == 0 {
 		return nil
 	}
@@ -164,10 +230,52 @@ func (l *listCmd) run() error {
 		}
 		return nil
 	}
-	fmt.Fprintln(l.out, formatList(rels, l.colWidth))
+	fmt.Fprintln(l.out, formatList(rels, l.colWidth, l.showBuild))
 	return nil
 }
 
+// sortKeys maps the keys accepted by --sort to the fields they sort by.
+var sortKeys = map[string]services.ListSort_SortBy{
+	"name":      services.ListSort_NAME,
+	"updated":   services.ListSort_LAST_RELEASED,
+	"namespace": services.ListSort_NAMESPACE,
+	"chart":     services.ListSort_CHART_NAME,
+	"revision":  services.ListSort_REVISION,
+}
+
+// sortKeyOption parses a --sort key of the form KEY[:asc|:desc].
+func sortKeyOption(key string) (helm.ReleaseListOption, error) {
+	parts := strings.SplitN(key, ":", 2)
+	sortBy, ok := sortKeys[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort key %q", parts[0])
+	}
+	sortOrder := services.ListSort_ASC
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+		case "desc":
+			sortOrder = services.ListSort_DESC
+		default:
+			return nil, fmt.Errorf("unknown sort order %q for key %q", parts[1], parts[0])
+		}
+	}
+	return helm.ReleaseListSortKey(int32(sortBy), int32(sortOrder)), nil
+}
+
+// parseListTime parses the time given to --deployed-before or --deployed-after,
+// either as a date or as an RFC 3339 time.
+func parseListTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return t, fmt.Errorf("invalid time %q: must be a date (2006-01-02) or an RFC 3339 time", s)
+	}
+	return t, nil
+}
+
 // filterList returns a list scrubbed of old releases.
 func filterList(rels []*release.Release) []*release.Release {
 	idx := map[string]int32{}
@@ -233,17 +341,65 @@ func (l *listCmd) statusCodes() []release.Status_Code {
 	return status
 }
 
-func formatList(rels []*release.Release, colWidth uint) string {
+// listItem is a release as printed by the structured output formats.
+type listItem struct {
+	Name       string `json:"name"`
+	Revision   int32  `json:"revision"`
+	Updated    string `json:"updated"`
+	Status     string `json:"status"`
+	Paused     bool   `json:"paused,omitempty"`
+	Chart      string `json:"chart"`
+	AppVersion string `json:"app_version,omitempty"`
+	Namespace  string `json:"namespace"`
+	Pipeline   string `json:"pipeline,omitempty"`
+	Commit     string `json:"commit,omitempty"`
+	Actor      string `json:"actor,omitempty"`
+}
+
+func listItems(rels []*release.Release) []interface{} {
+	items := make([]interface{}, 0, len(rels))
+	for _, r := range rels {
+		b := r.Info.GetBuildInfo()
+		items = append(items, listItem{
+			Name:       r.Name,
+			Revision:   r.Version,
+			Updated:    timeconv.Format(r.Info.LastDeployed, time.RFC3339),
+			Status:     r.Info.Status.Code.String(),
+			Paused:     r.Info.Paused,
+			Chart:      formatChartname(r.Chart),
+			AppVersion: r.Chart.GetMetadata().GetAppVersion(),
+			Namespace:  r.Namespace,
+			Pipeline:   b.GetPipeline(),
+			Commit:     b.GetCommit(),
+			Actor:      b.GetActor(),
+		})
+	}
+	return items
+}
+
+func formatList(rels []*release.Release, colWidth uint, showBuild bool) string {
 	table := uitable.New()
 
 	table.MaxColWidth = colWidth
-	table.AddRow("NAME", "REVISION", "UPDATED", "STATUS", "CHART", "NAMESPACE")
+	if showBuild {
+		table.AddRow("NAME", "REVISION", "UPDATED", "STATUS", "CHART", "NAMESPACE", "PIPELINE", "COMMIT", "ACTOR")
+	} else {
+		table.AddRow("NAME", "REVISION", "UPDATED", "STATUS", "CHART", "NAMESPACE")
+	}
 	for _, r := range rels {
 		c := fmt.Sprintf("%s-%s", r.Chart.Metadata.Name, r.Chart.Metadata.Version)
 		t := timeconv.String(r.Info.LastDeployed)
 		s := r.Info.Status.Code.String()
+		if r.Info.Paused {
+			s += " (PAUSED)"
+		}
 		v := r.Version
 		n := r.Namespace
+		if showBuild {
+			b := r.Info.GetBuildInfo()
+			table.AddRow(r.Name, v, t, s, c, n, b.GetPipeline(), b.GetCommit(), b.GetActor())
+			continue
+		}
 		table.AddRow(r.Name, v, t, s, c, n)
 	}
 	return table.String()
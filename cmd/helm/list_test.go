@@ -18,7 +18,10 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/helm"
@@ -126,6 +129,78 @@ func TestListCmd(t *testing.T) {
 			},
 			expected: "thomas-guide",
 		},
+		{
+			name: "sorted by several keys",
+			args: []string{"--sort", "namespace,updated:desc", "-q"},
+			resp: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas"}),
+			},
+			expected: "atlas",
+		},
+		{
+			name: "filtered by chart and date",
+			args: []string{"--chart-name", "foo", "--deployed-before", "2018-01-01", "--deployed-after", "2017-06-01T12:00:00Z", "-q"},
+			resp: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas"}),
+			},
+			expected: "atlas",
+		},
+		{
+			name: "filtered by commit",
+			args: []string{"--build-commit", "abc123", "-q"},
+			resp: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas"}),
+			},
+			expected: "atlas",
+		},
+		{
+			name: "with build info",
+			args: []string{"--show-build"},
+			resp: func() []*release.Release {
+				rel := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas"})
+				rel.Info.BuildInfo = &release.BuildInfo{Pipeline: "deploy", Commit: "abc123", Actor: "ci-bot"}
+				return []*release.Release{rel}
+			}(),
+			expected: "NAME \tREVISION\tUPDATED                 \tSTATUS  \tCHART           \tNAMESPACE\tPIPELINE\tCOMMIT\tACTOR \natlas\t1       \t(.*)\tDEPLOYED\tfoo-0.1.0-beta.1\tdefault  \tdeploy  \tabc123\tci-bot\n",
+		},
+		{
+			name: "with an invalid date",
+			args: []string{"--deployed-before", "last tuesday"},
+			err:  true,
+		},
+		{
+			name: "list as json",
+			args: []string{"--output", "json"},
+			resp: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas"}),
+			},
+			expected: `^\[\{"name":"atlas","revision":1,"updated":"[^"]+","status":"DEPLOYED","chart":"foo-0.1.0-beta.1","namespace":"default"\}\]\n$`,
+		},
+		{
+			name: "list as yaml",
+			args: []string{"--output", "yaml"},
+			resp: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas"}),
+			},
+			expected: `- chart: foo-0.1.0-beta.1\n  name: atlas\n  namespace: default\n  revision: 1\n  status: DEPLOYED\n`,
+		},
+		{
+			name:     "with an unknown output format",
+			args:     []string{"--output", "xml"},
+			resp:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas"})},
+			expected: "^$",
+			err:      true,
+		},
+		{
+			name: "with an unknown sort key",
+			args: []string{"--sort", "color"},
+			err:  true,
+		},
+		{
+			name: "with an unknown sort order",
+			args: []string{"--sort", "name:sideways"},
+			err:  true,
+		},
 	}
 
 	var buf bytes.Buffer
@@ -146,3 +221,81 @@ func TestListCmd(t *testing.T) {
 		buf.Reset()
 	}
 }
+
+func TestListStatusCodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		list   listCmd
+		expect []release.Status_Code
+	}{
+		{
+			name:   "no status flag",
+			expect: []release.Status_Code{release.Status_DEPLOYED, release.Status_FAILED},
+		},
+		{
+			name:   "deployed",
+			list:   listCmd{deployed: true},
+			expect: []release.Status_Code{release.Status_DEPLOYED},
+		},
+		{
+			name:   "failed",
+			list:   listCmd{failed: true},
+			expect: []release.Status_Code{release.Status_FAILED},
+		},
+		{
+			name:   "pending",
+			list:   listCmd{pending: true},
+			expect: []release.Status_Code{release.Status_PENDING_INSTALL, release.Status_PENDING_UPGRADE, release.Status_PENDING_ROLLBACK},
+		},
+		{
+			name:   "deleted",
+			list:   listCmd{deleted: true},
+			expect: []release.Status_Code{release.Status_DELETED},
+		},
+		{
+			name:   "deployed, failed and pending",
+			list:   listCmd{deployed: true, failed: true, pending: true},
+			expect: []release.Status_Code{release.Status_DEPLOYED, release.Status_FAILED, release.Status_PENDING_INSTALL, release.Status_PENDING_UPGRADE, release.Status_PENDING_ROLLBACK},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.list.statusCodes(); !reflect.DeepEqual(got, tt.expect) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expect, got)
+		}
+	}
+}
+
+func TestListCmdJSONLines(t *testing.T) {
+	c := &helm.FakeClient{
+		Rels: []*release.Release{
+			helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas"}),
+			helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide", StatusCode: release.Status_FAILED}),
+			helm.ReleaseMock(&helm.MockReleaseOptions{Name: "grumpy-cat", Namespace: "cats"}),
+		},
+	}
+	var buf bytes.Buffer
+	cmd := newListCmd(c, &buf)
+	args := []string{"--output", "jsonl"}
+	cmd.ParseFlags(args)
+	if err := cmd.RunE(cmd, args); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(c.Rels) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(c.Rels), len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var item listItem
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			t.Fatalf("line %d is not a JSON object: %s\n%s", i+1, err, line)
+		}
+		if item.Name != c.Rels[i].Name || item.Namespace != c.Rels[i].Namespace {
+			t.Errorf("line %d: expected release %s in %s, got %s in %s", i+1, c.Rels[i].Name, c.Rels[i].Namespace, item.Name, item.Namespace)
+		}
+		if status := c.Rels[i].Info.Status.Code.String(); item.Status != status {
+			t.Errorf("line %d: expected status %s, got %s", i+1, status, item.Status)
+		}
+	}
+}
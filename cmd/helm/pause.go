@@ -0,0 +1,89 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+)
+
+const pauseDesc = `
+This command pauses a release.
+
+A paused release cannot be upgraded, so automation cannot change it until it
+is resumed with 'helm resume'. To upgrade a paused release anyway, use
+'helm upgrade --force-paused'.
+`
+
+const resumeDesc = `
+This command resumes a release paused with 'helm pause', so it can be
+upgraded again.
+`
+
+type pauseCmd struct {
+	name   string
+	paused bool
+	out    io.Writer
+	client helm.Interface
+}
+
+func newPauseCmd(c helm.Interface, out io.Writer) *cobra.Command {
+	return newSetPausedCmd(c, out, true, "pause [flags] RELEASE_NAME", "prevent a release from being upgraded", pauseDesc)
+}
+
+func newResumeCmd(c helm.Interface, out io.Writer) *cobra.Command {
+	return newSetPausedCmd(c, out, false, "resume [flags] RELEASE_NAME", "allow a paused release to be upgraded again", resumeDesc)
+}
+
+func newSetPausedCmd(c helm.Interface, out io.Writer, paused bool, use, short, long string) *cobra.Command {
+	p := &pauseCmd{
+		paused: paused,
+		out:    out,
+		client: c,
+	}
+
+	return &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Long:    long,
+		PreRunE: setupConnection,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errReleaseRequired
+			}
+			p.name = args[0]
+			p.client = ensureHelmClient(p.client)
+			return p.run()
+		},
+	}
+}
+
+func (p *pauseCmd) run() error {
+	if _, err := p.client.SetReleasePaused(p.name, p.paused); err != nil {
+		return prettyError(err)
+	}
+	if p.paused {
+		fmt.Fprintf(p.out, "Release %q has been paused. It will not be upgraded until it is resumed.\n", p.name)
+	} else {
+		fmt.Fprintf(p.out, "Release %q has been resumed.\n", p.name)
+	}
+	return nil
+}
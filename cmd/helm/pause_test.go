@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestPauseCmd(t *testing.T) {
+	tests := []releaseCase{
+		{
+			name:     "pause a release",
+			args:     []string{"funny-honey"},
+			expected: "Release \"funny-honey\" has been paused. It will not be upgraded until it is resumed.\n",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-honey"})},
+		},
+		{
+			name: "pause without a release name",
+			args: []string{},
+			err:  true,
+		},
+		{
+			name: "pause a missing release",
+			args: []string{"funny-honey"},
+			err:  true,
+		},
+	}
+
+	cmd := func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newPauseCmd(c, out)
+	}
+
+	runReleaseCases(t, tests, cmd)
+}
+
+func TestResumeCmd(t *testing.T) {
+	tests := []releaseCase{
+		{
+			name:     "resume a release",
+			args:     []string{"funny-honey"},
+			expected: "Release \"funny-honey\" has been resumed.\n",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-honey", Paused: true})},
+		},
+		{
+			name: "resume without a release name",
+			args: []string{},
+			err:  true,
+		},
+	}
+
+	cmd := func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newResumeCmd(c, out)
+	}
+
+	runReleaseCases(t, tests, cmd)
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"text/template"
 	"time"
 
+	"github.com/ghodss/yaml"
+
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/timeconv"
@@ -66,6 +69,40 @@ func printRelease(out io.Writer, rel *release.Release) error {
 	return tpl(printReleaseTemplate, data, out)
 }
 
+// outputFormatUsage describes the formats accepted by the --output flag of
+// the list and history commands.
+const outputFormatUsage = "output format: table, json, yaml or jsonl (one JSON object per line)"
+
+// printStructured writes items in a machine readable format. The json and
+// yaml formats print them as a single list, jsonl prints each of them as a
+// JSON object on a line of its own.
+func printStructured(out io.Writer, format string, items []interface{}) error {
+	switch format {
+	case "json":
+		data, err := json.Marshal(items)
+		if err != nil {
+			return fmt.Errorf("Failed to Marshal JSON output: %s", err)
+		}
+		fmt.Fprintln(out, string(data))
+	case "yaml":
+		data, err := yaml.Marshal(items)
+		if err != nil {
+			return fmt.Errorf("Failed to Marshal YAML output: %s", err)
+		}
+		out.Write(data)
+	case "jsonl":
+		enc := json.NewEncoder(out)
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				return fmt.Errorf("Failed to Marshal JSON output: %s", err)
+			}
+		}
+	default:
+		return fmt.Errorf("Unknown output format %q", format)
+	}
+	return nil
+}
+
 func tpl(t string, vals map[string]interface{}, out io.Writer) error {
 	tt, err := template.New("_").Parse(t)
 	if err != nil {
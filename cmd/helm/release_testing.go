@@ -31,6 +31,15 @@ The test command runs the tests for a release.
 
 The argument this command takes is the name of a deployed release.
 The tests to be run are defined in the chart that was installed.
+
+To run only some of the tests, use '--filter' with a shell pattern or, after
+'~', a regular expression that test names must match. The flag can be given
+multiple times to run the tests matching any of the filters:
+
+	$ helm test --filter name=~smoke my-release
+	$ helm test --filter 'name=api-*' my-release
+
+It is an error if no test matches.
 `
 
 type releaseTestCmd struct {
@@ -39,6 +48,7 @@ type releaseTestCmd struct {
 	client  helm.Interface
 	timeout int64
 	cleanup bool
+	filters []string
 }
 
 func newReleaseTestCmd(c helm.Interface, out io.Writer) *cobra.Command {
@@ -64,8 +74,9 @@ func newReleaseTestCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	}
 
 	f := cmd.Flags()
-	f.Int64Var(&rlsTest.timeout, "timeout", 300, "time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
+	f.Var(newTimeoutValue(defaultTimeout, &rlsTest.timeout), "timeout", timeoutUsage)
 	f.BoolVar(&rlsTest.cleanup, "cleanup", false, "delete test pods upon completion")
+	f.StringArrayVar(&rlsTest.filters, "filter", []string{}, "only run the tests whose name matches, given as name=GLOB or name=~REGEX (can specify multiple)")
 
 	return cmd
 }
@@ -75,6 +86,7 @@ func (t *releaseTestCmd) run() (err error) {
 		t.name,
 		helm.ReleaseTestTimeout(t.timeout),
 		helm.ReleaseTestCleanup(t.cleanup),
+		helm.ReleaseTestFilters(t.filters),
 	)
 	testErr := &testErr{}
 
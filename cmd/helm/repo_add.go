@@ -33,6 +33,12 @@ type repoAddCmd struct {
 	home     helmpath.Home
 	noupdate bool
 
+	username string
+	password string
+	token    string
+	// passCredentials sends the credentials to chart URLs on other hosts.
+	passCredentials bool
+
 	certFile string
 	keyFile  string
 	caFile   string
@@ -61,6 +67,10 @@ func newRepoAddCmd(out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	f.BoolVar(&add.noupdate, "no-update", false, "raise error if repo is already registered")
+	f.StringVar(&add.username, "username", "", "chart repository username")
+	f.StringVar(&add.password, "password", "", "chart repository password")
+	f.StringVar(&add.token, "token", "", "chart repository bearer token")
+	f.BoolVar(&add.passCredentials, "pass-credentials", false, "pass credentials to chart URLs on all hosts, not only the repository host")
 	f.StringVar(&add.certFile, "cert-file", "", "identify HTTPS client using this SSL certificate file")
 	f.StringVar(&add.keyFile, "key-file", "", "identify HTTPS client using this SSL key file")
 	f.StringVar(&add.caFile, "ca-file", "", "verify certificates of HTTPS-enabled servers using this CA bundle")
@@ -69,14 +79,14 @@ func newRepoAddCmd(out io.Writer) *cobra.Command {
 }
 
 func (a *repoAddCmd) run() error {
-	if err := addRepository(a.name, a.url, a.home, a.certFile, a.keyFile, a.caFile, a.noupdate); err != nil {
+	if err := addRepository(a.name, a.url, a.username, a.password, a.token, a.passCredentials, a.home, a.certFile, a.keyFile, a.caFile, a.noupdate); err != nil {
 		return err
 	}
 	fmt.Fprintf(a.out, "%q has been added to your repositories\n", a.name)
 	return nil
 }
 
-func addRepository(name, url string, home helmpath.Home, certFile, keyFile, caFile string, noUpdate bool) error {
+func addRepository(name, url, username, password, token string, passCredentials bool, home helmpath.Home, certFile, keyFile, caFile string, noUpdate bool) error {
 	f, err := repo.LoadRepositoriesFile(home.RepositoryFile())
 	if err != nil {
 		return err
@@ -91,9 +101,14 @@ func addRepository(name, url string, home helmpath.Home, certFile, keyFile, caFi
 		Name:     name,
 		Cache:    cif,
 		URL:      url,
+		Username: username,
+		Password: password,
+		Token:    token,
 		CertFile: certFile,
 		KeyFile:  keyFile,
 		CAFile:   caFile,
+
+		PassCredentialsAll: passCredentials,
 	}
 
 	r, err := repo.NewChartRepository(&c, getter.All(settings))
@@ -107,5 +122,5 @@ func addRepository(name, url string, home helmpath.Home, certFile, keyFile, caFi
 
 	f.Update(&c)
 
-	return f.WriteFile(home.RepositoryFile(), 0644)
+	return f.WriteFile(home.RepositoryFile(), 0600)
 }
@@ -68,7 +68,7 @@ func removeRepoLine(out io.Writer, name string, home helmpath.Home) error {
 	if !r.Remove(name) {
 		return fmt.Errorf("no repo named %q found", name)
 	}
-	if err := r.WriteFile(repoFile, 0644); err != nil {
+	if err := r.WriteFile(repoFile, 0600); err != nil {
 		return err
 	}
 
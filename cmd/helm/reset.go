@@ -35,12 +35,22 @@ const resetDesc = `
 This command uninstalls Tiller (the Helm server-side component) from your
 Kubernetes Cluster and optionally deletes local configuration in
 $HELM_HOME (default ~/.helm/)
+
+The release records Tiller stores are kept by default, so the releases and
+their history survive a reinstall of Tiller; '--keep-history' says so
+explicitly. Use '--purge-history' to also delete the release records, along
+with the history of every release. The records are looked up in the namespace
+given by '--storage-namespace', which must match the '--storage-namespace' Tiller
+was started with and defaults to Tiller's namespace.
 `
 
 type resetCmd struct {
 	force          bool
 	removeHelmHome bool
+	keepHistory    bool
+	purgeHistory   bool
 	namespace      string
+	storageNS      string
 	out            io.Writer
 	home           helmpath.Home
 	client         helm.Interface
@@ -79,12 +89,19 @@ func newResetCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	f.BoolVarP(&d.force, "force", "f", false, "forces Tiller uninstall even if there are releases installed, or if Tiller is not in ready state")
 	f.BoolVar(&d.removeHelmHome, "remove-helm-home", false, "if set deletes $HELM_HOME")
+	f.BoolVar(&d.keepHistory, "keep-history", false, "keep the release records Tiller stores, so the releases and their history survive a reinstall of Tiller. This is the default")
+	f.BoolVar(&d.purgeHistory, "purge-history", false, "also delete the release records Tiller stores, and so the history of every release")
+	f.StringVar(&d.storageNS, "storage-namespace", "", "namespace Tiller stores the release records in, if not its own. Used with --purge-history")
 
 	return cmd
 }
 
 // runReset uninstalls tiller from Kubernetes Cluster and deletes local config
 func (d *resetCmd) run() error {
+	if d.keepHistory && d.purgeHistory {
+		return errors.New("--keep-history and --purge-history cannot be used together")
+	}
+
 	if d.kubeClient == nil {
 		c, err := getInternalKubeClient(settings.KubeContext)
 		if err != nil {
@@ -108,6 +125,16 @@ func (d *resetCmd) run() error {
 		return fmt.Errorf("error unstalling Tiller: %s", err)
 	}
 
+	if d.purgeHistory {
+		storageNS := d.storageNS
+		if storageNS == "" {
+			storageNS = d.namespace
+		}
+		if err := installer.DeleteReleaseRecords(d.kubeClient, storageNS); err != nil {
+			return fmt.Errorf("error deleting release records: %s", err)
+		}
+	}
+
 	if d.removeHelmHome {
 		if err := deleteDirectories(d.home, d.out); err != nil {
 			return err
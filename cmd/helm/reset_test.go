@@ -23,6 +23,7 @@ import (
 	"strings"
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/fake"
 
@@ -168,3 +169,99 @@ func TestReset_forceFlag(t *testing.T) {
 		t.Errorf("Helm home directory %s does not exists", home)
 	}
 }
+
+func TestReset_purgeHistory(t *testing.T) {
+	const storageNS = "tiller-storage"
+
+	tests := []struct {
+		name         string
+		keepHistory  bool
+		purgeHistory bool
+		storageNS    string
+		remaining    map[string]int
+	}{
+		{"keep history by default", false, false, storageNS, map[string]int{core.NamespaceDefault: 2, storageNS: 3}},
+		{"keep history", true, false, "", map[string]int{core.NamespaceDefault: 2, storageNS: 3}},
+		{"purge tiller namespace", false, true, "", map[string]int{core.NamespaceDefault: 0, storageNS: 3}},
+		{"purge storage namespace", false, true, storageNS, map[string]int{core.NamespaceDefault: 2, storageNS: 1}},
+	}
+
+	for _, tt := range tests {
+		home, err := ioutil.TempDir("", "helm_home")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(home)
+
+		var buf bytes.Buffer
+		fc := fake.NewSimpleClientset(
+			&core.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      "atlas-guide.v1",
+				Namespace: core.NamespaceDefault,
+				Labels:    map[string]string{"OWNER": "TILLER", "NAME": "atlas-guide"},
+			}},
+			&core.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:      "thomas-guide.v1",
+				Namespace: core.NamespaceDefault,
+				Labels:    map[string]string{"OWNER": "TILLER", "NAME": "thomas-guide"},
+			}},
+			&core.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      "atlas-guide.v1",
+				Namespace: storageNS,
+				Labels:    map[string]string{"OWNER": "TILLER", "NAME": "atlas-guide"},
+			}},
+			&core.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:      "thomas-guide.v1",
+				Namespace: storageNS,
+				Labels:    map[string]string{"OWNER": "TILLER", "NAME": "thomas-guide"},
+			}},
+			&core.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      "unrelated",
+				Namespace: storageNS,
+			}},
+		)
+		cmd := &resetCmd{
+			keepHistory:  tt.keepHistory,
+			purgeHistory: tt.purgeHistory,
+			storageNS:    tt.storageNS,
+			out:          &buf,
+			home:         helmpath.Home(home),
+			client:       &helm.FakeClient{},
+			kubeClient:   fc,
+			namespace:    core.NamespaceDefault,
+		}
+		if err := cmd.run(); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+
+		for ns, expect := range tt.remaining {
+			cms, err := fc.Core().ConfigMaps(ns).List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			secrets, err := fc.Core().Secrets(ns).List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(cms.Items) + len(secrets.Items); got != expect {
+				t.Errorf("%s: expected %d objects left in %s, got %d", tt.name, expect, ns, got)
+			}
+		}
+	}
+}
+
+func TestReset_keepAndPurgeHistory(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &resetCmd{
+		keepHistory:  true,
+		purgeHistory: true,
+		out:          &buf,
+		client:       &helm.FakeClient{},
+		kubeClient:   fake.NewSimpleClientset(),
+		namespace:    core.NamespaceDefault,
+	}
+	err := cmd.run()
+	if err == nil || !strings.Contains(err.Error(), "cannot be used together") {
+		t.Errorf("expected --keep-history and --purge-history to be rejected, got %v", err)
+	}
+}
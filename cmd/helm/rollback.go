@@ -45,6 +45,7 @@ type rollbackCmd struct {
 	client       helm.Interface
 	timeout      int64
 	wait         bool
+	description  string
 }
 
 func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
@@ -81,8 +82,9 @@ func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&rollback.recreate, "recreate-pods", false, "performs pods restart for the resource if applicable")
 	f.BoolVar(&rollback.force, "force", false, "force resource update through delete/recreate if needed")
 	f.BoolVar(&rollback.disableHooks, "no-hooks", false, "prevent hooks from running during rollback")
-	f.Int64Var(&rollback.timeout, "timeout", 300, "time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
+	f.Var(newTimeoutValue(defaultTimeout, &rollback.timeout), "timeout", timeoutUsage)
 	f.BoolVar(&rollback.wait, "wait", false, "if set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
+	f.StringVar(&rollback.description, "description", "", "specify a description for the release, shown in 'helm history'")
 
 	return cmd
 }
@@ -96,7 +98,8 @@ func (r *rollbackCmd) run() error {
 		helm.RollbackDisableHooks(r.disableHooks),
 		helm.RollbackVersion(r.revision),
 		helm.RollbackTimeout(r.timeout),
-		helm.RollbackWait(r.wait))
+		helm.RollbackWait(r.wait),
+		helm.RollbackDescription(r.description))
 	if err != nil {
 		return prettyError(err)
 	}
@@ -43,6 +43,9 @@ The status consists of:
 - list of resources that this release consists of, sorted by kind
 - details on last test suite run, if applicable
 - additional notes provided by the chart
+
+With --revision, the status of an earlier revision is shown as it was
+recorded, along with the values and the manifest of that revision.
 `
 
 type statusCmd struct {
@@ -91,6 +94,9 @@ func (s *statusCmd) run() error {
 	switch s.outfmt {
 	case "":
 		PrintStatus(s.out, res)
+		if s.version > 0 {
+			printRevision(s.out, res)
+		}
 		return nil
 	case "json":
 		data, err := json.Marshal(res)
@@ -119,6 +125,14 @@ func PrintStatus(out io.Writer, res *services.GetReleaseStatusResponse) {
 	}
 	fmt.Fprintf(out, "NAMESPACE: %s\n", res.Namespace)
 	fmt.Fprintf(out, "STATUS: %s\n", res.Info.Status.Code)
+	if res.Info.Paused {
+		fmt.Fprintf(out, "PAUSED: true\n")
+	}
+	if b := res.Info.BuildInfo; b != nil {
+		fmt.Fprintf(out, "BUILD PIPELINE: %s\n", b.Pipeline)
+		fmt.Fprintf(out, "BUILD COMMIT: %s\n", b.Commit)
+		fmt.Fprintf(out, "BUILD ACTOR: %s\n", b.Actor)
+	}
 	fmt.Fprintf(out, "\n")
 	if len(res.Info.Status.Resources) > 0 {
 		re := regexp.MustCompile("  +")
@@ -140,6 +154,13 @@ func PrintStatus(out io.Writer, res *services.GetReleaseStatusResponse) {
 	}
 }
 
+// printRevision prints out the values and manifest stored for the revision
+// of a release.
+func printRevision(out io.Writer, res *services.GetReleaseStatusResponse) {
+	fmt.Fprintf(out, "USER-SUPPLIED VALUES:\n%s\n", res.Config.GetRaw())
+	fmt.Fprintf(out, "MANIFEST:\n%s\n", res.Manifest)
+}
+
 func formatTestResults(results []*release.TestRun) string {
 	tbl := uitable.New()
 	tbl.MaxColWidth = 50
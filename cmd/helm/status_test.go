@@ -75,6 +75,16 @@ func TestStatusCmd(t *testing.T) {
 				Notes: "release notes",
 			}),
 		},
+		{
+			name:     "get status of a deployed release with build info",
+			args:     []string{"flummoxed-chickadee"},
+			expected: outputWithStatus("DEPLOYED\nBUILD PIPELINE: deploy\nBUILD COMMIT: abc123\nBUILD ACTOR: ci-bot\n\n"),
+			rel: func() *release.Release {
+				rel := releaseMockWithStatus(&release.Status{Code: release.Status_DEPLOYED})
+				rel.Info.BuildInfo = &release.BuildInfo{Pipeline: "deploy", Commit: "abc123", Actor: "ci-bot"}
+				return rel
+			}(),
+		},
 		{
 			name:     "get status of a deployed release with resources",
 			args:     []string{"flummoxed-chickadee"},
@@ -152,6 +162,35 @@ func TestStatusCmd(t *testing.T) {
 	}
 }
 
+func TestStatusCmdRevision(t *testing.T) {
+	rels := []*release.Release{
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "flummoxed-chickadee", Version: 2}),
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "flummoxed-chickadee", Version: 1, StatusCode: release.Status_SUPERSEDED}),
+	}
+	rels[1].Config.Raw = "name: old"
+
+	tests := []releaseCase{
+		{
+			name:     "get status of an older revision",
+			args:     []string{"flummoxed-chickadee"},
+			flags:    []string{"--revision", "1"},
+			expected: "STATUS: SUPERSEDED\n\nUSER-SUPPLIED VALUES:\nname: old\nMANIFEST:\n" + helm.MockManifest + "\n$",
+			rels:     rels,
+		},
+		{
+			name:     "get status of a missing revision",
+			args:     []string{"flummoxed-chickadee"},
+			flags:    []string{"--revision", "3"},
+			expected: "^$",
+			err:      true,
+			rels:     rels,
+		},
+	}
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newStatusCmd(c, out)
+	})
+}
+
 func outputWithStatus(status string) string {
 	return fmt.Sprintf("LAST DEPLOYED: %s\nNAMESPACE: \nSTATUS: %s",
 		dateString,
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -32,6 +33,7 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/engine"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	util "k8s.io/helm/pkg/releaseutil"
@@ -60,6 +62,23 @@ is done.
 To render just one template in a chart, use '-x':
 
 	$ helm template mychart -x templates/deployment.yaml
+
+To check the rendered manifests against a cluster without creating anything,
+use '--validate'. The manifests are sent to the API server of the current kube
+context as a server-side dry run, so schema validation and admission webhooks
+are applied. This requires Kubernetes 1.13 or later.
+
+Templates are rendered as for an install. To render them as for an upgrade,
+with .Release.IsUpgrade set, use '--is-upgrade'.
+
+With '--debug', a trace of the rendering is printed first: for every template,
+the values of its chart and its rendered output, as YAML comments. If a
+template fails, the trace ends with it and its error. The data of Secrets is
+redacted in the trace.
+
+A packaged chart can be read from stdin by giving '-' as the chart:
+
+	$ cat mychart-0.1.0.tgz | helm template -
 `
 
 type templateCmd struct {
@@ -68,12 +87,19 @@ type templateCmd struct {
 	chartPath    string
 	out          io.Writer
 	values       []string
+	jsonValues   []string
+	envPrefix    string
+	precedence   string
+	defaultVals  string
+	defaultsPrec string
 	nameTemplate string
 	showNotes    bool
 	releaseName  string
 	renderFiles  []string
 	kubeVersion  string
 	outputDir    string
+	validate     bool
+	isUpgrade    bool
 }
 
 func newTemplateCmd(out io.Writer) *cobra.Command {
@@ -96,9 +122,16 @@ func newTemplateCmd(out io.Writer) *cobra.Command {
 	f.VarP(&t.valueFiles, "values", "f", "specify values in a YAML file (can specify multiple)")
 	f.StringVar(&t.namespace, "namespace", "", "namespace to install the release into")
 	f.StringArrayVar(&t.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&t.jsonValues, "set-json", []string{}, "set JSON values on the command line (can specify multiple or separate values with commas: key1=jsonval1,key2=jsonval2)")
+	f.StringVar(&t.envPrefix, "values-env-prefix", "", "set values from environment variables with this prefix, using __ to separate nested keys (e.g. HELM_VAL_image__tag=1.2)")
+	f.StringVar(&t.precedence, "values-precedence", "file-first", "order in which values are merged: file-first lets --set and --set-json override -f files, set-first lets -f files override them")
+	f.StringVar(&t.defaultVals, "default-values", "", "values file applied to the chart and its subcharts, beneath the user's values")
+	f.StringVar(&t.defaultsPrec, "default-values-precedence", defaultsFirst, "order in which --default-values and the default values of the charts are merged: defaults-first lets the charts override the file, chart-first lets the file override the charts")
 	f.StringVar(&t.nameTemplate, "name-template", "", "specify template used to name the release")
 	f.StringVar(&t.kubeVersion, "kube-version", defaultKubeVersion, "kubernetes version used as Capabilities.KubeVersion.Major/Minor")
 	f.StringVar(&t.outputDir, "output-dir", "", "writes the executed templates to files in output-dir instead of stdout")
+	f.BoolVar(&t.validate, "validate", false, "validate the rendered manifests against the cluster with a server-side dry run")
+	f.BoolVar(&t.isUpgrade, "is-upgrade", false, "render as an upgrade, setting .Release.IsUpgrade instead of .Release.IsInstall")
 
 	return cmd
 }
@@ -107,8 +140,21 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
 		return errors.New("chart is required")
 	}
-	// verify chart path exists
-	if _, err := os.Stat(args[0]); err == nil {
+	// read the chart from stdin, or verify chart path exists
+	if args[0] == stdinChart {
+		if len(t.renderFiles) > 0 {
+			return errors.New("--execute cannot be used with a chart read from stdin")
+		}
+		if t.valueFiles.hasStdin() {
+			return errors.New("stdin cannot hold both the chart and values")
+		}
+		cp, cleanup, err := bufferStdinChart(os.Stdin)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		t.chartPath = cp
+	} else if _, err := os.Stat(args[0]); err == nil {
 		if t.chartPath, err = filepath.Abs(args[0]); err != nil {
 			return err
 		}
@@ -148,13 +194,6 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 	if t.namespace == "" {
 		t.namespace = defaultNamespace()
 	}
-	// get combined values and create config
-	rawVals, err := vals(t.valueFiles, t.values)
-	if err != nil {
-		return err
-	}
-	config := &chart.Config{Raw: string(rawVals), Values: map[string]*chart.Value{}}
-
 	// If template is specified, try to run the template.
 	if t.nameTemplate != "" {
 		t.releaseName, err = generateName(t.nameTemplate)
@@ -176,10 +215,30 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 	} else if err != chartutil.ErrRequirementsNotFound {
 		return fmt.Errorf("cannot load requirements: %v", err)
 	}
+
+	// get combined values and create config
+	types, err := chartutil.SchemaTypes(c)
+	if err != nil {
+		return err
+	}
+	rawVals, err := vals(os.Stderr, t.valueFiles, t.values, t.jsonValues, t.envPrefix, t.precedence, types)
+	if err != nil {
+		return err
+	}
+	rawVals, err = applyDefaultValues(c, rawVals, t.defaultVals, t.defaultsPrec)
+	if err != nil {
+		return err
+	}
+	config := &chart.Config{Raw: string(rawVals), Values: map[string]*chart.Value{}}
+
+	// The manifests go to stdout, so keep the warnings out of them.
+	printTypeWarnings(os.Stderr, c, rawVals)
 	options := chartutil.ReleaseOptions{
 		Name:      t.releaseName,
 		Time:      timeconv.Now(),
 		Namespace: t.namespace,
+		IsInstall: !t.isUpgrade,
+		IsUpgrade: t.isUpgrade,
 	}
 
 	err = chartutil.ProcessRequirementsEnabled(c, config)
@@ -214,7 +273,14 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	out, err := renderer.Render(c, vals)
+	var out map[string]string
+	if settings.Debug {
+		var trace []engine.TemplateTrace
+		out, trace, err = renderer.RenderWithTrace(c, vals)
+		printRenderTrace(t.out, trace)
+	} else {
+		out, err = renderer.Render(c, vals)
+	}
 	listManifests := []tiller.Manifest{}
 	if err != nil {
 		return err
@@ -255,6 +321,7 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 		printRelease(os.Stdout, rel)
 	}
 
+	var manifests bytes.Buffer
 	for _, m := range tiller.SortByKind(listManifests) {
 		if len(t.renderFiles) > 0 && !in(m.Name, rf) {
 			continue
@@ -267,6 +334,9 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 		if strings.HasPrefix(b, "_") {
 			continue
 		}
+		if t.validate && b != "NOTES.txt" && !whitespaceRegex.MatchString(data) {
+			fmt.Fprintf(&manifests, "---\n# Source: %s\n%s\n", m.Name, data)
+		}
 
 		if t.outputDir != "" {
 			// blank template after execution
@@ -282,9 +352,41 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 		fmt.Printf("---\n# Source: %s\n", m.Name)
 		fmt.Println(data)
 	}
+
+	if t.validate {
+		kc := kube.New(kube.GetConfig(settings.KubeContext, settings.KubeConfig))
+		if err := kc.DryRunCreate(t.namespace, &manifests); err != nil {
+			return fmt.Errorf("validation failed: %s", err)
+		}
+	}
 	return nil
 }
 
+// printRenderTrace prints, for every rendered template, the keys of the values
+// it was rendered with (their contents are redacted) and its output, as YAML
+// comments.
+func printRenderTrace(out io.Writer, trace []engine.TemplateTrace) {
+	comment := func(s string) {
+		for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+			fmt.Fprintln(out, strings.TrimRight("#   "+line, " "))
+		}
+	}
+	for _, tt := range trace {
+		fmt.Fprintf(out, "---\n# Trace: %s\n# Values:\n", tt.Template)
+		vals, err := tt.Values.YAML()
+		if err != nil {
+			vals = err.Error()
+		}
+		comment(vals)
+		fmt.Fprintln(out, "# Output:")
+		comment(tt.Output)
+		if tt.Err != nil {
+			fmt.Fprintln(out, "# Error:")
+			comment(tt.Err.Error())
+		}
+	}
+}
+
 // write the <data> to <output-dir>/<name>
 func writeToFile(outputDir string, name string, data string) error {
 	outfileName := strings.Join([]string{outputDir, name}, string(filepath.Separator))
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -111,6 +112,20 @@ func TestTemplateCmd(t *testing.T) {
 			expectKey:   "subchart1/templates/service.yaml",
 			expectValue: "kube-version/major: \"1\"\n    kube-version/minor: \"6\"\n    kube-version/gitversion: \"v1.6.0\"",
 		},
+		{
+			name:        "check_is_install",
+			desc:        "verify templates render as an install by default",
+			args:        []string{chartPath},
+			expectKey:   "subchart1/templates/service.yaml",
+			expectValue: "release-action: \"install\"",
+		},
+		{
+			name:        "check_is_upgrade",
+			desc:        "verify --is-upgrade renders templates as an upgrade",
+			args:        []string{chartPath, "--is-upgrade"},
+			expectKey:   "subchart1/templates/service.yaml",
+			expectValue: "release-action: \"upgrade\"",
+		},
 	}
 
 	var buf bytes.Buffer
@@ -165,3 +180,61 @@ func TestTemplateCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateCmdTrace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-template-trace-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"Chart.yaml":               "name: traced\nversion: 0.1.0\n",
+		"values.yaml":              "greeting: hello\npassword: hunter2\n",
+		"templates/configmap.yaml": "kind: ConfigMap\nmetadata:\n  name: traced\ndata:\n  greeting: {{ .Values.greeting }}\n",
+		"templates/secret.yaml":    "kind: Secret\nmetadata:\n  name: traced\nstringData:\n  password: {{ .Values.password }}\n",
+	}
+	for name, data := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dbg := settings.Debug
+	settings.Debug = true
+	defer func() { settings.Debug = dbg }()
+
+	// The manifests and the release are printed to stdout, the trace to out.
+	old := os.Stdout
+	os.Stdout, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	defer func() { os.Stdout = old }()
+
+	out := bytes.NewBuffer(nil)
+	cmd := newTemplateCmd(out)
+	cmd.SetArgs([]string{dir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	trace := out.String()
+	for _, expect := range []string{
+		"# Trace: traced/templates/configmap.yaml\n# Values:\n",
+		"#   greeting: REDACTED\n#   password: REDACTED\n",
+		"# Output:\n#   kind: ConfigMap\n",
+		"#   data:\n#     greeting: hello\n",
+		"# Trace: traced/templates/secret.yaml\n",
+		"#     password: REDACTED\n",
+	} {
+		if !strings.Contains(trace, expect) {
+			t.Errorf("expected %q in the trace, got\n%s", expect, trace)
+		}
+	}
+	// Neither the values nor the Secret show the password.
+	if strings.Contains(trace, "hunter2") {
+		t.Errorf("expected the password to be redacted, got\n%s", trace)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// defaultTimeout is the default value of the --timeout flags, in seconds.
+const defaultTimeout = 300
+
+const timeoutUsage = "time to wait for any individual Kubernetes operation (like Jobs for hooks). Accepts a duration such as 10m or 1h30m, or a number of seconds"
+
+// timeoutValue is a flag value holding a timeout in whole seconds.
+//
+// It accepts a Go duration string, such as "10m" or "1h30m". A bare integer is
+// interpreted as a number of seconds, as the flag used to be.
+type timeoutValue int64
+
+func newTimeoutValue(val int64, p *int64) *timeoutValue {
+	*p = val
+	return (*timeoutValue)(p)
+}
+
+func (t *timeoutValue) String() string {
+	return (time.Duration(*t) * time.Second).String()
+}
+
+func (t *timeoutValue) Type() string {
+	return "duration"
+}
+
+func (t *timeoutValue) Set(value string) error {
+	secs, err := parseTimeout(value)
+	if err != nil {
+		return err
+	}
+	*t = timeoutValue(secs)
+	return nil
+}
+
+// parseTimeout parses a timeout into whole seconds. Durations that are not a
+// whole number of seconds are rounded up.
+func parseTimeout(value string) (int64, error) {
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if secs < 0 {
+			return 0, errors.New("timeout must not be negative")
+		}
+		return secs, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.New("timeout must be a duration such as 10m or 1h30m, or a number of seconds")
+	}
+	if d < 0 {
+		return 0, errors.New("timeout must not be negative")
+	}
+	return int64((d + time.Second - 1) / time.Second), nil
+}
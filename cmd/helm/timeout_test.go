@@ -0,0 +1,75 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		value  string
+		expect int64
+		err    bool
+	}{
+		{value: "10m", expect: 600},
+		{value: "1h30m", expect: 5400},
+		{value: "300", expect: 300},
+		{value: "0", expect: 0},
+		{value: "1500ms", expect: 2},
+		{value: "ten minutes", err: true},
+		{value: "-5", err: true},
+		{value: "-1m", err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseTimeout(tt.value)
+		if (err != nil) != tt.err {
+			t.Errorf("%q: expected error %t, got %v", tt.value, tt.err, err)
+			continue
+		}
+		if got != tt.expect {
+			t.Errorf("%q: expected %d seconds, got %d", tt.value, tt.expect, got)
+		}
+	}
+}
+
+func TestTimeoutFlag(t *testing.T) {
+	var timeout int64
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	f.Var(newTimeoutValue(defaultTimeout, &timeout), "timeout", timeoutUsage)
+
+	if timeout != defaultTimeout {
+		t.Errorf("expected default of %d seconds, got %d", defaultTimeout, timeout)
+	}
+	if def := f.Lookup("timeout").DefValue; def != "5m0s" {
+		t.Errorf("expected default to be shown as 5m0s, got %q", def)
+	}
+
+	if err := f.Parse([]string{"--timeout", "10m"}); err != nil {
+		t.Fatal(err)
+	}
+	if timeout != 600 {
+		t.Errorf("expected 600 seconds, got %d", timeout)
+	}
+
+	if err := f.Parse([]string{"--timeout", "soon"}); err == nil {
+		t.Error("expected an invalid timeout to fail")
+	}
+}
@@ -50,6 +50,29 @@ last (right-most) set specified. For example, if both 'bar' and 'newbar' values
 set for a key called 'foo', the 'newbar' value would take precedence:
 
 	$ helm upgrade --set foo=bar --set foo=newbar redis ./redis
+
+By default, the resources created by a failed upgrade are left in place. With
+'--cleanup-on-fail' they are deleted again, leaving the release with the
+resources it had before. Resources that already existed and were only updated
+are never deleted.
+
+Every upgrade creates a new revision, even if it changes nothing. With
+'--skip-unchanged', an upgrade that renders the same chart version, values,
+manifests and hooks as the deployed release keeps its revision and only
+updates its timestamp. The helm.sh/revision labels of the resources are not
+compared. Templates that use .Release.Revision or .Release.Time change with
+every revision, so such upgrades are never skipped. '--force' always creates a
+new revision.
+
+An upgrade fails if one of its resources is labeled as owned by another
+release, or if a resource it adds already exists in the cluster. With
+'--take-ownership', such resources are adopted and patched to the rendered
+resources instead.
+
+As with 'helm install', resources are applied in the order of their kinds.
+With '--ordered-apply', they are applied in the order of their template files
+instead. Namespaces and CustomResourceDefinitions still go first. Pass the
+flag on every upgrade of a release that needs it; it is not remembered.
 `
 
 type upgradeCmd struct {
@@ -60,10 +83,19 @@ type upgradeCmd struct {
 	dryRun       bool
 	recreate     bool
 	force        bool
+	forcePaused  bool
 	disableHooks bool
 	valueFiles   valueFiles
 	values       []string
+	jsonValues   []string
+	envPrefix    string
+	precedence   string
+	defaultVals  string
+	defaultsPrec string
+	valuesFrom   string
+	patches      []string
 	verify       bool
+	noCache      bool
 	keyring      string
 	install      bool
 	namespace    string
@@ -72,9 +104,21 @@ type upgradeCmd struct {
 	resetValues  bool
 	reuseValues  bool
 	wait         bool
+	postWait     int64
+	cleanupFail  bool
+	failOnEmpty  bool
+	skipSame     bool
+	takeOwner    bool
+	orderedApply bool
 	repoURL      string
+	description  string
+	commonLabels bool
 	devel        bool
 
+	buildPipeline string
+	buildCommit   string
+	buildActor    string
+
 	certFile string
 	keyFile  string
 	caFile   string
@@ -115,23 +159,43 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&upgrade.dryRun, "dry-run", false, "simulate an upgrade")
 	f.BoolVar(&upgrade.recreate, "recreate-pods", false, "performs pods restart for the resource if applicable")
 	f.BoolVar(&upgrade.force, "force", false, "force resource update through delete/recreate if needed")
+	f.BoolVar(&upgrade.forcePaused, "force-paused", false, "upgrade the release even if it has been paused with 'helm pause'")
 	f.StringArrayVar(&upgrade.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&upgrade.jsonValues, "set-json", []string{}, "set JSON values on the command line (can specify multiple or separate values with commas: key1=jsonval1,key2=jsonval2)")
+	f.StringVar(&upgrade.envPrefix, "values-env-prefix", "", "set values from environment variables with this prefix, using __ to separate nested keys (e.g. HELM_VAL_image__tag=1.2)")
+	f.StringVar(&upgrade.precedence, "values-precedence", "file-first", "order in which values are merged: file-first lets --set and --set-json override -f files, set-first lets -f files override them")
+	f.StringVar(&upgrade.defaultVals, "default-values", "", "values file applied to the chart and its subcharts, beneath the user's values")
+	f.StringVar(&upgrade.defaultsPrec, "default-values-precedence", defaultsFirst, "order in which --default-values and the default values of the charts are merged: defaults-first lets the charts override the file, chart-first lets the file override the charts")
+	f.StringVar(&upgrade.valuesFrom, "values-from", "", "read values from a ConfigMap key in the namespace of the release, as configmap/NAMESPACE/NAME[:KEY] (KEY defaults to values.yaml). Values from -f and --set, and the values carried over from the current release, take precedence")
+	f.StringArrayVar(&upgrade.patches, "patch", []string{}, "set a field of one rendered resource before it is applied, as KIND/NAME:PATH=VALUE (can specify multiple)")
 	f.BoolVar(&upgrade.disableHooks, "disable-hooks", false, "disable pre/post upgrade hooks. DEPRECATED. Use no-hooks")
 	f.BoolVar(&upgrade.disableHooks, "no-hooks", false, "disable pre/post upgrade hooks")
 	f.BoolVar(&upgrade.verify, "verify", false, "verify the provenance of the chart before upgrading")
+	f.BoolVar(&upgrade.noCache, "no-cache", false, "download the chart even if it is in the chart cache")
 	f.StringVar(&upgrade.keyring, "keyring", defaultKeyring(), "path to the keyring that contains public signing keys")
 	f.BoolVarP(&upgrade.install, "install", "i", false, "if a release by this name doesn't already exist, run an install")
 	f.StringVar(&upgrade.namespace, "namespace", "", "namespace to install the release into (only used if --install is set). Defaults to the current kube config namespace")
 	f.StringVar(&upgrade.version, "version", "", "specify the exact chart version to use. If this is not specified, the latest version is used")
-	f.Int64Var(&upgrade.timeout, "timeout", 300, "time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
+	f.Var(newTimeoutValue(defaultTimeout, &upgrade.timeout), "timeout", timeoutUsage)
 	f.BoolVar(&upgrade.resetValues, "reset-values", false, "when upgrading, reset the values to the ones built into the chart")
 	f.BoolVar(&upgrade.reuseValues, "reuse-values", false, "when upgrading, reuse the last release's values, and merge in any new values. If '--reset-values' is specified, this is ignored.")
 	f.BoolVar(&upgrade.wait, "wait", false, "if set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
+	f.Var(newTimeoutValue(0, &upgrade.postWait), "post-wait-settle", "wait this long after --wait finds the release ready, then fail the release if any of its resources is no longer ready or any of its pods restarted. The --wait flag will be set automatically if this is set")
+	f.BoolVar(&upgrade.cleanupFail, "cleanup-on-fail", false, "delete the resources created by the upgrade if it fails. Resources that already existed are left as they are")
+	f.BoolVar(&upgrade.failOnEmpty, "fail-on-empty", false, "fail if a template renders to nothing but whitespace and comments, unless it contains the comment \"# helm.sh/allow-empty\"")
+	f.BoolVar(&upgrade.skipSame, "skip-unchanged", false, "do not create a new revision if the upgrade would not change the manifests, hooks or values of the deployed release")
+	f.BoolVar(&upgrade.takeOwner, "take-ownership", false, "adopt resources that already exist in the cluster, even if another release owns them")
+	f.BoolVar(&upgrade.orderedApply, "ordered-apply", false, "apply the resources in the order of their template files instead of by kind. Namespaces and CustomResourceDefinitions are still applied first")
 	f.StringVar(&upgrade.repoURL, "repo", "", "chart repository url where to locate the requested chart")
 	f.StringVar(&upgrade.certFile, "cert-file", "", "identify HTTPS client using this SSL certificate file")
 	f.StringVar(&upgrade.keyFile, "key-file", "", "identify HTTPS client using this SSL key file")
 	f.StringVar(&upgrade.caFile, "ca-file", "", "verify certificates of HTTPS-enabled servers using this CA bundle")
 	f.BoolVar(&upgrade.devel, "devel", false, "use development versions, too. Equivalent to version '>0.0.0-0'. If --version is set, this is ignored.")
+	f.BoolVar(&upgrade.commonLabels, "common-labels", false, "add the recommended app.kubernetes.io and helm.sh/chart labels to every resource that does not already set them")
+	f.StringVar(&upgrade.description, "description", "", "specify a description for the release, shown in 'helm history'")
+	f.StringVar(&upgrade.buildPipeline, "build-pipeline", "", "record the CI pipeline that built the release. If no --build-* flag is set, the build of the last release is kept")
+	f.StringVar(&upgrade.buildCommit, "build-commit", "", "record the commit the release was built from")
+	f.StringVar(&upgrade.buildActor, "build-actor", "", "record who triggered the build of the release")
 
 	f.MarkDeprecated("disable-hooks", "use --no-hooks instead")
 
@@ -139,7 +203,7 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 }
 
 func (u *upgradeCmd) run() error {
-	chartPath, err := locateChartPath(u.repoURL, u.chart, u.version, u.verify, u.keyring, u.certFile, u.keyFile, u.caFile)
+	chartPath, err := locateChartPath(u.repoURL, u.chart, u.version, u.verify, u.noCache, u.keyring, u.certFile, u.keyFile, u.caFile)
 	if err != nil {
 		return err
 	}
@@ -173,44 +237,83 @@ func (u *upgradeCmd) run() error {
 				disableHooks: u.disableHooks,
 				keyring:      u.keyring,
 				values:       u.values,
+				jsonValues:   u.jsonValues,
+				envPrefix:    u.envPrefix,
+				precedence:   u.precedence,
+				defaultVals:  u.defaultVals,
+				defaultsPrec: u.defaultsPrec,
+				valuesFrom:   u.valuesFrom,
+				patches:      u.patches,
 				namespace:    u.namespace,
 				timeout:      u.timeout,
 				wait:         u.wait,
+				postWait:     u.postWait,
+				description:  u.description,
+				commonLabels: u.commonLabels,
+				failOnEmpty:  u.failOnEmpty,
+				takeOwner:    u.takeOwner,
+				orderedApply: u.orderedApply,
+
+				buildPipeline: u.buildPipeline,
+				buildCommit:   u.buildCommit,
+				buildActor:    u.buildActor,
 			}
 			return ic.run()
 		}
 	}
 
-	rawVals, err := vals(u.valueFiles, u.values)
+	// Check chart requirements to make sure all dependencies are present in /charts
+	ch, err := chartutil.Load(chartPath)
+	if err != nil {
+		return prettyError(err)
+	}
+	if req, err := chartutil.LoadRequirements(ch); err == nil {
+		if err := checkDependencies(ch, req); err != nil {
+			return err
+		}
+	} else if err != chartutil.ErrRequirementsNotFound {
+		return fmt.Errorf("cannot load requirements: %v", err)
+	}
+
+	types, err := chartutil.SchemaTypes(ch)
+	if err != nil {
+		return err
+	}
+	rawVals, err := vals(u.out, u.valueFiles, u.values, u.jsonValues, u.envPrefix, u.precedence, types)
 	if err != nil {
 		return err
 	}
 
-	// Check chart requirements to make sure all dependencies are present in /charts
-	if ch, err := chartutil.Load(chartPath); err == nil {
-		if req, err := chartutil.LoadRequirements(ch); err == nil {
-			if err := checkDependencies(ch, req); err != nil {
-				return err
-			}
-		} else if err != chartutil.ErrRequirementsNotFound {
-			return fmt.Errorf("cannot load requirements: %v", err)
-		}
-	} else {
-		return prettyError(err)
+	rawVals, err = applyDefaultValues(ch, rawVals, u.defaultVals, u.defaultsPrec)
+	if err != nil {
+		return err
 	}
+	printTypeWarnings(u.out, ch, rawVals)
 
-	resp, err := u.client.UpdateRelease(
+	resp, err := u.client.UpdateReleaseFromChart(
 		u.release,
-		chartPath,
+		ch,
 		helm.UpdateValueOverrides(rawVals),
 		helm.UpgradeDryRun(u.dryRun),
 		helm.UpgradeRecreate(u.recreate),
 		helm.UpgradeForce(u.force),
+		helm.UpgradeForcePaused(u.forcePaused),
 		helm.UpgradeDisableHooks(u.disableHooks),
 		helm.UpgradeTimeout(u.timeout),
 		helm.ResetValues(u.resetValues),
 		helm.ReuseValues(u.reuseValues),
-		helm.UpgradeWait(u.wait))
+		helm.UpgradeWait(u.wait || u.postWait > 0),
+		helm.UpgradePostWaitSettle(u.postWait),
+		helm.UpgradeDescription(u.description),
+		helm.UpgradeCommonLabels(u.commonLabels),
+		helm.UpgradeValuesFrom(u.valuesFrom),
+		helm.UpgradePatches(u.patches),
+		helm.UpgradeBuildInfo(buildInfo(u.buildPipeline, u.buildCommit, u.buildActor)),
+		helm.UpgradeCleanupOnFail(u.cleanupFail),
+		helm.UpgradeFailOnEmpty(u.failOnEmpty),
+		helm.UpgradeSkipUnchanged(u.skipSame),
+		helm.UpgradeTakeOwnership(u.takeOwner),
+		helm.UpgradeOrderedApply(u.orderedApply))
 	if err != nil {
 		return fmt.Errorf("UPGRADE FAILED: %v", prettyError(err))
 	}
@@ -159,6 +159,20 @@ func TestUpgradeCmd(t *testing.T) {
 			resp: helm.ReleaseMock(&helm.MockReleaseOptions{Name: "bonkers-bunny", Version: 1, Chart: ch3}),
 			err:  true,
 		},
+		{
+			name: "upgrade a paused release",
+			args: []string{"paused-bunny", chartPath},
+			rels: []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "paused-bunny", Version: 1, Chart: ch, Paused: true})},
+			err:  true,
+		},
+		{
+			name:     "upgrade a paused release with --force-paused",
+			args:     []string{"paused-bunny", chartPath},
+			flags:    []string{"--force-paused"},
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "paused-bunny", Version: 2, Chart: ch}),
+			expected: "Release \"paused-bunny\" has been upgraded. Happy Helming!\n",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "paused-bunny", Version: 1, Chart: ch, Paused: true})},
+		},
 	}
 
 	cmd := func(c *helm.FakeClient, out io.Writer) *cobra.Command {
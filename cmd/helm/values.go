@@ -0,0 +1,151 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const valuesDesc = `
+This command prints the values a chart would be rendered with, without
+rendering its templates. It does not require Tiller.
+
+The values given with '-f', '--set-json' and '--set' are merged over the
+chart's default values, and the values of subcharts are coalesced with globals
+propagated to them, just as for 'helm install':
+
+	$ helm values ./redis -f myvalues.yaml --set replicas=3
+
+The values are printed as YAML. Use '--output json' to print them as JSON.
+`
+
+type valuesCmd struct {
+	chartPath    string
+	valueFiles   valueFiles
+	values       []string
+	jsonValues   []string
+	envPrefix    string
+	precedence   string
+	defaultVals  string
+	defaultsPrec string
+	output       string
+	out          io.Writer
+}
+
+func newValuesCmd(out io.Writer) *cobra.Command {
+	v := &valuesCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "values [flags] CHART",
+		Short: "print the coalesced values of a chart",
+		Long:  valuesDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("chart is required")
+			}
+			if _, err := os.Stat(args[0]); err != nil {
+				return err
+			}
+			p, err := filepath.Abs(args[0])
+			if err != nil {
+				return err
+			}
+			v.chartPath = p
+			return v.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.VarP(&v.valueFiles, "values", "f", "specify values in a YAML file (can specify multiple)")
+	f.StringArrayVar(&v.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&v.jsonValues, "set-json", []string{}, "set JSON values on the command line (can specify multiple or separate values with commas: key1=jsonval1,key2=jsonval2)")
+	f.StringVar(&v.envPrefix, "values-env-prefix", "", "set values from environment variables with this prefix, using __ to separate nested keys (e.g. HELM_VAL_image__tag=1.2)")
+	f.StringVar(&v.precedence, "values-precedence", "file-first", "order in which values are merged: file-first lets --set and --set-json override -f files, set-first lets -f files override them")
+	f.StringVar(&v.defaultVals, "default-values", "", "values file applied to the chart and its subcharts, beneath the user's values")
+	f.StringVar(&v.defaultsPrec, "default-values-precedence", defaultsFirst, "order in which --default-values and the default values of the charts are merged: defaults-first lets the charts override the file, chart-first lets the file override the charts")
+	f.StringVarP(&v.output, "output", "o", "yaml", "output the values in the specified format (json or yaml)")
+
+	return cmd
+}
+
+func (v *valuesCmd) run() error {
+	if v.output != "yaml" && v.output != "json" {
+		return fmt.Errorf("Unknown output format %q", v.output)
+	}
+
+	c, err := chartutil.Load(v.chartPath)
+	if err != nil {
+		return prettyError(err)
+	}
+	if req, err := chartutil.LoadRequirements(c); err == nil {
+		if err := checkDependencies(c, req); err != nil {
+			return prettyError(err)
+		}
+	} else if err != chartutil.ErrRequirementsNotFound {
+		return fmt.Errorf("cannot load requirements: %v", err)
+	}
+
+	types, err := chartutil.SchemaTypes(c)
+	if err != nil {
+		return err
+	}
+	rawVals, err := vals(os.Stderr, v.valueFiles, v.values, v.jsonValues, v.envPrefix, v.precedence, types)
+	if err != nil {
+		return err
+	}
+	rawVals, err = applyDefaultValues(c, rawVals, v.defaultVals, v.defaultsPrec)
+	if err != nil {
+		return err
+	}
+	config := &chart.Config{Raw: string(rawVals), Values: map[string]*chart.Value{}}
+	if err := chartutil.ProcessRequirementsEnabled(c, config); err != nil {
+		return err
+	}
+	if err := chartutil.ProcessRequirementsImportValues(c); err != nil {
+		return err
+	}
+
+	cvals, err := chartutil.CoalesceValues(c, config)
+	if err != nil {
+		return err
+	}
+
+	if v.output == "json" {
+		data, err := json.MarshalIndent(cvals, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Failed to Marshal JSON output: %s", err)
+		}
+		fmt.Fprintln(v.out, string(data))
+		return nil
+	}
+	data, err := cvals.YAML()
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(v.out, data)
+	return nil
+}
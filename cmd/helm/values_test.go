@@ -0,0 +1,87 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+func TestValuesCmd(t *testing.T) {
+	f, err := ioutil.TempFile("", "helm-values")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString("global:\n  region: eu\noverridden-chart1:\n  SC1int: 1\n  SC1string: file\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	args := []string{"./../../pkg/chartutil/testdata/subpop", "-f", f.Name(), "--set", "overridden-chart1.SC1string=set"}
+	expect := map[string]interface{}{
+		// From the file, over the chart's defaults.
+		"overridden-chart1.SC1int": 1.0,
+		// From --set, over the file.
+		"overridden-chart1.SC1string": "set",
+		// Chart defaults the file and --set left alone.
+		"overridden-chart1.SPextra2": 42.0,
+		// Subchart defaults, with globals propagated to the subchart.
+		"subchart1.service.name":  "nginx",
+		"subchart1.global.region": "eu",
+		"global.region":           "eu",
+	}
+
+	for _, output := range []string{"yaml", "json"} {
+		var buf bytes.Buffer
+		cmd := newValuesCmd(&buf)
+		cmd.SetArgs(append(args, "--output", output))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%s: %s", output, err)
+		}
+
+		vals := chartutil.Values{}
+		if output == "json" {
+			err = json.Unmarshal(buf.Bytes(), &vals)
+		} else {
+			err = yaml.Unmarshal(buf.Bytes(), &vals)
+		}
+		if err != nil {
+			t.Fatalf("%s: failed to parse output: %s\n%s", output, err, buf.String())
+		}
+		for path, want := range expect {
+			if got, err := vals.PathValue(path); err != nil {
+				t.Errorf("%s: %s: %s", output, path, err)
+			} else if got != want {
+				t.Errorf("%s: expected %s to be %v, got %v", output, path, want, got)
+			}
+		}
+	}
+
+	cmd := newValuesCmd(ioutil.Discard)
+	cmd.SetArgs(append(args, "--output", "xml"))
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected an error for an unknown output format")
+	}
+}
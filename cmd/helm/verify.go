@@ -17,6 +17,7 @@ package main
 
 import (
 	"errors"
+	"fmt"
 	"io"
 
 	"github.com/spf13/cobra"
@@ -33,11 +34,22 @@ tampered with, and was packaged by a trusted provider.
 This command can be used to verify a local chart. Several other commands provide
 '--verify' flags that run the same validation. To generate a signed package, use
 the 'helm package --sign' command.
+
+Verification is done entirely offline. By default, the provenance file is
+expected to sit next to the chart archive with a '.prov' extension. Use
+'--prov' to point to a provenance file at a different location.
+
+With '--transparency-log', the signature must also be recorded in the
+transparency log at the given URL, which is queried with a Rekor-style API. If
+the log cannot be reached, the chart is verified with the keyring only and a
+warning is printed.
 `
 
 type verifyCmd struct {
 	keyring   string
 	chartfile string
+	provfile  string
+	logURL    string
 
 	out io.Writer
 }
@@ -60,11 +72,33 @@ func newVerifyCmd(out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	f.StringVar(&vc.keyring, "keyring", defaultKeyring(), "keyring containing public keys")
+	f.StringVar(&vc.provfile, "prov", "", "path to the provenance file. Defaults to PATH with a .prov extension")
+	f.StringVar(&vc.logURL, "transparency-log", "", "URL of a transparency log that must record the signature")
 
 	return cmd
 }
 
 func (v *verifyCmd) run() error {
-	_, err := downloader.VerifyChart(v.chartfile, v.keyring)
-	return err
+	provfile := v.provfile
+	if provfile == "" {
+		provfile = v.chartfile + ".prov"
+	}
+
+	ver, err := downloader.VerifyChartWithLog(v.chartfile, provfile, v.keyring, v.logURL)
+	if err != nil {
+		return err
+	}
+
+	for name := range ver.SignedBy.Identities {
+		fmt.Fprintf(v.out, "Signed by: %s\n", name)
+	}
+	fmt.Fprintf(v.out, "Using Key With Fingerprint: %X\n", ver.SignedBy.PrimaryKey.Fingerprint)
+	fmt.Fprintf(v.out, "Chart Hash Verified: %s\n", ver.FileHash)
+	if ver.LogEntry != "" {
+		fmt.Fprintf(v.out, "Transparency Log Entry: %s\n", ver.LogEntry)
+	}
+	if ver.LogUnreachable != nil {
+		fmt.Fprintf(v.out, "WARNING: %s, verified with the keyring only\n", ver.LogUnreachable)
+	}
+	return nil
 }
@@ -23,6 +23,10 @@ import (
 )
 
 func TestVerifyCmd(t *testing.T) {
+	verifiedOutput := `Signed by: Helm Testing (This key should only be used for testing. DO NOT TRUST.) <helm-testing@helm.sh>
+Using Key With Fingerprint: 5E615389B53CA37F0EE60BD3843BBF981FC18762
+Chart Hash Verified: sha256:dee72947753628425b82814516bdaa37aef49f25e8820dd2a6e15a33a007823b
+`
 
 	statExe := "stat"
 	statPathMsg := "no such file or directory"
@@ -67,9 +71,30 @@ func TestVerifyCmd(t *testing.T) {
 			name:   "verify validates a properly signed chart",
 			args:   []string{"testdata/testcharts/signtest-0.1.0.tgz"},
 			flags:  []string{"--keyring", "testdata/helm-test-key.pub"},
-			expect: "",
+			expect: verifiedOutput,
 			err:    false,
 		},
+		{
+			name:   "verify validates a chart against an explicit prov file",
+			args:   []string{"testdata/testcharts/signtest-0.1.0.tgz"},
+			flags:  []string{"--keyring", "testdata/helm-test-key.pub", "--prov", "testdata/testcharts/signtest-0.1.0.tgz.prov"},
+			expect: verifiedOutput,
+			err:    false,
+		},
+		{
+			name:   "verify rejects a chart whose hash does not match the prov file",
+			args:   []string{"testdata/testcharts/tampered/signtest-0.1.0.tgz"},
+			flags:  []string{"--keyring", "testdata/helm-test-key.pub", "--prov", "testdata/testcharts/signtest-0.1.0.tgz.prov"},
+			expect: `sha256 sum does not match for signtest-0.1.0.tgz: "sha256:dee72947753628425b82814516bdaa37aef49f25e8820dd2a6e15a33a007823b" != "sha256:b6098c65c52d76c070d4a86cff9309d59608f56db873519afa08d4d13d5ddd87"`,
+			err:    true,
+		},
+		{
+			name:   "verify rejects a chart signed by an untrusted key",
+			args:   []string{"testdata/testcharts/signtest-0.1.0.tgz"},
+			flags:  []string{"--keyring", "testdata/helm-password-key.pub"},
+			expect: "openpgp: signature made by unknown entity",
+			err:    true,
+		},
 	}
 
 	for _, tt := range tests {
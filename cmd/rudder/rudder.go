@@ -87,11 +87,15 @@ func (r *ReleaseModuleServiceServer) Version(ctx context.Context, in *rudderAPI.
 	}, nil
 }
 
-// InstallRelease creates a release using kubeClient.Create
+// InstallRelease creates a release the way Tiller does, creating its
+// CustomResourceDefinitions first
 func (r *ReleaseModuleServiceServer) InstallRelease(ctx context.Context, in *rudderAPI.InstallReleaseRequest) (*rudderAPI.InstallReleaseResponse, error) {
 	grpclog.Print("install")
-	b := bytes.NewBufferString(in.Release.Manifest)
-	err := kubeClient.Create(in.Release.Namespace, b, 500, false)
+	err := tiller.CreateRelease(in.Release, kubeClient, kube.CreateOptions{
+		Timeout:         500,
+		ContinueOnError: in.ContinueOnError,
+		TakeOwnership:   in.TakeOwnership,
+	})
 	if err != nil {
 		grpclog.Printf("error when creating release: %v", err)
 	}
@@ -109,7 +113,7 @@ func (r *ReleaseModuleServiceServer) DeleteRelease(ctx context.Context, in *rudd
 		return resp, fmt.Errorf("Could not get apiVersions from Kubernetes: %v", err)
 	}
 
-	kept, errs := tiller.DeleteRelease(rel, vs, kubeClient)
+	kept, errs := tiller.DeleteRelease(rel, vs, kubeClient, tiller.DeleteOptions(in.Cascade, in.GracePeriod))
 	rel.Manifest = kept
 
 	allErrors := ""
@@ -140,7 +144,14 @@ func (r *ReleaseModuleServiceServer) UpgradeRelease(ctx context.Context, in *rud
 	grpclog.Print("upgrade")
 	c := bytes.NewBufferString(in.Current.Manifest)
 	t := bytes.NewBufferString(in.Target.Manifest)
-	err := kubeClient.Update(in.Target.Namespace, c, t, in.Force, in.Recreate, in.Timeout, in.Wait)
+	err := kubeClient.UpdateWithOptions(in.Target.Namespace, c, t, kube.UpdateOptions{
+		Force:         in.Force,
+		Recreate:      in.Recreate,
+		Timeout:       in.Timeout,
+		ShouldWait:    in.Wait,
+		CleanupOnFail: in.CleanupOnFail,
+		TakeOwnership: in.TakeOwnership,
+	})
 	// upgrade response object should be changed to include status
 	return &rudderAPI.UpgradeReleaseResponse{}, err
 }
@@ -17,12 +17,19 @@ limitations under the License.
 package main
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// readinessProbe fails while the release storage cannot be reached, so that
+// Tiller does not receive requests it could not serve.
 func readinessProbe(w http.ResponseWriter, r *http.Request) {
+	if err := env.Releases.Ping(); err != nil {
+		http.Error(w, fmt.Sprintf("storage driver %s: %s", env.Releases.Name(), err), http.StatusServiceUnavailable)
+		return
+	}
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -17,11 +17,26 @@ limitations under the License.
 package main
 
 import (
+	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/driver"
 )
 
+// unreachableDriver is a memory driver whose storage cannot be reached.
+type unreachableDriver struct {
+	*driver.Memory
+}
+
+func (d unreachableDriver) Ping() error {
+	return errors.New("connection refused")
+}
+
 func TestProbesServer(t *testing.T) {
 	mux := newProbesMux()
 	srv := httptest.NewServer(mux)
@@ -43,6 +58,45 @@ func TestProbesServer(t *testing.T) {
 	}
 }
 
+func TestReadinessProbeStorage(t *testing.T) {
+	defer func(releases *storage.Storage) { env.Releases = releases }(env.Releases)
+
+	srv := httptest.NewServer(newProbesMux())
+	defer srv.Close()
+
+	env.Releases = storage.Init(driver.NewMemory())
+	resp, err := http.Get(srv.URL + "/readiness")
+	if err != nil {
+		t.Fatalf("GET /readiness returned an error (%s)", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /readiness returned status code %d with a healthy storage, expected %d", resp.StatusCode, http.StatusOK)
+	}
+
+	env.Releases = storage.Init(unreachableDriver{driver.NewMemory()})
+	resp, err = http.Get(srv.URL + "/readiness")
+	if err != nil {
+		t.Fatalf("GET /readiness returned an error (%s)", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("GET /readiness returned status code %d with an unreachable storage, expected %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "connection refused") {
+		t.Errorf("GET /readiness returned %q, expected the storage error", body)
+	}
+
+	// the liveness probe does not depend on the storage
+	resp, err = http.Get(srv.URL + "/liveness")
+	if err != nil {
+		t.Fatalf("GET /liveness returned an error (%s)", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /liveness returned status code %d, expected %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
 func TestPrometheus(t *testing.T) {
 	mux := http.NewServeMux()
 	addPrometheusHandler(mux)
@@ -35,7 +35,9 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 
+	"k8s.io/helm/pkg/engine"
 	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/logging"
 	"k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/storage"
 	"k8s.io/helm/pkg/storage/driver"
@@ -56,6 +58,9 @@ const (
 	tlsCertsEnvVar = "TILLER_TLS_CERTS"
 	// historyMaxEnvVar is the name of the env var for setting max history.
 	historyMaxEnvVar = "TILLER_HISTORY_MAX"
+	// storageNamespaceEnvVar is the name of the env var for setting the
+	// namespace releases are stored in.
+	storageNamespaceEnvVar = "TILLER_STORAGE_NAMESPACE"
 
 	storageMemory    = "memory"
 	storageConfigMap = "configmap"
@@ -72,14 +77,21 @@ var (
 	grpcAddr             = flag.String("listen", ":44134", "address:port to listen on")
 	enableTracing        = flag.Bool("trace", false, "enable rpc tracing")
 	store                = flag.String("storage", storageConfigMap, "storage driver to use. One of 'configmap', 'memory', or 'secret'")
+	storageNamespace     = flag.String("storage-namespace", os.Getenv(storageNamespaceEnvVar), "namespace to store releases in. Defaults to the namespace of Tiller")
 	remoteReleaseModules = flag.Bool("experimental-release", false, "enable experimental release modules")
 	tlsEnable            = flag.Bool("tls", tlsEnableEnvVarDefault(), "enable TLS")
 	tlsVerify            = flag.Bool("tls-verify", tlsVerifyEnvVarDefault(), "enable TLS and verify remote certificate")
 	keyFile              = flag.String("tls-key", tlsDefaultsFromEnv("tls-key"), "path to TLS private key file")
 	certFile             = flag.String("tls-cert", tlsDefaultsFromEnv("tls-cert"), "path to TLS certificate file")
 	caCertFile           = flag.String("tls-ca-cert", tlsDefaultsFromEnv("tls-ca-cert"), "trust certificates signed by this CA")
+	tlsMinVersion        = flag.String("tls-min-version", "1.2", "minimum TLS version accepted. One of '1.0', '1.1' or '1.2'")
+	tlsCipherSuites      = flag.String("tls-cipher-suites", "", "comma-separated list of the TLS cipher suites accepted. Defaults to the ECDHE suites with AES-GCM or ChaCha20-Poly1305")
 	maxHistory           = flag.Int("history-max", historyMaxFromEnv(), "maximum number of releases kept in release history, with 0 meaning no limit")
 	printVersion         = flag.Bool("version", false, "print the version number")
+	logFormat            = flag.String("log-format", string(logging.TextFormat), "format of log entries. One of 'text' or 'json'")
+	logLevel             = flag.String("log-level", logging.InfoLevel.String(), "minimum level of log entries. One of 'debug', 'info', 'warn' or 'error'")
+	fieldManager         = flag.String("field-manager", kube.DefaultFieldManager, "name recorded as the manager of the fields Tiller sets on the resources of releases")
+	maxRenderedBytes     = flag.Int64("max-rendered-bytes", engine.DefaultMaxRenderedBytes, "maximum total size of the rendered templates of a chart, with 0 meaning no limit")
 
 	// rootServer is the root gRPC server.
 	//
@@ -91,7 +103,8 @@ var (
 	// Any changes to env should be done before rootServer.Serve() is called.
 	env = environment.New()
 
-	logger *log.Logger
+	rootLogger *logging.Logger
+	logger     *logging.Logger
 )
 
 func main() {
@@ -103,10 +116,26 @@ func main() {
 		os.Exit(0)
 	}
 
+	format, err := logging.ParseFormat(*logFormat)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	rootLogger = logging.New(os.Stderr, format, level)
+	logger = newLogger("main")
+
+	// Packages that log with the standard logger go through the leveled
+	// logger too, so that --log-level and --log-format apply to them.
+	log.SetFlags(0)
 	if *enableTracing {
 		log.SetFlags(log.Lshortfile)
 	}
-	logger = newLogger("main")
+	log.SetOutput(newLogger("log").Writer(logging.InfoLevel))
 
 	start()
 }
@@ -122,16 +151,18 @@ func start() {
 	case storageMemory:
 		env.Releases = storage.Init(driver.NewMemory())
 	case storageConfigMap:
-		cfgmaps := driver.NewConfigMaps(clientset.Core().ConfigMaps(namespace()))
-		cfgmaps.Log = newLogger("storage/driver").Printf
-
-		env.Releases = storage.Init(cfgmaps)
+		env.Releases = storage.Init(storageDriver(func(ns string) driver.Driver {
+			cfgmaps := driver.NewConfigMaps(clientset.Core().ConfigMaps(ns))
+			cfgmaps.Log = newLogger("storage/driver").Printf
+			return cfgmaps
+		}))
 		env.Releases.Log = newLogger("storage").Printf
 	case storageSecret:
-		secrets := driver.NewSecrets(clientset.Core().Secrets(namespace()))
-		secrets.Log = newLogger("storage/driver").Printf
-
-		env.Releases = storage.Init(secrets)
+		env.Releases = storage.Init(storageDriver(func(ns string) driver.Driver {
+			secrets := driver.NewSecrets(clientset.Core().Secrets(ns))
+			secrets.Log = newLogger("storage/driver").Printf
+			return secrets
+		}))
 		env.Releases.Log = newLogger("storage").Printf
 	}
 
@@ -139,8 +170,13 @@ func start() {
 		env.Releases.MaxHistory = *maxHistory
 	}
 
+	if e, ok := env.EngineYard.Get(environment.GoTplEngine); ok {
+		e.(*engine.Engine).MaxRenderedBytes = *maxRenderedBytes
+	}
+
 	kubeClient := kube.New(nil)
 	kubeClient.Log = newLogger("kube").Printf
+	kubeClient.FieldManager = *fieldManager
 	env.KubeClient = kubeClient
 
 	if *tlsEnable || *tlsVerify {
@@ -152,7 +188,11 @@ func start() {
 
 	var opts []grpc.ServerOption
 	if *tlsEnable || *tlsVerify {
-		cfg, err := tlsutil.ServerConfig(tlsOptions())
+		tlsOpts, err := tlsOptions()
+		if err != nil {
+			logger.Fatalf("Invalid TLS options: %v", err)
+		}
+		cfg, err := tlsutil.ServerConfig(tlsOpts)
 		if err != nil {
 			logger.Fatalf("Could not create server TLS configuration: %v", err)
 		}
@@ -163,6 +203,7 @@ func start() {
 		}))
 	}
 
+	tiller.Logger = newLogger("rpc")
 	rootServer = tiller.NewServer(opts...)
 
 	lstn, err := net.Listen("tcp", *grpcAddr)
@@ -211,11 +252,8 @@ func start() {
 	}
 }
 
-func newLogger(prefix string) *log.Logger {
-	if len(prefix) > 0 {
-		prefix = fmt.Sprintf("[%s] ", prefix)
-	}
-	return log.New(os.Stderr, prefix, log.Flags())
+func newLogger(component string) *logging.Logger {
+	return rootLogger.With(logging.Fields{"component": component})
 }
 
 // namespace returns the namespace of tiller
@@ -234,7 +272,23 @@ func namespace() string {
 	return environment.DefaultTillerNamespace
 }
 
-func tlsOptions() tlsutil.Options {
+// storageDriver creates the driver for the namespace releases are stored in.
+//
+// Releases used to be stored in the namespace of Tiller. If another storage
+// namespace is configured, the releases stored there are still found, and are
+// moved to the storage namespace as they are updated.
+func storageDriver(newDriver func(namespace string) driver.Driver) driver.Driver {
+	ns := namespace()
+	if *storageNamespace == "" || *storageNamespace == ns {
+		return newDriver(ns)
+	}
+	logger.Printf("Storing releases in namespace %s, falling back to %s", *storageNamespace, ns)
+	fallback := driver.NewFallback(newDriver(*storageNamespace), newDriver(ns))
+	fallback.Log = newLogger("storage/driver").Printf
+	return fallback
+}
+
+func tlsOptions() (tlsutil.Options, error) {
 	opts := tlsutil.Options{CertFile: *certFile, KeyFile: *keyFile}
 	if *tlsVerify {
 		opts.CaCertFile = *caCertFile
@@ -244,7 +298,15 @@ func tlsOptions() tlsutil.Options {
 		// http://www.bite-code.com/2015/06/25/tls-mutual-auth-in-golang/
 		opts.ClientAuth = tls.RequireAndVerifyClientCert
 	}
-	return opts
+
+	var err error
+	if opts.MinVersion, err = tlsutil.ParseVersion(*tlsMinVersion); err != nil {
+		return opts, err
+	}
+	if opts.CipherSuites, err = tlsutil.ParseCipherSuites(*tlsCipherSuites); err != nil {
+		return opts, err
+	}
+	return opts, nil
 }
 
 func tlsDefaultsFromEnv(name string) (value string) {
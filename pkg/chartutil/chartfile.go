@@ -22,6 +22,9 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/ghodss/yaml"
 
@@ -43,6 +46,66 @@ func UnmarshalChartfile(data []byte) (*chart.Metadata, error) {
 	return y, nil
 }
 
+// deprecatedChartfileFields are the Chart.yaml fields that no longer have any
+// effect, with a hint at what to use instead. They are accepted by the strict
+// parser, with a warning.
+var deprecatedChartfileFields = map[string]string{
+	"dependencies": "declare dependencies in requirements.yaml",
+	"details":      "use description",
+	"source":       "use sources",
+}
+
+// UnmarshalChartfileStrict is UnmarshalChartfile, but fails on top-level
+// fields that Chart.yaml does not define, naming the first such field.
+//
+// Deprecated fields are accepted, and a warning is returned for each of them.
+func UnmarshalChartfileStrict(data []byte) (*chart.Metadata, []string, error) {
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	known := chartfileFields()
+	var warnings []string
+	for _, name := range names {
+		if known[name] {
+			continue
+		}
+		if hint, ok := deprecatedChartfileFields[name]; ok {
+			warnings = append(warnings, fmt.Sprintf("field %q in Chart.yaml is deprecated and has no effect: %s", name, hint))
+			continue
+		}
+		for k := range known {
+			if strings.EqualFold(k, name) {
+				return nil, warnings, fmt.Errorf("unknown field %q in Chart.yaml (did you mean %q?)", name, k)
+			}
+		}
+		return nil, warnings, fmt.Errorf("unknown field %q in Chart.yaml", name)
+	}
+
+	m, err := UnmarshalChartfile(data)
+	return m, warnings, err
+}
+
+// chartfileFields returns the names of the fields of Chart.yaml.
+func chartfileFields() map[string]bool {
+	fields := map[string]bool{}
+	t := reflect.TypeOf(chart.Metadata{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
+
 // LoadChartfile loads a Chart.yaml file into a *chart.Metadata.
 func LoadChartfile(filename string) (*chart.Metadata, error) {
 	b, err := ioutil.ReadFile(filename)
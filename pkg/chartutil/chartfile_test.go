@@ -17,6 +17,7 @@ limitations under the License.
 package chartutil
 
 import (
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/proto/hapi/chart"
@@ -33,6 +34,55 @@ func TestLoadChartfile(t *testing.T) {
 	verifyChartfile(t, f, "frobnitz")
 }
 
+func TestUnmarshalChartfileStrict(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		err      string
+		warnings []string
+	}{
+		{
+			name: "known fields",
+			data: "apiVersion: v1\nname: strict\nversion: 0.1.0\nappVersion: \"1.0\"\nsources:\n- https://example.com\n",
+		},
+		{
+			name: "unknown field",
+			data: "name: strict\nversion: 0.1.0\nappversion: \"1.0\"\n",
+			err:  `unknown field "appversion" in Chart.yaml (did you mean "appVersion"?)`,
+		},
+		{
+			name: "misspelled field",
+			data: "name: strict\nversion: 0.1.0\ndescripton: typo\n",
+			err:  `unknown field "descripton" in Chart.yaml`,
+		},
+		{
+			name:     "deprecated field",
+			data:     "name: strict\nversion: 0.1.0\ndependencies:\n- name: sub\n",
+			warnings: []string{`field "dependencies" in Chart.yaml is deprecated and has no effect: declare dependencies in requirements.yaml`},
+		},
+	}
+
+	for _, tt := range tests {
+		m, warnings, err := UnmarshalChartfileStrict([]byte(tt.data))
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("%s: expected error %q, got %v", tt.name, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, err)
+			continue
+		}
+		if m.Name != "strict" {
+			t.Errorf("%s: expected name strict, got %q", tt.name, m.Name)
+		}
+		if strings.Join(warnings, "\n") != strings.Join(tt.warnings, "\n") {
+			t.Errorf("%s: expected warnings %q, got %q", tt.name, tt.warnings, warnings)
+		}
+	}
+}
+
 func verifyChartfile(t *testing.T, f *chart.Metadata, name string) {
 
 	if f == nil {
@@ -0,0 +1,82 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// CRDsDir is the directory of a chart holding CustomResourceDefinitions.
+//
+// Files in this directory are not rendered as templates. They are installed
+// as-is, before any of the templates of the chart.
+const CRDsDir = "crds"
+
+// CRDFile is a file from the crds/ directory of a chart.
+type CRDFile struct {
+	// Name is the path of the file, prefixed with the path of the chart in the
+	// same way the engine names rendered templates, e.g.
+	// "mychart/charts/subchart/crds/crontab.yaml".
+	Name string
+	// Data is the unrendered content of the file.
+	Data []byte
+}
+
+// CRDs returns the YAML and JSON files in the crds/ directories of a chart and
+// all of its subcharts.
+//
+// The files of a chart precede those of its subcharts, and the files of each
+// chart are sorted by name.
+func CRDs(c *chart.Chart) []*CRDFile {
+	return crds(c, "")
+}
+
+func crds(c *chart.Chart, parentID string) []*CRDFile {
+	id := c.GetMetadata().GetName()
+	if parentID != "" {
+		id = path.Join(parentID, "charts", id)
+	}
+
+	var files []*CRDFile
+	for _, f := range c.Files {
+		if !isCRDFile(f.TypeUrl) {
+			continue
+		}
+		files = append(files, &CRDFile{Name: path.Join(id, f.TypeUrl), Data: f.Value})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	for _, dep := range c.Dependencies {
+		files = append(files, crds(dep, id)...)
+	}
+	return files
+}
+
+func isCRDFile(name string) bool {
+	if !strings.HasPrefix(name, CRDsDir+"/") {
+		return false
+	}
+	switch path.Ext(name) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCRDs(t *testing.T) {
+	c, err := Load("testdata/whaler")
+	if err != nil {
+		t.Fatalf("Failed to load testdata: %s", err)
+	}
+
+	for _, tpl := range c.Templates {
+		if strings.HasPrefix(tpl.Name, CRDsDir+"/") {
+			t.Errorf("Expected %s not to be loaded as a template", tpl.Name)
+		}
+	}
+
+	files := CRDs(c)
+	expect := []string{
+		"whaler/crds/whale.yaml",
+		"whaler/charts/harpoon/crds/harpoon.yaml",
+	}
+	if len(files) != len(expect) {
+		t.Fatalf("Expected %d CRD files, got %d", len(expect), len(files))
+	}
+	for i, name := range expect {
+		if files[i].Name != name {
+			t.Errorf("Expected CRD file %d to be %s, got %s", i, name, files[i].Name)
+		}
+	}
+
+	if !strings.Contains(string(files[0].Data), `description: "{{ .Values.group }}"`) {
+		t.Errorf("Expected CRD file to be unrendered, got %q", files[0].Data)
+	}
+}
@@ -132,3 +132,68 @@ func TestCreateFrom(t *testing.T) {
 		t.Errorf("Did not expect %s to be present in %s", "<CHARTNAME>", mychart.Values.Raw)
 	}
 }
+
+func TestCreateFromSchema(t *testing.T) {
+	tdir, err := ioutil.TempDir("", "helm-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	schema, err := ioutil.ReadFile("testdata/schema/values.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect, err := ioutil.ReadFile("testdata/schema/values.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cf := &chart.Metadata{Name: "foo"}
+	c, err := CreateFromSchema(cf, tdir, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := ioutil.ReadFile(filepath.Join(c, ValuesfileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(values) != string(expect) {
+		t.Errorf("Expected values:\n%s\nGot:\n%s", expect, values)
+	}
+
+	if _, err := ReadValues(values); err != nil {
+		t.Errorf("Generated values do not parse: %s", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(c, SchemafileName)); err != nil {
+		t.Errorf("Expected %s file: %s", SchemafileName, err)
+	}
+
+	// The schema has no service or ingress, so the templates reading them are
+	// removed, while those that only read the generated values are kept.
+	for _, f := range []string{DeploymentName, HelpersName} {
+		if _, err := os.Stat(filepath.Join(c, TemplatesDir, f)); err != nil {
+			t.Errorf("Expected %s file: %s", f, err)
+		}
+	}
+	for _, f := range []string{ServiceName, IngressFileName, NotesName} {
+		if _, err := os.Stat(filepath.Join(c, TemplatesDir, f)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got %v", f, err)
+		}
+	}
+
+	if _, err := LoadDir(c); err != nil {
+		t.Errorf("Failed to load newly created chart %q: %s", c, err)
+	}
+}
+
+func TestValuesFromSchemaNotObject(t *testing.T) {
+	if _, err := ValuesFromSchema("foo", []byte(`{"type": "string"}`)); err == nil {
+		t.Error("Expected error for non-object schema")
+	}
+	if _, err := ValuesFromSchema("foo", []byte(`not json`)); err == nil {
+		t.Error("Expected error for invalid schema")
+	}
+}
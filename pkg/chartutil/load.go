@@ -43,6 +43,29 @@ import (
 // If a .helmignore file is present, the directory loader will skip loading any files
 // matching it. But .helmignore is not evaluated when reading out of an archive.
 func Load(name string) (*chart.Chart, error) {
+	return (&loader{}).load(name)
+}
+
+// LoadStrict is Load, but fails if the Chart.yaml of the chart or of any of
+// its subcharts has a top-level field that Chart.yaml does not define.
+//
+// Deprecated Chart.yaml fields are accepted, and a warning naming each of them
+// is returned.
+func LoadStrict(name string) (*chart.Chart, []string, error) {
+	l := &loader{strict: true}
+	c, err := l.load(name)
+	return c, l.warnings, err
+}
+
+// loader loads charts, and their subcharts, in the same mode.
+type loader struct {
+	// strict rejects unknown Chart.yaml fields.
+	strict bool
+	// warnings collects the warnings of a strict load.
+	warnings []string
+}
+
+func (l *loader) load(name string) (*chart.Chart, error) {
 	fi, err := os.Stat(name)
 	if err != nil {
 		return nil, err
@@ -51,9 +74,24 @@ func Load(name string) (*chart.Chart, error) {
 		if validChart, err := IsChartDir(name); !validChart {
 			return nil, err
 		}
-		return LoadDir(name)
+		return l.loadDir(name)
 	}
-	return LoadFile(name)
+	return l.loadFile(name)
+}
+
+// chartfile parses the data of a Chart.yaml file.
+func (l *loader) chartfile(data []byte) (*chart.Metadata, error) {
+	if !l.strict {
+		return UnmarshalChartfile(data)
+	}
+	m, warnings, err := UnmarshalChartfileStrict(data)
+	if err != nil {
+		return m, err
+	}
+	for _, w := range warnings {
+		l.warnings = append(l.warnings, fmt.Sprintf("%s: %s", m.Name, w))
+	}
+	return m, nil
 }
 
 // BufferedFile represents an archive file buffered for later processing.
@@ -64,6 +102,10 @@ type BufferedFile struct {
 
 // LoadArchive loads from a reader containing a compressed tar archive.
 func LoadArchive(in io.Reader) (*chart.Chart, error) {
+	return (&loader{}).loadArchive(in)
+}
+
+func (l *loader) loadArchive(in io.Reader) (*chart.Chart, error) {
 	unzipped, err := gzip.NewReader(in)
 	if err != nil {
 		return &chart.Chart{}, err
@@ -116,17 +158,21 @@ func LoadArchive(in io.Reader) (*chart.Chart, error) {
 		return nil, errors.New("no files in chart archive")
 	}
 
-	return LoadFiles(files)
+	return l.loadFiles(files)
 }
 
 // LoadFiles loads from in-memory files.
 func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
+	return (&loader{}).loadFiles(files)
+}
+
+func (l *loader) loadFiles(files []*BufferedFile) (*chart.Chart, error) {
 	c := &chart.Chart{}
 	subcharts := map[string][]*BufferedFile{}
 
 	for _, f := range files {
 		if f.Name == "Chart.yaml" {
-			m, err := UnmarshalChartfile(f.Data)
+			m, err := l.chartfile(f.Data)
 			if err != nil {
 				return c, err
 			}
@@ -175,7 +221,7 @@ func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
 			}
 			// Untar the chart and add to c.Dependencies
 			b := bytes.NewBuffer(file.Data)
-			sc, err = LoadArchive(b)
+			sc, err = l.loadArchive(b)
 		} else {
 			// We have to trim the prefix off of every file, and ignore any file
 			// that is in charts/, but isn't actually a chart.
@@ -188,7 +234,7 @@ func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
 				f.Name = parts[1]
 				buff = append(buff, f)
 			}
-			sc, err = LoadFiles(buff)
+			sc, err = l.loadFiles(buff)
 		}
 
 		if err != nil {
@@ -203,6 +249,10 @@ func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
 
 // LoadFile loads from an archive file.
 func LoadFile(name string) (*chart.Chart, error) {
+	return (&loader{}).loadFile(name)
+}
+
+func (l *loader) loadFile(name string) (*chart.Chart, error) {
 	if fi, err := os.Stat(name); err != nil {
 		return nil, err
 	} else if fi.IsDir() {
@@ -215,13 +265,17 @@ func LoadFile(name string) (*chart.Chart, error) {
 	}
 	defer raw.Close()
 
-	return LoadArchive(raw)
+	return l.loadArchive(raw)
 }
 
 // LoadDir loads from a directory.
 //
 // This loads charts only from directories.
 func LoadDir(dir string) (*chart.Chart, error) {
+	return (&loader{}).loadDir(dir)
+}
+
+func (l *loader) loadDir(dir string) (*chart.Chart, error) {
 	topdir, err := filepath.Abs(dir)
 	if err != nil {
 		return nil, err
@@ -284,5 +338,5 @@ func LoadDir(dir string) (*chart.Chart, error) {
 		return c, err
 	}
 
-	return LoadFiles(files)
+	return l.loadFiles(files)
 }
@@ -17,7 +17,11 @@ limitations under the License.
 package chartutil
 
 import (
+	"io/ioutil"
+	"os"
 	"path"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/proto/hapi/chart"
@@ -43,6 +47,57 @@ func TestLoadFile(t *testing.T) {
 	verifyRequirements(t, c)
 }
 
+func TestLoadStrict(t *testing.T) {
+	if _, warnings, err := LoadStrict("testdata/frobnitz"); err != nil {
+		t.Fatalf("Failed to load testdata: %s", err)
+	} else if len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %q", warnings)
+	}
+
+	dir, err := ioutil.TempDir("", "helm-strict-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"Chart.yaml":            "name: parent\nversion: 0.1.0\nsource:\n- https://example.com\n",
+		"charts/sub/Chart.yaml": "name: sub\nversion: 0.1.0\n",
+	}
+	for name, data := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, warnings, err := LoadStrict(dir)
+	if err != nil {
+		t.Fatalf("Failed to load chart with a deprecated field: %s", err)
+	}
+	if len(c.Dependencies) != 1 {
+		t.Errorf("Expected 1 dependency, got %d", len(c.Dependencies))
+	}
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], `parent: field "source"`) {
+		t.Errorf("Expected a warning for the source field, got %q", warnings)
+	}
+	if _, err := Load(dir); err != nil {
+		t.Errorf("Expected the default loader to ignore Chart.yaml fields, got %s", err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "charts/sub/Chart.yaml"), []byte("name: sub\nversion: 0.1.0\nappversion: 1.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := LoadStrict(dir); err == nil || !strings.Contains(err.Error(), `unknown field "appversion"`) {
+		t.Errorf("Expected an error for the unknown field of the subchart, got %v", err)
+	}
+	if _, err := Load(dir); err != nil {
+		t.Errorf("Expected the default loader to ignore unknown fields, got %s", err)
+	}
+}
+
 func TestLoadFiles(t *testing.T) {
 	goodFiles := []*BufferedFile{
 		{
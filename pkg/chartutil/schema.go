@@ -0,0 +1,339 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// SchemafileName is the default values schema file name.
+const SchemafileName = "values.schema.json"
+
+// valuesSchema is the subset of JSON Schema used to generate default values.
+type valuesSchema struct {
+	Type        interface{}             `json:"type,omitempty"`
+	Description string                  `json:"description,omitempty"`
+	Default     interface{}             `json:"default,omitempty"`
+	Properties  map[string]valuesSchema `json:"properties,omitempty"`
+	Items       *valuesSchema           `json:"items,omitempty"`
+	Required    []string                `json:"required,omitempty"`
+}
+
+// typeName returns the first non-null type declared by the schema.
+func (s valuesSchema) typeName() string {
+	switch t := s.Type.(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, v := range t {
+			if n, ok := v.(string); ok && n != "null" {
+				return n
+			}
+		}
+	}
+	if len(s.Properties) > 0 {
+		return "object"
+	}
+	return ""
+}
+
+// CreateFromSchema creates a new chart in a directory, generating its values.yaml
+// from the given JSON schema.
+//
+// The chart is scaffolded as by Create. The default values file is then replaced
+// with one generated by ValuesFromSchema, and the schema itself is stored in the
+// chart as values.schema.json.
+//
+// The scaffold templates that read values below a key the generated values do
+// not have would fail to render, so they are removed from the chart.
+func CreateFromSchema(chartfile *chart.Metadata, dir string, schema []byte) (string, error) {
+	values, err := ValuesFromSchema(chartfile.Name, schema)
+	if err != nil {
+		return "", err
+	}
+	vals, err := ReadValues(values)
+	if err != nil {
+		return "", err
+	}
+
+	cdir, err := Create(chartfile, dir)
+	if err != nil {
+		return cdir, err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(cdir, ValuesfileName), values, 0644); err != nil {
+		return cdir, err
+	}
+	if err := removeUnrenderable(cdir, chartfile.Name, vals); err != nil {
+		return cdir, err
+	}
+	return cdir, ioutil.WriteFile(filepath.Join(cdir, SchemafileName), schema, 0644)
+}
+
+// valuesRef matches the values a template reads, e.g. .Values.image.tag.
+var valuesRef = regexp.MustCompile(`\.Values((?:\.[A-Za-z_][A-Za-z0-9_]*)+)`)
+
+// removeUnrenderable removes the scaffold templates of the chart in cdir that
+// read values below a key vals does not have. Templates that differ from the
+// scaffold, e.g. because they existed before, are left alone.
+func removeUnrenderable(cdir, name string, vals Values) error {
+	for file, tpl := range map[string]string{
+		IngressFileName: defaultIngress,
+		DeploymentName:  defaultDeployment,
+		ServiceName:     defaultService,
+		NotesName:       defaultNotes,
+	} {
+		content := Transform(tpl, "<CHARTNAME>", name)
+		if renderable(content, vals) {
+			continue
+		}
+		path := filepath.Join(cdir, TemplatesDir, file)
+		if existing, err := ioutil.ReadFile(path); err != nil || !bytes.Equal(existing, content) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderable reports whether every value tpl reads is either in vals or a
+// missing key of a table in vals. Reading below a missing key fails to render.
+func renderable(tpl []byte, vals Values) bool {
+	for _, m := range valuesRef.FindAllSubmatch(tpl, -1) {
+		path := strings.Split(strings.TrimPrefix(string(m[1]), "."), ".")
+		if len(path) == 1 {
+			continue
+		}
+		if _, err := vals.Table(strings.Join(path[:len(path)-1], ".")); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ValuesFromSchema generates the contents of a values.yaml file from a JSON schema.
+//
+// Properties are emitted in alphabetical order. A property's default is used when
+// present; otherwise an example value of the declared type is emitted. Required
+// properties that have no default are preceded by a placeholder comment.
+func ValuesFromSchema(name string, schema []byte) ([]byte, error) {
+	var s valuesSchema
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil, fmt.Errorf("could not parse values schema: %s", err)
+	}
+	if t := s.typeName(); t != "object" {
+		return nil, fmt.Errorf("values schema must describe an object, not %q", t)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# Default values for %s.\n", name)
+	b.WriteString("# This is a YAML-formatted file.\n")
+	b.WriteString("# Declare variables to be passed into your templates.\n")
+	if err := writeSchemaProperties(&b, s, 0); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func writeSchemaProperties(b *bytes.Buffer, s valuesSchema, depth int) error {
+	indent := strings.Repeat("  ", depth)
+
+	required := make(map[string]bool, len(s.Required))
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	keys := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := s.Properties[k]
+		if depth == 0 {
+			b.WriteString("\n")
+		}
+		if p.Description != "" {
+			fmt.Fprintf(b, "%s# %s\n", indent, p.Description)
+		}
+
+		if p.Default == nil {
+			if p.typeName() == "object" && len(p.Properties) > 0 {
+				fmt.Fprintf(b, "%s%s:\n", indent, k)
+				if err := writeSchemaProperties(b, p, depth+1); err != nil {
+					return err
+				}
+				continue
+			}
+			if required[k] {
+				fmt.Fprintf(b, "%s# REQUIRED: %s has no default value\n", indent, k)
+			}
+		}
+
+		if err := writeSchemaValue(b, indent, k, schemaValue(p)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schemaValue returns the default for a property, or an example of its type.
+func schemaValue(s valuesSchema) interface{} {
+	if s.Default != nil {
+		return s.Default
+	}
+	switch s.typeName() {
+	case "string":
+		return ""
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	case "array":
+		return []interface{}{}
+	case "object":
+		return map[string]interface{}{}
+	}
+	return nil
+}
+
+func writeSchemaValue(b *bytes.Buffer, indent, key string, v interface{}) error {
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	out = bytes.TrimSuffix(out, []byte("\n"))
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if len(t) == 0 {
+			break
+		}
+		fmt.Fprintf(b, "%s%s:\n", indent, key)
+		writeIndented(b, indent+"  ", out)
+		return nil
+	case []interface{}:
+		if len(t) == 0 {
+			break
+		}
+		fmt.Fprintf(b, "%s%s:\n", indent, key)
+		writeIndented(b, indent, out)
+		return nil
+	}
+	fmt.Fprintf(b, "%s%s: %s\n", indent, key, out)
+	return nil
+}
+
+func writeIndented(b *bytes.Buffer, indent string, out []byte) {
+	for _, line := range strings.Split(string(out), "\n") {
+		fmt.Fprintf(b, "%s%s\n", indent, line)
+	}
+}
+
+// SchemaTypes returns a function that looks up the type that the chart's
+// values.schema.json declares for a value, as taken by strvals.ParseIntoTyped.
+// The schema of a subchart declares the types of the values under the name of
+// the subchart. SchemaTypes returns nil if neither the chart nor its
+// subcharts have a schema.
+func SchemaTypes(c *chart.Chart) (func(path []string) string, error) {
+	st, err := loadSchemaTypes(c)
+	if err != nil || st == nil {
+		return nil, err
+	}
+	return st.typeOf, nil
+}
+
+// schemaTypes holds the values schemas of a chart and of its subcharts.
+type schemaTypes struct {
+	schema    *valuesSchema
+	subcharts map[string]*schemaTypes
+}
+
+func loadSchemaTypes(c *chart.Chart) (*schemaTypes, error) {
+	st := &schemaTypes{subcharts: map[string]*schemaTypes{}}
+	for _, f := range c.Files {
+		if f.TypeUrl != SchemafileName {
+			continue
+		}
+		st.schema = &valuesSchema{}
+		if err := json.Unmarshal(f.Value, st.schema); err != nil {
+			return nil, fmt.Errorf("could not parse values schema of %s: %s", c.Metadata.GetName(), err)
+		}
+	}
+	for _, dep := range c.Dependencies {
+		sub, err := loadSchemaTypes(dep)
+		if err != nil {
+			return nil, err
+		}
+		if sub != nil {
+			st.subcharts[dep.Metadata.GetName()] = sub
+		}
+	}
+	if st.schema == nil && len(st.subcharts) == 0 {
+		return nil, nil
+	}
+	return st, nil
+}
+
+func (st *schemaTypes) typeOf(path []string) string {
+	if st.schema != nil {
+		if t, ok := st.schema.lookup(path); ok {
+			return t
+		}
+	}
+	if len(path) > 0 {
+		if sub, ok := st.subcharts[path[0]]; ok {
+			return sub.typeOf(path[1:])
+		}
+	}
+	return ""
+}
+
+// lookup returns the type of the value at path, and whether the schema
+// describes that value at all.
+func (s valuesSchema) lookup(path []string) (string, bool) {
+	for _, k := range path {
+		if k == "[]" {
+			if s.Items == nil {
+				return "", false
+			}
+			s = *s.Items
+			continue
+		}
+		p, ok := s.Properties[k]
+		if !ok {
+			return "", false
+		}
+		s = p
+	}
+	return s.typeName(), true
+}
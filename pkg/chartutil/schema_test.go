@@ -0,0 +1,82 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestSchemaTypes(t *testing.T) {
+	sub := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "db"},
+		Files: []*any.Any{
+			{TypeUrl: SchemafileName, Value: []byte(`{"properties": {"port": {"type": "integer"}}}`)},
+		},
+	}
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "app"},
+		Files: []*any.Any{
+			{TypeUrl: SchemafileName, Value: []byte(`{
+				"properties": {
+					"replicas": {"type": "integer"},
+					"debug": {"type": ["null", "boolean"]},
+					"image": {"properties": {"tag": {"type": "string"}}},
+					"ports": {"type": "array", "items": {"type": "integer"}},
+					"db": {"properties": {"name": {"type": "string"}}}
+				}
+			}`)},
+		},
+		Dependencies: []*chart.Chart{sub, {Metadata: &chart.Metadata{Name: "cache"}}},
+	}
+
+	typeOf, err := SchemaTypes(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		path []string
+		want string
+	}{
+		{[]string{"replicas"}, "integer"},
+		{[]string{"debug"}, "boolean"},
+		{[]string{"image"}, "object"},
+		{[]string{"image", "tag"}, "string"},
+		{[]string{"image", "pullPolicy"}, ""},
+		{[]string{"ports", "[]"}, "integer"},
+		{[]string{"db", "name"}, "string"},
+		{[]string{"db", "port"}, "integer"},
+		{[]string{"cache", "size"}, ""},
+		{[]string{"unknown"}, ""},
+	} {
+		if got := typeOf(tt.path); got != tt.want {
+			t.Errorf("%v: expected type %q, got %q", tt.path, tt.want, got)
+		}
+	}
+
+	if typeOf, err := SchemaTypes(&chart.Chart{Metadata: &chart.Metadata{Name: "plain"}}); err != nil || typeOf != nil {
+		t.Errorf("Expected no types for a chart without a schema, got %v", err)
+	}
+
+	c.Files[0].Value = []byte("{")
+	if _, err := SchemaTypes(c); err == nil {
+		t.Error("Expected an error for an invalid schema")
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/ghodss/yaml"
@@ -141,6 +142,9 @@ func ReadValuesFile(filename string) (Values, error) {
 //	- Scalar values and arrays are replaced, maps are merged
 //	- A chart has access to all of the variables for it, as well as all of
 //		the values destined for its dependencies.
+//	- A string value of a subchart may reference a value of its parent chart,
+//		as ${parent.global.domain}. References are resolved once the values
+//		are coalesced, and a reference to a missing value is an error.
 func CoalesceValues(chrt *chart.Chart, vals *chart.Config) (Values, error) {
 	cvals := Values{}
 	// Parse values if not nil. We merge these at the top level because
@@ -158,7 +162,76 @@ func CoalesceValues(chrt *chart.Chart, vals *chart.Config) (Values, error) {
 
 	var err error
 	cvals, err = coalesceDeps(chrt, cvals)
-	return cvals, err
+	if err != nil {
+		return cvals, err
+	}
+	return cvals, resolveValueRefs(chrt, cvals, nil, "")
+}
+
+// CoalesceValuesWithWarnings coalesces the values like CoalesceValues, and also
+// returns a warning for each value in vals that changes the type of the chart
+// default it overrides, like a string replacing a table. Such values do not
+// fail the coalesce, but often break templates in confusing ways.
+func CoalesceValuesWithWarnings(chrt *chart.Chart, vals *chart.Config) (Values, []string, error) {
+	var warnings []string
+	if vals != nil {
+		if evals, err := ReadValues([]byte(vals.Raw)); err == nil {
+			warnings = typeWarnings(chrt, evals, "")
+		}
+	}
+	cvals, err := CoalesceValues(chrt, vals)
+	return cvals, warnings, err
+}
+
+// typeWarnings compares the types of the overrides with the default values of
+// the chart and its dependencies.
+func typeWarnings(c *chart.Chart, overrides map[string]interface{}, prefix string) []string {
+	var warnings []string
+	if c.Values != nil && c.Values.Raw != "" {
+		if defaults, err := ReadValues([]byte(c.Values.Raw)); err == nil {
+			warnings = compareTypes(defaults, overrides, prefix)
+		}
+	}
+	for _, sub := range c.Dependencies {
+		if sv, ok := overrides[sub.Metadata.Name].(map[string]interface{}); ok {
+			warnings = append(warnings, typeWarnings(sub, sv, prefix+sub.Metadata.Name+".")...)
+		}
+	}
+	return warnings
+}
+
+func compareTypes(defaults, overrides map[string]interface{}, prefix string) []string {
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var warnings []string
+	for _, key := range keys {
+		val, def := overrides[key], defaults[key]
+		if val == nil || def == nil {
+			continue
+		}
+		vk, dk := valueKind(val), valueKind(def)
+		if vk != dk {
+			warnings = append(warnings, fmt.Sprintf("%s%s: a %s overrides a %s in the chart defaults", prefix, key, vk, dk))
+		} else if vk == "table" {
+			warnings = append(warnings, compareTypes(def.(map[string]interface{}), val.(map[string]interface{}), prefix+key+".")...)
+		}
+	}
+	return warnings
+}
+
+// valueKind returns whether v is a table, a list or a scalar.
+func valueKind(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "table"
+	case []interface{}:
+		return "list"
+	}
+	return "scalar"
 }
 
 // coalesce coalesces the dest values and the chart values, giving priority to the dest values.
@@ -331,6 +404,39 @@ func coalesceTables(dst, src map[string]interface{}) map[string]interface{} {
 	return dst
 }
 
+// DeepMerge merges the given maps into a new map.
+//
+// Later maps take precedence over earlier ones. Nested tables are merged key
+// by key, while any other value, including a list, replaces the earlier value
+// as a whole. None of the given maps is modified.
+//
+// This is designed to be called from a template.
+func DeepMerge(maps ...map[string]interface{}) map[string]interface{} {
+	dst := map[string]interface{}{}
+	for _, m := range maps {
+		deepMerge(dst, m)
+	}
+	return dst
+}
+
+// deepMerge merges src into dst. Every table in dst is created here, so it
+// can be modified without touching the tables of src.
+func deepMerge(dst, src map[string]interface{}) {
+	for key, val := range src {
+		st := asTable(val)
+		if st == nil {
+			dst[key] = val
+			continue
+		}
+		dt, ok := dst[key].(map[string]interface{})
+		if !ok {
+			dt = map[string]interface{}{}
+			dst[key] = dt
+		}
+		deepMerge(dt, st)
+	}
+}
+
 // ReleaseOptions represents the additional release options needed
 // for the composition of the final values struct
 type ReleaseOptions struct {
@@ -0,0 +1,91 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"reflect"
+	"sort"
+)
+
+// ValuesChangeType describes how a value differs between two sets of values.
+type ValuesChangeType string
+
+// Types of values changes
+const (
+	ValueAdded   ValuesChangeType = "added"
+	ValueRemoved ValuesChangeType = "removed"
+	ValueChanged ValuesChangeType = "changed"
+)
+
+// ValuesChange is a single difference between two sets of values.
+type ValuesChange struct {
+	// Path is the dotted path of the value, e.g. "image.tag".
+	Path string
+	Type ValuesChangeType
+	// Old is the previous value. It is nil for added values.
+	Old interface{}
+	// New is the current value. It is nil for removed values.
+	New interface{}
+}
+
+// DiffValues returns the differences between two sets of values, sorted by path.
+//
+// Nested tables are compared key by key. Any other value, including lists, is
+// compared as a whole.
+func DiffValues(old, new map[string]interface{}) []ValuesChange {
+	changes := diffValues("", old, new)
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+func diffValues(prefix string, old, new map[string]interface{}) []ValuesChange {
+	var changes []ValuesChange
+	for k, ov := range old {
+		path := prefix + k
+		nv, ok := new[k]
+		if !ok {
+			changes = append(changes, ValuesChange{Path: path, Type: ValueRemoved, Old: ov})
+			continue
+		}
+		if ot, nt := asTable(ov), asTable(nv); ot != nil && nt != nil {
+			changes = append(changes, diffValues(path+".", ot, nt)...)
+			continue
+		}
+		if !reflect.DeepEqual(ov, nv) {
+			changes = append(changes, ValuesChange{Path: path, Type: ValueChanged, Old: ov, New: nv})
+		}
+	}
+	for k, nv := range new {
+		if _, ok := old[k]; !ok {
+			changes = append(changes, ValuesChange{Path: prefix + k, Type: ValueAdded, New: nv})
+		}
+	}
+	return changes
+}
+
+// asTable returns v as a table, or nil if it is not one.
+func asTable(v interface{}) map[string]interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return t
+	case Values:
+		return t
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffValues(t *testing.T) {
+	old, err := ReadValues([]byte(`
+replicaCount: 1
+debug: true
+image:
+  repository: nginx
+  tag: "1.13"
+ports: [80]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	new, err := ReadValues([]byte(`
+replicaCount: 1
+image:
+  repository: nginx
+  tag: "1.14"
+ports: [80, 443]
+service:
+  type: ClusterIP
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []ValuesChange{
+		{Path: "debug", Type: ValueRemoved, Old: true},
+		{Path: "image.tag", Type: ValueChanged, Old: "1.13", New: "1.14"},
+		{Path: "ports", Type: ValueChanged, Old: []interface{}{float64(80)}, New: []interface{}{float64(80), float64(443)}},
+		{Path: "service", Type: ValueAdded, New: map[string]interface{}{"type": "ClusterIP"}},
+	}
+	if got := DiffValues(old, new); !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected changes:\n%#v\nGot:\n%#v", expect, got)
+	}
+
+	if got := DiffValues(old, old); len(got) != 0 {
+		t.Errorf("Expected no changes for identical values, got %v", got)
+	}
+}
+
+func TestDiffValuesTableReplaced(t *testing.T) {
+	old := map[string]interface{}{"image": map[string]interface{}{"tag": "1.13"}}
+	new := map[string]interface{}{"image": "nginx:1.14"}
+
+	expect := []ValuesChange{
+		{Path: "image", Type: ValueChanged, Old: old["image"], New: "nginx:1.14"},
+	}
+	if got := DiffValues(old, new); !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected changes:\n%#v\nGot:\n%#v", expect, got)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// valueRefPattern matches a reference to a value of a parent chart, such as
+// ${parent.global.domain}. Each "parent." goes up one chart.
+var valueRefPattern = regexp.MustCompile(`\$\{((?:parent\.)+)([^}]+)\}`)
+
+// resolveValueRefs replaces the references to parent values in the coalesced
+// values of a chart and, in turn, of its subcharts. A string that is a single
+// reference takes the referenced value as it is, of any type. References
+// within a longer string must be to scalars.
+//
+// The values of a chart are resolved before those of its subcharts, so a
+// reference to a parent value that is a reference itself works.
+func resolveValueRefs(chrt *chart.Chart, vals map[string]interface{}, parents []map[string]interface{}, prefix string) error {
+	deps := map[string]bool{}
+	for _, sub := range chrt.Dependencies {
+		deps[sub.Metadata.Name] = true
+	}
+	for k, v := range vals {
+		if deps[k] {
+			continue
+		}
+		resolved, err := resolveRefsIn(v, parents, prefix+k)
+		if err != nil {
+			return err
+		}
+		vals[k] = resolved
+	}
+
+	parents = append(parents, vals)
+	for _, sub := range chrt.Dependencies {
+		sv, ok := vals[sub.Metadata.Name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := resolveValueRefs(sub, sv, parents, prefix+sub.Metadata.Name+"."); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resolveRefsIn(v interface{}, parents []map[string]interface{}, path string) (interface{}, error) {
+	switch t := v.(type) {
+	case string:
+		return resolveRefString(t, parents, path)
+	case map[string]interface{}:
+		for k, vv := range t {
+			resolved, err := resolveRefsIn(vv, parents, path+"."+k)
+			if err != nil {
+				return nil, err
+			}
+			t[k] = resolved
+		}
+	case []interface{}:
+		for i, vv := range t {
+			resolved, err := resolveRefsIn(vv, parents, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			t[i] = resolved
+		}
+	}
+	return v, nil
+}
+
+func resolveRefString(s string, parents []map[string]interface{}, path string) (interface{}, error) {
+	matches := valueRefPattern.FindAllStringSubmatch(s, -1)
+	if len(matches) == 0 {
+		return s, nil
+	}
+	if len(matches) == 1 && matches[0][0] == s {
+		return lookupValueRef(matches[0], parents, path)
+	}
+
+	var err error
+	out := valueRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		val, lerr := lookupValueRef(valueRefPattern.FindStringSubmatch(ref), parents, path)
+		if lerr != nil {
+			err = lerr
+			return ref
+		}
+		switch val.(type) {
+		case map[string]interface{}, []interface{}:
+			err = fmt.Errorf("%s: cannot use %s within a string: it is not a scalar", path, ref)
+			return ref
+		}
+		return fmt.Sprint(val)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// lookupValueRef returns the value a matched reference points at.
+func lookupValueRef(match []string, parents []map[string]interface{}, path string) (interface{}, error) {
+	ref, depth, key := match[0], strings.Count(match[1], "parent."), match[2]
+	if depth > len(parents) {
+		return nil, fmt.Errorf("%s: cannot resolve %s: the chart has only %d parent chart(s)", path, ref, len(parents))
+	}
+	var cur interface{} = parents[len(parents)-depth]
+	for _, k := range strings.Split(key, ".") {
+		table, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: cannot resolve %s: no value at %s", path, ref, key)
+		}
+		if cur, ok = table[k]; !ok || cur == nil {
+			return nil, fmt.Errorf("%s: cannot resolve %s: no value at %s", path, ref, key)
+		}
+	}
+	return cur, nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func refsChart(subValues string) *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{Name: "pequod"},
+		Values: &chart.Config{Raw: `
+global:
+  domain: example.com
+replicas: 3
+`},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "whaleboat"},
+				Values:   &chart.Config{Raw: subValues},
+				Dependencies: []*chart.Chart{
+					{
+						Metadata: &chart.Metadata{Name: "oar"},
+						Values:   &chart.Config{Raw: "host: ${parent.host}\nroot: ${parent.parent.global.domain}\n"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCoalesceValuesReferences(t *testing.T) {
+	c := refsChart(`
+domain: ${parent.global.domain}
+host: api.${parent.global.domain}
+replicas: ${parent.replicas}
+`)
+	v, err := CoalesceValues(c, &chart.Config{Raw: "replicas: 5\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		tpl    string
+		expect string
+	}{
+		{"{{.whaleboat.domain}}", "example.com"},
+		{"{{.whaleboat.host}}", "api.example.com"},
+		{"{{.whaleboat.replicas}}", "5"},
+		{"{{.whaleboat.oar.host}}", "api.example.com"},
+		{"{{.whaleboat.oar.root}}", "example.com"},
+	}
+	for _, tt := range tests {
+		if o, err := ttpl(tt.tpl, v); err != nil || o != tt.expect {
+			t.Errorf("Expected %q to expand to %q, got %q", tt.tpl, tt.expect, o)
+		}
+	}
+
+	// A value given for the subchart replaces the reference.
+	v, err = CoalesceValues(c, &chart.Config{Raw: "whaleboat:\n  domain: example.org\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o, _ := ttpl("{{.whaleboat.domain}}", v); o != "example.org" {
+		t.Errorf("Expected the override to win, got %q", o)
+	}
+}
+
+func TestCoalesceValuesUnresolvableReference(t *testing.T) {
+	tests := []struct {
+		vals   string
+		expect string
+	}{
+		{"domain: ${parent.global.missing}\n", "whaleboat.domain: cannot resolve ${parent.global.missing}: no value at global.missing"},
+		{"host: api.${parent.global}\n", "whaleboat.host: cannot use ${parent.global} within a string: it is not a scalar"},
+		{"domain: ${parent.parent.global.domain}\n", "whaleboat.domain: cannot resolve ${parent.parent.global.domain}: the chart has only 1 parent chart(s)"},
+	}
+	for _, tt := range tests {
+		_, err := CoalesceValues(refsChart(tt.vals), &chart.Config{})
+		if err == nil || !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("Expected error %q, got %v", tt.expect, err)
+		}
+	}
+}
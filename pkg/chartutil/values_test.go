@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"testing"
 	"text/template"
 
@@ -421,6 +422,79 @@ func TestCoalesceTables(t *testing.T) {
 		t.Errorf("Expected boat string, got %v", dst["boat"])
 	}
 }
+
+func TestDeepMerge(t *testing.T) {
+	newDefaults := func() map[string]interface{} {
+		return map[string]interface{}{
+			"name": "Ishmael",
+			"address": map[string]interface{}{
+				"street": "123 Spouter Inn Ct.",
+				"city":   "Nantucket",
+			},
+			"friends": []interface{}{"Tashtego", "Queequeg"},
+			"boat":    "pequod",
+		}
+	}
+	newOverrides := func() map[string]interface{} {
+		return map[string]interface{}{
+			"occupation": "whaler",
+			"address": map[string]interface{}{
+				"street": "234 Spouter Inn Ct.",
+				"geo": map[string]interface{}{
+					"lat": 41.28,
+				},
+			},
+			"friends": []interface{}{"Starbuck"},
+			"boat": map[string]interface{}{
+				"mast": true,
+			},
+		}
+	}
+
+	defaults, overrides := newDefaults(), newOverrides()
+	got := DeepMerge(defaults, overrides, map[string]interface{}{
+		"address": Values{"state": "MA"},
+	})
+
+	expect := map[string]interface{}{
+		"name":       "Ishmael",
+		"occupation": "whaler",
+		"address": map[string]interface{}{
+			"street": "234 Spouter Inn Ct.",
+			"city":   "Nantucket",
+			"state":  "MA",
+			"geo": map[string]interface{}{
+				"lat": 41.28,
+			},
+		},
+		"friends": []interface{}{"Starbuck"},
+		"boat": map[string]interface{}{
+			"mast": true,
+		},
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected %v, got %v", expect, got)
+	}
+
+	// Neither input may have been modified by the merge.
+	if !reflect.DeepEqual(defaults, newDefaults()) {
+		t.Errorf("Defaults were modified: %v", defaults)
+	}
+	if !reflect.DeepEqual(overrides, newOverrides()) {
+		t.Errorf("Overrides were modified: %v", overrides)
+	}
+
+	// Changing the result must not leak back into the inputs.
+	got["address"].(map[string]interface{})["city"] = "New Bedford"
+	got["boat"].(map[string]interface{})["mast"] = false
+	if defaults["address"].(map[string]interface{})["city"] != "Nantucket" {
+		t.Error("Result shares a table with the defaults")
+	}
+	if overrides["boat"].(map[string]interface{})["mast"] != true {
+		t.Error("Result shares a table with the overrides")
+	}
+}
+
 func TestPathValue(t *testing.T) {
 	doc := `
 title: "Moby Dick"
@@ -457,3 +531,51 @@ chapter:
 		}
 	}
 }
+
+func TestCoalesceValuesWithWarnings(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "pequod"},
+		Values: &chart.Config{Raw: `
+captain:
+  name: Ahab
+  leg: ivory
+boats: 4
+crew: [Starbuck, Stubb]
+`},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "whaleboat"},
+				Values:   &chart.Config{Raw: "harpooner: Queequeg\n"},
+			},
+		},
+	}
+	vals := &chart.Config{Raw: `
+captain: Ahab
+boats: [1, 2, 3, 4]
+crew: [Starbuck, Stubb, Flask]
+whaleboat:
+  harpooner:
+    name: Queequeg
+`}
+
+	v, warnings, err := CoalesceValuesWithWarnings(c, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{
+		"boats: a list overrides a scalar in the chart defaults",
+		"captain: a scalar overrides a table in the chart defaults",
+		"whaleboat.harpooner: a table overrides a scalar in the chart defaults",
+	}
+	if !reflect.DeepEqual(warnings, expect) {
+		t.Errorf("Expected warnings %q, got %q", expect, warnings)
+	}
+
+	// The warnings do not change how the values are coalesced.
+	if captain, _ := v["captain"].(string); captain != "Ahab" {
+		t.Errorf("Expected the override to win, got %v", v["captain"])
+	}
+	if _, warnings, err := CoalesceValuesWithWarnings(c, &chart.Config{Raw: "boats: 5\n"}); err != nil || len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %q (%v)", warnings, err)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decrypt
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"k8s.io/helm/pkg/helm/environment"
+)
+
+// Decryptor decrypts the content of a values file.
+type Decryptor interface {
+	// Decrypt returns the plaintext of the values file name, whose encrypted
+	// content is data.
+	Decrypt(name string, data []byte) ([]byte, error)
+}
+
+// DecryptorFunc adapts an ordinary function to a Decryptor.
+type DecryptorFunc func(name string, data []byte) ([]byte, error)
+
+// Decrypt calls f(name, data).
+func (f DecryptorFunc) Decrypt(name string, data []byte) ([]byte, error) {
+	return f(name, data)
+}
+
+// Provider represents a decryptor and the values files it handles.
+type Provider struct {
+	// Patterns are matched against the base name of a values file, with the
+	// syntax of filepath.Match. For example, 'secrets.*.yaml'.
+	Patterns  []string
+	Decryptor Decryptor
+}
+
+// Handles returns true if the values file name matches a pattern of this
+// Provider.
+func (p Provider) Handles(name string) bool {
+	base := filepath.Base(name)
+	for _, pattern := range p.Patterns {
+		if ok, _ := filepath.Match(pattern, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Providers is a collection of Provider objects.
+type Providers []Provider
+
+// Decrypt decrypts data, the content of the values file name, with the first
+// provider that handles the file. Files that no provider handles are returned
+// as they are.
+func (p Providers) Decrypt(name string, data []byte) ([]byte, error) {
+	for _, pp := range p {
+		if !pp.Handles(name) {
+			continue
+		}
+		plain, err := pp.Decryptor.Decrypt(name, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s: %s", name, err)
+		}
+		return plain, nil
+	}
+	return data, nil
+}
+
+var (
+	registeredMu sync.RWMutex
+	registered   Providers
+)
+
+// Register makes a decryptor available to All for the values files matching
+// the patterns of the given Provider.
+//
+// Registered providers take precedence over plugins for the files they share.
+func Register(p Provider) error {
+	if p.Decryptor == nil {
+		return fmt.Errorf("decryptor provider for %v has no decryptor", p.Patterns)
+	}
+	if len(p.Patterns) == 0 {
+		return fmt.Errorf("decryptor provider has no patterns")
+	}
+	for _, pattern := range p.Patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid decryptor pattern %q: %s", pattern, err)
+		}
+	}
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	registered = append(registered, p)
+	return nil
+}
+
+// Registered returns the providers added with Register.
+func Registered() Providers {
+	registeredMu.RLock()
+	defer registeredMu.RUnlock()
+	return append(Providers(nil), registered...)
+}
+
+// All finds all of the decryptors as a list of Provider instances. The
+// providers added with Register come first, followed by the discovered
+// plugins with decryptor notations.
+func All(settings environment.EnvSettings) Providers {
+	result := Registered()
+	pluginDecryptors, _ := collectPlugins(settings)
+	return append(result, pluginDecryptors...)
+}
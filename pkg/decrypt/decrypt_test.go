@@ -0,0 +1,89 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decrypt
+
+import (
+	"encoding/base64"
+	"os"
+	"strings"
+	"testing"
+)
+
+// base64Decryptor "decrypts" values files that are base64 encoded.
+var base64Decryptor = DecryptorFunc(func(name string, data []byte) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(string(data))
+})
+
+func TestProvidersDecrypt(t *testing.T) {
+	p := Providers{{Patterns: []string{"secrets.*.yaml", "*.enc"}, Decryptor: base64Decryptor}}
+	ciphertext := base64.StdEncoding.EncodeToString([]byte("password: hunter2\n"))
+
+	for _, name := range []string{"secrets.prod.yaml", "/values/secrets.dev.yaml", "values.enc"} {
+		plain, err := p.Decrypt(name, []byte(ciphertext))
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if expect := "password: hunter2\n"; string(plain) != expect {
+			t.Errorf("%s: expected %q, got %q", name, expect, plain)
+		}
+	}
+
+	// Files that match no pattern are not decrypted.
+	plain, err := p.Decrypt("values.yaml", []byte(ciphertext))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plain) != ciphertext {
+		t.Errorf("Expected values.yaml to be left as it is, got %q", plain)
+	}
+
+	_, err = p.Decrypt("secrets.prod.yaml", []byte("not base64!"))
+	if err == nil || !strings.HasPrefix(err.Error(), "failed to decrypt secrets.prod.yaml: ") {
+		t.Errorf("Expected a decryption error, got %v", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	oldhh := os.Getenv("HELM_HOME")
+	defer os.Setenv("HELM_HOME", oldhh)
+	os.Setenv("HELM_HOME", "")
+
+	defer func(old Providers) { registered = old }(registered)
+	registered = nil
+
+	if err := Register(Provider{Patterns: []string{"secrets.*.yaml"}}); err == nil {
+		t.Error("Expected error registering a provider without a decryptor")
+	}
+	if err := Register(Provider{Decryptor: base64Decryptor}); err == nil {
+		t.Error("Expected error registering a provider without patterns")
+	}
+	if err := Register(Provider{Patterns: []string{"secrets.[.yaml"}, Decryptor: base64Decryptor}); err == nil {
+		t.Error("Expected error registering a provider with an invalid pattern")
+	}
+	if err := Register(Provider{Patterns: []string{"secrets.*.yaml"}, Decryptor: base64Decryptor}); err != nil {
+		t.Fatal(err)
+	}
+
+	all := All(hh(false))
+	if len(all) != 2 {
+		t.Fatalf("Expected 2 providers, got %d", len(all))
+	}
+	// The registered decryptor takes precedence over the plugin.
+	if _, ok := all[0].Decryptor.(DecryptorFunc); !ok {
+		t.Errorf("Expected the registered decryptor first, got %T", all[0].Decryptor)
+	}
+}
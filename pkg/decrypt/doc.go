@@ -0,0 +1,25 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package decrypt provides pluggable decryption of encrypted values files.
+
+Decryptors are chosen by the name of a values file, so that files such as
+'secrets.prod.yaml' can be committed encrypted and are decrypted before they
+are parsed. Decryptors are either registered by programs embedding Helm or
+provided by plugins.
+*/
+package decrypt
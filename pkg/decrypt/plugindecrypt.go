@@ -0,0 +1,85 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decrypt
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/helm/pkg/helm/environment"
+	"k8s.io/helm/pkg/plugin"
+)
+
+// collectPlugins scans for decryptor plugins.
+// This will load plugins according to the environment.
+func collectPlugins(settings environment.EnvSettings) (Providers, error) {
+	plugins, err := plugin.FindPlugins(settings.PluginDirs())
+	if err != nil {
+		return nil, err
+	}
+	var result Providers
+	for _, plugin := range plugins {
+		for _, decryptor := range plugin.Metadata.Decryptors {
+			result = append(result, Provider{
+				Patterns: decryptor.Patterns,
+				Decryptor: &pluginDecryptor{
+					command:  decryptor.Command,
+					settings: settings,
+					name:     plugin.Metadata.Name,
+					base:     plugin.Dir,
+				},
+			})
+		}
+	}
+	return result, nil
+}
+
+// pluginDecryptor invokes a custom decryptor implemented in a plugin.
+//
+// The command is given the path of the values file as its argument and the
+// encrypted content on stdin, and writes the plaintext to stdout.
+type pluginDecryptor struct {
+	command  string
+	settings environment.EnvSettings
+	name     string
+	base     string
+}
+
+// Decrypt runs the decryptor plugin command.
+func (p *pluginDecryptor) Decrypt(name string, data []byte) ([]byte, error) {
+	prog := exec.Command(filepath.Join(p.base, p.command), name)
+	plugin.SetupPluginEnv(p.settings, p.name, p.base)
+	prog.Env = os.Environ()
+	prog.Stdin = bytes.NewReader(data)
+	var stdout, stderr bytes.Buffer
+	prog.Stdout = &stdout
+	prog.Stderr = &stderr
+	if err := prog.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("plugin %q exited with error: %s", p.name, msg)
+			}
+			return nil, fmt.Errorf("plugin %q exited with error", p.name)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
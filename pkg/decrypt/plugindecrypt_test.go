@@ -0,0 +1,85 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decrypt
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm/environment"
+	"k8s.io/helm/pkg/helm/helmpath"
+)
+
+func hh(debug bool) environment.EnvSettings {
+	apath, err := filepath.Abs("./testdata")
+	if err != nil {
+		panic(err)
+	}
+	hp := helmpath.Home(apath)
+	return environment.EnvSettings{
+		Home:  hp,
+		Debug: debug,
+	}
+}
+
+func TestCollectPlugins(t *testing.T) {
+	oldhh := os.Getenv("HELM_HOME")
+	defer os.Setenv("HELM_HOME", oldhh)
+	os.Setenv("HELM_HOME", "")
+
+	p, err := collectPlugins(hh(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p) != 1 {
+		t.Fatalf("Expected 1 plugin, got %d: %v", len(p), p)
+	}
+	if !p[0].Handles("values/secrets.prod.yaml") {
+		t.Error("Expected the plugin to handle secrets.prod.yaml")
+	}
+	if p[0].Handles("values.yaml") {
+		t.Error("Did not expect the plugin to handle values.yaml")
+	}
+}
+
+func TestPluginDecryptor(t *testing.T) {
+	oldhh := os.Getenv("HELM_HOME")
+	defer os.Setenv("HELM_HOME", oldhh)
+	os.Setenv("HELM_HOME", "")
+
+	p, err := collectPlugins(hh(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ciphertext := base64.StdEncoding.EncodeToString([]byte("password: hunter2\n"))
+	plain, err := p.Decrypt("secrets.prod.yaml", []byte(ciphertext))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "password: hunter2\n"; string(plain) != expect {
+		t.Errorf("Expected %q, got %q", expect, plain)
+	}
+
+	_, err = p.Decrypt("secrets.prod.yaml", []byte("password: hunter2\n"))
+	if err == nil || !strings.Contains(err.Error(), `failed to decrypt secrets.prod.yaml: plugin "testdecryptor" exited with error`) {
+		t.Errorf("Expected a decryption error, got %v", err)
+	}
+}
@@ -0,0 +1,3 @@
+#!/bin/sh
+
+exec base64 -d
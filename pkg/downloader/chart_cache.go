@@ -0,0 +1,103 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package downloader
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s.io/helm/pkg/provenance"
+)
+
+// errDigestMismatch indicates that an archive does not hash to its digest.
+var errDigestMismatch = errors.New("digest mismatch")
+
+var sha256Digest = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// chartCache is a directory of chart archives addressed by their SHA256
+// digest, as recorded in repository indexes.
+type chartCache string
+
+// key returns the cache key for digest, which may carry a "sha256:" prefix.
+func (c chartCache) key(digest string) string {
+	return strings.ToLower(strings.TrimPrefix(digest, "sha256:"))
+}
+
+// accepts reports whether digest can address a cache entry.
+func (c chartCache) accepts(digest string) bool {
+	return sha256Digest.MatchString(c.key(digest))
+}
+
+func (c chartCache) path(digest string) string {
+	return filepath.Join(string(c), c.key(digest)+".tgz")
+}
+
+// get returns the archive stored under digest.
+//
+// An entry whose content no longer matches its digest is removed, and
+// errDigestMismatch is returned so that the caller fetches the archive again.
+func (c chartCache) get(digest string) (*bytes.Buffer, error) {
+	data, err := ioutil.ReadFile(c.path(digest))
+	if err != nil {
+		return nil, err
+	}
+	if sum, err := provenance.Digest(bytes.NewReader(data)); err != nil || sum != c.key(digest) {
+		os.Remove(c.path(digest))
+		return nil, errDigestMismatch
+	}
+	return bytes.NewBuffer(data), nil
+}
+
+// put stores data under digest. Data that does not match digest is refused.
+func (c chartCache) put(digest string, data []byte) error {
+	sum, err := provenance.Digest(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if sum != c.key(digest) {
+		return errDigestMismatch
+	}
+	if err := os.MkdirAll(string(c), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename it into place so that concurrent
+	// readers never see a partially written archive.
+	f, err := ioutil.TempFile(string(c), "partial-")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), c.path(digest))
+}
@@ -16,6 +16,7 @@ limitations under the License.
 package downloader
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -67,6 +68,11 @@ type ChartDownloader struct {
 	HelmHome helmpath.Home
 	// Getter collection for the operation
 	Getters getter.Providers
+	// NoCache disables the chart cache, so chart archives are always fetched.
+	NoCache bool
+	// TransparencyLog, if set, is the URL of a transparency log that must
+	// record the signature of a verified chart.
+	TransparencyLog string
 }
 
 // DownloadTo retrieves a chart. Depending on the settings, it may also download a provenance file.
@@ -78,15 +84,19 @@ type ChartDownloader struct {
 //
 // For VerifyNever and VerifyIfPossible, the Verification may be empty.
 //
+// Charts whose digest is recorded in a repository index are kept in the chart
+// cache, and later downloads of the same archive are served from there unless
+// NoCache is set.
+//
 // Returns a string path to the location where the file was downloaded and a verification
 // (if provenance was verified), or an error if something bad happened.
 func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *provenance.Verification, error) {
-	u, g, err := c.ResolveChartVersion(ref, version)
+	u, g, digest, err := c.resolveChartVersion(ref, version)
 	if err != nil {
 		return "", nil, err
 	}
 
-	data, err := g.Get(u.String())
+	data, err := c.fetch(u, g, digest)
 	if err != nil {
 		return "", nil, err
 	}
@@ -114,17 +124,46 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 		}
 
 		if c.Verify != VerifyLater {
-			ver, err = VerifyChart(destfile, c.Keyring)
+			ver, err = VerifyChartWithLog(destfile, provfile, c.Keyring, c.TransparencyLog)
 			if err != nil {
 				// Fail always in this case, since it means the verification step
 				// failed.
 				return destfile, ver, err
 			}
+			if ver.LogUnreachable != nil {
+				fmt.Fprintf(c.Out, "WARNING: %s, verified %s with the keyring only\n", ver.LogUnreachable, ref)
+			}
 		}
 	}
 	return destfile, ver, nil
 }
 
+// fetch retrieves the chart archive at u. When digest is known, the archive is
+// looked up in the chart cache first and stored there after a fetch.
+func (c *ChartDownloader) fetch(u *url.URL, g getter.Getter, digest string) (*bytes.Buffer, error) {
+	cache := chartCache(c.HelmHome.ChartCache())
+	if c.NoCache || !cache.accepts(digest) {
+		return g.Get(u.String())
+	}
+
+	data, err := cache.get(digest)
+	if err == nil {
+		return data, nil
+	}
+	if err == errDigestMismatch {
+		fmt.Fprintf(c.Out, "WARNING: Evicted corrupted cache entry for %s\n", u)
+	}
+
+	data, err = g.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := cache.put(digest, data.Bytes()); err != nil {
+		fmt.Fprintf(c.Out, "WARNING: Could not cache %s: %s\n", u, err)
+	}
+	return data, nil
+}
+
 // ResolveChartVersion resolves a chart reference to a URL.
 //
 // It returns the URL as well as a preconfigured repo.Getter that can fetch
@@ -140,14 +179,21 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 //		* If version is empty, this will return the URL for the latest version
 //		* If no version can be found, an error is returned
 func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, getter.Getter, error) {
+	u, g, _, err := c.resolveChartVersion(ref, version)
+	return u, g, err
+}
+
+// resolveChartVersion is ResolveChartVersion, but also returns the digest the
+// repository index records for the chart, if any.
+func (c *ChartDownloader) resolveChartVersion(ref, version string) (*url.URL, getter.Getter, string, error) {
 	u, err := url.Parse(ref)
 	if err != nil {
-		return nil, nil, fmt.Errorf("invalid chart URL format: %s", ref)
+		return nil, nil, "", fmt.Errorf("invalid chart URL format: %s", ref)
 	}
 
 	rf, err := repo.LoadRepositoriesFile(c.HelmHome.RepositoryFile())
 	if err != nil {
-		return u, nil, err
+		return u, nil, "", err
 	}
 
 	if u.IsAbs() && len(u.Host) > 0 && len(u.Path) > 0 {
@@ -156,80 +202,111 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, ge
 		// through each repo cache file and finding a matching URL. But basically
 		// we want to find the repo in case we have special SSL cert config
 		// for that repo.
-		rc, err := c.scanReposForURL(ref, rf)
+		rc, cv, err := c.findChartURL(ref, rf)
 		if err != nil {
 			// If there is no special config, return the default HTTP client and
 			// swallow the error.
 			if err == ErrNoOwnerRepo {
 				getterConstructor, err := c.Getters.ByScheme(u.Scheme)
 				if err != nil {
-					return u, nil, err
+					return u, nil, "", err
 				}
 				getter, err := getterConstructor(ref, "", "", "")
-				return u, getter, err
+				return u, getter, "", err
 			}
-			return u, nil, err
+			return u, nil, "", err
 		}
 		r, err := repo.NewChartRepository(rc, c.Getters)
+		if err != nil {
+			return u, nil, "", err
+		}
+		g, err := c.repoGetter(r, u)
+		if err != nil {
+			return u, nil, "", err
+		}
 		// If we get here, we don't need to go through the next phase of looking
 		// up the URL. We have it already. So we just return.
-		return u, r.Client, err
+		return u, g, cv.Digest, nil
 	}
 
 	// See if it's of the form: repo/path_to_chart
 	p := strings.SplitN(u.Path, "/", 2)
 	if len(p) < 2 {
-		return u, nil, fmt.Errorf("Non-absolute URLs should be in form of repo_name/path_to_chart, got: %s", u)
+		return u, nil, "", fmt.Errorf("Non-absolute URLs should be in form of repo_name/path_to_chart, got: %s", u)
 	}
 
 	repoName := p[0]
 	chartName := p[1]
 	rc, err := pickChartRepositoryConfigByName(repoName, rf.Repositories)
 	if err != nil {
-		return u, nil, err
+		return u, nil, "", err
 	}
 
 	r, err := repo.NewChartRepository(rc, c.Getters)
 	if err != nil {
-		return u, nil, err
+		return u, nil, "", err
 	}
 
 	// Next, we need to load the index, and actually look up the chart.
 	i, err := repo.LoadIndexFile(c.HelmHome.CacheIndex(r.Config.Name))
 	if err != nil {
-		return u, r.Client, fmt.Errorf("no cached repo found. (try 'helm repo update'). %s", err)
+		return u, r.Client, "", fmt.Errorf("no cached repo found. (try 'helm repo update'). %s", err)
 	}
 
 	cv, err := i.Get(chartName, version)
 	if err != nil {
-		return u, r.Client, fmt.Errorf("chart %q matching %s not found in %s index. (try 'helm repo update'). %s", chartName, version, r.Config.Name, err)
+		return u, r.Client, "", fmt.Errorf("chart %q matching %s not found in %s index. (try 'helm repo update'). %s", chartName, version, r.Config.Name, err)
 	}
 
 	if len(cv.URLs) == 0 {
-		return u, r.Client, fmt.Errorf("chart %q has no downloadable URLs", ref)
+		return u, r.Client, "", fmt.Errorf("chart %q has no downloadable URLs", ref)
 	}
 
 	// TODO: Seems that picking first URL is not fully correct
 	u, err = url.Parse(cv.URLs[0])
 	if err != nil {
-		return u, r.Client, fmt.Errorf("invalid chart URL format: %s", ref)
+		return u, r.Client, "", fmt.Errorf("invalid chart URL format: %s", ref)
 	}
 
 	// If the URL is relative (no scheme), prepend the chart repo's base URL
 	if !u.IsAbs() {
 		repoURL, err := url.Parse(rc.URL)
 		if err != nil {
-			return repoURL, r.Client, err
+			return repoURL, r.Client, "", err
 		}
 		q := repoURL.Query()
 		// We need a trailing slash for ResolveReference to work, but make sure there isn't already one
 		repoURL.Path = strings.TrimSuffix(repoURL.Path, "/") + "/"
 		u = repoURL.ResolveReference(u)
 		u.RawQuery = q.Encode()
-		return u, r.Client, err
+		return u, r.Client, cv.Digest, err
 	}
 
-	return u, r.Client, nil
+	g, err := c.repoGetter(r, u)
+	if err != nil {
+		return u, nil, "", err
+	}
+	return u, g, cv.Digest, nil
+}
+
+// repoGetter returns the getter to download u, a chart of the repository r.
+//
+// The credentials of the repository are only sent to the scheme and host of
+// the repository URL, so that an index cannot point them at another server.
+// A chart hosted elsewhere is downloaded without them, unless the repository
+// passes its credentials to all hosts.
+func (c *ChartDownloader) repoGetter(r *repo.ChartRepository, u *url.URL) (getter.Getter, error) {
+	if r.Config.PassCredentialsAll {
+		return r.Client, nil
+	}
+	if repoURL, err := url.Parse(r.Config.URL); err == nil && repoURL.Scheme == u.Scheme && repoURL.Host == u.Host {
+		return r.Client, nil
+	}
+	getterConstructor, err := c.Getters.ByScheme(u.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	return getterConstructor(u.String(), r.Config.CertFile, r.Config.KeyFile, r.Config.CAFile)
 }
 
 // VerifyChart takes a path to a chart archive and a keyring, and verifies the chart.
@@ -237,6 +314,24 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, ge
 // It assumes that a chart archive file is accompanied by a provenance file whose
 // name is the archive file name plus the ".prov" extension.
 func VerifyChart(path string, keyring string) (*provenance.Verification, error) {
+	return VerifyChartWithProvenance(path, path+".prov", keyring)
+}
+
+// VerifyChartWithProvenance takes a path to a chart archive, a path to its
+// provenance file, and a keyring, and verifies the chart.
+//
+// Verification happens entirely offline: the provenance signature is checked
+// against the keyring, and the archive is hashed and compared to the hash
+// recorded in the provenance file.
+func VerifyChartWithProvenance(path, provfile, keyring string) (*provenance.Verification, error) {
+	return VerifyChartWithLog(path, provfile, keyring, "")
+}
+
+// VerifyChartWithLog is VerifyChartWithProvenance, but if logURL is set the
+// signature must also be recorded in the transparency log at logURL. If the log
+// cannot be reached, the chart is verified with the keyring only, and the
+// returned verification says so.
+func VerifyChartWithLog(path, provfile, keyring, logURL string) (*provenance.Verification, error) {
 	// For now, error out if it's not a tar file.
 	if fi, err := os.Stat(path); err != nil {
 		return nil, err
@@ -246,7 +341,6 @@ func VerifyChart(path string, keyring string) (*provenance.Verification, error)
 		return nil, errors.New("chart must be a tgz file")
 	}
 
-	provfile := path + ".prov"
 	if _, err := os.Stat(provfile); err != nil {
 		return nil, fmt.Errorf("could not load provenance file %s: %s", provfile, err)
 	}
@@ -255,6 +349,9 @@ func VerifyChart(path string, keyring string) (*provenance.Verification, error)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load keyring: %s", err)
 	}
+	if logURL != "" {
+		sig.Log = &provenance.TransparencyLog{URL: logURL}
+	}
 	return sig.Verify(path, provfile)
 }
 
@@ -278,11 +375,12 @@ func pickChartRepositoryConfigByName(name string, cfgs []*repo.Entry) (*repo.Ent
 	return nil, fmt.Errorf("repo %s not found", name)
 }
 
-// scanReposForURL scans all repos to find which repo contains the given URL.
+// findChartURL scans all repos to find which repo contains the given URL.
 //
 // This will attempt to find the given URL in all of the known repositories files.
 //
-// If the URL is found, this will return the repo entry that contained that URL.
+// If the URL is found, this will return the repo entry that contained that URL,
+// along with the chart version the URL belongs to.
 //
 // If all of the repos are checked, but the URL is not found, an ErrNoOwnerRepo
 // error is returned.
@@ -296,30 +394,30 @@ func pickChartRepositoryConfigByName(name string, cfgs []*repo.Entry) (*repo.Ent
 // The same URL can technically exist in two or more repositories. This algorithm
 // will return the first one it finds. Order is determined by the order of repositories
 // in the repositories.yaml file.
-func (c *ChartDownloader) scanReposForURL(u string, rf *repo.RepoFile) (*repo.Entry, error) {
+func (c *ChartDownloader) findChartURL(u string, rf *repo.RepoFile) (*repo.Entry, *repo.ChartVersion, error) {
 	// FIXME: This is far from optimal. Larger installations and index files will
 	// incur a performance hit for this type of scanning.
 	for _, rc := range rf.Repositories {
 		r, err := repo.NewChartRepository(rc, c.Getters)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		i, err := repo.LoadIndexFile(c.HelmHome.CacheIndex(r.Config.Name))
 		if err != nil {
-			return nil, fmt.Errorf("no cached repo found. (try 'helm repo update'). %s", err)
+			return nil, nil, fmt.Errorf("no cached repo found. (try 'helm repo update'). %s", err)
 		}
 
 		for _, entry := range i.Entries {
 			for _, ver := range entry {
 				for _, dl := range ver.URLs {
 					if urlutil.Equal(u, dl) {
-						return rc, nil
+						return rc, ver, nil
 					}
 				}
 			}
 		}
 	}
 	// This means that there is no repo file for the given URL.
-	return nil, ErrNoOwnerRepo
+	return nil, nil, ErrNoOwnerRepo
 }
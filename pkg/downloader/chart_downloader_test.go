@@ -16,6 +16,7 @@ limitations under the License.
 package downloader
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -28,6 +29,8 @@ import (
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm/environment"
 	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/provenance"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/repo/repotest"
 )
@@ -293,7 +296,7 @@ func TestScanReposForURL(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	entry, err := c.scanReposForURL(u, rf)
+	entry, cv, err := c.findChartURL(u, rf)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -301,10 +304,372 @@ func TestScanReposForURL(t *testing.T) {
 	if entry.Name != "testing" {
 		t.Errorf("Unexpected repo %q for URL %q", entry.Name, u)
 	}
+	if cv.Name != "alpine" || cv.Version != "0.2.0" {
+		t.Errorf("Unexpected chart %s-%s for URL %q", cv.Name, cv.Version, u)
+	}
 
 	// A lookup failure should produce an ErrNoOwnerRepo
 	u = "https://no.such.repo/foo/bar-1.23.4.tgz"
-	if _, err = c.scanReposForURL(u, rf); err != ErrNoOwnerRepo {
+	if _, _, err = c.findChartURL(u, rf); err != ErrNoOwnerRepo {
 		t.Fatalf("expected ErrNoOwnerRepo, got %v", err)
 	}
 }
+
+func TestDownloadTo_BasicAuth(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-downloadto-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	hh := helmpath.Home(tmp)
+	dest := filepath.Join(hh.String(), "dest")
+	docroot := filepath.Join(hh.String(), "docroot")
+	for _, p := range []string{hh.Repository(), hh.Cache(), dest, docroot} {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatalf("Could not create %s: %s", p, err)
+		}
+	}
+
+	data, err := ioutil.ReadFile("testdata/signtest-0.1.0.tgz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(docroot, "signtest-0.1.0.tgz"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every request must authenticate, and we keep track of what was requested.
+	requested := map[string]bool{}
+	fs := http.FileServer(http.Dir(docroot))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "username" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		requested[r.URL.Path] = true
+		fs.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	index, err := repo.IndexDirectory(docroot, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := index.WriteFile(filepath.Join(docroot, "index.yaml"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := &repo.Entry{
+		Name:     "test",
+		URL:      srv.URL,
+		Cache:    hh.CacheIndex("test"),
+		Username: "username",
+		Password: "password",
+	}
+	rf := repo.NewRepoFile()
+	rf.Add(entry)
+	if err := rf.WriteFile(hh.RepositoryFile(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	getters := getter.All(environment.EnvSettings{})
+	r, err := repo.NewChartRepository(entry, getters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.DownloadIndexFile(hh.Cache()); err != nil {
+		t.Fatalf("failed to download index: %s", err)
+	}
+
+	c := ChartDownloader{
+		HelmHome: hh,
+		Out:      os.Stderr,
+		Verify:   VerifyNever,
+		Getters:  getters,
+	}
+	if _, _, err := c.DownloadTo("test/signtest", "", dest); err != nil {
+		t.Fatalf("failed to download chart: %s", err)
+	}
+
+	for _, p := range []string{"/index.yaml", "/signtest-0.1.0.tgz"} {
+		if !requested[p] {
+			t.Errorf("Expected an authenticated request for %s", p)
+		}
+	}
+}
+
+func TestDownloadTo_CredentialsOtherHost(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-downloadto-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	hh := helmpath.Home(tmp)
+	dest := filepath.Join(hh.String(), "dest")
+	docroot := filepath.Join(hh.String(), "docroot")
+	for _, p := range []string{hh.Repository(), hh.Cache(), dest, docroot} {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatalf("Could not create %s: %s", p, err)
+		}
+	}
+
+	data, err := ioutil.ReadFile("testdata/signtest-0.1.0.tgz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(docroot, "signtest-0.1.0.tgz"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The index of the repository points at charts hosted on another server,
+	// which records the credentials it is sent.
+	var leaked string
+	fs := http.FileServer(http.Dir(docroot))
+	chartSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = r.Header.Get("Authorization")
+		fs.ServeHTTP(w, r)
+	}))
+	defer chartSrv.Close()
+	repoSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "username" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fs.ServeHTTP(w, r)
+	}))
+	defer repoSrv.Close()
+
+	index, err := repo.IndexDirectory(docroot, chartSrv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := index.WriteFile(filepath.Join(docroot, "index.yaml"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, passCredentials := range []bool{false, true} {
+		entry := &repo.Entry{
+			Name:               "test",
+			URL:                repoSrv.URL,
+			Cache:              hh.CacheIndex("test"),
+			Username:           "username",
+			Password:           "password",
+			PassCredentialsAll: passCredentials,
+		}
+		rf := repo.NewRepoFile()
+		rf.Add(entry)
+		if err := rf.WriteFile(hh.RepositoryFile(), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		getters := getter.All(environment.EnvSettings{})
+		r, err := repo.NewChartRepository(entry, getters)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := r.DownloadIndexFile(hh.Cache()); err != nil {
+			t.Fatalf("failed to download index: %s", err)
+		}
+
+		c := ChartDownloader{
+			HelmHome: hh,
+			Out:      os.Stderr,
+			Verify:   VerifyNever,
+			Getters:  getters,
+			NoCache:  true,
+		}
+		leaked = ""
+		if _, _, err := c.DownloadTo("test/signtest", "", dest); err != nil {
+			t.Fatalf("failed to download chart: %s", err)
+		}
+		if passCredentials && leaked == "" {
+			t.Error("Expected the credentials to be passed to the chart host")
+		}
+		if !passCredentials && leaked != "" {
+			t.Errorf("Expected no credentials to be sent to another host, got %q", leaked)
+		}
+	}
+}
+
+type fakeGetter struct {
+	urls []string
+}
+
+func (g *fakeGetter) Get(u string) (*bytes.Buffer, error) {
+	g.urls = append(g.urls, u)
+	return bytes.NewBufferString("fake chart"), nil
+}
+
+func TestDownloadTo_CustomGetter(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-downloadto-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	hh := helmpath.Home(tmp)
+	dest := filepath.Join(hh.String(), "dest")
+	for _, p := range []string{hh.Repository(), dest} {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repo.NewRepoFile().WriteFile(hh.RepositoryFile(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The provider is passed to the downloader rather than registered, so that
+	// it does not leak into other tests.
+	fg := &fakeGetter{}
+	p := getter.Provider{
+		Schemes: []string{"myproto"},
+		New: func(URL, CertFile, KeyFile, CAFile string) (getter.Getter, error) {
+			return fg, nil
+		},
+	}
+
+	c := ChartDownloader{
+		HelmHome: hh,
+		Out:      os.Stderr,
+		Verify:   VerifyNever,
+		Getters:  append(getter.Providers{p}, getter.All(environment.EnvSettings{})...),
+	}
+	where, _, err := c.DownloadTo("myproto://store.example.com/charts/foo-1.2.3.tgz", "", dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := filepath.Join(dest, "foo-1.2.3.tgz"); where != expect {
+		t.Errorf("Expected download to %s, got %s", expect, where)
+	}
+	if len(fg.urls) != 1 || fg.urls[0] != "myproto://store.example.com/charts/foo-1.2.3.tgz" {
+		t.Errorf("Expected the custom getter to fetch the chart, got %v", fg.urls)
+	}
+	if _, _, err := c.DownloadTo("yourproto://store.example.com/charts/foo-1.2.3.tgz", "", dest); err == nil {
+		t.Error("Expected error for unsupported scheme")
+	}
+}
+
+// countingGetter serves a fixed chart archive and counts how often it is asked to.
+type countingGetter struct {
+	data []byte
+	gets int
+}
+
+func (g *countingGetter) Get(u string) (*bytes.Buffer, error) {
+	g.gets++
+	return bytes.NewBuffer(g.data), nil
+}
+
+// cacheFixture sets up a $HELM_HOME with a "cached" repository served over
+// scheme by the returned getter. The repository holds signtest 0.1.0, whose
+// digest is returned.
+func cacheFixture(t *testing.T, scheme string) (*ChartDownloader, *countingGetter, string, func()) {
+	tmp, err := ioutil.TempDir("", "helm-downloadto-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hh := helmpath.Home(tmp)
+	for _, p := range []string{hh.Cache(), filepath.Join(tmp, "dest")} {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := ioutil.ReadFile("testdata/signtest-0.1.0.tgz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest, err := provenance.DigestFile("testdata/signtest-0.1.0.tgz")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repoURL := scheme + "://charts.example.com"
+	rf := repo.NewRepoFile()
+	rf.Add(&repo.Entry{Name: "cached", URL: repoURL})
+	if err := rf.WriteFile(hh.RepositoryFile(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	i := repo.NewIndexFile()
+	i.Add(&chart.Metadata{Name: "signtest", Version: "0.1.0"}, "signtest-0.1.0.tgz", repoURL, digest)
+	if err := i.WriteFile(hh.CacheIndex("cached"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	g := &countingGetter{data: data}
+	p := getter.Provider{
+		Schemes: []string{scheme},
+		New: func(URL, CertFile, KeyFile, CAFile string) (getter.Getter, error) {
+			return g, nil
+		},
+	}
+
+	c := &ChartDownloader{
+		HelmHome: hh,
+		Out:      ioutil.Discard,
+		Verify:   VerifyNever,
+		Getters:  append(getter.Providers{p}, getter.All(environment.EnvSettings{})...),
+	}
+	return c, g, digest, func() { os.RemoveAll(tmp) }
+}
+
+func TestDownloadTo_CacheHit(t *testing.T) {
+	c, g, _, cleanup := cacheFixture(t, "cachehit")
+	defer cleanup()
+	dest := c.HelmHome.Path("dest")
+
+	for n := 0; n < 2; n++ {
+		where, _, err := c.DownloadTo("cached/signtest", "", dest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := ioutil.ReadFile(where); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(got, g.data) {
+			t.Errorf("download %d: unexpected chart content", n)
+		}
+	}
+	if g.gets != 1 {
+		t.Errorf("Expected the chart to be fetched once, got %d fetches", g.gets)
+	}
+
+	c.NoCache = true
+	if _, _, err := c.DownloadTo("cached/signtest", "", dest); err != nil {
+		t.Fatal(err)
+	}
+	if g.gets != 2 {
+		t.Errorf("Expected NoCache to fetch the chart, got %d fetches", g.gets)
+	}
+}
+
+func TestDownloadTo_CacheCorrupted(t *testing.T) {
+	c, g, digest, cleanup := cacheFixture(t, "cachecorrupt")
+	defer cleanup()
+	dest := c.HelmHome.Path("dest")
+
+	if _, _, err := c.DownloadTo("cached/signtest", "", dest); err != nil {
+		t.Fatal(err)
+	}
+	entry := filepath.Join(c.HelmHome.ChartCache(), digest+".tgz")
+	if err := ioutil.WriteFile(entry, []byte("not a chart"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	where, _, err := c.DownloadTo("cached/signtest", "", dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.gets != 2 {
+		t.Errorf("Expected the corrupted entry to be fetched again, got %d fetches", g.gets)
+	}
+	for _, f := range []string{where, entry} {
+		if got, err := ioutil.ReadFile(f); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(got, g.data) {
+			t.Errorf("Expected %s to hold the fetched chart", f)
+		}
+	}
+}
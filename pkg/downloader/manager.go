@@ -24,6 +24,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -34,6 +35,7 @@ import (
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/provenance"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/resolver"
 	"k8s.io/helm/pkg/urlutil"
@@ -57,8 +59,15 @@ type Manager struct {
 	SkipUpdate bool
 	// Getter collection for the operation
 	Getters []getter.Provider
+	// Concurrency is the maximum number of charts downloaded at the same
+	// time. If it is less than 1, defaultConcurrency is used.
+	Concurrency int
 }
 
+// defaultConcurrency is the default maximum number of charts downloaded at
+// the same time.
+const defaultConcurrency = 4
+
 // Build rebuilds a local charts directory from a lockfile.
 //
 // If the lockfile is not present, this will run a Manager.Update()
@@ -226,7 +235,10 @@ func (m *Manager) downloadAll(deps []*chartutil.Dependency) error {
 	}
 
 	fmt.Fprintf(m.Out, "Saving %d charts\n", len(deps))
-	var saveError error
+	var (
+		saveError error
+		downloads []chartDownload
+	)
 	for _, dep := range deps {
 		if strings.HasPrefix(dep.Repository, "file://") {
 			if m.Debug {
@@ -245,16 +257,15 @@ func (m *Manager) downloadAll(deps []*chartutil.Dependency) error {
 
 		// Any failure to resolve/download a chart should fail:
 		// https://github.com/kubernetes/helm/issues/1439
-		churl, err := findChartURL(dep.Name, dep.Version, dep.Repository, repos)
+		churl, digest, err := findChartURL(dep.Name, dep.Version, dep.Repository, repos)
 		if err != nil {
 			saveError = fmt.Errorf("could not find %s: %s", churl, err)
 			break
 		}
-
-		if _, _, err := dl.DownloadTo(churl, "", destPath); err != nil {
-			saveError = fmt.Errorf("could not download %s: %s", churl, err)
-			break
-		}
+		downloads = append(downloads, chartDownload{url: churl, digest: digest})
+	}
+	if saveError == nil {
+		saveError = m.parallelDownload(dl, downloads, destPath)
 	}
 
 	if saveError == nil {
@@ -289,6 +300,97 @@ func (m *Manager) downloadAll(deps []*chartutil.Dependency) error {
 	return nil
 }
 
+// chartDownload is a chart archive to download into charts/.
+type chartDownload struct {
+	url string
+	// digest is the SHA256 digest of the archive listed in the repository
+	// index. It is not checked if empty.
+	digest string
+}
+
+// parallelDownload downloads the given charts into dest, at most
+// m.Concurrency at a time.
+//
+// Every archive URL is only downloaded once, even if several dependencies
+// refer to it. Archives of different URLs that are saved under the same file
+// name are downloaded one after the other, so that no two downloads write the
+// same file at once. All downloads are attempted, and the errors of those that
+// failed are returned together.
+func (m *Manager) parallelDownload(dl ChartDownloader, charts []chartDownload, dest string) error {
+	concurrency := m.Concurrency
+	if concurrency < 1 {
+		concurrency = defaultConcurrency
+	}
+
+	var (
+		names  []string
+		groups = map[string][]chartDownload{}
+		seen   = map[string]bool{}
+	)
+	for _, c := range charts {
+		if seen[c.url] {
+			continue
+		}
+		seen[c.url] = true
+		name := path.Base(c.url)
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], c)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []string
+		sem  = make(chan struct{}, concurrency)
+	)
+	for _, name := range names {
+		wg.Add(1)
+		go func(group []chartDownload) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			for _, c := range group {
+				if err := downloadChart(dl, c, dest); err != nil {
+					mu.Lock()
+					errs = append(errs, err.Error())
+					mu.Unlock()
+				}
+			}
+		}(groups[name])
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// downloadChart downloads a chart archive into dest and checks its digest.
+// An archive with a mismatching digest is removed.
+func downloadChart(dl ChartDownloader, c chartDownload, dest string) error {
+	file, _, err := dl.DownloadTo(c.url, "", dest)
+	if err != nil {
+		return fmt.Errorf("could not download %s: %s", c.url, err)
+	}
+	if c.digest == "" {
+		return nil
+	}
+	digest, err := provenance.DigestFile(file)
+	if err != nil {
+		return err
+	}
+	if digest != c.digest {
+		os.Remove(file)
+		return fmt.Errorf("digest of %s does not match the repository index: expected %s, got %s", c.url, c.digest, digest)
+	}
+	return nil
+}
+
 // safeDeleteDep deletes any versions of the given dependency in the given directory.
 //
 // It does this by first matching the file name to an expected pattern, then loading
@@ -476,22 +578,26 @@ func (m *Manager) parallelRepoUpdate(repos []*repo.Entry) error {
 // repoURL is the repository to search
 //
 // If it finds a URL that is "relative", it will prepend the repoURL.
-func findChartURL(name, version, repoURL string, repos map[string]*repo.ChartRepository) (string, error) {
+//
+// The digest of the chart archive listed in the index is returned along with
+// the URL.
+func findChartURL(name, version, repoURL string, repos map[string]*repo.ChartRepository) (string, string, error) {
 	for _, cr := range repos {
 		if urlutil.Equal(repoURL, cr.Config.URL) {
 			entry, err := findEntryByName(name, cr)
 			if err != nil {
-				return "", err
+				return "", "", err
 			}
 			ve, err := findVersionedEntry(version, entry)
 			if err != nil {
-				return "", err
+				return "", "", err
 			}
 
-			return normalizeURL(repoURL, ve.URLs[0])
+			churl, err := normalizeURL(repoURL, ve.URLs[0])
+			return churl, ve.Digest, err
 		}
 	}
-	return "", fmt.Errorf("chart %s not found in %s", name, repoURL)
+	return "", "", fmt.Errorf("chart %s not found in %s", name, repoURL)
 }
 
 // findEntryByName finds an entry in the chart repository whose name matches the given name.
@@ -17,11 +17,23 @@ package downloader
 
 import (
 	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/getter"
+	"k8s.io/helm/pkg/helm/environment"
 	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/repo"
+	"k8s.io/helm/pkg/repo/repotest"
 )
 
 func TestVersionEquals(t *testing.T) {
@@ -77,7 +89,7 @@ func TestFindChartURL(t *testing.T) {
 	version := "0.1.0"
 	repoURL := "http://example.com/charts"
 
-	churl, err := findChartURL(name, version, repoURL, repos)
+	churl, _, err := findChartURL(name, version, repoURL, repos)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -163,3 +175,235 @@ func TestGetRepoNames(t *testing.T) {
 		}
 	}
 }
+
+func TestTarFromLocalDir(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-localdep-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	chartpath := filepath.Join(tmp, "parent")
+	if err := os.MkdirAll(filepath.Join(chartpath, "charts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	src, err := filepath.Abs("testdata/signtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ver, err := tarFromLocalDir(chartpath, "signtest", "file://"+src, "^0.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ver != "0.1.0" {
+		t.Errorf("Expected version 0.1.0, got %s", ver)
+	}
+
+	ch, err := chartutil.LoadFile(filepath.Join(chartpath, "charts", "signtest-0.1.0.tgz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Metadata.Name != "signtest" {
+		t.Errorf("Expected signtest chart in charts/, got %s", ch.Metadata.Name)
+	}
+
+	// Relative paths are resolved against the parent chart.
+	if err := os.MkdirAll(filepath.Join(tmp, "common"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, repo, version string
+	}{
+		{name: "nonexistent path", repo: "file://../notexist", version: "0.1.0"},
+		{name: "not a chart", repo: "file://../common", version: "0.1.0"},
+		{name: "version mismatch", repo: "file://" + src, version: "^1.0.0"},
+	}
+	for _, tt := range tests {
+		if _, err := tarFromLocalDir(chartpath, "dep", tt.repo, tt.version); err == nil {
+			t.Errorf("%s: expected error for %s", tt.name, tt.repo)
+		}
+	}
+}
+
+// newDependencyRepo starts a repository server serving a chart for each of
+// the given names, and returns a Manager for a parent chart using it.
+func newDependencyRepo(t *testing.T, tmp string, names ...string) (*Manager, *repotest.Server) {
+	hh := helmpath.Home(tmp)
+	for _, p := range []string{hh.Cache(), filepath.Join(tmp, "src"), filepath.Join(tmp, "parent")} {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range names {
+		ch := &chart.Chart{Metadata: &chart.Metadata{Name: name, Version: "0.1.0"}}
+		if _, err := chartutil.Save(ch, filepath.Join(tmp, "src")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	srv := repotest.NewServer(tmp)
+	if _, err := srv.CopyCharts(filepath.Join(tmp, "src", "*.tgz")); err != nil {
+		srv.Stop()
+		t.Fatal(err)
+	}
+	if err := srv.LinkIndices(); err != nil {
+		srv.Stop()
+		t.Fatal(err)
+	}
+
+	return &Manager{
+		Out:         bytes.NewBuffer(nil),
+		ChartPath:   filepath.Join(tmp, "parent"),
+		HelmHome:    hh,
+		Getters:     getter.All(environment.EnvSettings{}),
+		Concurrency: 2,
+	}, srv
+}
+
+func TestDownloadAllParallel(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-downloadall-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	names := []string{"alpha", "bravo", "charlie", "delta", "echo"}
+	m, srv := newDependencyRepo(t, tmp, names...)
+	defer srv.Stop()
+
+	var deps []*chartutil.Dependency
+	for _, name := range names {
+		deps = append(deps, &chartutil.Dependency{Name: name, Version: "0.1.0", Repository: srv.URL()})
+	}
+	// A dependency listed twice is downloaded once.
+	deps = append(deps, &chartutil.Dependency{Name: "alpha", Version: "0.1.0", Repository: srv.URL()})
+
+	if err := m.downloadAll(deps); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		ch, err := chartutil.LoadFile(filepath.Join(m.ChartPath, "charts", name+"-0.1.0.tgz"))
+		if err != nil {
+			t.Errorf("Expected %s in charts/: %s", name, err)
+			continue
+		}
+		if ch.Metadata.Name != name {
+			t.Errorf("Expected chart %s, got %s", name, ch.Metadata.Name)
+		}
+	}
+}
+
+func TestDownloadAllParallelError(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-downloadall-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	names := []string{"alpha", "bravo", "charlie", "delta"}
+	m, srv := newDependencyRepo(t, tmp, names...)
+	defer srv.Stop()
+
+	// charlie is in the index, but missing from the server, and the archive
+	// of delta no longer matches the digest in the index.
+	if err := os.Remove(filepath.Join(srv.Root(), "charlie-0.1.0.tgz")); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(srv.Root(), "delta-0.1.0.tgz"), []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The charts that were there before the update are kept.
+	charts := filepath.Join(m.ChartPath, "charts")
+	if err := os.MkdirAll(charts, 0755); err != nil {
+		t.Fatal(err)
+	}
+	old := &chart.Chart{Metadata: &chart.Metadata{Name: "alpha", Version: "0.0.1"}}
+	if _, err := chartutil.Save(old, charts); err != nil {
+		t.Fatal(err)
+	}
+
+	var deps []*chartutil.Dependency
+	for _, name := range names {
+		deps = append(deps, &chartutil.Dependency{Name: name, Version: "0.1.0", Repository: srv.URL()})
+	}
+	err = m.downloadAll(deps)
+	if err == nil {
+		t.Fatal("Expected download to fail")
+	}
+	for _, name := range []string{"charlie", "delta"} {
+		if !strings.Contains(err.Error(), name+"-0.1.0.tgz") {
+			t.Errorf("Expected error for %s, got %q", name, err)
+		}
+	}
+	for _, name := range []string{"alpha", "bravo"} {
+		if strings.Contains(err.Error(), name+"-0.1.0.tgz") {
+			t.Errorf("Did not expect error for %s, got %q", name, err)
+		}
+	}
+
+	files, err := ioutil.ReadDir(charts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name() != "alpha-0.0.1.tgz" {
+		var got []string
+		for _, f := range files {
+			got = append(got, f.Name())
+		}
+		t.Errorf("Expected charts/ to be restored to alpha-0.0.1.tgz, got %v", got)
+	}
+}
+
+func TestParallelDownloadSameFileName(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-downloadall-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	hh := helmpath.Home(tmp)
+	dest := filepath.Join(tmp, "charts")
+	for _, p := range []string{hh.Repository(), hh.Cache(), dest} {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repo.NewRepoFile().WriteFile(hh.RepositoryFile(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	requested := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested[r.URL.Path]++
+		mu.Unlock()
+		w.Write([]byte("chart"))
+	}))
+	defer srv.Close()
+
+	// Archives with the same file name in different directories are distinct
+	// downloads, while a URL listed twice is downloaded once.
+	charts := []chartDownload{
+		{url: srv.URL + "/stable/foo-0.1.0.tgz"},
+		{url: srv.URL + "/incubator/foo-0.1.0.tgz"},
+		{url: srv.URL + "/stable/foo-0.1.0.tgz"},
+	}
+	m := &Manager{Out: bytes.NewBuffer(nil), HelmHome: hh, Concurrency: 2}
+	dl := ChartDownloader{
+		Out:      m.Out,
+		Verify:   VerifyNever,
+		HelmHome: hh,
+		Getters:  getter.All(environment.EnvSettings{}),
+		NoCache:  true,
+	}
+	if err := m.parallelDownload(dl, charts, dest); err != nil {
+		t.Fatal(err)
+	}
+	expect := map[string]int{"/stable/foo-0.1.0.tgz": 1, "/incubator/foo-0.1.0.tgz": 1}
+	if !reflect.DeepEqual(requested, expect) {
+		t.Errorf("Expected requests %v, got %v", expect, requested)
+	}
+}
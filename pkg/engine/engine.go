@@ -18,6 +18,7 @@ package engine
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"path"
 	"sort"
@@ -37,10 +38,28 @@ type Engine struct {
 	FuncMap template.FuncMap
 	// If strict is enabled, template rendering will fail if a template references
 	// a value that was not passed in.
-	Strict           bool
-	CurrentTemplates map[string]renderable
+	Strict bool
+	// MaxRenderedBytes limits the total size of the templates rendered for a
+	// chart. Rendering fails as soon as the limit is exceeded, so that a
+	// runaway template cannot exhaust memory. Zero or less means no limit.
+	MaxRenderedBytes int64
 }
 
+// FuncCollision says how RenderWithFuncs handles a custom function that has
+// the name of a built-in function.
+type FuncCollision int
+
+const (
+	// OverrideBuiltins replaces the built-in function with the custom one.
+	OverrideBuiltins FuncCollision = iota
+	// RejectCollisions fails the render.
+	RejectCollisions
+)
+
+// DefaultMaxRenderedBytes is the MaxRenderedBytes of a new Engine. It is far
+// above the size of any release that can be stored.
+const DefaultMaxRenderedBytes = 64 << 20
+
 // New creates a new Go template Engine instance.
 //
 // The FuncMap is initialized here. You may modify the FuncMap _prior to_ the
@@ -51,7 +70,8 @@ type Engine struct {
 func New() *Engine {
 	f := FuncMap()
 	return &Engine{
-		FuncMap: f,
+		FuncMap:          f,
+		MaxRenderedBytes: DefaultMaxRenderedBytes,
 	}
 }
 
@@ -69,6 +89,10 @@ func New() *Engine {
 //	   included in the FuncMap is a placeholder.
 //      - "tpl": This is late-bound in Engine.Render(). The version
 //	   included in the FuncMap is a placeholder.
+//      - "tryInclude": This is late-bound in Engine.Render(). The version
+//	   included in the FuncMap is a placeholder.
+//      - "requiredAt": This is late-bound in Engine.Render(). The version
+//	   included in the FuncMap is a placeholder.
 func FuncMap() template.FuncMap {
 	f := sprig.TxtFuncMap()
 	delete(f, "env")
@@ -82,12 +106,19 @@ func FuncMap() template.FuncMap {
 		"toJson":   chartutil.ToJson,
 		"fromJson": chartutil.FromJson,
 
+		"deepMerge": chartutil.DeepMerge,
+
+		"appVersionImageTag": appVersionImageTag,
+		"imageRef":           imageRef,
+
 		// This is a placeholder for the "include" function, which is
 		// late-bound to a template. By declaring it here, we preserve the
 		// integrity of the linter.
-		"include":  func(string, interface{}) string { return "not implemented" },
-		"required": func(string, interface{}) interface{} { return "not implemented" },
-		"tpl":      func(string, interface{}) interface{} { return "not implemented" },
+		"include":    func(string, interface{}) string { return "not implemented" },
+		"required":   func(string, interface{}) interface{} { return "not implemented" },
+		"tpl":        func(string, interface{}) interface{} { return "not implemented" },
+		"tryInclude": func(string, interface{}, string) string { return "not implemented" },
+		"requiredAt": func(string, string, interface{}) interface{} { return "not implemented" },
 	}
 
 	for k, v := range extra {
@@ -117,10 +148,38 @@ func FuncMap() template.FuncMap {
 // section contains a value named "bar", that value will be passed on to the
 // bar chart during render time.
 func (e *Engine) Render(chrt *chart.Chart, values chartutil.Values) (map[string]string, error) {
+	return e.RenderWithFuncs(chrt, values, nil, OverrideBuiltins)
+}
+
+// RenderWithFuncs is Render, with funcs available to the templates in addition
+// to the built-in functions. This lets programs embedding the engine provide
+// their own functions without changing the Engine's FuncMap.
+//
+// collision says what to do when a function in funcs has the name of a
+// built-in function, including the late-bound ones such as "include".
+func (e *Engine) RenderWithFuncs(chrt *chart.Chart, values chartutil.Values, funcs template.FuncMap, collision FuncCollision) (map[string]string, error) {
+	if collision == RejectCollisions {
+		var names []string
+		for name := range funcs {
+			if _, ok := e.FuncMap[name]; ok {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			sort.Strings(names)
+			return map[string]string{}, fmt.Errorf("custom template functions collide with built-in functions: %s", strings.Join(names, ", "))
+		}
+	}
+
+	return e.renderChart(chrt, values, funcs, nil)
+}
+
+// renderChart renders the templates of a chart and its dependencies, adding
+// each rendered template to trace.
+func (e *Engine) renderChart(chrt *chart.Chart, values chartutil.Values, funcs template.FuncMap, trace *traceLog) (map[string]string, error) {
 	// Render the charts
 	tmap := allTemplates(chrt, values)
-	e.CurrentTemplates = tmap
-	return e.render(tmap)
+	return e.render(tmap, tmap, funcs, trace)
 }
 
 // renderable is an object that can be rendered.
@@ -133,10 +192,12 @@ type renderable struct {
 	basePath string
 }
 
-// alterFuncMap takes the Engine's FuncMap and adds context-specific functions.
+// alterFuncMap takes the Engine's FuncMap and adds context-specific functions,
+// then the custom functions of the current render.
 //
-// The resulting FuncMap is only valid for the passed-in template.
-func (e *Engine) alterFuncMap(t *template.Template) template.FuncMap {
+// The resulting FuncMap is only valid for the passed-in template. The 'tpl'
+// function can reference any of the chart's templates in all.
+func (e *Engine) alterFuncMap(t *template.Template, all map[string]renderable, funcs template.FuncMap) template.FuncMap {
 	// Clone the func map because we are adding context-specific functions.
 	var funcMap template.FuncMap = map[string]interface{}{}
 	for k, v := range e.FuncMap {
@@ -152,6 +213,19 @@ func (e *Engine) alterFuncMap(t *template.Template) template.FuncMap {
 		return buf.String(), nil
 	}
 
+	// Add the 'tryInclude' function here. It behaves like 'include', but
+	// returns the fallback if the named template is not defined.
+	funcMap["tryInclude"] = func(name string, data interface{}, fallback string) (string, error) {
+		if t.Lookup(name) == nil {
+			return fallback, nil
+		}
+		buf := bytes.NewBuffer(nil)
+		if err := t.ExecuteTemplate(buf, name, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+
 	// Add the 'required' function here
 	funcMap["required"] = func(warn string, val interface{}) (interface{}, error) {
 		if val == nil {
@@ -164,6 +238,17 @@ func (e *Engine) alterFuncMap(t *template.Template) template.FuncMap {
 		return val, nil
 	}
 
+	// Add the 'requiredAt' function here. Unlike 'required', it is given the
+	// path of the value below .Values and the template context, so the error
+	// can name both the missing value and the template that requires it.
+	funcMap["requiredAt"] = func(warn, ypath string, vals chartutil.Values) (interface{}, error) {
+		val := valueAt(vals, ypath)
+		if val == nil || val == "" {
+			return val, fmt.Errorf("%s: .Values.%s is required by %s", warn, ypath, templateName(vals))
+		}
+		return val, nil
+	}
+
 	// Add the 'tpl' function here
 	funcMap["tpl"] = func(tpl string, vals chartutil.Values) (string, error) {
 		basePath, err := vals.PathValue("Template.BasePath")
@@ -185,18 +270,28 @@ func (e *Engine) alterFuncMap(t *template.Template) template.FuncMap {
 
 		templates[templateName.(string)] = r
 
-		result, err := e.render(templates)
+		result, err := e.render(templates, all, funcs, nil)
 		if err != nil {
 			return "", fmt.Errorf("Error during tpl function execution for %q: %s", tpl, err.Error())
 		}
 		return result[templateName.(string)], nil
 	}
 
+	for k, v := range funcs {
+		funcMap[k] = v
+	}
+
 	return funcMap
 }
 
-// render takes a map of templates/values and renders them.
-func (e *Engine) render(tpls map[string]renderable) (map[string]string, error) {
+// render takes a map of templates/values and renders them, with funcs
+// available in addition to the built-in functions. The templates in all are
+// parsed too, so that the rendered templates can reference them. Each rendered
+// template is added to trace, if it is not nil.
+//
+// The chart's templates, the custom functions and the trace are passed along
+// rather than kept on the Engine, so that one Engine can render concurrently.
+func (e *Engine) render(tpls, all map[string]renderable, funcs template.FuncMap, trace *traceLog) (map[string]string, error) {
 	// Basically, what we do here is start with an empty parent template and then
 	// build up a list of templates -- one for each file. Once all of the templates
 	// have been parsed, we loop through again and execute every template.
@@ -213,7 +308,7 @@ func (e *Engine) render(tpls map[string]renderable) (map[string]string, error) {
 		t.Option("missingkey=zero")
 	}
 
-	funcMap := e.alterFuncMap(t)
+	funcMap := e.alterFuncMap(t, all, funcs)
 
 	// We want to parse the templates in a predictable order. The order favors
 	// higher-level (in file system) templates over deeply nested templates.
@@ -225,24 +320,25 @@ func (e *Engine) render(tpls map[string]renderable) (map[string]string, error) {
 		r := tpls[fname]
 		t = t.New(fname).Funcs(funcMap)
 		if _, err := t.Parse(r.tpl); err != nil {
-			return map[string]string{}, fmt.Errorf("parse error in %q: %s", fname, err)
+			return map[string]string{}, newTemplateError("parse", fname, err)
 		}
 		files = append(files, fname)
 	}
 
-	// Adding the engine's currentTemplates to the template context
+	// Adding the chart's templates to the template context
 	// so they can be referenced in the tpl function
-	for fname, r := range e.CurrentTemplates {
+	for fname, r := range all {
 		if t.Lookup(fname) == nil {
 			t = t.New(fname).Funcs(funcMap)
 			if _, err := t.Parse(r.tpl); err != nil {
-				return map[string]string{}, fmt.Errorf("parse error in %q: %s", fname, err)
+				return map[string]string{}, newTemplateError("parse", fname, err)
 			}
 		}
 	}
 
 	rendered := make(map[string]string, len(files))
-	var buf bytes.Buffer
+	buf := &limitedBuffer{limit: -1}
+	var total int64
 	for _, file := range files {
 		// Don't render partials. We don't care out the direct output of partials.
 		// They are only included from other templates.
@@ -252,20 +348,132 @@ func (e *Engine) render(tpls map[string]renderable) (map[string]string, error) {
 		// At render time, add information about the template that is being rendered.
 		vals := tpls[file].vals
 		vals["Template"] = map[string]interface{}{"Name": file, "BasePath": tpls[file].basePath}
-		if err := t.ExecuteTemplate(&buf, file, vals); err != nil {
-			return map[string]string{}, fmt.Errorf("render error in %q: %s", file, err)
+		if e.MaxRenderedBytes > 0 {
+			buf.limit = e.MaxRenderedBytes - total
 		}
+		if err := t.ExecuteTemplate(buf, file, vals); err != nil {
+			if buf.exceeded {
+				err = fmt.Errorf("rendered templates exceed the maximum size of %d bytes while rendering %s", e.MaxRenderedBytes, file)
+			} else {
+				err = newTemplateError("render", file, err)
+			}
+			trace.record(file, vals, buf.String(), err)
+			return map[string]string{}, err
+		}
+		total += int64(buf.Len())
 
 		// Work around the issue where Go will emit "<no value>" even if Options(missing=zero)
 		// is set. Since missing=error will never get here, we do not need to handle
 		// the Strict case.
 		rendered[file] = strings.Replace(buf.String(), "<no value>", "", -1)
+		trace.record(file, vals, rendered[file], nil)
 		buf.Reset()
 	}
 
 	return rendered, nil
 }
 
+// limitedBuffer is a buffer that refuses writes that would grow it beyond
+// limit bytes, unless limit is negative.
+type limitedBuffer struct {
+	buf      bytes.Buffer
+	limit    int64
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.limit >= 0 && int64(b.buf.Len()+len(p)) > b.limit {
+		b.exceeded = true
+		return 0, errors.New("maximum rendered size exceeded")
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) Len() int       { return b.buf.Len() }
+func (b *limitedBuffer) String() string { return b.buf.String() }
+func (b *limitedBuffer) Reset()         { b.buf.Reset() }
+
+// valueAt returns the value found at ypath below the "Values" of vals, or nil
+// if there is none.
+func valueAt(vals chartutil.Values, ypath string) interface{} {
+	keys := strings.Split(ypath, ".")
+	table := strings.Join(append([]string{"Values"}, keys[:len(keys)-1]...), ".")
+	t, err := vals.Table(table)
+	if err != nil {
+		return nil
+	}
+	return t[keys[len(keys)-1]]
+}
+
+// appVersionImageTag returns the image tag for the chart rendered with vals.
+//
+// The tag is .Values.image.tag if it is set, otherwise the chart's appVersion.
+func appVersionImageTag(vals chartutil.Values) string {
+	if tag := valueAt(vals, "image.tag"); tag != nil && tag != "" {
+		return fmt.Sprint(tag)
+	}
+	if md, ok := vals["Chart"].(*chart.Metadata); ok && md != nil {
+		return md.AppVersion
+	}
+	return ""
+}
+
+// imageRef returns the container image reference described by image, a table
+// of values with the keys registry, repository, tag and digest, for the chart
+// rendered with vals.
+//
+// .Values.global.imageRegistry, if it is set, replaces the registry of image.
+// A digest is used instead of the tag, and the tag defaults to the chart's
+// appVersion.
+func imageRef(image interface{}, vals chartutil.Values) string {
+	var img map[string]interface{}
+	switch v := image.(type) {
+	case map[string]interface{}:
+		img = v
+	case chartutil.Values:
+		img = v
+	}
+	str := func(v interface{}) string {
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprint(v)
+	}
+
+	ref := str(img["repository"])
+	registry := str(img["registry"])
+	if global := str(valueAt(vals, "global.imageRegistry")); global != "" {
+		registry = global
+	}
+	if registry != "" {
+		ref = strings.TrimSuffix(registry, "/") + "/" + ref
+	}
+
+	if digest := str(img["digest"]); digest != "" {
+		return ref + "@" + digest
+	}
+	tag := str(img["tag"])
+	if tag == "" {
+		if md, ok := vals["Chart"].(*chart.Metadata); ok && md != nil {
+			tag = md.AppVersion
+		}
+	}
+	if tag != "" {
+		ref += ":" + tag
+	}
+	return ref
+}
+
+// templateName returns the name of the template being rendered with vals.
+func templateName(vals chartutil.Values) string {
+	if t, ok := vals["Template"].(map[string]interface{}); ok {
+		if name, ok := t["Name"].(string); ok {
+			return name
+		}
+	}
+	return "unknown template"
+}
+
 func sortTemplates(tpls map[string]renderable) []string {
 	keys := make([]string, len(tpls))
 	i := 0
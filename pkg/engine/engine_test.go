@@ -18,8 +18,10 @@ package engine
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
+	"text/template"
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/chart"
@@ -80,7 +82,7 @@ func TestFuncMap(t *testing.T) {
 	}
 
 	// Test for Engine-specific template functions.
-	expect := []string{"include", "required", "tpl", "toYaml", "fromYaml", "toToml", "toJson", "fromJson"}
+	expect := []string{"include", "required", "tpl", "toYaml", "fromYaml", "toToml", "toJson", "fromJson", "deepMerge", "tryInclude", "requiredAt", "appVersionImageTag", "imageRef"}
 	for _, f := range expect {
 		if _, ok := fns[f]; !ok {
 			t.Errorf("Expected add-on function %q", f)
@@ -141,6 +143,244 @@ global:
 	}
 }
 
+func TestRenderWithFuncs(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/secret", Data: []byte(`{{ lookupSecret "db" }} {{ include "moby.upper" "tide" }}`)},
+			{Name: "templates/_helpers", Data: []byte(`{{ define "moby.upper" }}{{ upper . }}{{ end }}`)},
+			{Name: "templates/tpl", Data: []byte(`{{ tpl "{{ lookupSecret \"api\" }}" . }}`)},
+		},
+	}
+	funcs := template.FuncMap{
+		"lookupSecret": func(name string) string { return "secret-" + name },
+		"upper":        func(s string) string { return "<" + s + ">" },
+	}
+
+	out, err := New().RenderWithFuncs(c, chartutil.Values{}, funcs, OverrideBuiltins)
+	if err != nil {
+		t.Fatalf("Failed to render templates: %s", err)
+	}
+	if expect := "secret-db <tide>"; out["moby/templates/secret"] != expect {
+		t.Errorf("Expected %q, got %q", expect, out["moby/templates/secret"])
+	}
+	if expect := "secret-api"; out["moby/templates/tpl"] != expect {
+		t.Errorf("Expected %q, got %q", expect, out["moby/templates/tpl"])
+	}
+
+	_, err = New().RenderWithFuncs(c, chartutil.Values{}, funcs, RejectCollisions)
+	if err == nil || !strings.Contains(err.Error(), "collide with built-in functions: upper") {
+		t.Errorf("Expected a collision error, got %v", err)
+	}
+
+	delete(funcs, "upper")
+	out, err = New().RenderWithFuncs(c, chartutil.Values{}, funcs, RejectCollisions)
+	if err != nil {
+		t.Fatalf("Failed to render templates: %s", err)
+	}
+	if expect := "secret-db TIDE"; out["moby/templates/secret"] != expect {
+		t.Errorf("Expected %q, got %q", expect, out["moby/templates/secret"])
+	}
+
+	// The functions only apply to the render they were passed to.
+	e := New()
+	if _, err := e.RenderWithFuncs(c, chartutil.Values{}, funcs, OverrideBuiltins); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Render(c, chartutil.Values{}); err == nil {
+		t.Error("Expected lookupSecret to be undefined in a later render")
+	}
+}
+
+func TestRenderWithFuncsConcurrent(t *testing.T) {
+	c := &chart.Chart{
+		Metadata:  &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{{Name: "templates/owner", Data: []byte(`{{ owner }}`)}},
+	}
+	e := New()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			expect := fmt.Sprintf("render-%d", i)
+			funcs := template.FuncMap{"owner": func() string { return expect }}
+			out, err := e.RenderWithFuncs(c, chartutil.Values{}, funcs, OverrideBuiltins)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if got := out["moby/templates/owner"]; got != expect {
+				errs <- fmt.Errorf("expected %q, got %q", expect, got)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestRenderWithTraceConcurrent(t *testing.T) {
+	c := &chart.Chart{
+		Metadata:  &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{{Name: "templates/name", Data: []byte(`{{ .name }}`)}},
+	}
+	e := New()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			expect := fmt.Sprintf("render-%d", i)
+			_, trace, err := e.RenderWithTrace(c, chartutil.Values{"name": expect})
+			if err != nil {
+				errs <- err
+				return
+			}
+			if len(trace) != 1 || trace[0].Output != expect {
+				errs <- fmt.Errorf("expected a trace of %q, got %+v", expect, trace)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestRenderMaxRenderedBytes(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby", Version: "1.2.3"},
+		Templates: []*chart.Template{
+			{Name: "templates/small", Data: []byte("small")},
+			{Name: "templates/loop", Data: []byte(`{{ range until (int .count) }}0123456789{{ end }}`)},
+		},
+		Values: &chart.Config{Raw: "count: 10"},
+	}
+	v, err := chartutil.CoalesceValues(c, &chart.Config{})
+	if err != nil {
+		t.Fatalf("Failed to coalesce values: %s", err)
+	}
+
+	e := New()
+	if e.MaxRenderedBytes != DefaultMaxRenderedBytes {
+		t.Errorf("Expected the default limit of %d bytes, got %d", DefaultMaxRenderedBytes, e.MaxRenderedBytes)
+	}
+
+	// The templates render 105 bytes.
+	e.MaxRenderedBytes = 105
+	out, err := e.Render(c, v)
+	if err != nil {
+		t.Fatalf("Expected templates under the limit to render, got %s", err)
+	}
+	if len(out["moby/templates/loop"]) != 100 {
+		t.Errorf("Expected 100 bytes, got %q", out["moby/templates/loop"])
+	}
+
+	e.MaxRenderedBytes = 104
+	_, err = e.Render(c, v)
+	if err == nil || !strings.Contains(err.Error(), "exceed the maximum size of 104 bytes") {
+		t.Fatalf("Expected the limit to be exceeded, got %v", err)
+	}
+
+	e.MaxRenderedBytes = 0
+	v["count"] = 100000
+	out, err = e.Render(c, v)
+	if err != nil {
+		t.Fatalf("Expected no limit, got %s", err)
+	}
+	if len(out["moby/templates/loop"]) != 1000000 {
+		t.Errorf("Expected 1000000 bytes, got %d", len(out["moby/templates/loop"]))
+	}
+}
+
+func TestRenderWithTrace(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby", Version: "1.2.3"},
+		Templates: []*chart.Template{
+			{Name: "templates/_helpers.tpl", Data: []byte(`{{ define "moby.name" }}moby{{ end }}`)},
+			{Name: "templates/configmap.yaml", Data: []byte("kind: ConfigMap\nmetadata:\n  name: {{ include \"moby.name\" . }}\ndata:\n  greeting: {{ .Values.greeting }}\n")},
+			{Name: "templates/secret.yaml", Data: []byte("kind: Secret\nmetadata:\n  name: moby\ndata:\n  password: {{ .Values.password | b64enc }}\nstringData:\n  token: {{ .Values.token }}\n")},
+		},
+		Values: &chart.Config{Raw: "greeting: hello\npassword: hunter2\ntoken: s3cr3t\ndb:\n  host: db.example.com"},
+	}
+	v, err := chartutil.ToRenderValues(c, &chart.Config{}, chartutil.ReleaseOptions{Name: "moby"})
+	if err != nil {
+		t.Fatalf("Failed to create render values: %s", err)
+	}
+
+	out, trace, err := New().RenderWithTrace(c, v)
+	if err != nil {
+		t.Fatalf("Failed to render templates: %s", err)
+	}
+	traced := map[string]TemplateTrace{}
+	for _, tt := range trace {
+		traced[tt.Template] = tt
+	}
+	if len(trace) != 2 || len(traced) != 2 {
+		t.Fatalf("Expected a trace of 2 templates, got %+v", trace)
+	}
+
+	cm := traced["moby/templates/configmap.yaml"]
+	if cm.Output != out[cm.Template] || cm.Output == "" {
+		t.Errorf("Expected the output of the ConfigMap, got %+v", cm)
+	}
+	if cm.Values["greeting"] != Redacted || cm.Values["password"] != Redacted {
+		t.Errorf("Expected the values of the chart to be redacted, got %v", cm.Values)
+	}
+	if db, ok := cm.Values["db"].(map[string]interface{}); !ok || db["host"] != Redacted {
+		t.Errorf("Expected the keys of nested values to be kept, got %v", cm.Values)
+	}
+
+	secret := traced["moby/templates/secret.yaml"]
+	for _, leaked := range []string{"aHVudGVyMg==", "s3cr3t"} {
+		if strings.Contains(secret.Output, leaked) {
+			t.Errorf("Expected the Secret data to be redacted, got %q", secret.Output)
+		}
+		if !strings.Contains(out[secret.Template], leaked) {
+			t.Errorf("Expected the rendered Secret to be left as it is, got %q", out[secret.Template])
+		}
+	}
+	for _, redacted := range []string{"password: " + Redacted, "token: " + Redacted, "name: moby"} {
+		if !strings.Contains(secret.Output, redacted) {
+			t.Errorf("Expected %q in the trace, got %q", redacted, secret.Output)
+		}
+	}
+
+	// The failed template ends the trace.
+	c.Templates = append(c.Templates, &chart.Template{Name: "templates/zz-broken.yaml", Data: []byte(`{{ fail "broken" }}`)})
+	_, trace, err = New().RenderWithTrace(c, v)
+	if err == nil {
+		t.Fatal("Expected the render to fail")
+	}
+	if last := trace[len(trace)-1]; last.Template != "moby/templates/zz-broken.yaml" || last.Err == nil {
+		t.Errorf("Expected the failed template at the end of the trace, got %+v", last)
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	manifest := "kind: ConfigMap\ndata:\n  a: b\n---\nkind: Secret\ndata:\n  a: c2VjcmV0\n"
+	out := RedactSecrets(manifest)
+	if strings.Contains(out, "c2VjcmV0") || !strings.Contains(out, "a: "+Redacted) {
+		t.Errorf("Expected the Secret data to be redacted, got %q", out)
+	}
+	if !strings.HasPrefix(out, "kind: ConfigMap\ndata:\n  a: b\n---\n") {
+		t.Errorf("Expected the ConfigMap to be left as it is, got %q", out)
+	}
+
+	broken := "kind: Secret\ndata: [\n"
+	if out := RedactSecrets(broken); strings.Contains(out, "data") {
+		t.Errorf("Expected an unparsable Secret to be redacted entirely, got %q", out)
+	}
+}
+
 func TestRenderInternals(t *testing.T) {
 	// Test the internals of the rendering tool.
 	e := New()
@@ -154,7 +394,7 @@ func TestRenderInternals(t *testing.T) {
 		"three": {tpl: `{{template "two" dict "Value" "three"}}`, vals: vals},
 	}
 
-	out, err := e.render(tpls)
+	out, err := e.render(tpls, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("Failed template rendering: %s", err)
 	}
@@ -187,7 +427,7 @@ func TestParallelRenderInternals(t *testing.T) {
 			tt := fmt.Sprintf("expect-%d", i)
 			v := chartutil.Values{"val": tt}
 			tpls := map[string]renderable{fname: {tpl: `{{.val}}`, vals: v}}
-			out, err := e.render(tpls)
+			out, err := e.render(tpls, nil, nil, nil)
 			if err != nil {
 				t.Errorf("Failed to render %s: %s", tt, err)
 			}
@@ -564,3 +804,244 @@ func TestAlterFuncMap(t *testing.T) {
 	}
 
 }
+
+func TestTryInclude(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/defined", Data: []byte(`{{tryInclude "moby/templates/_partial" . "fallback" | upper}}`)},
+			{Name: "templates/missing", Data: []byte(`{{tryInclude "moby/templates/_missing" . "fallback" | upper}}`)},
+			{Name: "templates/_partial", Data: []byte(`{{.Release.Name}} - whale`)},
+		},
+		Values:       &chart.Config{Raw: ``},
+		Dependencies: []*chart.Chart{},
+	}
+
+	v := chartutil.Values{
+		"Values": &chart.Config{Raw: ""},
+		"Chart":  c.Metadata,
+		"Release": chartutil.Values{
+			"Name": "Ahab",
+		},
+	}
+
+	out, err := New().Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := map[string]string{
+		"moby/templates/defined": "AHAB - WHALE",
+		"moby/templates/missing": "FALLBACK",
+	}
+	for name, data := range expect {
+		if got := out[name]; got != data {
+			t.Errorf("Expected %q for %s, got %q", data, name, got)
+		}
+	}
+}
+
+func TestRequiredAt(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "pequod"},
+		Templates: []*chart.Template{
+			{Name: "templates/captain", Data: []byte(`{{requiredAt "A captain is required" "crew.captain" .}}`)},
+			{Name: "templates/mate", Data: []byte(`{{requiredAt "A mate is required" "crew.mate" .}}`)},
+		},
+		Values:       &chart.Config{Raw: ``},
+		Dependencies: []*chart.Chart{},
+	}
+
+	v := chartutil.Values{
+		"Values": chartutil.Values{
+			"crew": map[string]interface{}{
+				"captain": "Ahab",
+			},
+		},
+		"Chart": c.Metadata,
+		"Release": chartutil.Values{
+			"Name": "whaler",
+		},
+	}
+
+	_, err := New().Render(c, v)
+	if err == nil {
+		t.Fatal("Expected an error for the missing mate")
+	}
+	for _, expect := range []string{"A mate is required", ".Values.crew.mate", "pequod/templates/mate"} {
+		if !strings.Contains(err.Error(), expect) {
+			t.Errorf("Expected %q in error, got %q", expect, err)
+		}
+	}
+
+	v["Values"].(chartutil.Values)["crew"].(map[string]interface{})["mate"] = "Starbuck"
+	out, err := New().Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["pequod/templates/mate"]; got != "Starbuck" {
+		t.Errorf("Expected %q, got %q", "Starbuck", got)
+	}
+	if got := out["pequod/templates/captain"]; got != "Ahab" {
+		t.Errorf("Expected %q, got %q", "Ahab", got)
+	}
+}
+
+func TestAppVersionImageTag(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "pequod", AppVersion: "1.8.2"},
+		Templates: []*chart.Template{
+			{Name: "templates/appversion", Data: []byte(`{{.Chart.AppVersion}}`)},
+			{Name: "templates/image", Data: []byte(`whaler:{{appVersionImageTag .}}`)},
+		},
+		Values:       &chart.Config{Raw: ``},
+		Dependencies: []*chart.Chart{},
+	}
+
+	v := chartutil.Values{
+		"Values": chartutil.Values{},
+		"Chart":  c.Metadata,
+		"Release": chartutil.Values{
+			"Name": "whaler",
+		},
+	}
+
+	out, err := New().Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["pequod/templates/appversion"]; got != "1.8.2" {
+		t.Errorf("Expected %q, got %q", "1.8.2", got)
+	}
+	if got := out["pequod/templates/image"]; got != "whaler:1.8.2" {
+		t.Errorf("Expected %q, got %q", "whaler:1.8.2", got)
+	}
+
+	v["Values"] = chartutil.Values{
+		"image": map[string]interface{}{
+			"tag": "canary",
+		},
+	}
+	out, err = New().Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["pequod/templates/image"]; got != "whaler:canary" {
+		t.Errorf("Expected %q, got %q", "whaler:canary", got)
+	}
+}
+
+func TestImageRef(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "pequod", AppVersion: "1.8.2"},
+		Templates: []*chart.Template{
+			{Name: "templates/image", Data: []byte(`{{imageRef .Values.image .}}`)},
+		},
+		Values:       &chart.Config{Raw: ``},
+		Dependencies: []*chart.Chart{},
+	}
+
+	const digest = "sha256:4b2a2a4f31dbb5f3d7d6a2a7b9a5cb9d5e0c8f1e2d3c4b5a6978879665544332"
+	tests := []struct {
+		name   string
+		values chartutil.Values
+		expect string
+	}{
+		{
+			"repository only",
+			chartutil.Values{"image": map[string]interface{}{"repository": "whaler"}},
+			"whaler:1.8.2",
+		},
+		{
+			"registry and tag",
+			chartutil.Values{"image": map[string]interface{}{"registry": "quay.io", "repository": "pequod/whaler", "tag": "canary"}},
+			"quay.io/pequod/whaler:canary",
+		},
+		{
+			"global registry",
+			chartutil.Values{
+				"global": map[string]interface{}{"imageRegistry": "registry.example.com/"},
+				"image":  map[string]interface{}{"registry": "quay.io", "repository": "pequod/whaler"},
+			},
+			"registry.example.com/pequod/whaler:1.8.2",
+		},
+		{
+			"digest",
+			chartutil.Values{"image": map[string]interface{}{"registry": "quay.io", "repository": "pequod/whaler", "tag": "canary", "digest": digest}},
+			"quay.io/pequod/whaler@" + digest,
+		},
+	}
+	for _, tt := range tests {
+		v := chartutil.Values{
+			"Values":  tt.values,
+			"Chart":   c.Metadata,
+			"Release": chartutil.Values{"Name": "whaler"},
+		}
+		out, err := New().Render(c, v)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if got := out["pequod/templates/image"]; got != tt.expect {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expect, got)
+		}
+	}
+}
+
+func TestTemplateError(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "pequod"},
+		Templates: []*chart.Template{
+			{Name: "templates/_helpers.tpl", Data: []byte("{{define \"pequod.mate\"}}\n{{required \"A mate is required\" .Values.mate}}\n{{end}}")},
+			{Name: "templates/captain", Data: []byte("captain:\n  name: Ahab\n  ship: {{required \"A ship is required\" .Values.ship}}\n")},
+			{Name: "templates/mate", Data: []byte("mate: {{include \"pequod.mate\" .}}\n")},
+		},
+		Values:       &chart.Config{Raw: ``},
+		Dependencies: []*chart.Chart{},
+	}
+	v := chartutil.Values{
+		"Values": chartutil.Values{"mate": "Starbuck"},
+		"Chart":  c.Metadata,
+	}
+
+	_, err := New().Render(c, v)
+	te, ok := err.(*TemplateError)
+	if !ok {
+		t.Fatalf("Expected a *TemplateError, got %T: %v", err, err)
+	}
+	if te.Phase != "render" || te.Template != "pequod/templates/captain" || te.File != "pequod/templates/captain" {
+		t.Errorf("Unexpected template error %+v", te)
+	}
+	if te.Line != 3 || te.Column != 10 {
+		t.Errorf("Expected the error at 3:10, got %d:%d", te.Line, te.Column)
+	}
+	if !strings.HasPrefix(te.Error(), `render error in "pequod/templates/captain": `) || !strings.Contains(te.Error(), "A ship is required") {
+		t.Errorf("Unexpected error message %q", te)
+	}
+
+	// An error in an included template is located in that template.
+	v["Values"] = chartutil.Values{"ship": "Pequod"}
+	_, err = New().Render(c, v)
+	te, ok = err.(*TemplateError)
+	if !ok {
+		t.Fatalf("Expected a *TemplateError, got %T: %v", err, err)
+	}
+	if te.Template != "pequod/templates/mate" || te.File != "pequod/templates/_helpers.tpl" || te.Line != 2 {
+		t.Errorf("Unexpected template error %+v", te)
+	}
+
+	// Parse errors have a line, but no column.
+	c.Templates = []*chart.Template{
+		{Name: "templates/broken", Data: []byte("ship: Pequod\ncaptain: {{ .Values.captain }\n")},
+	}
+	_, err = New().Render(c, v)
+	te, ok = err.(*TemplateError)
+	if !ok {
+		t.Fatalf("Expected a *TemplateError, got %T: %v", err, err)
+	}
+	if te.Phase != "parse" || te.File != "pequod/templates/broken" || te.Line != 2 || te.Column != 0 {
+		t.Errorf("Unexpected template error %+v", te)
+	}
+	if !strings.HasPrefix(te.Error(), `parse error in "pequod/templates/broken": `) {
+		t.Errorf("Unexpected error message %q", te)
+	}
+}
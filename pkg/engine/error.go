@@ -0,0 +1,67 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// TemplateError is returned by Render when a template cannot be parsed or
+// rendered. It carries the location of the error so that callers can show
+// diagnostics without parsing the error message.
+type TemplateError struct {
+	// Phase is "parse" or "render".
+	Phase string
+	// Template is the name of the template that was being parsed or rendered.
+	Template string
+	// File is the name of the template the error occurred in. It differs from
+	// Template when the error is in a template included by Template.
+	File string
+	// Line and Column locate the error in File. They are zero when unknown.
+	Line   int
+	Column int
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("%s error in %q: %s", e.Phase, e.Template, e.Err)
+}
+
+// templateLocation matches the location text/template puts at the start of
+// its errors, e.g. "template: mychart/templates/a.yaml:3:5: ".
+var templateLocation = regexp.MustCompile(`template: (.+?):(\d+)(?::(\d+))?: `)
+
+// newTemplateError wraps an error from text/template, finding the file, line
+// and column in its message when they are present. Errors from included
+// templates are nested in the message, so the innermost location is used.
+func newTemplateError(phase, name string, err error) *TemplateError {
+	te := &TemplateError{Phase: phase, Template: name, File: name, Err: err}
+	all := templateLocation.FindAllStringSubmatch(err.Error(), -1)
+	if len(all) == 0 {
+		return te
+	}
+	m := all[len(all)-1]
+	te.File = m[1]
+	te.Line, _ = strconv.Atoi(m[2])
+	if m[3] != "" {
+		te.Column, _ = strconv.Atoi(m[3])
+	}
+	return te
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// TemplateTrace records how one template was rendered, for debugging charts.
+type TemplateTrace struct {
+	// Template is the name of the template.
+	Template string
+	// Values are the values of the chart the template belongs to, with every
+	// value redacted. Only their keys are kept.
+	Values chartutil.Values
+	// Output is the rendered template, with the data of Secrets redacted.
+	Output string
+	// Err is the error rendering the template failed with, if any.
+	Err error
+}
+
+// RenderWithTrace is Render, also returning a trace of every template it
+// rendered, in order. If rendering fails, the trace ends with the template
+// that failed. Each call collects its own trace, so one Engine can trace
+// concurrent renders.
+func (e *Engine) RenderWithTrace(chrt *chart.Chart, values chartutil.Values) (map[string]string, []TemplateTrace, error) {
+	trace := &traceLog{}
+	out, err := e.renderChart(chrt, values, nil, trace)
+	return out, trace.templates, err
+}
+
+// traceLog collects the templates of a render. A nil traceLog collects
+// nothing.
+type traceLog struct {
+	templates []TemplateTrace
+}
+
+// record adds the rendering of a template to the trace.
+func (l *traceLog) record(name string, vals chartutil.Values, output string, err error) {
+	if l == nil {
+		return
+	}
+	scoped, _ := vals.Table("Values")
+	l.templates = append(l.templates, TemplateTrace{
+		Template: name,
+		Values:   redactValues(scoped),
+		Output:   RedactSecrets(output),
+		Err:      err,
+	})
+}
+
+// redactValues returns a copy of vals with every value that is not a table
+// replaced by Redacted, since values may hold credentials.
+func redactValues(vals map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(vals))
+	for k, v := range vals {
+		switch table := v.(type) {
+		case map[string]interface{}:
+			redacted[k] = redactValues(table)
+		case chartutil.Values:
+			redacted[k] = redactValues(table)
+		default:
+			redacted[k] = Redacted
+		}
+	}
+	return redacted
+}
+
+// Redacted is the value RedactSecrets puts in place of the data of Secrets.
+const Redacted = "REDACTED"
+
+var (
+	documentSeparator = regexp.MustCompile(`(?m)^---.*$`)
+	secretKind        = regexp.MustCompile(`(?m)^kind:\s*["']?Secret["']?\s*$`)
+)
+
+// RedactSecrets replaces the values of the data and stringData of the Secrets
+// in a rendered manifest, which may hold several YAML documents. A document
+// that looks like a Secret but cannot be parsed is replaced entirely.
+func RedactSecrets(manifest string) string {
+	if !secretKind.MatchString(manifest) {
+		return manifest
+	}
+	docs := documentSeparator.Split(manifest, -1)
+	for i, doc := range docs {
+		docs[i] = redactSecret(doc)
+	}
+	return strings.Join(docs, "---")
+}
+
+func redactSecret(doc string) string {
+	if !secretKind.MatchString(doc) {
+		return doc
+	}
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+		return "\n# Secret redacted: it could not be parsed\n"
+	}
+	if obj["kind"] != "Secret" {
+		return doc
+	}
+	for _, field := range []string{"data", "stringData"} {
+		if data, ok := obj[field].(map[string]interface{}); ok {
+			for k := range data {
+				data[k] = Redacted
+			}
+		}
+	}
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return "\n# Secret redacted: it could not be parsed\n"
+	}
+	// Keep the newline that follows a document separator.
+	lead := doc[:len(doc)-len(strings.TrimLeft(doc, "\n"))]
+	return lead + string(out)
+}
@@ -19,6 +19,8 @@ package getter
 import (
 	"bytes"
 	"fmt"
+	"strings"
+	"sync"
 
 	"k8s.io/helm/pkg/helm/environment"
 )
@@ -29,6 +31,15 @@ type Getter interface {
 	Get(url string) (*bytes.Buffer, error)
 }
 
+// Authenticator is implemented by getters that can attach credentials to
+// the requests they make.
+type Authenticator interface {
+	// SetCredentials sets the username and password used for basic auth.
+	SetCredentials(username, password string)
+	// SetBearerToken sets the token sent in a bearer Authorization header.
+	SetBearerToken(token string)
+}
+
 // Constructor is the function for every getter which creates a specific instance
 // according to the configuration
 type Constructor func(URL, CertFile, KeyFile, CAFile string) (Getter, error)
@@ -44,12 +55,26 @@ type Provider struct {
 
 // Provides returns true if the given scheme is supported by this Provider.
 func (p Provider) Provides(scheme string) bool {
+	return p.match(scheme) > 0
+}
+
+// match returns the length of the longest scheme of this Provider that
+// handles the given scheme, or 0 if none does.
+//
+// A provider scheme handles a URL scheme that is equal to it, or one that
+// extends it with a '+' suffix. For example, 'myproto' handles both
+// 'myproto' and 'myproto+https'.
+func (p Provider) match(scheme string) int {
+	best := 0
 	for _, i := range p.Schemes {
-		if i == scheme {
-			return true
+		if i == "" || len(i) <= best {
+			continue
+		}
+		if i == scheme || strings.HasPrefix(scheme, i+"+") {
+			best = len(i)
 		}
 	}
-	return false
+	return best
 }
 
 // Providers is a collection of Provider objects.
@@ -57,26 +82,78 @@ type Providers []Provider
 
 // ByScheme returns a Provider that handles the given scheme.
 //
-// If no provider handles this scheme, this will return an error.
+// When more than one provider handles the scheme, the one with the longest
+// matching scheme wins. If no provider handles this scheme, this will return
+// an error.
 func (p Providers) ByScheme(scheme string) (Constructor, error) {
+	pp, err := p.provider(scheme)
+	if err != nil {
+		return nil, err
+	}
+	return pp.New, nil
+}
+
+func (p Providers) provider(scheme string) (Provider, error) {
+	var (
+		found Provider
+		best  int
+	)
 	for _, pp := range p {
-		if pp.Provides(scheme) {
-			return pp.New, nil
+		if n := pp.match(scheme); n > best {
+			found, best = pp, n
 		}
 	}
-	return nil, fmt.Errorf("scheme %q not supported", scheme)
+	if best == 0 {
+		return Provider{}, fmt.Errorf("scheme %q not supported", scheme)
+	}
+	return found, nil
+}
+
+var (
+	registeredMu sync.RWMutex
+	registered   Providers
+)
+
+// Register makes a getter available to All and ByScheme for the schemes
+// of the given Provider.
+//
+// It is intended for programs embedding Helm that need to fetch charts over
+// a custom protocol. Registered providers take precedence over the built-in
+// getters and plugins for the schemes they share.
+func Register(p Provider) error {
+	if p.New == nil {
+		return fmt.Errorf("getter provider for %v has no constructor", p.Schemes)
+	}
+	if len(p.Schemes) == 0 {
+		return fmt.Errorf("getter provider has no schemes")
+	}
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	registered = append(registered, p)
+	return nil
+}
+
+// Registered returns the providers added with Register.
+func Registered() Providers {
+	registeredMu.RLock()
+	defer registeredMu.RUnlock()
+	return append(Providers(nil), registered...)
 }
 
 // All finds all of the registered getters as a list of Provider instances.
-// Currently the build-in http/https getter and the discovered
-// plugins with downloader notations are collected.
+// The providers added with Register, the build-in http/https getter and the
+// discovered plugins with downloader notations are collected.
 func All(settings environment.EnvSettings) Providers {
-	result := Providers{
-		{
-			Schemes: []string{"http", "https"},
-			New:     newHTTPGetter,
-		},
-	}
+	result := Registered()
+	result = append(result, Provider{
+		Schemes: []string{"http", "https"},
+		New: newHTTPProvider(httpOptions{
+			userAgent: UserAgent(settings.UserAgentSuffix),
+			proxy:     settings.HTTPProxy,
+			noProxy:   settings.NoProxy,
+			retries:   settings.HTTPRetries,
+		}),
+	})
 	pluginDownloaders, _ := collectPlugins(settings)
 	result = append(result, pluginDownloaders...)
 	return result
@@ -88,11 +165,5 @@ func All(settings environment.EnvSettings) Providers {
 func ByScheme(scheme string, settings environment.EnvSettings) (Provider, error) {
 	// Q: What do you call a scheme string who's the boss?
 	// A: Bruce Schemestring, of course.
-	a := All(settings)
-	for _, p := range a {
-		if p.Provides(scheme) {
-			return p, nil
-		}
-	}
-	return Provider{}, fmt.Errorf("scheme %q not supported", scheme)
+	return All(settings).provider(scheme)
 }
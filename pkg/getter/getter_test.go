@@ -79,3 +79,72 @@ func TestByScheme(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestProvidersLongestMatch(t *testing.T) {
+	var got string
+	newGetter := func(name string) Constructor {
+		return func(h, e, l, m string) (Getter, error) {
+			got = name
+			return nil, nil
+		}
+	}
+	ps := Providers{
+		{[]string{"myproto"}, newGetter("myproto")},
+		{[]string{"myproto+https"}, newGetter("myproto+https")},
+	}
+
+	tests := map[string]string{
+		"myproto":       "myproto",
+		"myproto+https": "myproto+https",
+		"myproto+ssh":   "myproto",
+	}
+	for scheme, expect := range tests {
+		c, err := ps.ByScheme(scheme)
+		if err != nil {
+			t.Errorf("%s: %s", scheme, err)
+			continue
+		}
+		c("", "", "", "")
+		if got != expect {
+			t.Errorf("%s: expected %s getter, got %s", scheme, expect, got)
+		}
+	}
+
+	for _, scheme := range []string{"myprotox", "my", "https"} {
+		if _, err := ps.ByScheme(scheme); err == nil {
+			t.Errorf("Did not expect handler for %s", scheme)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	oldhh := os.Getenv("HELM_HOME")
+	defer os.Setenv("HELM_HOME", oldhh)
+	os.Setenv("HELM_HOME", "")
+
+	defer func(old Providers) { registered = old }(registered)
+	registered = nil
+
+	if err := Register(Provider{Schemes: []string{"myproto"}}); err == nil {
+		t.Error("Expected error registering a provider without a constructor")
+	}
+
+	p := Provider{
+		Schemes: []string{"myproto"},
+		New:     func(h, e, l, m string) (Getter, error) { return nil, nil },
+	}
+	if err := Register(p); err != nil {
+		t.Fatal(err)
+	}
+
+	env := hh(false)
+	if len(All(env)) != 4 {
+		t.Errorf("expected 4 providers, got %d", len(All(env)))
+	}
+	if _, err := ByScheme("myproto", env); err != nil {
+		t.Error(err)
+	}
+	if _, err := ByScheme("yourproto", env); err == nil {
+		t.Error("Did not expect handler for yourproto")
+	}
+}
@@ -20,41 +20,148 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/helm/pkg/tlsutil"
 	"k8s.io/helm/pkg/urlutil"
 	"k8s.io/helm/pkg/version"
 )
 
+// UserAgent returns the User-Agent sent with HTTP requests. It identifies
+// Helm and its version, e.g. "Helm/2.8.0", followed by the given suffix, if
+// any.
+func UserAgent(suffix string) string {
+	ua := "Helm/" + strings.TrimPrefix(version.GetVersion(), "v")
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		ua += " " + suffix
+	}
+	return ua
+}
+
+// defaultRetryBackoff is the delay before the first retry of a failed
+// request. It doubles with every further retry.
+const defaultRetryBackoff = time.Second
+
+// defaultMaxRetryDelay bounds the delay before a retry, however long the
+// server asks the client to wait.
+const defaultMaxRetryDelay = 30 * time.Second
+
 //httpGetter is the efault HTTP(/S) backend handler
 type httpGetter struct {
-	client *http.Client
+	client    *http.Client
+	username  string
+	password  string
+	token     string
+	userAgent string
+	// retries is the number of times a failed request is retried.
+	retries int
+	// backoff is the delay before the first retry.
+	backoff time.Duration
+	// maxDelay bounds the delay before a retry.
+	maxDelay time.Duration
+}
+
+// SetCredentials sets the basic auth credentials sent with each request.
+func (g *httpGetter) SetCredentials(username, password string) {
+	g.username = username
+	g.password = password
+}
+
+// SetBearerToken sets the bearer token sent with each request.
+//
+// Basic auth credentials take precedence when both are set.
+func (g *httpGetter) SetBearerToken(token string) {
+	g.token = token
 }
 
 //Get performs a Get from repo.Getter and returns the body.
+//
+// Requests that fail to connect, or that the server answers with a 5xx or 429
+// status, are retried up to the configured number of times with exponential
+// backoff. A Retry-After header from the server takes precedence over the
+// backoff. Either delay is capped, so that a server cannot stall the client.
 func (g *httpGetter) Get(href string) (*bytes.Buffer, error) {
-	buf := bytes.NewBuffer(nil)
+	backoff := g.backoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	maxDelay := g.maxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxRetryDelay
+	}
+	for attempt := 0; ; attempt++ {
+		buf, retry, after, err := g.get(href)
+		if err == nil || !retry || attempt >= g.retries {
+			return buf, err
+		}
+		if after <= 0 {
+			after = backoff
+		}
+		if after > maxDelay {
+			after = maxDelay
+		}
+		time.Sleep(after)
+		backoff *= 2
+	}
+}
+
+// get performs a single request. If it fails, retry reports whether it may be
+// retried, and after is the delay the server asked for, if any.
+func (g *httpGetter) get(href string) (buf *bytes.Buffer, retry bool, after time.Duration, err error) {
+	buf = bytes.NewBuffer(nil)
 
 	// Set a helm specific user agent so that a repo server and metrics can
 	// separate helm calls from other tools interacting with repos.
 	req, err := http.NewRequest("GET", href, nil)
 	if err != nil {
-		return buf, err
+		return buf, false, 0, err
+	}
+	ua := g.userAgent
+	if ua == "" {
+		ua = UserAgent("")
+	}
+	req.Header.Set("User-Agent", ua)
+
+	if g.username != "" || g.password != "" {
+		req.SetBasicAuth(g.username, g.password)
+	} else if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
 	}
-	req.Header.Set("User-Agent", "Helm/"+strings.TrimPrefix(version.GetVersion(), "v"))
 
 	resp, err := g.client.Do(req)
 	if err != nil {
-		return buf, err
+		return buf, true, 0, err
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
-		return buf, fmt.Errorf("Failed to fetch %s : %s", href, resp.Status)
+		retry = resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return buf, retry, retryAfter(resp.Header.Get("Retry-After")), fmt.Errorf("Failed to fetch %s : %s", href, resp.Status)
 	}
 
 	_, err = io.Copy(buf, resp.Body)
-	resp.Body.Close()
-	return buf, err
+	return buf, false, 0, err
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date. It returns 0 if the value is missing or
+// invalid.
+func retryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
 }
 
 // newHTTPGetter constructs a valid http/https client as Getter
@@ -83,3 +190,88 @@ func newHTTPGetter(URL, CertFile, KeyFile, CAFile string) (Getter, error) {
 	}
 	return &client, nil
 }
+
+// httpOptions configure the http/https getters created by All.
+type httpOptions struct {
+	// userAgent is sent with every request.
+	userAgent string
+	// proxy is the URL of the proxy requests are routed through. When it is
+	// empty, the proxy is taken from the environment.
+	proxy string
+	// noProxy is a comma-separated list of hosts that bypass proxy.
+	noProxy string
+	// retries is the number of times a failed request is retried.
+	retries int
+}
+
+// newHTTPProvider returns a Constructor for http/https getters configured with
+// the given options.
+func newHTTPProvider(opts httpOptions) Constructor {
+	return func(URL, CertFile, KeyFile, CAFile string) (Getter, error) {
+		g, err := newHTTPGetter(URL, CertFile, KeyFile, CAFile)
+		if err != nil {
+			return nil, err
+		}
+		hg := g.(*httpGetter)
+		hg.userAgent = opts.userAgent
+		hg.retries = opts.retries
+		if opts.proxy != "" {
+			proxy, err := proxyFunc(opts.proxy, opts.noProxy)
+			if err != nil {
+				return nil, err
+			}
+			if hg.client == http.DefaultClient {
+				hg.client = &http.Client{Transport: newDefaultTransport()}
+			}
+			hg.client.Transport.(*http.Transport).Proxy = proxy
+		}
+		return hg, nil
+	}
+}
+
+// newDefaultTransport returns a new transport with the settings of
+// http.DefaultTransport, including its timeouts.
+//
+// A Transport must not be copied, so the settings are copied one by one.
+func newDefaultTransport() *http.Transport {
+	d := http.DefaultTransport.(*http.Transport)
+	return &http.Transport{
+		Proxy:                 d.Proxy,
+		DialContext:           d.DialContext,
+		MaxIdleConns:          d.MaxIdleConns,
+		IdleConnTimeout:       d.IdleConnTimeout,
+		TLSHandshakeTimeout:   d.TLSHandshakeTimeout,
+		ExpectContinueTimeout: d.ExpectContinueTimeout,
+	}
+}
+
+// proxyFunc returns a proxy function for an http.Transport that routes all
+// requests through the given proxy, except those to the hosts in noProxy.
+//
+// A noProxy entry matches its host and all of its subdomains. A leading '.'
+// or '*.' is ignored, and the entry '*' matches every host.
+func proxyFunc(proxy, noProxy string) (func(*http.Request) (*url.URL, error), error) {
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", proxy)
+	}
+	var hosts []string
+	for _, h := range strings.Split(noProxy, ",") {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h == "*" {
+			return func(*http.Request) (*url.URL, error) { return nil, nil }, nil
+		}
+		if h = strings.TrimPrefix(strings.TrimPrefix(h, "*"), "."); h != "" {
+			hosts = append(hosts, h)
+		}
+	}
+	return func(req *http.Request) (*url.URL, error) {
+		host := strings.ToLower(req.URL.Hostname())
+		for _, h := range hosts {
+			if host == h || strings.HasSuffix(host, "."+h) {
+				return nil, nil
+			}
+		}
+		return u, nil
+	}, nil
+}
@@ -17,8 +17,13 @@ package getter
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"k8s.io/helm/pkg/helm/environment"
 )
 
 func TestHTTPGetter(t *testing.T) {
@@ -46,3 +51,281 @@ func TestHTTPGetter(t *testing.T) {
 		t.Fatal("Expected newHTTPGetter to produce an httpGetter")
 	}
 }
+
+func TestHTTPGetterCredentials(t *testing.T) {
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	g, err := newHTTPGetter(srv.URL, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, ok := g.(Authenticator)
+	if !ok {
+		t.Fatal("Expected httpGetter to be an Authenticator")
+	}
+
+	a.SetBearerToken("token")
+	if _, err := g.Get(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "Bearer token"; auth != expect {
+		t.Errorf("Expected Authorization header %q, got %q", expect, auth)
+	}
+
+	a.SetCredentials("username", "password")
+	if _, err := g.Get(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "Basic dXNlcm5hbWU6cGFzc3dvcmQ="; auth != expect {
+		t.Errorf("Expected Authorization header %q, got %q", expect, auth)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	ua := UserAgent("")
+	if !strings.HasPrefix(ua, "Helm/") || strings.HasSuffix(ua, " ") {
+		t.Errorf("Expected default User-Agent to identify Helm, got %q", ua)
+	}
+	if expect := ua + " my-ci/1.0"; UserAgent("my-ci/1.0") != expect {
+		t.Errorf("Expected %q, got %q", expect, UserAgent("my-ci/1.0"))
+	}
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.UserAgent()
+	}))
+	defer srv.Close()
+
+	for suffix, expect := range map[string]string{"": ua, "my-ci/1.0": ua + " my-ci/1.0"} {
+		c, err := All(environment.EnvSettings{UserAgentSuffix: suffix}).ByScheme("http")
+		if err != nil {
+			t.Fatal(err)
+		}
+		g, err := c(srv.URL, "", "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := g.Get(srv.URL); err != nil {
+			t.Fatal(err)
+		}
+		if got != expect {
+			t.Errorf("Expected User-Agent %q, got %q", expect, got)
+		}
+	}
+}
+
+func TestHTTPGetterProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+	}))
+	defer proxy.Close()
+
+	var direct int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		direct++
+	}))
+	defer srv.Close()
+
+	settings := environment.EnvSettings{HTTPProxy: proxy.URL, NoProxy: "example.org, 127.0.0.1"}
+	c, err := All(settings).ByScheme("http")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := c("http://charts.example.com", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := g.Get("http://charts.example.com/index.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	if len(proxied) != 1 || proxied[0] != "http://charts.example.com/index.yaml" {
+		t.Errorf("Expected request to be routed through the proxy, got %v", proxied)
+	}
+
+	// 127.0.0.1 is in the no-proxy list.
+	if _, err := g.Get(srv.URL + "/index.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	if direct != 1 || len(proxied) != 1 {
+		t.Errorf("Expected request to bypass the proxy, got %d direct and %v proxied", direct, proxied)
+	}
+
+	tr := g.(*httpGetter).client.Transport.(*http.Transport)
+	def := http.DefaultTransport.(*http.Transport)
+	if tr.DialContext == nil || tr.TLSHandshakeTimeout != def.TLSHandshakeTimeout || tr.IdleConnTimeout != def.IdleConnTimeout {
+		t.Errorf("Expected the transport to keep the timeouts of the default transport, got %+v", tr)
+	}
+
+	if _, err := newHTTPProvider(httpOptions{proxy: "::"})("http://example.com", "", "", ""); err == nil {
+		t.Error("Expected error for an invalid proxy URL")
+	}
+}
+
+func TestProxyFunc(t *testing.T) {
+	tests := []struct {
+		noProxy string
+		host    string
+		bypass  bool
+	}{
+		{"", "charts.example.com", false},
+		{"example.com", "example.com", true},
+		{"example.com", "charts.example.com", true},
+		{".example.com", "charts.example.com", true},
+		{"*.example.com", "charts.example.com", true},
+		{"example.com", "badexample.com", false},
+		{"other.org, example.com", "EXAMPLE.com", true},
+		{"*", "charts.example.com", true},
+	}
+	for _, tt := range tests {
+		fn, err := proxyFunc("http://proxy.example.org:3128", tt.noProxy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, _ := http.NewRequest("GET", "http://"+tt.host+":8080/index.yaml", nil)
+		u, err := fn(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (u == nil) != tt.bypass {
+			t.Errorf("no_proxy %q, host %q: expected bypass %t, got proxy %v", tt.noProxy, tt.host, tt.bypass, u)
+		}
+	}
+}
+
+func TestHTTPGetterRetry(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusInternalServerError)
+		case 3:
+			w.WriteHeader(http.StatusBadGateway)
+		case 4:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte("index"))
+		}
+	}))
+	defer srv.Close()
+
+	g, err := newHTTPProvider(httpOptions{retries: 4})(srv.URL, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.(*httpGetter).backoff = time.Millisecond
+
+	buf, err := g.Get(srv.URL + "/index.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "index" {
+		t.Errorf("Expected body %q, got %q", "index", buf.String())
+	}
+	if requests != 5 {
+		t.Errorf("Expected 5 requests, got %d", requests)
+	}
+}
+
+func TestHTTPGetterNoRetry(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	g, err := newHTTPProvider(httpOptions{retries: 3})(srv.URL, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.(*httpGetter).backoff = time.Millisecond
+
+	if _, err := g.Get(srv.URL + "/missing-0.1.0.tgz"); err == nil {
+		t.Error("Expected error for missing chart")
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+}
+
+func TestHTTPGetterRetriesExhausted(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	g, err := newHTTPProvider(httpOptions{retries: 2})(srv.URL, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.(*httpGetter).backoff = time.Millisecond
+
+	if _, err := g.Get(srv.URL + "/index.yaml"); err == nil {
+		t.Error("Expected error for a server error")
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+}
+
+func TestHTTPGetterRetryAfterCapped(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("index"))
+	}))
+	defer srv.Close()
+
+	g, err := newHTTPProvider(httpOptions{retries: 1})(srv.URL, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.(*httpGetter).maxDelay = 10 * time.Millisecond
+
+	start := time.Now()
+	if _, err := g.Get(srv.URL + "/index.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the Retry-After delay to be capped, waited %v", elapsed)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":        0,
+		"5":       5 * time.Second,
+		"-1":      0,
+		"invalid": 0,
+	}
+	for v, expect := range tests {
+		if got := retryAfter(v); got != expect {
+			t.Errorf("Retry-After %q: expected %v, got %v", v, expect, got)
+		}
+	}
+
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if got := retryAfter(date); got <= 0 || got > time.Hour {
+		t.Errorf("Retry-After %q: expected up to an hour, got %v", date, got)
+	}
+}
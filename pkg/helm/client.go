@@ -17,27 +17,47 @@ limitations under the License.
 package helm // import "k8s.io/helm/pkg/helm"
 
 import (
+	"crypto/tls"
+	"errors"
 	"io"
+	"sync"
 	"time"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tlsutil"
 )
 
+// ErrTimeout is returned when a call to Tiller does not complete before the
+// request timeout expires.
+var ErrTimeout = errors.New("timed out waiting for a response from Tiller")
+
 // Client manages client side of the Helm-Tiller protocol.
 type Client struct {
 	opts options
+
+	// mu guards conn, the connection kept open when connections are reused.
+	mu   sync.Mutex
+	conn *grpc.ClientConn
 }
 
 // NewClient creates a new client.
 func NewClient(opts ...Option) *Client {
-	var c Client
+	c := Client{
+		opts: options{
+			// Send keepalive every 30 seconds to prevent the connection from
+			// getting closed by upstreams
+			keepaliveTime: 30 * time.Second,
+		},
+	}
 	return c.Option(opts...)
 }
 
@@ -56,7 +76,8 @@ func (h *Client) ListReleases(opts ...ReleaseListOption) (*rls.ListReleasesRespo
 		opt(&reqOpts)
 	}
 	req := &reqOpts.listReq
-	ctx := NewContext()
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -90,7 +111,8 @@ func (h *Client) InstallReleaseFromChart(chart *chart.Chart, ns string, opts ...
 	req.DryRun = reqOpts.dryRun
 	req.DisableHooks = reqOpts.disableHooks
 	req.ReuseName = reqOpts.reuseName
-	ctx := NewContext()
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -106,6 +128,9 @@ func (h *Client) InstallReleaseFromChart(chart *chart.Chart, ns string, opts ...
 		return nil, err
 	}
 
+	if reqOpts.installProgress != nil {
+		return h.installStream(ctx, req, reqOpts.installProgress)
+	}
 	return h.install(ctx, req)
 }
 
@@ -129,7 +154,8 @@ func (h *Client) DeleteRelease(rlsName string, opts ...DeleteOption) (*rls.Unins
 	req := &reqOpts.uninstallReq
 	req.Name = rlsName
 	req.DisableHooks = reqOpts.disableHooks
-	ctx := NewContext()
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -139,6 +165,32 @@ func (h *Client) DeleteRelease(rlsName string, opts ...DeleteOption) (*rls.Unins
 	return h.delete(ctx, req)
 }
 
+// DeleteReleases uninstalls the named releases, and those matched by
+// DeleteReleasesFilter or DeleteReleasesSelector, in one call. The result of deleting each release is
+// reported in the response; the failure of one does not stop the others.
+func (h *Client) DeleteReleases(rlsNames []string, opts ...DeleteOption) (*rls.DeleteReleasesResponse, error) {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	uninstall := reqOpts.uninstallReq
+	uninstall.DisableHooks = reqOpts.disableHooks
+	req := &reqOpts.deleteReleasesReq
+	req.Names = rlsNames
+	req.Options = &uninstall
+	req.DryRun = reqOpts.dryRun
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.deleteReleases(ctx, req)
+}
+
 // UpdateRelease loads a chart from chstr and updates a release to a new/different chart.
 func (h *Client) UpdateRelease(rlsName string, chstr string, opts ...UpdateOption) (*rls.UpdateReleaseResponse, error) {
 	// load the chart to update
@@ -166,7 +218,8 @@ func (h *Client) UpdateReleaseFromChart(rlsName string, chart *chart.Chart, opts
 	req.Force = reqOpts.force
 	req.ResetValues = reqOpts.resetValues
 	req.ReuseValues = reqOpts.reuseValues
-	ctx := NewContext()
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -192,7 +245,8 @@ func (h *Client) GetVersion(opts ...VersionOption) (*rls.GetVersionResponse, err
 		opt(&reqOpts)
 	}
 	req := &rls.GetVersionRequest{}
-	ctx := NewContext()
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -202,6 +256,22 @@ func (h *Client) GetVersion(opts ...VersionOption) (*rls.GetVersionResponse, err
 	return h.version(ctx, req)
 }
 
+// GetServerInfo returns the server version and the features the server
+// supports.
+func (h *Client) GetServerInfo() (*rls.GetServerInfoResponse, error) {
+	reqOpts := h.opts
+	req := &rls.GetServerInfoRequest{}
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.serverInfo(ctx, req)
+}
+
 // RollbackRelease rolls back a release to the previous version.
 func (h *Client) RollbackRelease(rlsName string, opts ...RollbackOption) (*rls.RollbackReleaseResponse, error) {
 	reqOpts := h.opts
@@ -214,7 +284,8 @@ func (h *Client) RollbackRelease(rlsName string, opts ...RollbackOption) (*rls.R
 	req.DisableHooks = reqOpts.disableHooks
 	req.DryRun = reqOpts.dryRun
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -232,7 +303,8 @@ func (h *Client) ReleaseStatus(rlsName string, opts ...StatusOption) (*rls.GetRe
 	}
 	req := &reqOpts.statusReq
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -250,7 +322,8 @@ func (h *Client) ReleaseContent(rlsName string, opts ...ContentOption) (*rls.Get
 	}
 	req := &reqOpts.contentReq
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -260,6 +333,62 @@ func (h *Client) ReleaseContent(rlsName string, opts ...ContentOption) (*rls.Get
 	return h.content(ctx, req)
 }
 
+// ReleaseAll returns the metadata, values, manifest, hooks and notes of a
+// given release.
+func (h *Client) ReleaseAll(rlsName string, opts ...AllOption) (*rls.GetReleaseAllResponse, error) {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	req := &reqOpts.allReq
+	req.Name = rlsName
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.all(ctx, req)
+}
+
+// RenderRelease renders the chart stored in a release with the values given
+// by RenderValueOverrides, without installing anything.
+func (h *Client) RenderRelease(rlsName string, opts ...RenderOption) (*rls.RenderReleaseResponse, error) {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	req := &reqOpts.renderReq
+	req.Name = rlsName
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.render(ctx, req)
+}
+
+// SetReleasePaused pauses or resumes a release. A paused release is not
+// upgraded unless the upgrade uses UpgradeForcePaused.
+func (h *Client) SetReleasePaused(rlsName string, paused bool) (*rls.SetReleasePausedResponse, error) {
+	reqOpts := h.opts
+	req := &rls.SetReleasePausedRequest{Name: rlsName, Paused: paused}
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.setPaused(ctx, req)
+}
+
 // ReleaseHistory returns a release's revision history.
 func (h *Client) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls.GetHistoryResponse, error) {
 	reqOpts := h.opts
@@ -269,7 +398,8 @@ func (h *Client) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls.Get
 
 	req := &reqOpts.histReq
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -288,48 +418,197 @@ func (h *Client) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-ch
 
 	req := &reqOpts.testReq
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx, cancel := h.newContext(&reqOpts)
 
-	return h.test(ctx, req)
+	return h.test(ctx, cancel, req)
+}
+
+// InspectChart loads a chart and asks Tiller to describe its contents.
+func (h *Client) InspectChart(chstr string, opts ...InspectOption) (*rls.InspectChartResponse, error) {
+	chart, err := chartutil.Load(chstr)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.InspectChartFromChart(chart, opts...)
+}
+
+// InspectChartFromChart asks Tiller to describe the contents of a chart.
+func (h *Client) InspectChartFromChart(chart *chart.Chart, opts ...InspectOption) (*rls.InspectChartResponse, error) {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	req := &rls.InspectChartRequest{Chart: chart}
+	ctx, cancel := h.newContext(&reqOpts)
+	defer cancel()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.inspect(ctx, req)
 }
 
 // PingTiller pings the Tiller pod and ensure's that it is up and runnning
 func (h *Client) PingTiller() error {
-	ctx := NewContext()
+	ctx, cancel := h.newContext(&h.opts)
+	defer cancel()
 	return h.ping(ctx)
 }
 
+// Close releases the connection kept open for reuse, if any.
+func (h *Client) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.conn == nil {
+		return nil
+	}
+	err := h.conn.Close()
+	h.conn = nil
+	return err
+}
+
+// keepaliveParams returns the keepalive parameters used to dial Tiller.
+func (h *Client) keepaliveParams() keepalive.ClientParameters {
+	return keepalive.ClientParameters{
+		Time:                h.opts.keepaliveTime,
+		Timeout:             h.opts.keepaliveTimeout,
+		PermitWithoutStream: h.opts.keepalivePermitWithoutStream,
+	}
+}
+
 // connect returns a gRPC connection to Tiller or error. The gRPC dial options
 // are constructed here.
+//
+// If connection reuse is enabled, a previously established connection is
+// returned as long as it has not been shut down.
 func (h *Client) connect(ctx context.Context) (conn *grpc.ClientConn, err error) {
+	if h.opts.reuseConnection {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if h.conn != nil && h.conn.GetState() != connectivity.Shutdown {
+			return h.conn, nil
+		}
+	}
+
 	opts := []grpc.DialOption{
 		grpc.WithTimeout(5 * time.Second),
 		grpc.WithBlock(),
-		grpc.WithKeepaliveParams(keepalive.ClientParameters{
-			// Send keepalive every 30 seconds to prevent the connection from
-			// getting closed by upstreams
-			Time: time.Duration(30) * time.Second,
-		}),
+		grpc.WithKeepaliveParams(h.keepaliveParams()),
+		grpc.WithUnaryInterceptor(unaryTimeoutInterceptor),
+		grpc.WithStreamInterceptor(streamTimeoutInterceptor),
 	}
 	switch {
 	case h.opts.useTLS:
-		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(h.opts.tlsConfig)))
+		cfg, err := h.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
 	default:
 		opts = append(opts, grpc.WithInsecure())
 	}
-	if conn, err = grpc.Dial(h.opts.host, opts...); err != nil {
-		return nil, err
+	if conn, err = grpc.DialContext(ctx, h.opts.host, opts...); err != nil {
+		return nil, timeoutError(ctx, err)
+	}
+	if h.opts.reuseConnection {
+		h.conn = conn
 	}
 	return conn, nil
 }
 
+// newContext returns the context for a call to Tiller. If a request timeout
+// is set, the context expires once it elapses. The returned cancel function
+// must be called once the call has completed.
+func (h *Client) newContext(reqOpts *options) (context.Context, context.CancelFunc) {
+	ctx := NewContext()
+	if reqOpts.requestTimeout > 0 {
+		return context.WithTimeout(ctx, reqOpts.requestTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// timeoutError replaces err with ErrTimeout if the call failed because the
+// deadline of ctx was exceeded.
+func timeoutError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() == context.DeadlineExceeded || grpc.Code(err) == codes.DeadlineExceeded {
+		return ErrTimeout
+	}
+	return err
+}
+
+// unaryTimeoutInterceptor reports unary calls cancelled at their deadline as ErrTimeout.
+func unaryTimeoutInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return timeoutError(ctx, invoker(ctx, method, req, reply, cc, opts...))
+}
+
+// streamTimeoutInterceptor reports streaming calls cancelled at their deadline as ErrTimeout.
+func streamTimeoutInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	s, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, timeoutError(ctx, err)
+	}
+	return &timeoutStream{ClientStream: s, ctx: ctx}, nil
+}
+
+// timeoutStream is a grpc.ClientStream that reports messages not received
+// before the deadline as ErrTimeout.
+type timeoutStream struct {
+	grpc.ClientStream
+	ctx context.Context
+}
+
+func (s *timeoutStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == io.EOF {
+		return err
+	}
+	return timeoutError(s.ctx, err)
+}
+
+// release closes a connection returned by connect, unless it is being reused.
+func (h *Client) release(conn *grpc.ClientConn) {
+	if !h.opts.reuseConnection {
+		conn.Close()
+	}
+}
+
+// tlsConfig returns the TLS configuration used to dial Tiller.
+//
+// A configuration supplied with WithTLS takes precedence. Otherwise, one is
+// built from the CA, client certificate and server name options. Certificate
+// verification is only skipped if WithTLSInsecureSkipVerify was given.
+func (h *Client) tlsConfig() (*tls.Config, error) {
+	if h.opts.tlsConfig != nil {
+		if h.opts.tlsServerName == "" {
+			return h.opts.tlsConfig, nil
+		}
+		cfg := h.opts.tlsConfig.Clone()
+		cfg.ServerName = h.opts.tlsServerName
+		return cfg, nil
+	}
+	return tlsutil.ClientConfig(tlsutil.Options{
+		CaCertFile:         h.opts.tlsCaCertFile,
+		CertFile:           h.opts.tlsCertFile,
+		KeyFile:            h.opts.tlsKeyFile,
+		ServerName:         h.opts.tlsServerName,
+		InsecureSkipVerify: h.opts.tlsInsecureSkipVerify,
+	})
+}
+
 // Executes tiller.ListReleases RPC.
 func (h *Client) list(ctx context.Context, req *rls.ListReleasesRequest) (*rls.ListReleasesResponse, error) {
 	c, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer h.release(c)
 
 	rlc := rls.NewReleaseServiceClient(c)
 	s, err := rlc.ListReleases(ctx, req)
@@ -346,19 +625,54 @@ func (h *Client) install(ctx context.Context, req *rls.InstallReleaseRequest) (*
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer h.release(c)
 
 	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.InstallRelease(ctx, req)
 }
 
+// Executes tiller.InstallReleaseStream RPC, passing each progress event to fn.
+func (h *Client) installStream(ctx context.Context, req *rls.InstallReleaseRequest, fn func(*rls.InstallReleaseEvent)) (*rls.InstallReleaseResponse, error) {
+	c, err := h.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer h.release(c)
+
+	rlc := rls.NewReleaseServiceClient(c)
+	s, err := rlc.InstallReleaseStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *rls.InstallReleaseResponse
+	for {
+		msg, err := s.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return res, err
+		}
+		if msg.Response != nil {
+			res = msg.Response
+			continue
+		}
+		fn(msg)
+	}
+	if res == nil {
+		return nil, errors.New("install stream ended without a result")
+	}
+	return res, nil
+}
+
 // Executes tiller.UninstallRelease RPC.
 func (h *Client) delete(ctx context.Context, req *rls.UninstallReleaseRequest) (*rls.UninstallReleaseResponse, error) {
 	c, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer h.release(c)
 
 	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.UninstallRelease(ctx, req)
@@ -370,7 +684,7 @@ func (h *Client) update(ctx context.Context, req *rls.UpdateReleaseRequest) (*rl
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer h.release(c)
 
 	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.UpdateRelease(ctx, req)
@@ -382,7 +696,7 @@ func (h *Client) rollback(ctx context.Context, req *rls.RollbackReleaseRequest)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer h.release(c)
 
 	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.RollbackRelease(ctx, req)
@@ -394,7 +708,7 @@ func (h *Client) status(ctx context.Context, req *rls.GetReleaseStatusRequest) (
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer h.release(c)
 
 	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.GetReleaseStatus(ctx, req)
@@ -406,50 +720,102 @@ func (h *Client) content(ctx context.Context, req *rls.GetReleaseContentRequest)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer h.release(c)
 
 	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.GetReleaseContent(ctx, req)
 }
 
+// Executes tiller.GetReleaseAll RPC.
+func (h *Client) all(ctx context.Context, req *rls.GetReleaseAllRequest) (*rls.GetReleaseAllResponse, error) {
+	c, err := h.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer h.release(c)
+
+	rlc := rls.NewReleaseServiceClient(c)
+	return rlc.GetReleaseAll(ctx, req)
+}
+
 // Executes tiller.GetVersion RPC.
 func (h *Client) version(ctx context.Context, req *rls.GetVersionRequest) (*rls.GetVersionResponse, error) {
 	c, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer h.release(c)
 
 	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.GetVersion(ctx, req)
 }
 
+// Executes tiller.DeleteReleases RPC.
+func (h *Client) deleteReleases(ctx context.Context, req *rls.DeleteReleasesRequest) (*rls.DeleteReleasesResponse, error) {
+	c, err := h.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer h.release(c)
+
+	rlc := rls.NewReleaseServiceClient(c)
+	return rlc.DeleteReleases(ctx, req)
+}
+
+// Executes tiller.GetServerInfo RPC.
+func (h *Client) serverInfo(ctx context.Context, req *rls.GetServerInfoRequest) (*rls.GetServerInfoResponse, error) {
+	c, err := h.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer h.release(c)
+
+	rlc := rls.NewReleaseServiceClient(c)
+	return rlc.GetServerInfo(ctx, req)
+}
+
+// Executes tiller.SetReleasePaused RPC.
+func (h *Client) setPaused(ctx context.Context, req *rls.SetReleasePausedRequest) (*rls.SetReleasePausedResponse, error) {
+	c, err := h.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer h.release(c)
+
+	rlc := rls.NewReleaseServiceClient(c)
+	return rlc.SetReleasePaused(ctx, req)
+}
+
 // Executes tiller.GetHistory RPC.
 func (h *Client) history(ctx context.Context, req *rls.GetHistoryRequest) (*rls.GetHistoryResponse, error) {
 	c, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer h.release(c)
 
 	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.GetHistory(ctx, req)
 }
 
 // Executes tiller.TestRelease RPC.
-func (h *Client) test(ctx context.Context, req *rls.TestReleaseRequest) (<-chan *rls.TestReleaseResponse, <-chan error) {
+//
+// The stream is cancelled, by calling cancel, once it has been fully received.
+func (h *Client) test(ctx context.Context, cancel context.CancelFunc, req *rls.TestReleaseRequest) (<-chan *rls.TestReleaseResponse, <-chan error) {
 	errc := make(chan error, 1)
 	c, err := h.connect(ctx)
 	if err != nil {
+		cancel()
 		errc <- err
 		return nil, errc
 	}
 
 	ch := make(chan *rls.TestReleaseResponse, 1)
 	go func() {
+		defer cancel()
 		defer close(errc)
 		defer close(ch)
-		defer c.Close()
+		defer h.release(c)
 
 		rlc := rls.NewReleaseServiceClient(c)
 		s, err := rlc.RunReleaseTest(ctx, req)
@@ -474,13 +840,37 @@ func (h *Client) test(ctx context.Context, req *rls.TestReleaseRequest) (<-chan
 	return ch, errc
 }
 
+// Executes tiller.RenderRelease RPC.
+func (h *Client) render(ctx context.Context, req *rls.RenderReleaseRequest) (*rls.RenderReleaseResponse, error) {
+	c, err := h.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer h.release(c)
+
+	rlc := rls.NewReleaseServiceClient(c)
+	return rlc.RenderRelease(ctx, req)
+}
+
+// Executes tiller.InspectChart RPC.
+func (h *Client) inspect(ctx context.Context, req *rls.InspectChartRequest) (*rls.InspectChartResponse, error) {
+	c, err := h.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer h.release(c)
+
+	rlc := rls.NewReleaseServiceClient(c)
+	return rlc.InspectChart(ctx, req)
+}
+
 // Executes tiller.Ping RPC.
 func (h *Client) ping(ctx context.Context) error {
 	c, err := h.connect(ctx)
 	if err != nil {
 		return err
 	}
-	defer c.Close()
+	defer h.release(c)
 
 	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.PingTiller(ctx)
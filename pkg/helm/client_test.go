@@ -0,0 +1,403 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm // import "k8s.io/helm/pkg/helm"
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	rls "k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/proto/hapi/version"
+)
+
+// fakeReleaseServer answers GetVersion, GetServerInfo and InstallReleaseStream;
+// every other RPC is left unimplemented.
+type fakeReleaseServer struct {
+	rls.ReleaseServiceServer
+}
+
+func (s *fakeReleaseServer) GetVersion(ctx context.Context, req *rls.GetVersionRequest) (*rls.GetVersionResponse, error) {
+	return &rls.GetVersionResponse{Version: &version.Version{SemVer: "v2.0.0"}}, nil
+}
+
+func (s *fakeReleaseServer) GetServerInfo(ctx context.Context, req *rls.GetServerInfoRequest) (*rls.GetServerInfoResponse, error) {
+	return &rls.GetServerInfoResponse{
+		Version:       &version.Version{SemVer: "v2.0.0", GitCommit: "abc123"},
+		StorageDriver: "Secret",
+		Features:      []string{"atomic", "release-locks"},
+	}, nil
+}
+
+func (s *fakeReleaseServer) InstallReleaseStream(req *rls.InstallReleaseRequest, stream rls.ReleaseService_InstallReleaseStreamServer) error {
+	events := []*rls.InstallReleaseEvent{
+		{Stage: "rendered", Message: "rendered 1 resource(s) and 0 hook(s)"},
+		{Stage: "applying", Message: `ConfigMap "hello"`},
+		{Stage: "done", Message: "Install complete", Response: &rls.InstallReleaseResponse{
+			Release: &release.Release{Name: req.Name, Namespace: req.Namespace},
+		}},
+	}
+	for _, e := range events {
+		if err := stream.Send(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startReleaseServer starts a gRPC server on a local port and returns its address.
+func startReleaseServer(t *testing.T, srv rls.ReleaseServiceServer, opts ...grpc.ServerOption) (string, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(opts...)
+	rls.RegisterReleaseServiceServer(s, srv)
+	go s.Serve(lis)
+	return lis.Addr().String(), s.Stop
+}
+
+// testPKI holds the paths to a CA and the certificates it signed.
+type testPKI struct {
+	caCert, serverCert, serverKey, clientCert, clientKey string
+}
+
+// newTestPKI generates a CA, a server certificate valid for 127.0.0.1 and
+// "tiller-server", and a client certificate, all written to dir.
+func newTestPKI(t *testing.T, dir, prefix string) testPKI {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: prefix + "-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(name, typ string, b []byte) string {
+		path := filepath.Join(dir, prefix+"-"+name)
+		if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: b}), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			DNSNames:     []string{"tiller-server"},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return write(name+".pem", "CERTIFICATE", der), write(name+"-key.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key))
+	}
+
+	p := testPKI{caCert: write("ca.pem", "CERTIFICATE", caDER)}
+	p.serverCert, p.serverKey = issue("server", 2, x509.ExtKeyUsageServerAuth)
+	p.clientCert, p.clientKey = issue("client", 3, x509.ExtKeyUsageClientAuth)
+	return p
+}
+
+func TestClientMutualTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-client-tls-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pki := newTestPKI(t, dir, "tiller")
+	wrong := newTestPKI(t, dir, "other")
+
+	cert, err := tls.LoadX509KeyPair(pki.serverCert, pki.serverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	caPEM, err := ioutil.ReadFile(pki.caCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.AppendCertsFromPEM(caPEM)
+	creds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+
+	addr, stop := startReleaseServer(t, &fakeReleaseServer{}, grpc.Creds(creds))
+	defer stop()
+
+	tests := []struct {
+		name string
+		opts []Option
+		fail bool
+	}{
+		{
+			name: "client certificate verified by CA",
+			opts: []Option{WithTLSCaCert(pki.caCert), WithTLSClientCert(pki.clientCert, pki.clientKey)},
+		},
+		{
+			name: "client certificate verified by CA with server name",
+			opts: []Option{WithTLSCaCert(pki.caCert), WithTLSClientCert(pki.clientCert, pki.clientKey), WithTLSServerName("tiller-server")},
+		},
+		{
+			name: "server name mismatch",
+			opts: []Option{WithTLSCaCert(pki.caCert), WithTLSClientCert(pki.clientCert, pki.clientKey), WithTLSServerName("example.com")},
+			fail: true,
+		},
+		{
+			name: "wrong CA",
+			opts: []Option{WithTLSCaCert(wrong.caCert), WithTLSClientCert(pki.clientCert, pki.clientKey)},
+			fail: true,
+		},
+		{
+			name: "wrong CA with explicit insecure skip verify",
+			opts: []Option{WithTLSCaCert(wrong.caCert), WithTLSClientCert(pki.clientCert, pki.clientKey), WithTLSInsecureSkipVerify()},
+		},
+		{
+			name: "client certificate signed by another CA",
+			opts: []Option{WithTLSCaCert(pki.caCert), WithTLSClientCert(wrong.clientCert, wrong.clientKey)},
+			fail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		opts := append([]Option{Host(addr)}, tt.opts...)
+		_, err := NewClient(opts...).GetVersion()
+		if tt.fail && err == nil {
+			t.Errorf("%s: expected connection to fail", tt.name)
+		} else if !tt.fail && err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, err)
+		}
+	}
+}
+
+func TestClientKeepaliveParams(t *testing.T) {
+	kp := NewClient().keepaliveParams()
+	if kp.Time != 30*time.Second || kp.Timeout != 0 || kp.PermitWithoutStream {
+		t.Errorf("unexpected default keepalive parameters: %+v", kp)
+	}
+
+	kp = NewClient(KeepaliveTime(time.Minute), KeepaliveTimeout(10*time.Second), KeepalivePermitWithoutStream(true)).keepaliveParams()
+	if kp.Time != time.Minute {
+		t.Errorf("expected keepalive time %s, got %s", time.Minute, kp.Time)
+	}
+	if kp.Timeout != 10*time.Second {
+		t.Errorf("expected keepalive timeout %s, got %s", 10*time.Second, kp.Timeout)
+	}
+	if !kp.PermitWithoutStream {
+		t.Error("expected keepalive pings to be permitted without streams")
+	}
+}
+
+// countingListener counts the connections it accepts.
+type countingListener struct {
+	net.Listener
+	mu       sync.Mutex
+	accepted int
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err == nil {
+		l.mu.Lock()
+		l.accepted++
+		l.mu.Unlock()
+	}
+	return c, err
+}
+
+func (l *countingListener) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.accepted
+}
+
+func TestClientReuseConnection(t *testing.T) {
+	inner, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis := &countingListener{Listener: inner}
+	s := grpc.NewServer()
+	rls.RegisterReleaseServiceServer(s, &fakeReleaseServer{})
+	go s.Serve(lis)
+	defer s.Stop()
+
+	h := NewClient(Host(lis.Addr().String()), ReuseConnection(), KeepaliveTime(time.Minute), KeepalivePermitWithoutStream(true))
+	defer h.Close()
+
+	if _, err := h.GetVersion(); err != nil {
+		t.Fatal(err)
+	}
+	conn := h.conn
+	if conn == nil {
+		t.Fatal("expected the connection to be kept for reuse")
+	}
+
+	// Stay idle for less than the keepalive time; the connection must survive.
+	time.Sleep(200 * time.Millisecond)
+
+	if _, err := h.GetVersion(); err != nil {
+		t.Fatal(err)
+	}
+	if h.conn != conn {
+		t.Error("expected the connection to be reused")
+	}
+	if got := lis.count(); got != 1 {
+		t.Errorf("expected 1 connection to the server, got %d", got)
+	}
+
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if h.conn != nil {
+		t.Error("expected Close to release the connection")
+	}
+}
+
+func TestClientGetServerInfo(t *testing.T) {
+	addr, stop := startReleaseServer(t, &fakeReleaseServer{})
+	defer stop()
+
+	res, err := NewClient(Host(addr)).GetServerInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Version.SemVer != "v2.0.0" || res.Version.GitCommit != "abc123" {
+		t.Errorf("unexpected version %v", res.Version)
+	}
+	if res.StorageDriver != "Secret" {
+		t.Errorf("expected storage driver Secret, got %q", res.StorageDriver)
+	}
+	if !reflect.DeepEqual(res.Features, []string{"atomic", "release-locks"}) {
+		t.Errorf("unexpected features %v", res.Features)
+	}
+}
+
+func TestClientInstallProgress(t *testing.T) {
+	addr, stop := startReleaseServer(t, &fakeReleaseServer{})
+	defer stop()
+
+	var stages []string
+	progress := InstallProgress(func(e *rls.InstallReleaseEvent) {
+		stages = append(stages, e.Stage)
+	})
+	ch := &chart.Chart{Metadata: &chart.Metadata{Name: "hello"}}
+	res, err := NewClient(Host(addr)).InstallReleaseFromChart(ch, "spaced", ReleaseName("progress"), progress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(stages, []string{"rendered", "applying"}) {
+		t.Errorf("unexpected stages %v", stages)
+	}
+	if res.Release.Name != "progress" || res.Release.Namespace != "spaced" {
+		t.Errorf("unexpected release %v", res.Release)
+	}
+}
+
+// slowReleaseServer blocks until the call is cancelled by the client.
+type slowReleaseServer struct {
+	rls.ReleaseServiceServer
+}
+
+func (s *slowReleaseServer) GetVersion(ctx context.Context, req *rls.GetVersionRequest) (*rls.GetVersionResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (s *slowReleaseServer) RunReleaseTest(req *rls.TestReleaseRequest, stream rls.ReleaseService_RunReleaseTestServer) error {
+	if err := stream.Send(&rls.TestReleaseResponse{Msg: "running"}); err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+func TestClientRequestTimeout(t *testing.T) {
+	addr, stop := startReleaseServer(t, &slowReleaseServer{})
+	defer stop()
+
+	timeout := 200 * time.Millisecond
+	h := NewClient(Host(addr), RequestTimeout(timeout))
+
+	start := time.Now()
+	if _, err := h.GetVersion(); err != ErrTimeout {
+		t.Errorf("expected %v, got %v", ErrTimeout, err)
+	}
+	if elapsed := time.Since(start); elapsed > timeout+2*time.Second {
+		t.Errorf("expected the call to be cancelled at the deadline, took %s", elapsed)
+	}
+
+	start = time.Now()
+	ch, errc := h.RunReleaseTest("test")
+	var msgs int
+	for range ch {
+		msgs++
+	}
+	if err := <-errc; err != ErrTimeout {
+		t.Errorf("expected %v, got %v", ErrTimeout, err)
+	}
+	if msgs != 1 {
+		t.Errorf("expected 1 message before the deadline, got %d", msgs)
+	}
+	if elapsed := time.Since(start); elapsed > timeout+2*time.Second {
+		t.Errorf("expected the stream to be cancelled at the deadline, took %s", elapsed)
+	}
+}
@@ -23,8 +23,10 @@ These dependencies are expressed as interfaces so that alternate implementations
 package environment
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/spf13/pflag"
 
@@ -49,6 +51,25 @@ type EnvSettings struct {
 	KubeContext string
 	// KubeConfig is the name of the kubeconfig file.
 	KubeConfig string
+	// UserAgentSuffix is appended to the User-Agent of HTTP requests to
+	// chart repositories.
+	UserAgentSuffix string
+	// HTTPProxy is the URL of the proxy that HTTP requests to chart
+	// repositories are routed through. It overrides $HTTP_PROXY and
+	// $HTTPS_PROXY.
+	HTTPProxy string
+	// NoProxy is a comma-separated list of hosts that are reached without
+	// HTTPProxy.
+	NoProxy string
+	// HTTPRetries is the number of times a failed HTTP request to a chart
+	// repository is retried.
+	HTTPRetries int
+	// DefaultRepo is the repository that chart names without a repository
+	// are looked up in first.
+	DefaultRepo string
+	// DefaultNamespace is the namespace releases are installed in when no
+	// namespace is given. It overrides the namespace of the kubeconfig context.
+	DefaultNamespace string
 }
 
 // AddFlags binds flags to the given flagset.
@@ -66,6 +87,24 @@ func (s *EnvSettings) Init(fs *pflag.FlagSet) {
 	for name, envar := range envMap {
 		setFlagFromEnv(name, envar, fs)
 	}
+	for envar, v := range map[string]*string{
+		"HELM_USER_AGENT_SUFFIX": &s.UserAgentSuffix,
+		"HELM_HTTP_PROXY":        &s.HTTPProxy,
+		"HELM_NO_PROXY":          &s.NoProxy,
+		"HELM_DEFAULT_REPO":      &s.DefaultRepo,
+		"HELM_DEFAULT_NAMESPACE": &s.DefaultNamespace,
+	} {
+		if *v == "" {
+			*v = os.Getenv(envar)
+		}
+	}
+	if v, ok := os.LookupEnv("HELM_HTTP_RETRIES"); ok && s.HTTPRetries == 0 {
+		if n, err := strconv.Atoi(v); err != nil || n < 0 {
+			fmt.Fprintf(os.Stderr, "WARNING: ignoring $HELM_HTTP_RETRIES=%q: not a number of retries\n", v)
+		} else {
+			s.HTTPRetries = n
+		}
+	}
 }
 
 // PluginDirs is the path to the plugin directories.
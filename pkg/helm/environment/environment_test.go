@@ -117,6 +117,40 @@ func TestEnvSettings(t *testing.T) {
 	}
 }
 
+func TestEnvSettingsDefaultNamespace(t *testing.T) {
+	defer os.Setenv("HELM_DEFAULT_NAMESPACE", os.Getenv("HELM_DEFAULT_NAMESPACE"))
+
+	for _, ns := range []string{"", "ci"} {
+		os.Setenv("HELM_DEFAULT_NAMESPACE", ns)
+
+		flags := pflag.NewFlagSet("testing", pflag.ContinueOnError)
+		settings := &EnvSettings{}
+		settings.AddFlags(flags)
+		settings.Init(flags)
+
+		if settings.DefaultNamespace != ns {
+			t.Errorf("expected default namespace %q, got %q", ns, settings.DefaultNamespace)
+		}
+	}
+}
+
+func TestEnvSettingsHTTPRetries(t *testing.T) {
+	defer os.Setenv("HELM_HTTP_RETRIES", os.Getenv("HELM_HTTP_RETRIES"))
+
+	for v, expect := range map[string]int{"3": 3, "0": 0, "lots": 0, "-1": 0} {
+		os.Setenv("HELM_HTTP_RETRIES", v)
+
+		flags := pflag.NewFlagSet("testing", pflag.ContinueOnError)
+		settings := &EnvSettings{}
+		settings.AddFlags(flags)
+		settings.Init(flags)
+
+		if settings.HTTPRetries != expect {
+			t.Errorf("$HELM_HTTP_RETRIES=%q: expected %d retries, got %d", v, expect, settings.HTTPRetries)
+		}
+	}
+}
+
 func resetEnv() func() {
 	origEnv := os.Environ()
 
@@ -20,9 +20,12 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
@@ -71,7 +74,7 @@ func (c *FakeClient) InstallReleaseFromChart(chart *chart.Chart, ns string, opts
 	releaseName := c.Opts.instReq.Name
 
 	// Check to see if the release already exists.
-	rel, err := c.ReleaseStatus(releaseName, nil)
+	rel, err := c.ReleaseStatus(releaseName)
 	if err == nil && rel != nil {
 		return nil, errors.New("cannot re-use a name that is still in use")
 	}
@@ -98,6 +101,53 @@ func (c *FakeClient) DeleteRelease(rlsName string, opts ...DeleteOption) (*rls.U
 	return nil, fmt.Errorf("No such release: %s", rlsName)
 }
 
+// DeleteReleases deletes each of the named releases, and those whose NAME, STATUS and VERSION match the
+// DeleteReleasesSelector labels, from the fake release client, reporting a result for each
+func (c *FakeClient) DeleteReleases(rlsNames []string, opts ...DeleteOption) (*rls.DeleteReleasesResponse, error) {
+	reqOpts := options{}
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	if selector := reqOpts.deleteReleasesReq.Selector; selector != "" {
+		for _, rel := range c.Rels {
+			if fakeSelectorMatches(selector, rel) {
+				rlsNames = append(rlsNames, rel.Name)
+			}
+		}
+	}
+	if len(rlsNames) == 0 && reqOpts.deleteReleasesReq.Filter == "" && reqOpts.deleteReleasesReq.Selector == "" {
+		return nil, errors.New("no release name set")
+	}
+
+	res := &rls.DeleteReleasesResponse{}
+	for _, name := range rlsNames {
+		result := &rls.DeleteReleasesResult{Name: name}
+		if r, err := c.DeleteRelease(name, opts...); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Response = r
+		}
+		res.Results = append(res.Results, result)
+	}
+	return res, nil
+}
+
+// fakeSelectorMatches reports whether rel has all of the key=value labels of selector.
+func fakeSelectorMatches(selector string, rel *release.Release) bool {
+	labels := map[string]string{
+		"NAME":    rel.Name,
+		"STATUS":  rel.Info.Status.Code.String(),
+		"VERSION": strconv.Itoa(int(rel.Version)),
+	}
+	for _, pair := range strings.Split(selector, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || labels[strings.ToUpper(kv[0])] != kv[1] {
+			return false
+		}
+	}
+	return true
+}
+
 // GetVersion returns a fake version
 func (c *FakeClient) GetVersion(opts ...VersionOption) (*rls.GetVersionResponse, error) {
 	return &rls.GetVersionResponse{
@@ -107,6 +157,16 @@ func (c *FakeClient) GetVersion(opts ...VersionOption) (*rls.GetVersionResponse,
 	}, nil
 }
 
+// GetServerInfo returns a fake version and no features
+func (c *FakeClient) GetServerInfo() (*rls.GetServerInfoResponse, error) {
+	return &rls.GetServerInfoResponse{
+		Version: &version.Version{
+			SemVer: "1.2.3-fakeclient+testonly",
+		},
+		StorageDriver: "Memory",
+	}, nil
+}
+
 // UpdateRelease returns an UpdateReleaseResponse containing the updated release, if it exists
 func (c *FakeClient) UpdateRelease(rlsName string, chStr string, opts ...UpdateOption) (*rls.UpdateReleaseResponse, error) {
 	return c.UpdateReleaseFromChart(rlsName, &chart.Chart{}, opts...)
@@ -114,11 +174,19 @@ func (c *FakeClient) UpdateRelease(rlsName string, chStr string, opts ...UpdateO
 
 // UpdateReleaseFromChart returns an UpdateReleaseResponse containing the updated release, if it exists
 func (c *FakeClient) UpdateReleaseFromChart(rlsName string, chart *chart.Chart, opts ...UpdateOption) (*rls.UpdateReleaseResponse, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
 	// Check to see if the release already exists.
-	rel, err := c.ReleaseContent(rlsName, nil)
+	rel, err := c.ReleaseContent(rlsName)
 	if err != nil {
 		return nil, err
 	}
+	if rel.Release.Info.GetPaused() && !reqOpts.updateReq.ForcePaused {
+		return nil, fmt.Errorf("release %q is paused", rlsName)
+	}
 
 	return &rls.UpdateReleaseResponse{Release: rel.Release}, nil
 }
@@ -130,22 +198,52 @@ func (c *FakeClient) RollbackRelease(rlsName string, opts ...RollbackOption) (*r
 
 // ReleaseStatus returns a release status response with info from the matching release name.
 func (c *FakeClient) ReleaseStatus(rlsName string, opts ...StatusOption) (*rls.GetReleaseStatusResponse, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	version := reqOpts.statusReq.Version
+
+	found := false
 	for _, rel := range c.Rels {
-		if rel.Name == rlsName {
+		if rel.Name != rlsName {
+			continue
+		}
+		found = true
+		if version <= 0 {
 			return &rls.GetReleaseStatusResponse{
 				Name:      rel.Name,
 				Info:      rel.Info,
 				Namespace: rel.Namespace,
 			}, nil
 		}
+		if rel.Version == version {
+			return &rls.GetReleaseStatusResponse{
+				Name:      rel.Name,
+				Info:      rel.Info,
+				Namespace: rel.Namespace,
+				Version:   rel.Version,
+				Config:    rel.Config,
+				Manifest:  rel.Manifest,
+			}, nil
+		}
+	}
+	if found {
+		return nil, fmt.Errorf("release %q has no revision %d", rlsName, version)
 	}
 	return nil, fmt.Errorf("No such release: %s", rlsName)
 }
 
 // ReleaseContent returns the configuration for the matching release name in the fake release client.
 func (c *FakeClient) ReleaseContent(rlsName string, opts ...ContentOption) (resp *rls.GetReleaseContentResponse, err error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	version := reqOpts.contentReq.Version
+
 	for _, rel := range c.Rels {
-		if rel.Name == rlsName {
+		if rel.Name == rlsName && (version <= 0 || rel.Version == version) {
 			return &rls.GetReleaseContentResponse{
 				Release: rel,
 			}, nil
@@ -154,6 +252,65 @@ func (c *FakeClient) ReleaseContent(rlsName string, opts ...ContentOption) (resp
 	return resp, fmt.Errorf("No such release: %s", rlsName)
 }
 
+// ReleaseAll returns the metadata, values, manifest, hooks and notes for the matching release name in the fake release client.
+func (c *FakeClient) ReleaseAll(rlsName string, opts ...AllOption) (*rls.GetReleaseAllResponse, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	req := reqOpts.allReq
+
+	res, err := c.ReleaseContent(rlsName, ContentReleaseVersion(req.Version))
+	if err != nil {
+		return nil, err
+	}
+	rel := res.Release
+
+	vals, err := chartutil.CoalesceValues(rel.Chart, rel.Config)
+	if err != nil {
+		return nil, err
+	}
+	computed, err := vals.YAML()
+	if err != nil {
+		return nil, err
+	}
+
+	all := &rls.GetReleaseAllResponse{
+		Name:           rel.Name,
+		Namespace:      rel.Namespace,
+		Version:        rel.Version,
+		Info:           rel.Info,
+		Chart:          rel.GetChart().GetMetadata(),
+		Config:         rel.Config,
+		ComputedValues: &chart.Config{Raw: computed},
+	}
+	if !req.SkipManifest {
+		all.Manifest = rel.Manifest
+	}
+	if !req.SkipHooks {
+		all.Hooks = rel.Hooks
+	}
+	if !req.SkipNotes {
+		all.Notes = rel.GetInfo().GetStatus().GetNotes()
+	}
+	return all, nil
+}
+
+// SetReleasePaused pauses or resumes the latest revision of the matching release.
+func (c *FakeClient) SetReleasePaused(rlsName string, paused bool) (*rls.SetReleasePausedResponse, error) {
+	var last *release.Release
+	for _, rel := range c.Rels {
+		if rel.Name == rlsName && (last == nil || rel.Version > last.Version) {
+			last = rel
+		}
+	}
+	if last == nil {
+		return nil, fmt.Errorf("No such release: %s", rlsName)
+	}
+	last.Info.Paused = paused
+	return &rls.SetReleasePausedResponse{Release: last}, nil
+}
+
 // ReleaseHistory returns a release's revision history.
 func (c *FakeClient) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls.GetHistoryResponse, error) {
 	return &rls.GetHistoryResponse{Releases: c.Rels}, nil
@@ -184,11 +341,54 @@ func (c *FakeClient) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (
 	return results, errc
 }
 
+// RenderRelease returns the manifest, hooks and notes of the matching release name in the fake release client, along with the values it was asked to render
+func (c *FakeClient) RenderRelease(rlsName string, opts ...RenderOption) (*rls.RenderReleaseResponse, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	req := reqOpts.renderReq
+
+	res, err := c.ReleaseContent(rlsName, ContentReleaseVersion(req.Version))
+	if err != nil {
+		return nil, err
+	}
+	rel := res.Release
+
+	values := req.Values
+	if values == nil {
+		values = rel.Config
+	}
+	return &rls.RenderReleaseResponse{
+		Manifest: rel.Manifest,
+		Hooks:    rel.Hooks,
+		Notes:    rel.GetInfo().GetStatus().GetNotes(),
+		Config:   values,
+	}, nil
+}
+
 // PingTiller pings the Tiller pod and ensure's that it is up and runnning
 func (c *FakeClient) PingTiller() error {
 	return nil
 }
 
+// InspectChart returns an InspectChartResponse describing an empty chart
+func (c *FakeClient) InspectChart(chStr string, opts ...InspectOption) (*rls.InspectChartResponse, error) {
+	return c.InspectChartFromChart(&chart.Chart{}, opts...)
+}
+
+// InspectChartFromChart returns an InspectChartResponse with the chart's metadata, values and template names
+func (c *FakeClient) InspectChartFromChart(chart *chart.Chart, opts ...InspectOption) (*rls.InspectChartResponse, error) {
+	res := &rls.InspectChartResponse{
+		Metadata: chart.Metadata,
+		Values:   chart.Values,
+	}
+	for _, t := range chart.Templates {
+		res.Templates = append(res.Templates, t.Name)
+	}
+	return res, nil
+}
+
 // MockHookTemplate is the hook template used for all mock release objects.
 var MockHookTemplate = `apiVersion: v1
 kind: Job
@@ -211,6 +411,7 @@ type MockReleaseOptions struct {
 	Chart      *chart.Chart
 	StatusCode release.Status_Code
 	Namespace  string
+	Paused     bool
 }
 
 // ReleaseMock creates a mock release object based on options set by MockReleaseOptions. This function should typically not be used outside of testing.
@@ -257,6 +458,7 @@ func ReleaseMock(opts *MockReleaseOptions) *release.Release {
 			LastDeployed:  &date,
 			Status:        &release.Status{Code: scode},
 			Description:   "Release mock",
+			Paused:        opts.Paused,
 		},
 		Chart:     ch,
 		Config:    &chart.Config{Raw: `name: "value"`},
@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
@@ -29,6 +30,7 @@ import (
 	cpb "k8s.io/helm/pkg/proto/hapi/chart"
 	rls "k8s.io/helm/pkg/proto/hapi/release"
 	tpb "k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/timeconv"
 )
 
 // Path to example charts relative to pkg/helm.
@@ -52,16 +54,30 @@ func TestListReleases_VerifyOptions(t *testing.T) {
 		rls.Status_SUPERSEDED,
 	}
 	var namespace = "namespace"
+	var cursor = "cursor"
+	var chartName = "chart"
+	var before = time.Date(2018, time.March, 1, 0, 0, 0, 0, time.UTC)
+	var after = time.Date(2018, time.February, 1, 0, 0, 0, 0, time.UTC)
+	var commit = "commit"
 
 	// Expected ListReleasesRequest message
 	exp := &tpb.ListReleasesRequest{
-		Limit:       int64(limit),
-		Offset:      offset,
-		Filter:      filter,
-		SortBy:      tpb.ListSort_SortBy(sortBy),
-		SortOrder:   tpb.ListSort_SortOrder(sortOrd),
-		StatusCodes: codes,
-		Namespace:   namespace,
+		Limit:          int64(limit),
+		Offset:         offset,
+		Filter:         filter,
+		SortBy:         tpb.ListSort_SortBy(sortBy),
+		SortOrder:      tpb.ListSort_SortOrder(sortOrd),
+		StatusCodes:    codes,
+		Namespace:      namespace,
+		Cursor:         cursor,
+		ChartName:      chartName,
+		DeployedBefore: timeconv.Timestamp(before),
+		DeployedAfter:  timeconv.Timestamp(after),
+		BuildCommit:    commit,
+		SortKeys: []*tpb.ListSortKey{
+			{SortBy: tpb.ListSort_NAMESPACE, SortOrder: tpb.ListSort_ASC},
+			{SortBy: tpb.ListSort_SortBy(sortBy), SortOrder: tpb.ListSort_SortOrder(sortOrd)},
+		},
 	}
 
 	// Options used in ListReleases
@@ -73,6 +89,13 @@ func TestListReleases_VerifyOptions(t *testing.T) {
 		ReleaseListFilter(filter),
 		ReleaseListStatuses(codes),
 		ReleaseListNamespace(namespace),
+		ReleaseListCursor(cursor),
+		ReleaseListChartName(chartName),
+		ReleaseListDeployedBefore(before),
+		ReleaseListDeployedAfter(after),
+		ReleaseListBuildCommit(commit),
+		ReleaseListSortKey(int32(tpb.ListSort_NAMESPACE), int32(tpb.ListSort_ASC)),
+		ReleaseListSortKey(sortBy, sortOrd),
 	}
 
 	// BeforeCall option to intercept Helm client ListReleasesRequest
@@ -108,16 +131,30 @@ func TestInstallRelease_VerifyOptions(t *testing.T) {
 	var chartName = "alpine"
 	var chartPath = filepath.Join(chartsDir, chartName)
 	var overrides = []byte("key1=value1,key2=value2")
+	var buildInfo = &rls.BuildInfo{Pipeline: "deploy", Commit: "abc123", Actor: "ci"}
+	var quotaCheck = "fail"
+	var failOnEmpty = true
+	var apiCheck = "warn"
+	var postWaitSettle int64 = 30
+	var orderedApply = true
+	var takeOwnership = true
 
 	// Expected InstallReleaseRequest message
 	exp := &tpb.InstallReleaseRequest{
-		Chart:        loadChart(t, chartName),
-		Values:       &cpb.Config{Raw: string(overrides)},
-		DryRun:       dryRun,
-		Name:         releaseName,
-		DisableHooks: disableHooks,
-		Namespace:    namespace,
-		ReuseName:    reuseName,
+		Chart:          loadChart(t, chartName),
+		Values:         &cpb.Config{Raw: string(overrides)},
+		DryRun:         dryRun,
+		Name:           releaseName,
+		DisableHooks:   disableHooks,
+		Namespace:      namespace,
+		ReuseName:      reuseName,
+		BuildInfo:      buildInfo,
+		QuotaCheck:     quotaCheck,
+		FailOnEmpty:    failOnEmpty,
+		ApiCheck:       apiCheck,
+		PostWaitSettle: postWaitSettle,
+		OrderedApply:   orderedApply,
+		TakeOwnership:  takeOwnership,
 	}
 
 	// Options used in InstallRelease
@@ -127,6 +164,13 @@ func TestInstallRelease_VerifyOptions(t *testing.T) {
 		ReleaseName(releaseName),
 		InstallReuseName(reuseName),
 		InstallDisableHooks(disableHooks),
+		InstallBuildInfo(buildInfo),
+		InstallQuotaCheck(quotaCheck),
+		InstallFailOnEmpty(failOnEmpty),
+		InstallAPICheck(apiCheck),
+		InstallPostWaitSettle(postWaitSettle),
+		InstallOrderedApply(orderedApply),
+		InstallTakeOwnership(takeOwnership),
 	}
 
 	// BeforeCall option to intercept Helm client InstallReleaseRequest
@@ -162,12 +206,16 @@ func TestDeleteRelease_VerifyOptions(t *testing.T) {
 		Name:         releaseName,
 		Purge:        purgeFlag,
 		DisableHooks: disableHooks,
+		Cascade:      "Orphan",
+		GracePeriod:  30,
 	}
 
 	// Options used in DeleteRelease
 	ops := []DeleteOption{
 		DeletePurge(purgeFlag),
 		DeleteDisableHooks(disableHooks),
+		DeleteCascade("Orphan"),
+		DeleteGracePeriod(30),
 	}
 
 	// BeforeCall option to intercept Helm client DeleteReleaseRequest
@@ -191,6 +239,60 @@ func TestDeleteRelease_VerifyOptions(t *testing.T) {
 	assert(t, "", client.opts.uninstallReq.Name)
 }
 
+// Verify each DeleteOption is applied to a DeleteReleasesRequest correctly.
+func TestDeleteReleases_VerifyOptions(t *testing.T) {
+	// Options testdata
+	var releaseNames = []string{"test", "test2"}
+	var filter = "^ci-"
+	var namespace = "ci"
+	var selector = "STATUS=FAILED"
+
+	// Expected DeleteReleasesRequest message
+	exp := &tpb.DeleteReleasesRequest{
+		Names:     releaseNames,
+		Filter:    filter,
+		Namespace: namespace,
+		Selector:  selector,
+		Options: &tpb.UninstallReleaseRequest{
+			Purge:        true,
+			DisableHooks: true,
+			Timeout:      60,
+		},
+		DryRun: true,
+	}
+
+	// Options used in DeleteReleases
+	ops := []DeleteOption{
+		DeleteReleasesFilter(filter),
+		DeleteReleasesNamespace(namespace),
+		DeleteReleasesSelector(selector),
+		DeletePurge(true),
+		DeleteDisableHooks(true),
+		DeleteTimeout(60),
+		DeleteDryRun(true),
+	}
+
+	// BeforeCall option to intercept Helm client DeleteReleasesRequest
+	b4c := BeforeCall(func(_ context.Context, msg proto.Message) error {
+		switch act := msg.(type) {
+		case *tpb.DeleteReleasesRequest:
+			t.Logf("DeleteReleasesRequest: %#+v\n", act)
+			assert(t, exp, act)
+		default:
+			t.Fatalf("expected message of type DeleteReleasesRequest, got %T\n", act)
+		}
+		return errSkip
+	})
+
+	client := NewClient(b4c)
+	if _, err := client.DeleteReleases(releaseNames, ops...); err != errSkip {
+		t.Fatalf("did not expect error but got (%v)\n``", err)
+	}
+
+	// ensure options for call are not saved to client
+	assert(t, 0, len(client.opts.deleteReleasesReq.Names))
+}
+
 // Verify each UpdateOption is applied to an UpdateReleaseRequest correctly.
 func TestUpdateRelease_VerifyOptions(t *testing.T) {
 	// Options testdata
@@ -200,14 +302,28 @@ func TestUpdateRelease_VerifyOptions(t *testing.T) {
 	var disableHooks = true
 	var overrides = []byte("key1=value1,key2=value2")
 	var dryRun = false
+	var buildInfo = &rls.BuildInfo{Pipeline: "deploy", Commit: "abc123", Actor: "ci"}
+	var cleanupOnFail = true
+	var failOnEmpty = true
+	var postWaitSettle int64 = 30
+	var skipUnchanged = true
+	var takeOwnership = true
+	var orderedApply = true
 
 	// Expected UpdateReleaseRequest message
 	exp := &tpb.UpdateReleaseRequest{
-		Name:         releaseName,
-		Chart:        loadChart(t, chartName),
-		Values:       &cpb.Config{Raw: string(overrides)},
-		DryRun:       dryRun,
-		DisableHooks: disableHooks,
+		Name:           releaseName,
+		Chart:          loadChart(t, chartName),
+		Values:         &cpb.Config{Raw: string(overrides)},
+		DryRun:         dryRun,
+		DisableHooks:   disableHooks,
+		BuildInfo:      buildInfo,
+		CleanupOnFail:  cleanupOnFail,
+		FailOnEmpty:    failOnEmpty,
+		PostWaitSettle: postWaitSettle,
+		SkipUnchanged:  skipUnchanged,
+		TakeOwnership:  takeOwnership,
+		OrderedApply:   orderedApply,
 	}
 
 	// Options used in UpdateRelease
@@ -215,6 +331,13 @@ func TestUpdateRelease_VerifyOptions(t *testing.T) {
 		UpgradeDryRun(dryRun),
 		UpdateValueOverrides(overrides),
 		UpgradeDisableHooks(disableHooks),
+		UpgradeBuildInfo(buildInfo),
+		UpgradeCleanupOnFail(cleanupOnFail),
+		UpgradeFailOnEmpty(failOnEmpty),
+		UpgradePostWaitSettle(postWaitSettle),
+		UpgradeSkipUnchanged(skipUnchanged),
+		UpgradeTakeOwnership(takeOwnership),
+		UpgradeOrderedApply(orderedApply),
 	}
 
 	// BeforeCall option to intercept Helm client UpdateReleaseRequest
@@ -348,6 +471,114 @@ func TestReleaseContent_VerifyOptions(t *testing.T) {
 	assert(t, "", client.opts.contentReq.Name)
 }
 
+// Verify each AllOption is applied to a GetReleaseAllRequest correctly.
+func TestReleaseAll_VerifyOptions(t *testing.T) {
+	// Options testdata
+	var releaseName = "test"
+	var revision = int32(2)
+
+	// Expected GetReleaseAllRequest message
+	exp := &tpb.GetReleaseAllRequest{
+		Name:         releaseName,
+		Version:      revision,
+		SkipManifest: true,
+		SkipHooks:    true,
+		SkipNotes:    true,
+	}
+
+	// BeforeCall option to intercept Helm client GetReleaseAllRequest
+	b4c := BeforeCall(func(_ context.Context, msg proto.Message) error {
+		switch act := msg.(type) {
+		case *tpb.GetReleaseAllRequest:
+			t.Logf("GetReleaseAllRequest: %#+v\n", act)
+			assert(t, exp, act)
+		default:
+			t.Fatalf("expected message of type GetReleaseAllRequest, got %T\n", act)
+		}
+		return errSkip
+	})
+
+	client := NewClient(b4c)
+	opts := []AllOption{
+		AllReleaseVersion(revision),
+		AllSkipManifest(true),
+		AllSkipHooks(true),
+		AllSkipNotes(true),
+	}
+	if _, err := client.ReleaseAll(releaseName, opts...); err != errSkip {
+		t.Fatalf("did not expect error but got (%v)\n``", err)
+	}
+
+	assert(t, "", client.opts.allReq.Name)
+}
+
+// Verify each RenderOption is applied to a RenderReleaseRequest correctly.
+func TestRenderRelease_VerifyOptions(t *testing.T) {
+	// Options testdata
+	var releaseName = "test"
+	var revision = int32(2)
+	var overrides = []byte("replicas: 3\n")
+
+	// Expected RenderReleaseRequest message
+	exp := &tpb.RenderReleaseRequest{
+		Name:        releaseName,
+		Version:     revision,
+		Values:      &cpb.Config{Raw: string(overrides)},
+		ReuseValues: true,
+	}
+
+	// BeforeCall option to intercept Helm client RenderReleaseRequest
+	b4c := BeforeCall(func(_ context.Context, msg proto.Message) error {
+		switch act := msg.(type) {
+		case *tpb.RenderReleaseRequest:
+			t.Logf("RenderReleaseRequest: %#+v\n", act)
+			assert(t, exp, act)
+		default:
+			t.Fatalf("expected message of type RenderReleaseRequest, got %T\n", act)
+		}
+		return errSkip
+	})
+
+	client := NewClient(b4c)
+	opts := []RenderOption{
+		RenderReleaseVersion(revision),
+		RenderValueOverrides(overrides),
+		RenderReuseValues(true),
+	}
+	if _, err := client.RenderRelease(releaseName, opts...); err != errSkip {
+		t.Fatalf("did not expect error but got (%v)\n``", err)
+	}
+
+	assert(t, "", client.opts.renderReq.Name)
+}
+
+func TestInspectChart_VerifyOptions(t *testing.T) {
+	var chartName = "alpine"
+	var chartPath = filepath.Join(chartsDir, chartName)
+
+	// Expected InspectChartRequest message
+	exp := &tpb.InspectChartRequest{
+		Chart: loadChart(t, chartName),
+	}
+
+	// BeforeCall option to intercept Helm client InspectChartRequest
+	b4c := BeforeCall(func(_ context.Context, msg proto.Message) error {
+		switch act := msg.(type) {
+		case *tpb.InspectChartRequest:
+			t.Logf("InspectChartRequest: %#+v\n", act)
+			assert(t, exp, act)
+		default:
+			t.Fatalf("expected message of type InspectChartRequest, got %T\n", act)
+		}
+		return errSkip
+	})
+
+	client := NewClient(b4c)
+	if _, err := client.InspectChart(chartPath); err != errSkip {
+		t.Fatalf("did not expect error but got (%v)\n``", err)
+	}
+}
+
 func assert(t *testing.T, expect, actual interface{}) {
 	if !reflect.DeepEqual(expect, actual) {
 		t.Fatalf("expected %#+v, actual %#+v\n", expect, actual)
@@ -87,6 +87,12 @@ func (h Home) Archive() string {
 	return h.Path("cache", "archive")
 }
 
+// ChartCache returns the path to the cache of chart archives, which are stored
+// by digest.
+func (h Home) ChartCache() string {
+	return h.Path("cache", "charts")
+}
+
 // TLSCaCert returns the path to fetch the CA certificate.
 func (h Home) TLSCaCert() string {
 	return h.Path("ca.pem")
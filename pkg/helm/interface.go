@@ -27,13 +27,20 @@ type Interface interface {
 	InstallRelease(chStr, namespace string, opts ...InstallOption) (*rls.InstallReleaseResponse, error)
 	InstallReleaseFromChart(chart *chart.Chart, namespace string, opts ...InstallOption) (*rls.InstallReleaseResponse, error)
 	DeleteRelease(rlsName string, opts ...DeleteOption) (*rls.UninstallReleaseResponse, error)
+	DeleteReleases(rlsNames []string, opts ...DeleteOption) (*rls.DeleteReleasesResponse, error)
 	ReleaseStatus(rlsName string, opts ...StatusOption) (*rls.GetReleaseStatusResponse, error)
 	UpdateRelease(rlsName, chStr string, opts ...UpdateOption) (*rls.UpdateReleaseResponse, error)
 	UpdateReleaseFromChart(rlsName string, chart *chart.Chart, opts ...UpdateOption) (*rls.UpdateReleaseResponse, error)
 	RollbackRelease(rlsName string, opts ...RollbackOption) (*rls.RollbackReleaseResponse, error)
 	ReleaseContent(rlsName string, opts ...ContentOption) (*rls.GetReleaseContentResponse, error)
+	ReleaseAll(rlsName string, opts ...AllOption) (*rls.GetReleaseAllResponse, error)
+	RenderRelease(rlsName string, opts ...RenderOption) (*rls.RenderReleaseResponse, error)
 	ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls.GetHistoryResponse, error)
+	SetReleasePaused(rlsName string, paused bool) (*rls.SetReleasePausedResponse, error)
 	GetVersion(opts ...VersionOption) (*rls.GetVersionResponse, error)
+	GetServerInfo() (*rls.GetServerInfoResponse, error)
 	RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-chan *rls.TestReleaseResponse, <-chan error)
 	PingTiller() error
+	InspectChart(chStr string, opts ...InspectOption) (*rls.InspectChartResponse, error)
+	InspectChartFromChart(chart *chart.Chart, opts ...InspectOption) (*rls.InspectChartResponse, error)
 }
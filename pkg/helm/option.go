@@ -18,6 +18,7 @@ package helm
 
 import (
 	"crypto/tls"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
@@ -26,6 +27,7 @@ import (
 	cpb "k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/timeconv"
 	"k8s.io/helm/pkg/version"
 )
 
@@ -54,6 +56,26 @@ type options struct {
 	releaseName string
 	// tls.Config to use for rpc if tls enabled
 	tlsConfig *tls.Config
+	// path to the CA certificate used to verify Tiller's certificate
+	tlsCaCertFile string
+	// path to the client certificate presented to Tiller
+	tlsCertFile string
+	// path to the client key presented to Tiller
+	tlsKeyFile string
+	// server name used to verify Tiller's certificate
+	tlsServerName string
+	// if set, skip verification of Tiller's certificate
+	tlsInsecureSkipVerify bool
+	// interval after which an idle connection is pinged
+	keepaliveTime time.Duration
+	// time to wait for a keepalive ping acknowledgement before closing the connection
+	keepaliveTimeout time.Duration
+	// if set, send keepalive pings even when there are no active RPCs
+	keepalivePermitWithoutStream bool
+	// if set, keep the connection to Tiller open and reuse it across calls
+	reuseConnection bool
+	// maximum amount of time a single call to Tiller may take
+	requestTimeout time.Duration
 	// release list options are applied directly to the list releases request
 	listReq rls.ListReleasesRequest
 	// release install options are applied directly to the install release request
@@ -62,10 +84,16 @@ type options struct {
 	updateReq rls.UpdateReleaseRequest
 	// release uninstall options are applied directly to the uninstall release request
 	uninstallReq rls.UninstallReleaseRequest
+	// release bulk delete options are applied directly to the delete releases request
+	deleteReleasesReq rls.DeleteReleasesRequest
 	// release get status options are applied directly to the get release status request
 	statusReq rls.GetReleaseStatusRequest
 	// release get content options are applied directly to the get release content request
 	contentReq rls.GetReleaseContentRequest
+	// release get all options are applied directly to the get release all request
+	allReq rls.GetReleaseAllRequest
+	// release render options are applied directly to the render release request
+	renderReq rls.RenderReleaseRequest
 	// release rollback options are applied directly to the rollback release request
 	rollbackReq rls.RollbackReleaseRequest
 	// before intercepts client calls before sending
@@ -78,6 +106,8 @@ type options struct {
 	reuseValues bool
 	// release test options are applied directly to the test release history request
 	testReq rls.TestReleaseRequest
+	// installProgress receives the progress events of an install
+	installProgress func(*rls.InstallReleaseEvent)
 }
 
 // Host specifies the host address of the Tiller release server, (default = ":44134").
@@ -95,6 +125,86 @@ func WithTLS(cfg *tls.Config) Option {
 	}
 }
 
+// WithTLSCaCert specifies the CA certificate file used to verify the
+// certificate presented by Tiller. It enables TLS on helm client calls.
+func WithTLSCaCert(caFile string) Option {
+	return func(opts *options) {
+		opts.useTLS = true
+		opts.tlsCaCertFile = caFile
+	}
+}
+
+// WithTLSClientCert specifies the certificate and key files the helm client
+// presents to Tiller. It enables TLS on helm client calls.
+func WithTLSClientCert(certFile, keyFile string) Option {
+	return func(opts *options) {
+		opts.useTLS = true
+		opts.tlsCertFile = certFile
+		opts.tlsKeyFile = keyFile
+	}
+}
+
+// WithTLSServerName specifies the server name used to verify the certificate
+// presented by Tiller. By default, the host portion of the Tiller address is used.
+func WithTLSServerName(name string) Option {
+	return func(opts *options) {
+		opts.useTLS = true
+		opts.tlsServerName = name
+	}
+}
+
+// WithTLSInsecureSkipVerify disables verification of the certificate presented
+// by Tiller. This should only be used for testing.
+func WithTLSInsecureSkipVerify() Option {
+	return func(opts *options) {
+		opts.useTLS = true
+		opts.tlsInsecureSkipVerify = true
+	}
+}
+
+// KeepaliveTime specifies how long a connection may be idle before the client
+// pings Tiller to check that it is still alive, (default = 30s).
+func KeepaliveTime(d time.Duration) Option {
+	return func(opts *options) {
+		opts.keepaliveTime = d
+	}
+}
+
+// KeepaliveTimeout specifies how long the client waits for a keepalive ping to
+// be acknowledged before closing the connection, (default = 20s).
+func KeepaliveTimeout(d time.Duration) Option {
+	return func(opts *options) {
+		opts.keepaliveTimeout = d
+	}
+}
+
+// KeepalivePermitWithoutStream specifies whether keepalive pings are sent
+// even when there are no active RPCs, (default = false).
+func KeepalivePermitWithoutStream(permit bool) Option {
+	return func(opts *options) {
+		opts.keepalivePermitWithoutStream = permit
+	}
+}
+
+// ReuseConnection keeps the connection to Tiller open after a call and reuses
+// it for subsequent calls made by the same client. Call Close on the client to
+// release the connection.
+func ReuseConnection() Option {
+	return func(opts *options) {
+		opts.reuseConnection = true
+	}
+}
+
+// RequestTimeout specifies the maximum amount of time each call to Tiller may
+// take, including establishing the connection. Calls that do not complete in
+// time are cancelled and return ErrTimeout. Streaming calls, such as running
+// release tests, are cancelled if the whole stream is not received in time.
+func RequestTimeout(d time.Duration) Option {
+	return func(opts *options) {
+		opts.requestTimeout = d
+	}
+}
+
 // BeforeCall returns an option that allows intercepting a helm client rpc
 // before being sent OTA to tiller. The intercepting function should return
 // an error to indicate that the call should not proceed or nil otherwise.
@@ -116,6 +226,42 @@ func ReleaseListOffset(offset string) ReleaseListOption {
 	}
 }
 
+// ReleaseListCursor specifies the cursor returned by a previous list of
+// releases to continue listing from.
+func ReleaseListCursor(cursor string) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.Cursor = cursor
+	}
+}
+
+// ReleaseListChartName specifies the name of the chart to list releases of.
+func ReleaseListChartName(name string) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.ChartName = name
+	}
+}
+
+// ReleaseListDeployedBefore specifies to only list releases last deployed before t.
+func ReleaseListDeployedBefore(t time.Time) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.DeployedBefore = timeconv.Timestamp(t)
+	}
+}
+
+// ReleaseListBuildCommit specifies to only list releases built from commit.
+func ReleaseListBuildCommit(commit string) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.BuildCommit = commit
+	}
+}
+
+// ReleaseListDeployedAfter specifies to only list releases last deployed after t.
+func ReleaseListDeployedAfter(t time.Time) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.DeployedAfter = timeconv.Timestamp(t)
+	}
+}
+
 // ReleaseListFilter specifies a filter to apply a list of releases.
 func ReleaseListFilter(filter string) ReleaseListOption {
 	return func(opts *options) {
@@ -144,6 +290,18 @@ func ReleaseListSort(sort int32) ReleaseListOption {
 	}
 }
 
+// ReleaseListSortKey adds a key to sort a release list by, in the given order.
+// Releases are sorted by the keys in the order they are added. Sort keys take
+// precedence over ReleaseListSort and ReleaseListOrder.
+func ReleaseListSortKey(sort, order int32) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.SortKeys = append(opts.listReq.SortKeys, &rls.ListSortKey{
+			SortBy:    rls.ListSort_SortBy(sort),
+			SortOrder: rls.ListSort_SortOrder(order),
+		})
+	}
+}
+
 // ReleaseListStatuses specifies which status codes should be returned.
 func ReleaseListStatuses(statuses []release.Status_Code) ReleaseListOption {
 	return func(opts *options) {
@@ -201,6 +359,35 @@ func DeleteTimeout(timeout int64) DeleteOption {
 	}
 }
 
+// DeleteCascade sets the propagation policy for dependents of deleted resources:
+// Foreground, Background or Orphan.
+func DeleteCascade(cascade string) DeleteOption {
+	return func(opts *options) {
+		opts.uninstallReq.Cascade = cascade
+	}
+}
+
+// DeleteGracePeriod specifies the number of seconds each resource is given to terminate
+func DeleteGracePeriod(seconds int64) DeleteOption {
+	return func(opts *options) {
+		opts.uninstallReq.GracePeriod = seconds
+	}
+}
+
+// DeleteWait specifies whether to wait for pre-delete and post-delete hook Pods and Jobs to complete
+func DeleteWait(wait bool) DeleteOption {
+	return func(opts *options) {
+		opts.uninstallReq.Wait = wait
+	}
+}
+
+// DeleteContinueOnHookFailure specifies whether to delete the release even if a pre-delete hook fails
+func DeleteContinueOnHookFailure(cont bool) DeleteOption {
+	return func(opts *options) {
+		opts.uninstallReq.ContinueOnHookFailure = cont
+	}
+}
+
 // ReleaseTestTimeout specifies the number of seconds before kubernetes calls timeout
 func ReleaseTestTimeout(timeout int64) ReleaseTestOption {
 	return func(opts *options) {
@@ -215,6 +402,14 @@ func ReleaseTestCleanup(cleanup bool) ReleaseTestOption {
 	}
 }
 
+// ReleaseTestFilters restricts the tests run to those whose name matches one of
+// filters, either "name=GLOB" or "name=~REGEX"
+func ReleaseTestFilters(filters []string) ReleaseTestOption {
+	return func(opts *options) {
+		opts.testReq.Filters = filters
+	}
+}
+
 // RollbackTimeout specifies the number of seconds before kubernetes calls timeout
 func RollbackTimeout(timeout int64) RollbackOption {
 	return func(opts *options) {
@@ -229,6 +424,31 @@ func InstallWait(wait bool) InstallOption {
 	}
 }
 
+// InstallPostWaitSettle specifies the number of seconds to wait after the
+// resources are ready before checking that they still are
+func InstallPostWaitSettle(settle int64) InstallOption {
+	return func(opts *options) {
+		opts.instReq.PostWaitSettle = settle
+	}
+}
+
+// InstallProgress specifies a function to receive the progress of the install
+// as Tiller streams it. The final event is not passed to fn; its response is
+// returned by the install.
+func InstallProgress(fn func(*rls.InstallReleaseEvent)) InstallOption {
+	return func(opts *options) {
+		opts.installProgress = fn
+	}
+}
+
+// InstallAtomic specifies whether or not to purge the release and its hook
+// resources if the install fails
+func InstallAtomic(atomic bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.Atomic = atomic
+	}
+}
+
 // UpgradeWait specifies whether or not to wait for all resources to be ready
 func UpgradeWait(wait bool) UpdateOption {
 	return func(opts *options) {
@@ -236,6 +456,22 @@ func UpgradeWait(wait bool) UpdateOption {
 	}
 }
 
+// UpgradePostWaitSettle specifies the number of seconds to wait after the
+// resources are ready before checking that they still are
+func UpgradePostWaitSettle(settle int64) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.PostWaitSettle = settle
+	}
+}
+
+// UpgradeSkipUnchanged specifies whether to keep the current revision when
+// the upgrade would not change the release
+func UpgradeSkipUnchanged(skip bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.SkipUnchanged = skip
+	}
+}
+
 // RollbackWait specifies whether or not to wait for all resources to be ready
 func RollbackWait(wait bool) RollbackOption {
 	return func(opts *options) {
@@ -243,6 +479,168 @@ func RollbackWait(wait bool) RollbackOption {
 	}
 }
 
+// InstallDescription specifies the description stored on the release
+// instead of the default
+func InstallDescription(description string) InstallOption {
+	return func(opts *options) {
+		opts.instReq.Description = description
+	}
+}
+
+// UpgradeDescription specifies the description stored on the release
+// instead of the default
+func UpgradeDescription(description string) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Description = description
+	}
+}
+
+// InstallBuildInfo specifies the build recorded on the release
+func InstallBuildInfo(info *release.BuildInfo) InstallOption {
+	return func(opts *options) {
+		opts.instReq.BuildInfo = info
+	}
+}
+
+// InstallQuotaCheck specifies whether the resource requests of the release are
+// checked against the ResourceQuota of the namespace, as "warn" or "fail"
+func InstallQuotaCheck(mode string) InstallOption {
+	return func(opts *options) {
+		opts.instReq.QuotaCheck = mode
+	}
+}
+
+// InstallAPICheck specifies whether the apiVersions of the release are checked
+// against the APIs deprecated in the cluster, as "warn" or "fail"
+func InstallAPICheck(mode string) InstallOption {
+	return func(opts *options) {
+		opts.instReq.ApiCheck = mode
+	}
+}
+
+// InstallFailOnEmpty will (if true) fail the install if a template renders empty
+func InstallFailOnEmpty(fail bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.FailOnEmpty = fail
+	}
+}
+
+// InstallOrderedApply will (if true) apply the resources in the order of their
+// template files instead of by kind
+func InstallOrderedApply(ordered bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.OrderedApply = ordered
+	}
+}
+
+// UpgradeBuildInfo specifies the build recorded on the release instead of
+// the build of the release being upgraded
+func UpgradeBuildInfo(info *release.BuildInfo) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.BuildInfo = info
+	}
+}
+
+// UpgradeCleanupOnFail will (if true) delete the resources created by a failed upgrade
+func UpgradeCleanupOnFail(cleanup bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.CleanupOnFail = cleanup
+	}
+}
+
+// UpgradeTakeOwnership will (if true) adopt existing resources, even if another release owns them
+func UpgradeTakeOwnership(take bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.TakeOwnership = take
+	}
+}
+
+// UpgradeOrderedApply will (if true) apply the resources in the order of their
+// template files instead of by kind
+func UpgradeOrderedApply(ordered bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.OrderedApply = ordered
+	}
+}
+
+// UpgradeFailOnEmpty will (if true) fail the upgrade if a template renders empty
+func UpgradeFailOnEmpty(fail bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.FailOnEmpty = fail
+	}
+}
+
+// UpgradeForcePaused will (if true) upgrade the release even if it is paused
+func UpgradeForcePaused(force bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.ForcePaused = force
+	}
+}
+
+// RollbackDescription specifies the description stored on the release
+// instead of the default
+func RollbackDescription(description string) RollbackOption {
+	return func(opts *options) {
+		opts.rollbackReq.Description = description
+	}
+}
+
+// InstallCommonLabels specifies whether or not to add the recommended
+// app.kubernetes.io labels to every resource
+func InstallCommonLabels(commonLabels bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.CommonLabels = commonLabels
+	}
+}
+
+// InstallSkipCRDs specifies whether or not to leave out the
+// CustomResourceDefinitions rendered by the chart
+func InstallSkipCRDs(skipCRDs bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.SkipCrds = skipCRDs
+	}
+}
+
+// UpgradeCommonLabels specifies whether or not to add the recommended
+// app.kubernetes.io labels to every resource
+func UpgradeCommonLabels(commonLabels bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.CommonLabels = commonLabels
+	}
+}
+
+// InstallValuesFrom merges the values stored in a ConfigMap, referenced as
+// configmap/NAMESPACE/NAME[:KEY], beneath the values of the install.
+func InstallValuesFrom(ref string) InstallOption {
+	return func(opts *options) {
+		opts.instReq.ValuesFrom = ref
+	}
+}
+
+// UpgradeValuesFrom merges the values stored in a ConfigMap, referenced as
+// configmap/NAMESPACE/NAME[:KEY], beneath the values of the upgrade.
+func UpgradeValuesFrom(ref string) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.ValuesFrom = ref
+	}
+}
+
+// InstallPatches sets fields of individual rendered resources, given as
+// KIND/NAME:PATH=VALUE, before they are created.
+func InstallPatches(patches []string) InstallOption {
+	return func(opts *options) {
+		opts.instReq.Patches = patches
+	}
+}
+
+// UpgradePatches sets fields of individual rendered resources, given as
+// KIND/NAME:PATH=VALUE, before they are applied.
+func UpgradePatches(patches []string) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Patches = patches
+	}
+}
+
 // UpdateValueOverrides specifies a list of values to include when upgrading
 func UpdateValueOverrides(raw []byte) UpdateOption {
 	return func(opts *options) {
@@ -271,6 +669,52 @@ func DeletePurge(purge bool) DeleteOption {
 	}
 }
 
+// DeleteReleasesFilter also deletes the releases whose names match the regular
+// expression filter, when deleting several releases
+func DeleteReleasesFilter(filter string) DeleteOption {
+	return func(opts *options) {
+		opts.deleteReleasesReq.Filter = filter
+	}
+}
+
+// DeleteReleasesNamespace limits the releases matched by DeleteReleasesFilter
+// and DeleteReleasesSelector to a namespace
+func DeleteReleasesNamespace(namespace string) DeleteOption {
+	return func(opts *options) {
+		opts.deleteReleasesReq.Namespace = namespace
+	}
+}
+
+// DeleteReleasesSelector also deletes the releases whose last revision is stored
+// with all of the comma-separated key=value labels of selector, when deleting
+// several releases
+func DeleteReleasesSelector(selector string) DeleteOption {
+	return func(opts *options) {
+		opts.deleteReleasesReq.Selector = selector
+	}
+}
+
+// InstallContinueOnError specifies whether to attempt every resource of the release even if some fail
+func InstallContinueOnError(cont bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.ContinueOnError = cont
+	}
+}
+
+// InstallTakeOwnership specifies whether to adopt existing resources, even if another release owns them
+func InstallTakeOwnership(take bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.TakeOwnership = take
+	}
+}
+
+// InstallSubchartNotes specifies whether to append the notes of subcharts to the notes of the release
+func InstallSubchartNotes(subNotes bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.RenderSubchartNotes = subNotes
+	}
+}
+
 // InstallDryRun will (if true) execute an installation as a dry run.
 func InstallDryRun(dry bool) InstallOption {
 	return func(opts *options) {
@@ -382,6 +826,65 @@ func ContentReleaseVersion(version int32) ContentOption {
 	}
 }
 
+// AllOption allows setting optional attributes when
+// performing a GetReleaseAll tiller rpc.
+type AllOption func(*options)
+
+// AllReleaseVersion will instruct Tiller to retrieve a particular
+// version of a release.
+func AllReleaseVersion(version int32) AllOption {
+	return func(opts *options) {
+		opts.allReq.Version = version
+	}
+}
+
+// AllSkipManifest will (if true) leave the manifest out of the response.
+func AllSkipManifest(skip bool) AllOption {
+	return func(opts *options) {
+		opts.allReq.SkipManifest = skip
+	}
+}
+
+// AllSkipHooks will (if true) leave the hooks out of the response.
+func AllSkipHooks(skip bool) AllOption {
+	return func(opts *options) {
+		opts.allReq.SkipHooks = skip
+	}
+}
+
+// AllSkipNotes will (if true) leave the notes out of the response.
+func AllSkipNotes(skip bool) AllOption {
+	return func(opts *options) {
+		opts.allReq.SkipNotes = skip
+	}
+}
+
+// RenderOption allows setting optional attributes when
+// performing a RenderRelease tiller rpc.
+type RenderOption func(*options)
+
+// RenderReleaseVersion will instruct Tiller to render the chart of a
+// particular version of a release.
+func RenderReleaseVersion(version int32) RenderOption {
+	return func(opts *options) {
+		opts.renderReq.Version = version
+	}
+}
+
+// RenderValueOverrides specifies the values to render the chart with.
+func RenderValueOverrides(raw []byte) RenderOption {
+	return func(opts *options) {
+		opts.renderReq.Values = &cpb.Config{Raw: string(raw)}
+	}
+}
+
+// RenderReuseValues will (if true) merge the values over those of the release.
+func RenderReuseValues(reuse bool) RenderOption {
+	return func(opts *options) {
+		opts.renderReq.ReuseValues = reuse
+	}
+}
+
 // StatusOption allows setting optional attributes when
 // performing a GetReleaseStatus tiller rpc.
 type StatusOption func(*options)
@@ -432,3 +935,7 @@ func NewContext() context.Context {
 // ReleaseTestOption allows configuring optional request data for
 // issuing a TestRelease rpc.
 type ReleaseTestOption func(*options)
+
+// InspectOption allows configuring optional request data for
+// issuing an InspectChart rpc.
+type InspectOption func(*options)
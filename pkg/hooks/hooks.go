@@ -17,6 +17,11 @@ limitations under the License.
 package hooks
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
 	"k8s.io/helm/pkg/proto/hapi/release"
 )
 
@@ -45,10 +50,77 @@ const (
 
 // Type of policy for deleting the hook
 const (
-	HookSucceeded = "hook-succeeded"
-	HookFailed    = "hook-failed"
+	HookSucceeded      = "hook-succeeded"
+	HookFailed         = "hook-failed"
+	BeforeHookCreation = "before-hook-creation"
 )
 
+// knownHooks are the hooks that may be named in the hook annotation.
+var knownHooks = map[string]bool{
+	PreInstall:         true,
+	PostInstall:        true,
+	PreDelete:          true,
+	PostDelete:         true,
+	PreUpgrade:         true,
+	PostUpgrade:        true,
+	PreRollback:        true,
+	PostRollback:       true,
+	ReleaseTestSuccess: true,
+	ReleaseTestFailure: true,
+}
+
+// knownDeletePolicies are the policies that may be named in the delete policy
+// annotation.
+var knownDeletePolicies = map[string]bool{
+	HookSucceeded:      true,
+	HookFailed:         true,
+	BeforeHookCreation: true,
+}
+
+// Warnings returns a warning for each malformed hook annotation of a resource:
+// a hook or delete policy that is not known, or a weight that is not an
+// integer. Resources without the hook annotation have no warnings.
+func Warnings(annotations map[string]string) []string {
+	hookTypes, ok := annotations[HookAnno]
+	if !ok {
+		return nil
+	}
+
+	var warnings []string
+	for _, h := range strings.Split(hookTypes, ",") {
+		if h = strings.ToLower(strings.TrimSpace(h)); !knownHooks[h] {
+			warnings = append(warnings, fmt.Sprintf("unknown hook %q, the resource is skipped", h))
+		}
+	}
+	if w := strings.TrimSpace(annotations[HookWeightAnno]); w != "" {
+		if _, err := strconv.ParseInt(w, 10, 32); err != nil {
+			warnings = append(warnings, fmt.Sprintf("invalid hook weight %q, using 0", w))
+		}
+	}
+	if dps, ok := annotations[HookDeleteAnno]; ok {
+		for _, dp := range strings.Split(dps, ",") {
+			if dp = strings.ToLower(strings.TrimSpace(dp)); !knownDeletePolicies[dp] {
+				warnings = append(warnings, fmt.Sprintf("unknown hook delete policy %q", dp))
+			}
+		}
+	}
+	return warnings
+}
+
+// ForEvent returns the hooks of hs that run on event, in the order they run.
+func ForEvent(hs []*release.Hook, event release.Hook_Event) []*release.Hook {
+	var matched []*release.Hook
+	for _, h := range hs {
+		for _, e := range h.Events {
+			if e == event {
+				matched = append(matched, h)
+			}
+		}
+	}
+	SortByWeight(matched)
+	return matched
+}
+
 // FilterTestHooks filters the list of hooks are returns only testing hooks.
 func FilterTestHooks(hooks []*release.Hook) []*release.Hook {
 	testHooks := []*release.Hook{}
@@ -64,3 +136,25 @@ func FilterTestHooks(hooks []*release.Hook) []*release.Hook {
 
 	return testHooks
 }
+
+// ParseWeight parses the value of a hook weight annotation. Weights may be
+// negative. A missing or invalid weight, including one out of the int32
+// range, is 0.
+func ParseWeight(s string) int32 {
+	w, err := strconv.ParseInt(strings.TrimSpace(s), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(w)
+}
+
+// SortByWeight sorts hooks in place in ascending order of weight. Hooks of
+// equal weight are sorted by name, and hooks equal in both keep their order.
+func SortByWeight(hooks []*release.Hook) {
+	sort.SliceStable(hooks, func(i, j int) bool {
+		if hooks[i].Weight == hooks[j].Weight {
+			return hooks[i].Name < hooks[j].Name
+		}
+		return hooks[i].Weight < hooks[j].Weight
+	})
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestParseWeight(t *testing.T) {
+	tests := []struct {
+		weight string
+		expect int32
+	}{
+		{"", 0},
+		{"5", 5},
+		{"-10", -10},
+		{" -10 ", -10},
+		{"+3", 3},
+		{"heavy", 0},
+		{"1.5", 0},
+		{"2147483647", 2147483647},
+		{"-2147483648", -2147483648},
+		{"99999999999", 0},
+	}
+	for _, tt := range tests {
+		if got := ParseWeight(tt.weight); got != tt.expect {
+			t.Errorf("ParseWeight(%q): expected %d, got %d", tt.weight, tt.expect, got)
+		}
+	}
+}
+
+func TestSortByWeight(t *testing.T) {
+	hooks := []*release.Hook{
+		{Name: "migrate", Weight: 5},
+		{Name: "seed", Weight: 0},
+		{Name: "backup", Weight: -10},
+		{Name: "check", Weight: 0},
+		{Name: "announce", Weight: 5},
+	}
+	SortByWeight(hooks)
+
+	expect := []string{"backup", "check", "seed", "announce", "migrate"}
+	for i, h := range hooks {
+		if h.Name != expect[i] {
+			t.Errorf("Expected %q at %d, got %q", expect[i], i, h.Name)
+		}
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		expect      []string
+	}{
+		{map[string]string{HookWeightAnno: "heavy"}, nil},
+		{map[string]string{HookAnno: "pre-install, Post-Install", HookWeightAnno: " -5 "}, nil},
+		{map[string]string{HookAnno: "pre-install", HookWeightAnno: ""}, nil},
+		{
+			map[string]string{HookAnno: "pre-install,pre-instal", HookWeightAnno: "1.5", HookDeleteAnno: "hook-succeeded,on-success"},
+			[]string{
+				`unknown hook "pre-instal", the resource is skipped`,
+				`invalid hook weight "1.5", using 0`,
+				`unknown hook delete policy "on-success"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := Warnings(tt.annotations)
+		if len(got) != len(tt.expect) {
+			t.Errorf("Warnings(%v): expected %q, got %q", tt.annotations, tt.expect, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expect[i] {
+				t.Errorf("Warnings(%v): expected %q, got %q", tt.annotations, tt.expect, got)
+				break
+			}
+		}
+	}
+}
+
+func TestForEvent(t *testing.T) {
+	hooks := []*release.Hook{
+		{Name: "migrate", Weight: 5, Events: []release.Hook_Event{release.Hook_PRE_INSTALL}},
+		{Name: "notify", Weight: -5, Events: []release.Hook_Event{release.Hook_POST_INSTALL}},
+		{Name: "backup", Weight: -10, Events: []release.Hook_Event{release.Hook_PRE_INSTALL, release.Hook_PRE_UPGRADE}},
+		{Name: "check", Weight: 0, Events: []release.Hook_Event{release.Hook_PRE_INSTALL}},
+	}
+
+	expect := []string{"backup", "check", "migrate"}
+	got := ForEvent(hooks, release.Hook_PRE_INSTALL)
+	if len(got) != len(expect) {
+		t.Fatalf("Expected %d hooks, got %d", len(expect), len(got))
+	}
+	for i, h := range got {
+		if h.Name != expect[i] {
+			t.Errorf("Expected %q at %d, got %q", expect[i], i, h.Name)
+		}
+	}
+	if hooks[0].Name != "migrate" {
+		t.Errorf("Expected the hooks given to keep their order, got %q first", hooks[0].Name)
+	}
+}
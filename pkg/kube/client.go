@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"strconv"
 	"strings"
 	"time"
 
@@ -41,7 +42,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	batchinternal "k8s.io/kubernetes/pkg/apis/batch"
 	"k8s.io/kubernetes/pkg/apis/core"
@@ -56,6 +60,10 @@ import (
 const (
 	// MissingGetHeader is added to Get's outout when a resource is not found.
 	MissingGetHeader = "==> MISSING\nKIND\t\tNAME\n"
+
+	// DefaultFieldManager is the field manager of the resources created and
+	// updated by a Client, unless it is configured otherwise.
+	DefaultFieldManager = "helm"
 )
 
 // ErrNoObjectsVisited indicates that during a visit operation, no matching objects were found.
@@ -66,6 +74,9 @@ type Client struct {
 	cmdutil.Factory
 	// SchemaCacheDir is the path for loading cached schema.
 	SchemaCacheDir string
+	// FieldManager is the manager recorded in the managedFields of the
+	// resources the client creates and updates.
+	FieldManager string
 
 	Log func(string, ...interface{})
 }
@@ -75,6 +86,7 @@ func New(config clientcmd.ClientConfig) *Client {
 	return &Client{
 		Factory:        cmdutil.NewFactory(config),
 		SchemaCacheDir: clientcmd.RecommendedSchemaFile,
+		FieldManager:   DefaultFieldManager,
 		Log:            func(_ string, _ ...interface{}) {},
 	}
 }
@@ -86,6 +98,44 @@ type ResourceActorFunc func(*resource.Info) error
 //
 // Namespace will set the namespace.
 func (c *Client) Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	return c.CreateWithOptions(namespace, reader, CreateOptions{Timeout: timeout, ShouldWait: shouldWait})
+}
+
+// CreateAll creates Kubernetes resources from an io.reader like Create, but
+// does not stop at the first resource that fails. Every resource is attempted
+// and the failures are reported together. Resources are not waited for if any
+// of them failed.
+func (c *Client) CreateAll(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	return c.CreateWithOptions(namespace, reader, CreateOptions{Timeout: timeout, ShouldWait: shouldWait, ContinueOnError: true})
+}
+
+// CreateOptions controls how the resources of a release are created.
+type CreateOptions struct {
+	// Timeout is the number of seconds to wait for the resources to be ready.
+	Timeout int64
+	// ShouldWait waits for the resources to be ready.
+	ShouldWait bool
+	// ContinueOnError attempts every resource like CreateAll.
+	ContinueOnError bool
+	// TakeOwnership patches resources that already exist, even if another
+	// release owns them, instead of failing.
+	TakeOwnership bool
+	// CRDs is the manifest of the CustomResourceDefinitions of the release,
+	// created before these resources. Building resources of the kinds they
+	// define is retried for a short while if the kinds are not served yet.
+	CRDs string
+	// Applying, if set, is called with the kind and name of each resource
+	// just before it is created.
+	Applying func(kind, name string)
+	// Waiting, if set, is called when the wait for the resources starts.
+	Waiting func()
+}
+
+// CreateWithOptions creates resources like Create, with the given options.
+//
+// A resource that already exists and is labeled as owned by another release
+// fails with an OwnershipConflictError, unless opts.TakeOwnership is set.
+func (c *Client) CreateWithOptions(namespace string, reader io.Reader, opts CreateOptions) error {
 	client, err := c.ClientSet()
 	if err != nil {
 		return err
@@ -94,16 +144,36 @@ func (c *Client) Create(namespace string, reader io.Reader, timeout int64, shoul
 		return err
 	}
 	c.Log("building resources from manifest")
-	infos, buildErr := c.BuildUnstructured(namespace, reader)
+	timeout := time.Duration(opts.Timeout) * time.Second
+	infos, buildErr := c.buildRetryingNoMatch(namespace, reader, opts.CRDs)
 	if buildErr != nil {
 		return buildErr
 	}
+	if infos, err = orderByDependencies(infos); err != nil {
+		return err
+	}
 	c.Log("creating %d resource(s)", len(infos))
-	if err := perform(infos, createResource); err != nil {
+	create := func(info *resource.Info) error {
+		if err := c.awaitDependencies(info, infos, timeout); err != nil {
+			return err
+		}
+		if opts.Applying != nil {
+			opts.Applying(info.Mapping.GroupVersionKind.Kind, info.Name)
+		}
+		return c.createOrAdopt(info, opts.TakeOwnership)
+	}
+	run := perform
+	if opts.ContinueOnError {
+		run = performAll
+	}
+	if err := run(infos, create); err != nil {
 		return err
 	}
-	if shouldWait {
-		return c.waitForResources(time.Duration(timeout)*time.Second, infos)
+	if opts.ShouldWait {
+		if opts.Waiting != nil {
+			opts.Waiting()
+		}
+		return c.waitForResources(timeout, infos)
 	}
 	return nil
 }
@@ -234,6 +304,42 @@ func (c *Client) Get(namespace string, reader io.Reader) (string, error) {
 //
 // Namespace will set the namespaces.
 func (c *Client) Update(namespace string, originalReader, targetReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
+	return c.UpdateWithOptions(namespace, originalReader, targetReader, UpdateOptions{
+		Force:      force,
+		Recreate:   recreate,
+		Timeout:    timeout,
+		ShouldWait: shouldWait,
+	})
+}
+
+// UpdateOptions controls how the resources of a release are updated.
+type UpdateOptions struct {
+	// Force replaces resources that cannot be patched.
+	Force bool
+	// Recreate restarts the pods of updated workloads.
+	Recreate bool
+	// Timeout is the number of seconds to wait for the resources to be ready.
+	Timeout int64
+	// ShouldWait waits for the resources to be ready.
+	ShouldWait bool
+	// CleanupOnFail deletes the resources created by a failed update. The
+	// resources that already existed are left as they are.
+	CleanupOnFail bool
+	// TakeOwnership patches resources that exist but are not part of the
+	// original release, or are owned by another release, instead of failing.
+	TakeOwnership bool
+	// Applying, if set, is called with the kind and name of each resource
+	// just before it is created or updated.
+	Applying func(kind, name string)
+	// Waiting, if set, is called when the wait for the resources starts.
+	Waiting func()
+}
+
+// UpdateWithOptions updates resources like Update, with the given options.
+//
+// A resource that is labeled as owned by another release fails with an
+// OwnershipConflictError, unless opts.TakeOwnership is set.
+func (c *Client) UpdateWithOptions(namespace string, originalReader, targetReader io.Reader, opts UpdateOptions) error {
 	original, err := c.BuildUnstructured(namespace, originalReader)
 	if err != nil {
 		return fmt.Errorf("failed decoding reader into objects: %s", err)
@@ -245,7 +351,12 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 		return fmt.Errorf("failed decoding reader into objects: %s", err)
 	}
 
+	if target, err = orderByDependencies(target); err != nil {
+		return err
+	}
+
 	updateErrors := []string{}
+	var created Result
 
 	c.Log("checking %d resources for changes", len(target))
 	err = target.Visit(func(info *resource.Info, err error) error {
@@ -254,15 +365,21 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 		}
 
 		helper := resource.NewHelper(info.Client, info.Mapping)
-		if _, err := helper.Get(info.Namespace, info.Name, info.Export); err != nil {
+		live, err := helper.Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
 			if !errors.IsNotFound(err) {
 				return fmt.Errorf("Could not get information about the resource: %s", err)
 			}
 
 			// Since the resource does not exist, create it.
-			if err := createResource(info); err != nil {
+			if err := c.awaitDependencies(info, target, time.Duration(opts.Timeout)*time.Second); err != nil {
+				return err
+			}
+			opts.applying(info)
+			if err := c.createResource(info); err != nil {
 				return fmt.Errorf("failed to create resource: %s", err)
 			}
+			created.Append(info)
 
 			kind := info.Mapping.GroupVersionKind.Kind
 			c.Log("Created a new %s called %q\n", kind, info.Name)
@@ -270,12 +387,26 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 		}
 
 		originalInfo := original.Get(info)
+		if !opts.TakeOwnership {
+			if err := checkOwnership(info, live, originalInfo != nil); err != nil {
+				return err
+			}
+		}
+
 		if originalInfo == nil {
+			if opts.TakeOwnership {
+				opts.applying(info)
+				if err := c.adoptResource(info); err != nil {
+					updateErrors = append(updateErrors, err.Error())
+				}
+				return nil
+			}
 			kind := info.Mapping.GroupVersionKind.Kind
 			return fmt.Errorf("no %s with the name %q found", kind, info.Name)
 		}
 
-		if err := updateResource(c, info, originalInfo.Object, force, recreate); err != nil {
+		opts.applying(info)
+		if err := updateResource(c, info, originalInfo.Object, opts.Force, opts.Recreate); err != nil {
 			c.Log("error updating the resource %q:\n\t %v", info.Name, err)
 			updateErrors = append(updateErrors, err.Error())
 		}
@@ -285,9 +416,9 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 
 	switch {
 	case err != nil:
-		return err
+		return c.cleanupFailedUpdate(err, created, opts.CleanupOnFail)
 	case len(updateErrors) != 0:
-		return fmt.Errorf(strings.Join(updateErrors, " && "))
+		return c.cleanupFailedUpdate(fmt.Errorf(strings.Join(updateErrors, " && ")), created, opts.CleanupOnFail)
 	}
 
 	for _, info := range original.Difference(target) {
@@ -296,27 +427,194 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 			c.Log("Failed to delete %q, err: %s", info.Name, err)
 		}
 	}
-	if shouldWait {
-		return c.waitForResources(time.Duration(timeout)*time.Second, target)
+	if opts.ShouldWait {
+		if opts.Waiting != nil {
+			opts.Waiting()
+		}
+		if err := c.waitForResources(time.Duration(opts.Timeout)*time.Second, target); err != nil {
+			return c.cleanupFailedUpdate(err, created, opts.CleanupOnFail)
+		}
 	}
 	return nil
 }
 
+// applying reports info to opts.Applying, if it is set.
+func (opts UpdateOptions) applying(info *resource.Info) {
+	if opts.Applying != nil {
+		opts.Applying(info.Mapping.GroupVersionKind.Kind, info.Name)
+	}
+}
+
+// cleanupFailedUpdate deletes the resources created by an update that failed
+// with err, if cleanup is set, and returns err along with the failures of the
+// cleanup.
+func (c *Client) cleanupFailedUpdate(err error, created Result, cleanup bool) error {
+	if !cleanup || len(created) == 0 {
+		return err
+	}
+	c.Log("update failed, deleting the %d resources it created", len(created))
+	errs := []string{err.Error()}
+	for _, info := range created {
+		c.Log("Deleting %q in %s...", info.Name, info.Namespace)
+		if err := c.skipIfNotFound(deleteResource(c, info)); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to delete %s %q: %s", info.Mapping.GroupVersionKind.Kind, info.Name, err))
+		}
+	}
+	return goerrors.New(strings.Join(errs, " && "))
+}
+
+// DryRunCreate sends the resources in reader to the API server as a
+// server-side dry run, so that schema validation and admission control are
+// applied without persisting anything. Every rejected resource is reported.
+//
+// Requests are made with the identity of the client configuration, including
+// any impersonation it sets up.
+func (c *Client) DryRunCreate(namespace string, reader io.Reader) error {
+	client, err := c.ClientSet()
+	if err != nil {
+		return err
+	}
+	info, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return fmt.Errorf("could not get the server version: %s", err)
+	}
+	// Older API servers ignore the dryRun parameter and would create the
+	// resources, so refuse to validate against them.
+	if !supportsDryRun(info) {
+		return fmt.Errorf("server-side dry run requires Kubernetes 1.13 or later, the server is %s", info.GitVersion)
+	}
+
+	infos, err := c.BuildUnstructured(namespace, reader)
+	if err != nil {
+		return err
+	}
+	c.Log("validating %d resource(s) with a server-side dry run", len(infos))
+	return dryRunCreate(infos)
+}
+
+func dryRunCreate(infos Result) error {
+	if len(infos) == 0 {
+		return ErrNoObjectsVisited
+	}
+
+	var errs []string
+	for _, info := range infos {
+		if err := dryRunCreateResource(info); err != nil {
+			errs = append(errs, fmt.Sprintf("%s %q: %s", info.Mapping.GroupVersionKind.Kind, info.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d resource(s) rejected by the server:\n%s", len(errs), strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func dryRunCreateResource(info *resource.Info) error {
+	helper := resource.NewHelper(info.Client, info.Mapping)
+	return helper.RESTClient.Post().
+		NamespaceIfScoped(info.Namespace, helper.NamespaceScoped).
+		Resource(helper.Resource).
+		Param("dryRun", "All").
+		Body(info.Object).
+		Do().
+		Error()
+}
+
+// supportsDryRun reports whether the API server honours the dryRun parameter.
+func supportsDryRun(info *version.Info) bool {
+	major, err := strconv.Atoi(strings.TrimSuffix(info.Major, "+"))
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(strings.TrimSuffix(info.Minor, "+"))
+	if err != nil {
+		return false
+	}
+	return major > 1 || (major == 1 && minor >= 13)
+}
+
 // Delete deletes Kubernetes resources from an io.reader.
 //
 // Namespace will set the namespace.
 func (c *Client) Delete(namespace string, reader io.Reader) error {
+	return c.DeleteWithOptions(namespace, reader, nil)
+}
+
+// DeleteOptions controls how the resources of a release are deleted.
+type DeleteOptions struct {
+	// Cascade is the propagation policy for dependents of each resource:
+	// Foreground, Background or Orphan. Empty uses the server default.
+	Cascade string
+	// GracePeriod is the number of seconds each resource is given to
+	// terminate. Zero uses the default of each resource.
+	GracePeriod int64
+}
+
+// DeleteWithOptions deletes Kubernetes resources from an io.reader using the
+// given propagation policy and grace period.
+//
+// When opts is nil the resources are deleted as Delete does, scaling down
+// workloads first where a reaper exists.
+func (c *Client) DeleteWithOptions(namespace string, reader io.Reader, opts *DeleteOptions) error {
+	var deleteOpts *metav1.DeleteOptions
+	if opts != nil {
+		var err error
+		if deleteOpts, err = opts.toDeleteOptions(); err != nil {
+			return err
+		}
+	}
 	infos, err := c.BuildUnstructured(namespace, reader)
 	if err != nil {
 		return err
 	}
 	return perform(infos, func(info *resource.Info) error {
 		c.Log("Starting delete for %q %s", info.Name, info.Mapping.GroupVersionKind.Kind)
-		err := deleteResource(c, info)
+		var err error
+		if deleteOpts != nil {
+			err = deleteResourceWithOptions(info, deleteOpts)
+		} else {
+			err = deleteResource(c, info)
+		}
 		return c.skipIfNotFound(err)
 	})
 }
 
+// Validate checks that the cascade and grace period are acceptable.
+func (o *DeleteOptions) Validate() error {
+	_, err := o.toDeleteOptions()
+	return err
+}
+
+// toDeleteOptions validates the options and converts them for the API server.
+func (o *DeleteOptions) toDeleteOptions() (*metav1.DeleteOptions, error) {
+	if o.GracePeriod < 0 {
+		return nil, fmt.Errorf("invalid grace period %d: must not be negative", o.GracePeriod)
+	}
+	opts := &metav1.DeleteOptions{
+		TypeMeta: metav1.TypeMeta{Kind: "DeleteOptions", APIVersion: "v1"},
+	}
+	if o.GracePeriod > 0 {
+		opts.GracePeriodSeconds = &o.GracePeriod
+	}
+	if o.Cascade != "" {
+		policy, err := propagationPolicy(o.Cascade)
+		if err != nil {
+			return nil, err
+		}
+		opts.PropagationPolicy = &policy
+	}
+	return opts, nil
+}
+
+func propagationPolicy(cascade string) (metav1.DeletionPropagation, error) {
+	for _, p := range []metav1.DeletionPropagation{metav1.DeletePropagationForeground, metav1.DeletePropagationBackground, metav1.DeletePropagationOrphan} {
+		if strings.EqualFold(cascade, string(p)) {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("invalid cascade %q: expected Foreground, Background or Orphan", cascade)
+}
+
 func (c *Client) skipIfNotFound(err error) error {
 	if errors.IsNotFound(err) {
 		c.Log("%v", err)
@@ -325,9 +623,9 @@ func (c *Client) skipIfNotFound(err error) error {
 	return err
 }
 
-func (c *Client) watchTimeout(t time.Duration) ResourceActorFunc {
+func (c *Client) watchTimeout(t time.Duration, shouldWait bool) ResourceActorFunc {
 	return func(info *resource.Info) error {
-		return c.watchUntilReady(t, info)
+		return c.watchUntilReady(t, info, shouldWait)
 	}
 }
 
@@ -342,6 +640,9 @@ func (c *Client) watchTimeout(t time.Duration) ResourceActorFunc {
 // - Jobs: A job is marked "Ready" when it has successfully completed. This is
 //   ascertained by watching the Status fields in a job's output.
 //
+// - Pods: If shouldWait is set, a pod is marked "Ready" when it has
+//   successfully completed. A failed pod is an error.
+//
 // Handling for other kinds will be added as necessary.
 func (c *Client) WatchUntilReady(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
 	infos, err := c.Build(namespace, reader)
@@ -350,7 +651,56 @@ func (c *Client) WatchUntilReady(namespace string, reader io.Reader, timeout int
 	}
 	// For jobs, there's also the option to do poll c.Jobs(namespace).Get():
 	// https://github.com/adamreese/kubernetes/blob/master/test/e2e/job.go#L291-L300
-	return perform(infos, c.watchTimeout(time.Duration(timeout)*time.Second))
+	return perform(infos, c.watchTimeout(time.Duration(timeout)*time.Second, shouldWait))
+}
+
+// WaitUntilCRDEstablished polls the CustomResourceDefinitions given in the
+// reader until all of them are established, so that resources of the kinds
+// they define can be created.
+func (c *Client) WaitUntilCRDEstablished(reader io.Reader, timeout time.Duration) error {
+	infos, err := c.BuildUnstructured(metav1.NamespaceAll, reader)
+	if err != nil {
+		return err
+	}
+	return perform(infos, c.pollCRDEstablished(timeout))
+}
+
+func (c *Client) pollCRDEstablished(t time.Duration) ResourceActorFunc {
+	return func(info *resource.Info) error {
+		c.Log("Waiting for CustomResourceDefinition %s to be established with timeout of %v", info.Name, t)
+		return wait.PollImmediate(time.Second, t, func() (bool, error) {
+			if err := info.Get(); err != nil {
+				return false, fmt.Errorf("unable to get CustomResourceDefinition %s: %s", info.Name, err)
+			}
+			obj, ok := info.Object.(*unstructured.Unstructured)
+			if !ok {
+				return false, fmt.Errorf("%s is not a CustomResourceDefinition", info.Name)
+			}
+			return crdEstablished(obj.Object)
+		})
+	}
+}
+
+// crdEstablished reports whether the status conditions of a
+// CustomResourceDefinition mark it as established. It fails when the API
+// server did not accept the names of the definition, as it never will be.
+func crdEstablished(crd map[string]interface{}) (bool, error) {
+	status, _ := crd["status"].(map[string]interface{})
+	conds, _ := status["conditions"].([]interface{})
+	for _, c := range conds {
+		cond, _ := c.(map[string]interface{})
+		switch cond["type"] {
+		case "Established":
+			if cond["status"] == "True" {
+				return true, nil
+			}
+		case "NamesAccepted":
+			if cond["status"] == "False" {
+				return false, fmt.Errorf("naming conflict detected for CustomResourceDefinition: %v", cond["message"])
+			}
+		}
+	}
+	return false, nil
 }
 
 func perform(infos Result, fn ResourceActorFunc) error {
@@ -366,14 +716,50 @@ func perform(infos Result, fn ResourceActorFunc) error {
 	return nil
 }
 
-func createResource(info *resource.Info) error {
-	obj, err := resource.NewHelper(info.Client, info.Mapping).Create(info.Namespace, true, info.Object)
+// performAll runs fn on every resource, continuing past failures. The failures
+// are returned as a single error naming each resource that failed.
+func performAll(infos Result, fn ResourceActorFunc) error {
+	if len(infos) == 0 {
+		return ErrNoObjectsVisited
+	}
+
+	var errs []string
+	for _, info := range infos {
+		if err := fn(info); err != nil {
+			errs = append(errs, fmt.Sprintf("%s %q: %s", info.Mapping.GroupVersionKind.Kind, info.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d resource(s) failed:\n%s", len(errs), len(infos), strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func (c *Client) createResource(info *resource.Info) error {
+	if err := meta.NewAccessor().SetResourceVersion(info.Object, ""); err != nil {
+		return err
+	}
+	helper := resource.NewHelper(info.Client, info.Mapping)
+	obj, err := c.withFieldManager(helper.RESTClient.Post()).
+		NamespaceIfScoped(info.Namespace, helper.NamespaceScoped).
+		Resource(helper.Resource).
+		Body(info.Object).
+		Do().
+		Get()
 	if err != nil {
 		return err
 	}
 	return info.Refresh(obj, true)
 }
 
+// withFieldManager sets the field manager of a create or patch request.
+func (c *Client) withFieldManager(req *rest.Request) *rest.Request {
+	if c.FieldManager == "" {
+		return req
+	}
+	return req.Param("fieldManager", c.FieldManager)
+}
+
 func deleteResource(c *Client, info *resource.Info) error {
 	reaper, err := c.Reaper(info.Mapping)
 	if err != nil {
@@ -387,6 +773,25 @@ func deleteResource(c *Client, info *resource.Info) error {
 	return reaper.Stop(info.Namespace, info.Name, 0, nil)
 }
 
+// deleteResourceWithOptions deletes the resource directly rather than through
+// a reaper, so that the API server applies the propagation policy. Reapers
+// scale workloads down first, which would remove dependents even for Orphan.
+func deleteResourceWithOptions(info *resource.Info, opts *metav1.DeleteOptions) error {
+	body, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+	helper := resource.NewHelper(info.Client, info.Mapping)
+	return helper.RESTClient.Delete().
+		NamespaceIfScoped(info.Namespace, helper.NamespaceScoped).
+		Resource(helper.Resource).
+		Name(info.Name).
+		SetHeader("Content-Type", "application/json").
+		Body(body).
+		Do().
+		Error()
+}
+
 func createPatch(mapping *meta.RESTMapping, target, current runtime.Object) ([]byte, types.PatchType, error) {
 	oldData, err := json.Marshal(current)
 	if err != nil {
@@ -434,7 +839,13 @@ func updateResource(c *Client, target *resource.Info, currentObj runtime.Object,
 	// send patch to server
 	helper := resource.NewHelper(target.Client, target.Mapping)
 
-	obj, err := helper.Patch(target.Namespace, target.Name, patchType, patch)
+	obj, err := c.withFieldManager(helper.RESTClient.Patch(patchType)).
+		NamespaceIfScoped(target.Namespace, helper.NamespaceScoped).
+		Resource(helper.Resource).
+		Name(target.Name).
+		Body(patch).
+		Do().
+		Get()
 	if err != nil {
 		kind := target.Mapping.GroupVersionKind.Kind
 		log.Printf("Cannot patch %s: %q (%v)", kind, target.Name, err)
@@ -447,7 +858,7 @@ func updateResource(c *Client, target *resource.Info, currentObj runtime.Object,
 			log.Printf("Deleted %s: %q", kind, target.Name)
 
 			// ... and recreate
-			if err := createResource(target); err != nil {
+			if err := c.createResource(target); err != nil {
 				return fmt.Errorf("Failed to recreate resource: %s", err)
 			}
 			log.Printf("Created a new %s called %q\n", kind, target.Name)
@@ -525,7 +936,7 @@ func getSelectorFromObject(obj runtime.Object) (map[string]string, error) {
 	}
 }
 
-func (c *Client) watchUntilReady(timeout time.Duration, info *resource.Info) error {
+func (c *Client) watchUntilReady(timeout time.Duration, info *resource.Info, shouldWait bool) error {
 	w, err := resource.NewHelper(info.Client, info.Mapping).WatchSingle(info.Namespace, info.Name, info.ResourceVersion)
 	if err != nil {
 		return err
@@ -536,6 +947,7 @@ func (c *Client) watchUntilReady(timeout time.Duration, info *resource.Info) err
 
 	// What we watch for depends on the Kind.
 	// - For a Job, we watch for completion.
+	// - For a Pod, we watch for completion if shouldWait is set.
 	// - For all else, we watch until Ready.
 	// In the future, we might want to add some special logic for types
 	// like Ingress, Volume, etc.
@@ -551,6 +963,9 @@ func (c *Client) watchUntilReady(timeout time.Duration, info *resource.Info) err
 			if kind == "Job" {
 				return c.waitForJob(e, info.Name)
 			}
+			if kind == "Pod" && shouldWait {
+				return c.waitForPod(e, info.Name)
+			}
 			return true, nil
 		case watch.Deleted:
 			c.Log("Deleted event for %s", info.Name)
@@ -598,6 +1013,26 @@ func (c *Client) waitForJob(e watch.Event, name string) (bool, error) {
 	return false, nil
 }
 
+// waitForPod is a helper that waits for a pod to complete.
+//
+// This operates on an event returned from a watcher.
+func (c *Client) waitForPod(e watch.Event, name string) (bool, error) {
+	o, ok := e.Object.(*core.Pod)
+	if !ok {
+		return true, fmt.Errorf("Expected %s to be a *core.Pod, got %T", name, e.Object)
+	}
+
+	switch o.Status.Phase {
+	case core.PodSucceeded:
+		return true, nil
+	case core.PodFailed:
+		return true, fmt.Errorf("Pod %s failed", name)
+	}
+
+	c.Log("%s: Pod phase: %s", name, o.Status.Phase)
+	return false, nil
+}
+
 // scrubValidationError removes kubectl info from the message.
 func scrubValidationError(err error) error {
 	if err == nil {
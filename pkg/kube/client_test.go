@@ -29,8 +29,10 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest/fake"
@@ -230,6 +232,276 @@ func TestUpdate(t *testing.T) {
 
 }
 
+const cleanupOriginalManifest = `apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: web
+  namespace: default
+spec:
+  replicas: 1
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx
+`
+
+const cleanupTargetManifest = `apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: web
+  namespace: default
+spec:
+  replicas: 2
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web-lb
+  namespace: default
+spec:
+  selector:
+    app: web
+  ports:
+  - port: 80
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: broken
+  namespace: default
+spec:
+  containers:
+  - name: broken
+    image: nginx
+`
+
+type recordingReaper struct {
+	names []string
+}
+
+func (r *recordingReaper) Stop(namespace, name string, timeout time.Duration, gracePeriod *metav1.DeleteOptions) error {
+	r.names = append(r.names, name)
+	return nil
+}
+
+func TestUpdateCleanupOnFail(t *testing.T) {
+	deployment := `{"apiVersion":"extensions/v1beta1","kind":"Deployment","metadata":{"name":"web","namespace":"default"},"spec":{"replicas":2}}`
+	service := &core.Service{ObjectMeta: metav1.ObjectMeta{Name: "web-lb", Namespace: "default"}}
+
+	for _, cleanup := range []bool{false, true} {
+		f, tf, _, _ := cmdtesting.NewAPIFactory()
+		tf.UnstructuredClient = &fake.RESTClient{
+			GroupVersion:         schema.GroupVersion{Version: "v1"},
+			NegotiatedSerializer: dynamic.ContentConfig().NegotiatedSerializer,
+			Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+				p, m := req.URL.Path, req.Method
+				switch {
+				case p == "/namespaces/default/deployments/web" && (m == "GET" || m == "PATCH"):
+					header := http.Header{}
+					header.Set("Content-Type", runtime.ContentTypeJSON)
+					return &http.Response{StatusCode: http.StatusOK, Header: header, Body: ioutil.NopCloser(strings.NewReader(deployment))}, nil
+				case p == "/namespaces/default/services/web-lb" && m == "GET":
+					return newResponse(http.StatusNotFound, notFoundBody())
+				case p == "/namespaces/default/services" && m == "POST":
+					return newResponse(http.StatusCreated, service)
+				case p == "/namespaces/default/pods/broken" && m == "GET":
+					return newResponse(http.StatusNotFound, notFoundBody())
+				case p == "/namespaces/default/pods" && m == "POST":
+					return newResponse(http.StatusInternalServerError, &metav1.Status{
+						Status:  metav1.StatusFailure,
+						Code:    http.StatusInternalServerError,
+						Message: "the pod could not be created",
+					})
+				default:
+					t.Fatalf("unexpected request: %s %s", m, p)
+					return nil, nil
+				}
+			}),
+		}
+
+		reaper := &recordingReaper{}
+		c := newTestClient(&fakeReaperFactory{Factory: f, reaper: reaper})
+		err := c.UpdateWithOptions(core.NamespaceDefault, strings.NewReader(cleanupOriginalManifest), strings.NewReader(cleanupTargetManifest), UpdateOptions{CleanupOnFail: cleanup})
+		if err == nil {
+			t.Fatal("expected the update to fail")
+		}
+
+		// Only the Service was created by the update. The Deployment already
+		// existed and must be kept either way.
+		var expect []string
+		if cleanup {
+			expect = []string{"web-lb"}
+		}
+		if strings.Join(reaper.names, ",") != strings.Join(expect, ",") {
+			t.Errorf("cleanup=%t: expected %v to be deleted, got %v", cleanup, expect, reaper.names)
+		}
+	}
+}
+
+const ownershipOriginalManifest = `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: default
+  labels:
+    helm.sh/release: mine
+spec:
+  ports:
+  - port: 80
+`
+
+const ownershipTargetManifest = `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: default
+  labels:
+    helm.sh/release: mine
+spec:
+  ports:
+  - port: 80
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: shared
+  namespace: default
+  labels:
+    helm.sh/release: mine
+spec:
+  ports:
+  - port: 80
+`
+
+func TestUpdateOwnership(t *testing.T) {
+	web := `{"apiVersion":"v1","kind":"Service","metadata":{"name":"web","namespace":"default","labels":{"helm.sh/release":"mine"}},"spec":{"ports":[{"port":80}]}}`
+	shared := `{"apiVersion":"v1","kind":"Service","metadata":{"name":"shared","namespace":"default","labels":{"heritage":"Tiller","release":"theirs"}},"spec":{"ports":[{"port":80}]}}`
+
+	for _, take := range []bool{false, true} {
+		var patched []string
+		f, tf, _, _ := cmdtesting.NewAPIFactory()
+		tf.UnstructuredClient = &fake.RESTClient{
+			GroupVersion:         schema.GroupVersion{Version: "v1"},
+			NegotiatedSerializer: dynamic.ContentConfig().NegotiatedSerializer,
+			Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+				p, m := req.URL.Path, req.Method
+				header := http.Header{}
+				header.Set("Content-Type", runtime.ContentTypeJSON)
+				switch {
+				case p == "/namespaces/default/services/web" && m == "GET":
+					return &http.Response{StatusCode: http.StatusOK, Header: header, Body: ioutil.NopCloser(strings.NewReader(web))}, nil
+				case p == "/namespaces/default/services/shared" && m == "GET":
+					return &http.Response{StatusCode: http.StatusOK, Header: header, Body: ioutil.NopCloser(strings.NewReader(shared))}, nil
+				case p == "/namespaces/default/services/shared" && m == "PATCH":
+					data, err := ioutil.ReadAll(req.Body)
+					if err != nil {
+						t.Fatalf("could not dump request: %s", err)
+					}
+					req.Body.Close()
+					patched = append(patched, string(data))
+					return &http.Response{StatusCode: http.StatusOK, Header: header, Body: ioutil.NopCloser(strings.NewReader(shared))}, nil
+				default:
+					t.Fatalf("unexpected request: %s %s", m, p)
+					return nil, nil
+				}
+			}),
+		}
+
+		c := newTestClient(f)
+		err := c.UpdateWithOptions(core.NamespaceDefault, strings.NewReader(ownershipOriginalManifest), strings.NewReader(ownershipTargetManifest), UpdateOptions{TakeOwnership: take})
+		if !take {
+			if !IsOwnershipConflict(err) {
+				t.Fatalf("expected an ownership conflict, got %v", err)
+			}
+			if !strings.Contains(err.Error(), `Service "shared" is owned by release "theirs", not "mine"`) {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if len(patched) != 0 {
+				t.Errorf("expected the shared service not to be patched, got %v", patched)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("expected the shared service to be adopted, got %s", err)
+		}
+		if len(patched) != 1 || !strings.Contains(patched[0], `"helm.sh/release":"mine"`) {
+			t.Errorf("expected the shared service to be patched with the release label, got %v", patched)
+		}
+	}
+}
+
+func TestReleaseOwner(t *testing.T) {
+	tests := []struct {
+		labels map[string]string
+		owner  string
+	}{
+		{map[string]string{"helm.sh/release": "a", "release": "b", "heritage": "Tiller"}, "a"},
+		{map[string]string{"app.kubernetes.io/managed-by": "Helm", "app.kubernetes.io/instance": "a"}, "a"},
+		{map[string]string{"release": "a", "heritage": "Tiller"}, "a"},
+		{map[string]string{"release": "a"}, ""},
+		{map[string]string{"app.kubernetes.io/instance": "a"}, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		obj := &unstructured.Unstructured{}
+		obj.SetLabels(tt.labels)
+		if owner := releaseOwner(obj); owner != tt.owner {
+			t.Errorf("labels %v: expected owner %q, got %q", tt.labels, tt.owner, owner)
+		}
+	}
+}
+
+func TestCheckOwnership(t *testing.T) {
+	mine := map[string]string{"helm.sh/release": "mine"}
+	theirs := map[string]string{"helm.sh/release": "theirs"}
+	tests := []struct {
+		rendered, live map[string]string
+		inRelease      bool
+		conflict       string
+	}{
+		{mine, mine, false, ""},
+		{mine, theirs, true, `Service "web" is owned by release "theirs", not "mine"`},
+		{mine, nil, false, `Service "web" already exists and is not owned by any release`},
+		// Resources of releases made before they were labeled.
+		{mine, nil, true, ""},
+		{nil, nil, false, `Service "web" already exists and is not owned by any release`},
+		{nil, theirs, false, ""},
+	}
+	for _, tt := range tests {
+		rendered, live := &unstructured.Unstructured{}, &unstructured.Unstructured{}
+		rendered.SetLabels(tt.rendered)
+		live.SetLabels(tt.live)
+		info := &resource.Info{
+			Name:    "web",
+			Object:  rendered,
+			Mapping: &meta.RESTMapping{GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Service"}},
+		}
+		err := checkOwnership(info, live, tt.inRelease)
+		if tt.conflict == "" {
+			if err != nil {
+				t.Errorf("labels %v over %v: expected no conflict, got %s", tt.rendered, tt.live, err)
+			}
+			continue
+		}
+		if !IsOwnershipConflict(err) || !strings.Contains(err.Error(), tt.conflict) {
+			t.Errorf("labels %v over %v: expected conflict %q, got %v", tt.rendered, tt.live, tt.conflict, err)
+		}
+	}
+}
+
 func TestBuild(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -269,6 +541,255 @@ func TestBuild(t *testing.T) {
 	}
 }
 
+func TestDryRunCreate(t *testing.T) {
+	list := newPodList("starfish", "otter")
+	var actions []string
+
+	f, tf, codec, _ := cmdtesting.NewAPIFactory()
+	tf.UnstructuredClient = &fake.RESTClient{
+		GroupVersion:         schema.GroupVersion{Version: "v1"},
+		NegotiatedSerializer: dynamic.ContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			actions = append(actions, p+":"+m+":"+req.URL.Query().Get("dryRun"))
+			if p != "/namespaces/default/pods" || m != "POST" {
+				t.Fatalf("unexpected request: %s %s", m, p)
+				return nil, nil
+			}
+			data, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				t.Fatalf("could not dump request: %s", err)
+			}
+			req.Body.Close()
+			if strings.Contains(string(data), `"name":"otter"`) {
+				return newResponse(http.StatusBadRequest, &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Code:    http.StatusBadRequest,
+					Reason:  metav1.StatusReasonBadRequest,
+					Message: `admission webhook "policy.example.com" denied the request: otters are not allowed`,
+				})
+			}
+			return newResponse(http.StatusCreated, &list.Items[0])
+		}),
+	}
+
+	c := newTestClient(f)
+	infos, err := c.BuildUnstructured(core.NamespaceDefault, objBody(codec, &list))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = dryRunCreate(infos)
+	if err == nil {
+		t.Fatal("expected the otter pod to be rejected")
+	}
+	if !strings.Contains(err.Error(), `Pod "otter": admission webhook "policy.example.com" denied the request`) {
+		t.Errorf("expected the rejection of otter to be reported, got %q", err)
+	}
+	if strings.Contains(err.Error(), "starfish") {
+		t.Errorf("expected starfish to be accepted, got %q", err)
+	}
+
+	expectedActions := []string{
+		"/namespaces/default/pods:POST:All",
+		"/namespaces/default/pods:POST:All",
+	}
+	if strings.Join(actions, ",") != strings.Join(expectedActions, ",") {
+		t.Errorf("expected requests %v, got %v", expectedActions, actions)
+	}
+}
+
+func TestDeleteWithOptions(t *testing.T) {
+	list := newPodList("starfish", "otter")
+	var bodies []string
+
+	f, tf, codec, _ := cmdtesting.NewAPIFactory()
+	tf.UnstructuredClient = &fake.RESTClient{
+		GroupVersion:         schema.GroupVersion{Version: "v1"},
+		NegotiatedSerializer: dynamic.ContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			if m != "DELETE" || (p != "/namespaces/default/pods/starfish" && p != "/namespaces/default/pods/otter") {
+				t.Fatalf("unexpected request: %s %s", m, p)
+				return nil, nil
+			}
+			data, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				t.Fatalf("could not dump request: %s", err)
+			}
+			req.Body.Close()
+			bodies = append(bodies, string(data))
+			return newResponse(http.StatusOK, &metav1.Status{Status: metav1.StatusSuccess})
+		}),
+	}
+
+	c := newTestClient(f)
+	opts := &DeleteOptions{Cascade: "orphan", GracePeriod: 30}
+	if err := c.DeleteWithOptions(core.NamespaceDefault, objBody(codec, &list), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 delete requests, got %d", len(bodies))
+	}
+	for _, body := range bodies {
+		var got metav1.DeleteOptions
+		if err := json.Unmarshal([]byte(body), &got); err != nil {
+			t.Fatalf("could not decode delete options %q: %s", body, err)
+		}
+		if got.PropagationPolicy == nil || *got.PropagationPolicy != metav1.DeletePropagationOrphan {
+			t.Errorf("expected propagation policy Orphan, got %s", body)
+		}
+		if got.GracePeriodSeconds == nil || *got.GracePeriodSeconds != 30 {
+			t.Errorf("expected grace period 30, got %s", body)
+		}
+	}
+}
+
+func TestDeleteOptionsInvalid(t *testing.T) {
+	for _, opts := range []DeleteOptions{{Cascade: "sideways"}, {GracePeriod: -1}} {
+		if _, err := opts.toDeleteOptions(); err == nil {
+			t.Errorf("expected %+v to be rejected", opts)
+		}
+	}
+	for _, cascade := range []string{"Foreground", "background", "ORPHAN"} {
+		opts := DeleteOptions{Cascade: cascade}
+		got, err := opts.toDeleteOptions()
+		if err != nil {
+			t.Errorf("expected cascade %q to be accepted, got %s", cascade, err)
+			continue
+		}
+		if !strings.EqualFold(string(*got.PropagationPolicy), cascade) {
+			t.Errorf("expected propagation policy %q, got %q", cascade, *got.PropagationPolicy)
+		}
+		if got.GracePeriodSeconds != nil {
+			t.Errorf("expected no grace period, got %d", *got.GracePeriodSeconds)
+		}
+	}
+}
+
+func TestPerformAll(t *testing.T) {
+	list := newPodList("starfish", "otter", "squid")
+	var created []string
+
+	f, tf, codec, _ := cmdtesting.NewAPIFactory()
+	tf.UnstructuredClient = &fake.RESTClient{
+		GroupVersion:         schema.GroupVersion{Version: "v1"},
+		NegotiatedSerializer: dynamic.ContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			if p != "/namespaces/default/pods" || m != "POST" {
+				t.Fatalf("unexpected request: %s %s", m, p)
+				return nil, nil
+			}
+			data, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				t.Fatalf("could not dump request: %s", err)
+			}
+			req.Body.Close()
+			for _, pod := range list.Items {
+				if !strings.Contains(string(data), fmt.Sprintf(`"name":%q`, pod.Name)) {
+					continue
+				}
+				if pod.Name == "otter" {
+					return newResponse(http.StatusConflict, &metav1.Status{
+						Status:  metav1.StatusFailure,
+						Code:    http.StatusConflict,
+						Reason:  metav1.StatusReasonAlreadyExists,
+						Message: `pods "otter" already exists`,
+					})
+				}
+				created = append(created, pod.Name)
+				return newResponse(http.StatusCreated, &pod)
+			}
+			t.Fatalf("unexpected pod: %s", data)
+			return nil, nil
+		}),
+	}
+
+	c := newTestClient(f)
+	infos, err := c.BuildUnstructured(core.NamespaceDefault, objBody(codec, &list))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = performAll(infos, c.createResource)
+	if err == nil {
+		t.Fatal("expected the otter pod to fail")
+	}
+	if !strings.HasPrefix(err.Error(), "1 of 3 resource(s) failed") || !strings.Contains(err.Error(), `Pod "otter": pods "otter" already exists`) {
+		t.Errorf("expected the failure of otter to be reported, got %q", err)
+	}
+	if strings.Join(created, ",") != "starfish,squid" {
+		t.Errorf("expected the other pods to be created, got %v", created)
+	}
+}
+
+func TestFieldManager(t *testing.T) {
+	listA := newPodList("starfish")
+	listB := newPodList("starfish", "otter")
+	listB.Items[0].Spec.Containers[0].Image = "abc/app:v5"
+
+	var managers []string
+
+	f, tf, codec, _ := cmdtesting.NewAPIFactory()
+	tf.UnstructuredClient = &fake.RESTClient{
+		GroupVersion:         schema.GroupVersion{Version: "v1"},
+		NegotiatedSerializer: dynamic.ContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(200, &listA.Items[0])
+			case p == "/namespaces/default/pods/otter" && m == "GET":
+				return newResponse(404, notFoundBody())
+			case p == "/namespaces/default/pods/starfish" && m == "PATCH":
+				managers = append(managers, m+":"+req.URL.Query().Get("fieldManager"))
+				return newResponse(200, &listB.Items[0])
+			case p == "/namespaces/default/pods" && m == "POST":
+				managers = append(managers, m+":"+req.URL.Query().Get("fieldManager"))
+				return newResponse(201, &listB.Items[1])
+			default:
+				t.Fatalf("unexpected request: %s %s", m, p)
+				return nil, nil
+			}
+		}),
+	}
+
+	c := newTestClient(f)
+	if c.FieldManager != DefaultFieldManager {
+		t.Errorf("expected the default field manager %q, got %q", DefaultFieldManager, c.FieldManager)
+	}
+	c.FieldManager = "ci-deployer"
+	if err := c.Update(core.NamespaceDefault, objBody(codec, &listA), objBody(codec, &listB), false, false, 0, false); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := "PATCH:ci-deployer,POST:ci-deployer"
+	if got := strings.Join(managers, ","); got != expect {
+		t.Errorf("expected requests %s, got %s", expect, got)
+	}
+}
+
+func TestSupportsDryRun(t *testing.T) {
+	tests := []struct {
+		major, minor string
+		expect       bool
+	}{
+		{"1", "9", false},
+		{"1", "12+", false},
+		{"1", "13", true},
+		{"1", "14+", true},
+		{"2", "0", true},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := supportsDryRun(&version.Info{Major: tt.major, Minor: tt.minor}); got != tt.expect {
+			t.Errorf("%s.%s: expected %t, got %t", tt.major, tt.minor, tt.expect, got)
+		}
+	}
+}
+
 type testPrinter struct {
 	Objects []runtime.Object
 	Err     error
@@ -456,6 +977,88 @@ func TestWaitAndGetCompletedPodPhase(t *testing.T) {
 	}
 }
 
+func TestWatchUntilReadyPod(t *testing.T) {
+	tests := []struct {
+		podPhase   core.PodPhase
+		shouldWait bool
+		errMessage string
+	}{
+		{podPhase: core.PodRunning, shouldWait: false},
+		{podPhase: core.PodRunning, shouldWait: true, errMessage: "watch closed before Until timeout"},
+		{podPhase: core.PodSucceeded, shouldWait: true},
+		{podPhase: core.PodFailed, shouldWait: true, errMessage: "Pod bestpod failed"},
+	}
+
+	for _, tt := range tests {
+		f, tf, codec, ns := cmdtesting.NewAPIFactory()
+
+		var testPodList core.PodList
+		testPodList.Items = append(testPodList.Items, newPodWithStatus("bestpod", core.PodStatus{Phase: tt.podPhase}, "test"))
+
+		tf.Client = &fake.RESTClient{
+			NegotiatedSerializer: ns,
+			Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+				p, m := req.URL.Path, req.Method
+				switch {
+				case p == "/namespaces/test/pods" && m == "GET":
+					event := watch.Event{Type: watch.Added, Object: &testPodList.Items[0]}
+					return newEventResponse(200, &event)
+				default:
+					t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+					return nil, nil
+				}
+			}),
+		}
+
+		c := newTestClient(f)
+
+		err := c.WatchUntilReady("test", objBody(codec, &testPodList), 1, tt.shouldWait)
+		if tt.errMessage == "" && err != nil {
+			t.Errorf("%s (wait=%t): expected no error, got %s", tt.podPhase, tt.shouldWait, err)
+		}
+		if tt.errMessage != "" && (err == nil || err.Error() != tt.errMessage) {
+			t.Errorf("%s (wait=%t): expected error %q, got %v", tt.podPhase, tt.shouldWait, tt.errMessage, err)
+		}
+	}
+}
+
+func TestCRDEstablished(t *testing.T) {
+	crd := func(conds ...map[string]interface{}) map[string]interface{} {
+		list := []interface{}{}
+		for _, c := range conds {
+			list = append(list, c)
+		}
+		return map[string]interface{}{
+			"status": map[string]interface{}{"conditions": list},
+		}
+	}
+	cond := func(typ, status string) map[string]interface{} {
+		return map[string]interface{}{"type": typ, "status": status}
+	}
+
+	tests := []struct {
+		name        string
+		crd         map[string]interface{}
+		established bool
+		err         bool
+	}{
+		{name: "no status", crd: map[string]interface{}{}},
+		{name: "names accepted", crd: crd(cond("NamesAccepted", "True"))},
+		{name: "established", crd: crd(cond("NamesAccepted", "True"), cond("Established", "True")), established: true},
+		{name: "not established", crd: crd(cond("Established", "False"))},
+		{name: "names conflict", crd: crd(cond("NamesAccepted", "False")), err: true},
+	}
+	for _, tt := range tests {
+		established, err := crdEstablished(tt.crd)
+		if (err != nil) != tt.err {
+			t.Errorf("%s: expected error %t, got %v", tt.name, tt.err, err)
+		}
+		if established != tt.established {
+			t.Errorf("%s: expected established %t, got %t", tt.name, tt.established, established)
+		}
+	}
+}
+
 func TestReal(t *testing.T) {
 	t.Skip("This is a live test, comment this line to run")
 	c := New(nil)
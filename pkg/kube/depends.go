@@ -0,0 +1,139 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+)
+
+// DependsOnAnnotation names the resources of the release, as KIND/NAME and
+// separated by commas, that must be created before the annotated resource.
+const DependsOnAnnotation = "helm.sh/depends-on"
+
+// DependsOnReadyAnnotation, set to "true" on a resource with
+// DependsOnAnnotation, also waits for the resources it depends on to be ready
+// before the resource is created.
+const DependsOnReadyAnnotation = "helm.sh/depends-on-ready"
+
+// resourceKey names a resource as KIND/NAME.
+func resourceKey(info *resource.Info) string {
+	kind := info.Object.GetObjectKind().GroupVersionKind().Kind
+	if info.Mapping != nil {
+		kind = info.Mapping.GroupVersionKind.Kind
+	}
+	return kind + "/" + info.Name
+}
+
+// annotation returns the value of an annotation of a resource.
+func annotation(info *resource.Info, key string) string {
+	accessor, err := meta.Accessor(info.Object)
+	if err != nil {
+		return ""
+	}
+	return accessor.GetAnnotations()[key]
+}
+
+// dependsOn returns the KIND/NAME keys of the resources info depends on.
+func dependsOn(info *resource.Info) []string {
+	var keys []string
+	for _, key := range strings.Split(annotation(info, DependsOnAnnotation), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// orderByDependencies orders resources so that each comes after the resources
+// it depends on. Resources keep their order otherwise. A dependency on a
+// resource that is not among infos, or a cycle of dependencies, is an error.
+func orderByDependencies(infos Result) (Result, error) {
+	byKey := make(map[string]*resource.Info, len(infos))
+	for _, info := range infos {
+		byKey[resourceKey(info)] = info
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	ordered := make(Result, 0, len(infos))
+
+	var visit func(info *resource.Info, path []string) error
+	visit = func(info *resource.Info, path []string) error {
+		key := resourceKey(info)
+		switch state[key] {
+		case visited:
+			return nil
+		case visiting:
+			for i, p := range path {
+				if p == key {
+					path = path[i:]
+					break
+				}
+			}
+			return fmt.Errorf("dependency cycle: %s", strings.Join(append(path, key), " -> "))
+		}
+		state[key] = visiting
+		for _, dep := range dependsOn(info) {
+			d, ok := byKey[dep]
+			if !ok {
+				return fmt.Errorf("%s depends on %s, which is not part of the release", key, dep)
+			}
+			if err := visit(d, append(path, key)); err != nil {
+				return err
+			}
+		}
+		state[key] = visited
+		ordered = append(ordered, info)
+		return nil
+	}
+
+	for _, info := range infos {
+		if err := visit(info, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// awaitDependencies waits for the resources info depends on to be ready, if
+// info is annotated to wait for them. all holds the resources of the release.
+func (c *Client) awaitDependencies(info *resource.Info, all Result, timeout time.Duration) error {
+	if annotation(info, DependsOnReadyAnnotation) != "true" {
+		return nil
+	}
+	var deps Result
+	for _, key := range dependsOn(info) {
+		for _, other := range all {
+			if resourceKey(other) == key {
+				deps.Append(other)
+			}
+		}
+	}
+	c.Log("waiting for the dependencies of %s to be ready", resourceKey(info))
+	if err := c.waitForResources(timeout, deps); err != nil {
+		return fmt.Errorf("dependencies of %s are not ready: %s", resourceKey(info), err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kubernetes/pkg/api/testapi"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+)
+
+func newDependentInfo(t *testing.T, kind, name, dependsOn string) *resource.Info {
+	mapping, err := testapi.Default.RESTMapper().RESTMapping(schema.GroupKind{Kind: kind})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var annotations map[string]string
+	if dependsOn != "" {
+		annotations = map[string]string{DependsOnAnnotation: dependsOn}
+	}
+	return &resource.Info{
+		Name:      name,
+		Namespace: "default",
+		Mapping:   mapping,
+		Object: &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: annotations},
+		},
+	}
+}
+
+func resultKeys(infos Result) string {
+	var keys []string
+	for _, info := range infos {
+		keys = append(keys, resourceKey(info))
+	}
+	return strings.Join(keys, ",")
+}
+
+func TestOrderByDependencies(t *testing.T) {
+	infos := Result{
+		newDependentInfo(t, "Pod", "b", "ConfigMap/a"),
+		newDependentInfo(t, "Service", "c", ""),
+		newDependentInfo(t, "ConfigMap", "a", ""),
+		newDependentInfo(t, "Pod", "d", "Pod/b, Service/c"),
+	}
+	ordered, err := orderByDependencies(infos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expect := resultKeys(ordered), "ConfigMap/a,Pod/b,Service/c,Pod/d"; got != expect {
+		t.Errorf("expected %s, got %s", expect, got)
+	}
+}
+
+func TestOrderByDependenciesCycle(t *testing.T) {
+	infos := Result{
+		newDependentInfo(t, "Service", "c", ""),
+		newDependentInfo(t, "Pod", "a", "Pod/b"),
+		newDependentInfo(t, "Pod", "b", "ConfigMap/c"),
+		newDependentInfo(t, "ConfigMap", "c", "Pod/a"),
+	}
+	_, err := orderByDependencies(infos)
+	if err == nil || err.Error() != "dependency cycle: Pod/a -> Pod/b -> ConfigMap/c -> Pod/a" {
+		t.Errorf("expected a dependency cycle, got %v", err)
+	}
+}
+
+func TestOrderByDependenciesMissing(t *testing.T) {
+	infos := Result{newDependentInfo(t, "Pod", "a", "Secret/creds")}
+	_, err := orderByDependencies(infos)
+	if err == nil || !strings.Contains(err.Error(), "Pod/a depends on Secret/creds, which is not part of the release") {
+		t.Errorf("expected a missing dependency error, got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+)
+
+// noMatchBackoff is how often the resources of a manifest are built again
+// while the API server does not serve kinds defined by the
+// CustomResourceDefinitions of the release. The last attempt is made about
+// eight seconds after the first.
+var noMatchBackoff = wait.Backoff{Duration: 250 * time.Millisecond, Factor: 2, Steps: 6}
+
+// buildRetryingNoMatch builds the resources of a manifest like
+// BuildUnstructured. Custom resources cannot be built until the API server
+// serves their kind, which it does not right after their
+// CustomResourceDefinition is created. If the build fails while a kind
+// defined by crds, the manifest of the CustomResourceDefinitions of the
+// release, is not served yet, the discovery cache is invalidated and the build
+// is retried for a short while.
+func (c *Client) buildRetryingNoMatch(namespace string, reader io.Reader, crds string) (Result, error) {
+	manifest, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	defined := crdKinds([]byte(crds))
+	var result Result
+	err = retryOnNoMatch(noMatchBackoff, func() error {
+		var buildErr error
+		result, buildErr = c.BuildUnstructured(namespace, bytes.NewReader(manifest))
+		return buildErr
+	}, func() bool {
+		return c.awaitingKinds(manifest, defined)
+	}, c.invalidateDiscovery)
+	return result, err
+}
+
+// awaitingKinds reports whether the API server does not serve the kind of a
+// resource in manifest that is defined by a CustomResourceDefinition of the
+// release.
+func (c *Client) awaitingKinds(manifest []byte, defined map[schema.GroupKind]bool) bool {
+	if len(defined) == 0 {
+		return false
+	}
+	dc, err := c.DiscoveryClient()
+	if err != nil {
+		c.Log("warning: could not get the discovery information: %s", err)
+		return false
+	}
+	mapper := discovery.NewDeferredDiscoveryRESTMapper(dc, meta.InterfacesForUnstructured)
+	for _, obj := range manifestObjects(manifest) {
+		gvk := obj.GroupVersionKind()
+		if !defined[gvk.GroupKind()] {
+			continue
+		}
+		if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); meta.IsNoMatchError(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidateDiscovery drops the cached discovery information, so that kinds
+// added to the API server since it was cached are found.
+func (c *Client) invalidateDiscovery() {
+	dc, err := c.DiscoveryClient()
+	if err != nil {
+		c.Log("warning: could not refresh the discovery information: %s", err)
+		return
+	}
+	c.Log("kind not found, refreshing the discovery information")
+	dc.Invalidate()
+}
+
+// retryOnNoMatch calls fn until it succeeds, or fails while awaiting reports
+// that no kind is awaited, calling invalidate before each retry. The last
+// error is returned once the backoff is exhausted.
+func retryOnNoMatch(backoff wait.Backoff, fn func() error, awaiting func() bool, invalidate func()) error {
+	var err error
+	wait.ExponentialBackoff(backoff, func() (bool, error) {
+		if err = fn(); err == nil || !awaiting() {
+			return true, nil
+		}
+		invalidate()
+		return false, nil
+	})
+	return err
+}
+
+// crdKinds returns the kinds defined by the CustomResourceDefinitions in
+// manifest.
+func crdKinds(manifest []byte) map[schema.GroupKind]bool {
+	kinds := map[schema.GroupKind]bool{}
+	for _, obj := range manifestObjects(manifest) {
+		if obj.GetKind() != "CustomResourceDefinition" {
+			continue
+		}
+		spec, _ := obj.Object["spec"].(map[string]interface{})
+		names, _ := spec["names"].(map[string]interface{})
+		group, _ := spec["group"].(string)
+		kind, _ := names["kind"].(string)
+		if kind != "" {
+			kinds[schema.GroupKind{Group: group, Kind: kind}] = true
+		}
+	}
+	return kinds
+}
+
+// manifestObjects decodes the documents of a manifest, up to the first one
+// that cannot be decoded. Building the manifest reports that error.
+func manifestObjects(manifest []byte) []*unstructured.Unstructured {
+	var objs []*unstructured.Unstructured
+	d := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := d.Decode(&obj.Object); err != nil {
+			return objs
+		}
+		if len(obj.Object) > 0 {
+			objs = append(objs, obj)
+		}
+	}
+}
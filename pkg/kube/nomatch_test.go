@@ -0,0 +1,106 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// noKindMatch returns the error of mapping a kind the API server does not know.
+func noKindMatch() error {
+	_, err := meta.NewDefaultRESTMapper(nil, nil).RESTMapping(schema.GroupKind{Group: "example.com", Kind: "Widget"}, "v1")
+	return err
+}
+
+func TestCRDKinds(t *testing.T) {
+	manifest := `
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+`
+	expect := map[schema.GroupKind]bool{{Group: "example.com", Kind: "Widget"}: true}
+	if got := crdKinds([]byte(manifest)); !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected %v, got %v", expect, got)
+	}
+	if got := crdKinds(nil); len(got) != 0 {
+		t.Errorf("Expected no kinds for an empty manifest, got %v", got)
+	}
+}
+
+func TestRetryOnNoMatch(t *testing.T) {
+	backoff := wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 5}
+
+	// The kind of the custom resource is only found once discovery has been
+	// refreshed after its definition was created.
+	refreshed := false
+	applies := 0
+	err := retryOnNoMatch(backoff, func() error {
+		applies++
+		if !refreshed {
+			return fmt.Errorf("unable to recognize \"\": %s", noKindMatch())
+		}
+		return nil
+	}, func() bool { return !refreshed }, func() { refreshed = true })
+	if err != nil {
+		t.Fatalf("Expected the apply to succeed after the refresh, got %s", err)
+	}
+	if applies != 2 {
+		t.Errorf("Expected 2 applies, got %d", applies)
+	}
+
+	// Errors while no kind of the release is awaited are returned at once.
+	invalidations := 0
+	applies = 0
+	err = retryOnNoMatch(backoff, func() error {
+		applies++
+		return noKindMatch()
+	}, func() bool { return false }, func() { invalidations++ })
+	if !meta.IsNoMatchError(err) || applies != 1 || invalidations != 0 {
+		t.Errorf("Expected the error to be returned without retrying, got %v after %d applies", err, applies)
+	}
+
+	applies = 0
+	err = retryOnNoMatch(backoff, func() error {
+		applies++
+		return errors.New("still not served")
+	}, func() bool { return true }, func() {})
+	if err == nil || err.Error() != "still not served" {
+		t.Errorf("Expected the last error once the backoff is exhausted, got %v", err)
+	}
+	if applies != backoff.Steps {
+		t.Errorf("Expected %d applies, got %d", backoff.Steps, applies)
+	}
+}
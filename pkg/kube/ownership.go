@@ -0,0 +1,129 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+)
+
+// OwnershipConflictError is returned when a resource of a release already
+// exists and is owned by a different release, or by none. Owner is empty if
+// the existing resource has no Helm ownership labels.
+type OwnershipConflictError struct {
+	Kind    string
+	Name    string
+	Owner   string
+	Release string
+}
+
+func (e *OwnershipConflictError) Error() string {
+	if e.Owner == "" {
+		return fmt.Sprintf("%s %q already exists and is not owned by any release: use --take-ownership to let the release manage it",
+			e.Kind, e.Name)
+	}
+	return fmt.Sprintf("%s %q is owned by release %q, not %q: use --take-ownership to let %q manage it",
+		e.Kind, e.Name, e.Owner, e.Release, e.Release)
+}
+
+// IsOwnershipConflict reports whether err is an OwnershipConflictError.
+func IsOwnershipConflict(err error) bool {
+	_, ok := err.(*OwnershipConflictError)
+	return ok
+}
+
+// releaseOwner returns the name of the release obj is labeled with, or an
+// empty string if it has no Helm ownership labels. The labels are the ones
+// set by Tiller's common labels, or the release and heritage labels of charts
+// made with 'helm create'.
+func releaseOwner(obj runtime.Object) string {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	l := accessor.GetLabels()
+	switch {
+	case l["helm.sh/release"] != "":
+		return l["helm.sh/release"]
+	case l["app.kubernetes.io/managed-by"] == "Helm":
+		return l["app.kubernetes.io/instance"]
+	case l["heritage"] == "Tiller":
+		return l["release"]
+	}
+	return ""
+}
+
+// checkOwnership returns an OwnershipConflictError if the rendered resource
+// and the live object in the cluster are labeled with different releases. A
+// live object without ownership labels is a conflict too, unless it is part
+// of the release already, as resources of releases made before they were
+// labeled are.
+func checkOwnership(info *resource.Info, live runtime.Object, inRelease bool) error {
+	want, have := releaseOwner(info.Object), releaseOwner(live)
+	switch {
+	case have == "":
+		if inRelease {
+			return nil
+		}
+	case want == "" || want == have:
+		return nil
+	}
+	return &OwnershipConflictError{
+		Kind:    info.Mapping.GroupVersionKind.Kind,
+		Name:    info.Name,
+		Owner:   have,
+		Release: want,
+	}
+}
+
+// createOrAdopt creates the resource. If it already exists and is owned by
+// another release, or by none, an OwnershipConflictError is returned. With
+// takeOwnership, an existing resource is patched to the rendered one instead.
+func (c *Client) createOrAdopt(info *resource.Info, takeOwnership bool) error {
+	err := c.createResource(info)
+	if err == nil || !errors.IsAlreadyExists(err) {
+		return err
+	}
+	live, getErr := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name, info.Export)
+	if getErr != nil {
+		return err
+	}
+	if !takeOwnership {
+		if conflict := checkOwnership(info, live, false); conflict != nil {
+			return conflict
+		}
+		return err
+	}
+	return c.adoptResource(info)
+}
+
+// adoptResource patches an existing resource that a release did not create
+// to the rendered one. Fields set by others are left as they are, since the
+// patch only sets the fields of the rendered resource.
+func (c *Client) adoptResource(info *resource.Info) error {
+	c.Log("Taking ownership of %s %q", info.Mapping.GroupVersionKind.Kind, info.Name)
+	stub := &unstructured.Unstructured{}
+	stub.SetGroupVersionKind(info.Mapping.GroupVersionKind)
+	stub.SetName(info.Name)
+	stub.SetNamespace(info.Namespace)
+	return updateResource(c, info, stub, false, false)
+}
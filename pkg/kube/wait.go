@@ -17,6 +17,10 @@ limitations under the License.
 package kube // import "k8s.io/helm/pkg/kube"
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -24,6 +28,7 @@ import (
 	appsv1beta2 "k8s.io/api/apps/v1beta2"
 	"k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -33,8 +38,14 @@ import (
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	"k8s.io/kubernetes/pkg/apis/core/v1/helper"
 	deploymentutil "k8s.io/kubernetes/pkg/controller/deployment/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
 )
 
+// WaitSkipAnnotation, set to "true" on a resource, leaves the resource out of
+// the wait for the resources of a release to be ready. The resource is still
+// created or updated.
+const WaitSkipAnnotation = "helm.sh/wait-skip"
+
 // deployment holds associated replicaSets for a deployment
 type deployment struct {
 	replicaSets *extensions.ReplicaSet
@@ -44,6 +55,13 @@ type deployment struct {
 // waitForResources polls to get the current status of all pods, PVCs, and Services
 // until all are ready or a timeout is reached
 func (c *Client) waitForResources(timeout time.Duration, created Result) error {
+	for _, info := range created.Filter(skipsWait) {
+		c.Log("not waiting for %s, it is annotated %s", info.Name, WaitSkipAnnotation)
+	}
+	created = created.Filter(func(info *resource.Info) bool { return !skipsWait(info) })
+	if len(created) == 0 {
+		return nil
+	}
 	c.Log("beginning wait for %d resources with timeout of %v", len(created), timeout)
 
 	kcs, err := c.KubernetesClientSet()
@@ -51,105 +69,204 @@ func (c *Client) waitForResources(timeout time.Duration, created Result) error {
 		return err
 	}
 	return wait.Poll(2*time.Second, timeout, func() (bool, error) {
-		pods := []v1.Pod{}
-		services := []v1.Service{}
-		pvc := []v1.PersistentVolumeClaim{}
-		deployments := []deployment{}
-		for _, v := range created {
-			obj, err := c.AsVersionedObject(v.Object)
-			if err != nil && !runtime.IsNotRegisteredError(err) {
-				return false, err
+		ready, _, err := c.resourcesReady(kcs, created)
+		return ready, err
+	})
+}
+
+// resourcesReady reports whether all pods, PVCs, Services and Deployments of
+// the resources are ready. It also returns the pods it looked at.
+func (c *Client) resourcesReady(kcs kubernetes.Interface, created Result) (bool, []v1.Pod, error) {
+	pods := []v1.Pod{}
+	services := []v1.Service{}
+	pvc := []v1.PersistentVolumeClaim{}
+	deployments := []deployment{}
+	for _, v := range created {
+		obj, err := c.AsVersionedObject(v.Object)
+		if err != nil && !runtime.IsNotRegisteredError(err) {
+			return false, nil, err
+		}
+		switch value := obj.(type) {
+		case *v1.ReplicationController:
+			list, err := getPods(kcs, value.Namespace, value.Spec.Selector)
+			if err != nil {
+				return false, nil, err
+			}
+			pods = append(pods, list...)
+		case *v1.Pod:
+			pod, err := kcs.CoreV1().Pods(value.Namespace).Get(value.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, nil, err
+			}
+			pods = append(pods, *pod)
+		case *appsv1.Deployment:
+			currentDeployment, err := kcs.ExtensionsV1beta1().Deployments(value.Namespace).Get(value.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, nil, err
+			}
+			// Find RS associated with deployment
+			newReplicaSet, err := deploymentutil.GetNewReplicaSet(currentDeployment, kcs.ExtensionsV1beta1())
+			if err != nil || newReplicaSet == nil {
+				return false, nil, err
+			}
+			newDeployment := deployment{
+				newReplicaSet,
+				currentDeployment,
+			}
+			deployments = append(deployments, newDeployment)
+		case *extensions.Deployment:
+			currentDeployment, err := kcs.ExtensionsV1beta1().Deployments(value.Namespace).Get(value.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, nil, err
+			}
+			// Find RS associated with deployment
+			newReplicaSet, err := deploymentutil.GetNewReplicaSet(currentDeployment, kcs.ExtensionsV1beta1())
+			if err != nil || newReplicaSet == nil {
+				return false, nil, err
+			}
+			newDeployment := deployment{
+				newReplicaSet,
+				currentDeployment,
+			}
+			deployments = append(deployments, newDeployment)
+		case *extensions.DaemonSet:
+			list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
+			if err != nil {
+				return false, nil, err
 			}
-			switch value := obj.(type) {
-			case *v1.ReplicationController:
-				list, err := getPods(kcs, value.Namespace, value.Spec.Selector)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case *v1.Pod:
-				pod, err := kcs.CoreV1().Pods(value.Namespace).Get(value.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, *pod)
-			case *appsv1.Deployment:
-				currentDeployment, err := kcs.ExtensionsV1beta1().Deployments(value.Namespace).Get(value.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				// Find RS associated with deployment
-				newReplicaSet, err := deploymentutil.GetNewReplicaSet(currentDeployment, kcs.ExtensionsV1beta1())
-				if err != nil || newReplicaSet == nil {
-					return false, err
-				}
-				newDeployment := deployment{
-					newReplicaSet,
-					currentDeployment,
-				}
-				deployments = append(deployments, newDeployment)
-			case *extensions.Deployment:
-				currentDeployment, err := kcs.ExtensionsV1beta1().Deployments(value.Namespace).Get(value.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				// Find RS associated with deployment
-				newReplicaSet, err := deploymentutil.GetNewReplicaSet(currentDeployment, kcs.ExtensionsV1beta1())
-				if err != nil || newReplicaSet == nil {
-					return false, err
-				}
-				newDeployment := deployment{
-					newReplicaSet,
-					currentDeployment,
-				}
-				deployments = append(deployments, newDeployment)
-			case *extensions.DaemonSet:
-				list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case *appsv1.StatefulSet:
-				list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case *appsv1beta1.StatefulSet:
-				list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case *appsv1beta2.StatefulSet:
-				list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case *extensions.ReplicaSet:
-				list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case *v1.PersistentVolumeClaim:
-				claim, err := kcs.CoreV1().PersistentVolumeClaims(value.Namespace).Get(value.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				pvc = append(pvc, *claim)
-			case *v1.Service:
-				svc, err := kcs.CoreV1().Services(value.Namespace).Get(value.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				services = append(services, *svc)
+			pods = append(pods, list...)
+		case *appsv1.StatefulSet:
+			list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
+			if err != nil {
+				return false, nil, err
 			}
+			pods = append(pods, list...)
+		case *appsv1beta1.StatefulSet:
+			list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
+			if err != nil {
+				return false, nil, err
+			}
+			pods = append(pods, list...)
+		case *appsv1beta2.StatefulSet:
+			list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
+			if err != nil {
+				return false, nil, err
+			}
+			pods = append(pods, list...)
+		case *extensions.ReplicaSet:
+			list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
+			if err != nil {
+				return false, nil, err
+			}
+			pods = append(pods, list...)
+		case *v1.PersistentVolumeClaim:
+			claim, err := kcs.CoreV1().PersistentVolumeClaims(value.Namespace).Get(value.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, nil, err
+			}
+			pvc = append(pvc, *claim)
+		case *v1.Service:
+			svc, err := kcs.CoreV1().Services(value.Namespace).Get(value.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, nil, err
+			}
+			services = append(services, *svc)
 		}
-		isReady := c.podsReady(pods) && c.servicesReady(services) && c.volumesReady(pvc) && c.deploymentsReady(deployments)
-		return isReady, nil
-	})
+	}
+	isReady := c.podsReady(pods) && c.servicesReady(services) && c.volumesReady(pvc) && c.deploymentsReady(deployments)
+	return isReady, pods, nil
+}
+
+// WaitForSettle waits for settle after the resources in reader became ready,
+// and then checks that they still are, to catch pods that crash soon after
+// reporting ready. Pods that are no longer ready, or whose containers
+// restarted during the settle period, are reported in the error. The wait is
+// given up when ctx is done.
+func (c *Client) WaitForSettle(ctx context.Context, namespace string, reader io.Reader, settle time.Duration) error {
+	infos, err := c.BuildUnstructured(namespace, reader)
+	if err != nil {
+		return err
+	}
+	infos = infos.Filter(func(info *resource.Info) bool { return !skipsWait(info) })
+	if len(infos) == 0 {
+		return nil
+	}
+	kcs, err := c.KubernetesClientSet()
+	if err != nil {
+		return err
+	}
+	_, before, err := c.resourcesReady(kcs, infos)
+	if err != nil {
+		return err
+	}
+
+	c.Log("waiting %v for %d resources to settle", settle, len(infos))
+	if err := sleepContext(ctx, settle); err != nil {
+		return fmt.Errorf("gave up waiting for resources to settle: %s", err)
+	}
+
+	ready, after, err := c.resourcesReady(kcs, infos)
+	if err != nil {
+		return err
+	}
+	if regressed := settleRegressions(before, after); len(regressed) > 0 {
+		return fmt.Errorf("resources did not stay ready for %v: %s", settle, strings.Join(regressed, "; "))
+	}
+	if !ready {
+		return fmt.Errorf("resources did not stay ready for %v", settle)
+	}
+	return nil
+}
+
+// sleepContext waits for d to pass. It returns the error of ctx if ctx is
+// done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// settleRegressions describes the pods that are not ready after the settle
+// period, or whose containers restarted since before it. Pods that did not
+// exist before are compared with no restarts.
+func settleRegressions(before, after []v1.Pod) []string {
+	restarts := map[string]int32{}
+	for _, pod := range before {
+		restarts[pod.Namespace+"/"+pod.Name] = podRestarts(pod)
+	}
+	var regressed []string
+	for _, pod := range after {
+		name := pod.Namespace + "/" + pod.Name
+		if !podutil.IsPodReady(&pod) {
+			regressed = append(regressed, fmt.Sprintf("pod %s is no longer ready", name))
+		} else if n := podRestarts(pod) - restarts[name]; n > 0 {
+			regressed = append(regressed, fmt.Sprintf("pod %s restarted %d time(s)", name, n))
+		}
+	}
+	return regressed
+}
+
+// podRestarts returns the total restart count of the containers of a pod.
+func podRestarts(pod v1.Pod) int32 {
+	var n int32
+	for _, s := range pod.Status.ContainerStatuses {
+		n += s.RestartCount
+	}
+	return n
+}
+
+// skipsWait reports whether a resource is annotated to be left out of waits.
+func skipsWait(info *resource.Info) bool {
+	accessor, err := meta.Accessor(info.Object)
+	if err != nil {
+		return false
+	}
+	return accessor.GetAnnotations()[WaitSkipAnnotation] == "true"
 }
 
 func (c *Client) podsReady(pods []v1.Pod) bool {
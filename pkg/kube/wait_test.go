@@ -0,0 +1,139 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+	cmdtesting "k8s.io/kubernetes/pkg/kubectl/cmd/testing"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+)
+
+func newDeploymentInfo(name string, replicas int32, annotations map[string]string) *resource.Info {
+	return &resource.Info{
+		Name:      name,
+		Namespace: "default",
+		Object: &extensions.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: annotations},
+			Spec:       extensions.DeploymentSpec{Replicas: replicas},
+		},
+	}
+}
+
+func TestSkipsWait(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		expect      bool
+	}{
+		{nil, false},
+		{map[string]string{WaitSkipAnnotation: "true"}, true},
+		{map[string]string{WaitSkipAnnotation: "false"}, false},
+	}
+	for _, tt := range tests {
+		if got := skipsWait(newDeploymentInfo("web", 1, tt.annotations)); got != tt.expect {
+			t.Errorf("annotations %v: expected %t, got %t", tt.annotations, tt.expect, got)
+		}
+	}
+}
+
+func TestWaitForResourcesSkipsAnnotated(t *testing.T) {
+	f, _, _, _ := cmdtesting.NewAPIFactory()
+	c := newTestClient(f)
+
+	// A Deployment scaled to zero never becomes ready, so waiting for it
+	// would time out.
+	scaledDown := newDeploymentInfo("scaled-down", 0, map[string]string{WaitSkipAnnotation: "true"})
+
+	done := make(chan error, 1)
+	go func() { done <- c.waitForResources(time.Minute, Result{scaledDown}) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the annotated Deployment not to be waited for")
+	}
+}
+
+func newSettlePod(name string, ready bool, restarts int32) v1.Pod {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status: v1.PodStatus{
+			Conditions:        []v1.PodCondition{{Type: v1.PodReady, Status: status}},
+			ContainerStatuses: []v1.ContainerStatus{{Name: "app", Ready: ready, RestartCount: restarts}},
+		},
+	}
+}
+
+func TestSettleRegressions(t *testing.T) {
+	before := []v1.Pod{
+		newSettlePod("stable", true, 1),
+		newSettlePod("crashing", true, 0),
+		newSettlePod("flapping", true, 0),
+	}
+	after := []v1.Pod{
+		newSettlePod("stable", true, 1),
+		// Passed the wait, then crash-looped.
+		newSettlePod("crashing", false, 3),
+		// Crashed and came back ready during the settle period.
+		newSettlePod("flapping", true, 1),
+		// Replaced a pod during the settle period.
+		newSettlePod("replacement", true, 2),
+	}
+	expect := []string{
+		"pod default/crashing is no longer ready",
+		"pod default/flapping restarted 1 time(s)",
+		"pod default/replacement restarted 2 time(s)",
+	}
+	if got := settleRegressions(before, after); !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected %v, got %v", expect, got)
+	}
+
+	if got := settleRegressions(before[:1], after[:1]); len(got) != 0 {
+		t.Errorf("Expected no regressions for a settled pod, got %v", got)
+	}
+}
+
+func TestSleepContext(t *testing.T) {
+	if err := sleepContext(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan error, 1)
+	go func() { done <- sleepContext(ctx, time.Hour) }()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("Expected %v, got %v", context.Canceled, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the sleep to end when the context is cancelled")
+	}
+}
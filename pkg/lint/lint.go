@@ -17,8 +17,15 @@ limitations under the License.
 package lint // import "k8s.io/helm/pkg/lint"
 
 import (
+	"io/ioutil"
+	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/lint/rules"
 	"k8s.io/helm/pkg/lint/support"
 )
@@ -34,3 +41,100 @@ func All(basedir string, values []byte, namespace string, strict bool) support.L
 	rules.Templates(&linter, values, namespace, strict)
 	return linter
 }
+
+// AllWithSubcharts runs all of the available linters on the given base
+// directory and on every chart in its charts/ directory, recursively. The
+// paths of the messages of a subchart are prefixed with the path of the
+// subchart, such as "charts/mysql/", and its messages count towards the
+// highest severity of the returned linter.
+func AllWithSubcharts(basedir string, values []byte, namespace string, strict bool) support.Linter {
+	linter := All(basedir, values, namespace, strict)
+	lintSubcharts(&linter, linter.ChartDir, "", values, namespace, strict)
+	return linter
+}
+
+// lintSubcharts lints the charts in the charts/ directory of chartDir into
+// linter, both unpacked and packaged ones.
+func lintSubcharts(linter *support.Linter, chartDir, prefix string, values []byte, namespace string, strict bool) {
+	files, err := ioutil.ReadDir(filepath.Join(chartDir, "charts"))
+	if err != nil {
+		// A chart without a charts/ directory has no subcharts.
+		return
+	}
+	for _, fi := range files {
+		subPrefix := path.Join(prefix, "charts", fi.Name())
+		subDir := filepath.Join(chartDir, "charts", fi.Name())
+		switch {
+		case fi.IsDir():
+			if _, err := os.Stat(filepath.Join(subDir, "Chart.yaml")); err != nil {
+				continue
+			}
+			lintSubchart(linter, subDir, subPrefix, values, namespace, strict)
+		case strings.HasSuffix(fi.Name(), ".tgz"):
+			if err := lintPackagedSubchart(linter, subDir, subPrefix, values, namespace, strict); err != nil {
+				linter.RunLinterRule(support.ErrorSev, subPrefix, err)
+			}
+		}
+	}
+}
+
+// lintPackagedSubchart expands a packaged subchart to a temporary directory
+// and lints it there.
+func lintPackagedSubchart(linter *support.Linter, archive, prefix string, values []byte, namespace string, strict bool) error {
+	ch, err := chartutil.Load(archive)
+	if err != nil {
+		return err
+	}
+	tempDir, err := ioutil.TempDir("", "helm-lint")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	file, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := chartutil.Expand(tempDir, file); err != nil {
+		return err
+	}
+	lintSubchart(linter, filepath.Join(tempDir, ch.Metadata.Name), prefix, values, namespace, strict)
+	return nil
+}
+
+// lintSubchart lints the subchart in dir and its own subcharts, with the
+// values the parent sets for it, and adds the messages to linter under
+// prefix.
+func lintSubchart(linter *support.Linter, dir, prefix string, values []byte, namespace string, strict bool) {
+	subValues := subchartValues(dir, values)
+	sub := All(dir, subValues, namespace, strict)
+	for _, msg := range sub.Messages {
+		linter.RunLinterRule(msg.Severity, prefix+"/"+msg.Path, msg.Err)
+	}
+	lintSubcharts(linter, sub.ChartDir, prefix, subValues, namespace, strict)
+}
+
+// subchartValues returns the part of values scoped to the subchart in dir.
+// Values that cannot be parsed are left for the linting of the parent to
+// report, and none are passed to the subchart.
+func subchartValues(dir string, values []byte) []byte {
+	cf, err := chartutil.LoadChartfile(filepath.Join(dir, "Chart.yaml"))
+	if err != nil {
+		return []byte{}
+	}
+	vals, err := chartutil.ReadValues(values)
+	if err != nil {
+		return []byte{}
+	}
+	table, err := vals.Table(cf.Name)
+	if err != nil {
+		return []byte{}
+	}
+	out, err := yaml.Marshal(table)
+	if err != nil {
+		return []byte{}
+	}
+	return out
+}
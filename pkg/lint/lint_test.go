@@ -33,6 +33,8 @@ const badChartDir = "rules/testdata/badchartfile"
 const badValuesFileDir = "rules/testdata/badvaluesfile"
 const badYamlFileDir = "rules/testdata/albatross"
 const goodChartDir = "rules/testdata/goodone"
+const subchartsDir = "rules/testdata/withsubcharts"
+const badSubchartDir = "rules/testdata/withbadsubchart"
 
 func TestBadChart(t *testing.T) {
 	m := All(badChartDir, values, namespace, strict).Messages
@@ -96,3 +98,31 @@ func TestGoodChart(t *testing.T) {
 		t.Errorf("All failed but shouldn't have: %#v", m)
 	}
 }
+
+func TestGoodSubcharts(t *testing.T) {
+	linter := AllWithSubcharts(subchartsDir, values, namespace, strict)
+	if len(linter.Messages) != 0 {
+		t.Errorf("AllWithSubcharts failed but shouldn't have: %#v", linter.Messages)
+	}
+}
+
+func TestBadSubchart(t *testing.T) {
+	if m := All(badSubchartDir, values, namespace, strict).Messages; len(m) != 0 {
+		t.Errorf("All linted the subcharts: %#v", m)
+	}
+
+	linter := AllWithSubcharts(badSubchartDir, values, namespace, strict)
+	if linter.HighestSeverity != support.ErrorSev {
+		t.Errorf("Expected the subchart error to fail the lint, got severity %d", linter.HighestSeverity)
+	}
+	if len(linter.Messages) != 1 {
+		t.Fatalf("AllWithSubcharts didn't fail with expected errors, got %#v", linter.Messages)
+	}
+	msg := linter.Messages[0]
+	if msg.Path != "charts/goodsub/charts/badsub/Chart.yaml" {
+		t.Errorf("Expected the message to be prefixed with the subchart path, got %q", msg.Path)
+	}
+	if !strings.Contains(msg.Err.Error(), "version 0.0.0 is less than or equal to 0") {
+		t.Errorf("AllWithSubcharts didn't have the error for the subchart version: %s", msg.Err)
+	}
+}
@@ -17,10 +17,14 @@ limitations under the License.
 package rules
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
 
 	"github.com/ghodss/yaml"
 	"k8s.io/helm/pkg/chartutil"
@@ -112,6 +116,10 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 		// NOTE: disabled for now, Refs https://github.com/kubernetes/helm/issues/1037
 		// linter.RunLinterRule(support.WarningSev, path, validateQuotes(string(preExecutedTemplate)))
 
+		for _, err := range validateToYamlIndent(string(template.Data), valuesToRender) {
+			linter.RunLinterRule(support.WarningSev, path, err)
+		}
+
 		renderedContent := renderedContentMap[filepath.Join(chart.GetMetadata().Name, fileName)]
 		var yamlStruct K8sYamlStruct
 		// Even though K8sYamlStruct only defines Metadata namespace, an error in any other
@@ -156,6 +164,58 @@ func validateYamlContent(err error) error {
 	return nil
 }
 
+var (
+	// toYamlAction matches an action that calls toYaml, capturing the
+	// pipeline of the action.
+	toYamlAction = regexp.MustCompile(`\{\{-?\s*([^}]*\btoYaml\b[^}]*?)\s*-?\}\}`)
+	// indentCall matches a call to indent or nindent.
+	indentCall = regexp.MustCompile(`\bn?indent\b`)
+	// relativeRef matches a reference to the dot, or to a variable, whose
+	// value depends on where in the template the action is.
+	relativeRef = regexp.MustCompile(`\$|(^|[\s(|])\.($|[\s)|])`)
+)
+
+// validateToYamlIndent warns about the output of toYaml placed after a key or
+// on an indented line without being piped to indent or nindent. Only the first
+// line of such output is indented, so values that render to several lines end
+// up misindented.
+//
+// To avoid false positives, an action is only reported if it refers to
+// .Values alone and, rendered with the default values, spans several lines.
+func validateToYamlIndent(source string, values chartutil.Values) []error {
+	var errs []error
+	for i, line := range strings.Split(source, "\n") {
+		for _, m := range toYamlAction.FindAllStringSubmatchIndex(line, -1) {
+			pipeline := line[m[2]:m[3]]
+			if m[0] == 0 || indentCall.MatchString(pipeline) {
+				continue
+			}
+			if !strings.Contains(pipeline, ".Values") || relativeRef.MatchString(pipeline) {
+				continue
+			}
+			if !rendersMultiline(pipeline, values) {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("line %d: the output of {{ %s }} spans several lines but only the first is indented; pipe it to nindent", i+1, pipeline))
+		}
+	}
+	return errs
+}
+
+// rendersMultiline reports whether pipeline renders to more than one line with
+// values. Pipelines that fail to render are not reported.
+func rendersMultiline(pipeline string, values chartutil.Values) bool {
+	t, err := template.New("toYaml").Funcs(engine.FuncMap()).Parse("{{ " + pipeline + " }}")
+	if err != nil {
+		return false
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, values); err != nil {
+		return false
+	}
+	return strings.Contains(strings.TrimRight(out.String(), "\n"), "\n")
+}
+
 // K8sYamlStruct stubs a Kubernetes YAML file.
 // Need to access for now to Namespace only
 type K8sYamlStruct struct {
@@ -22,6 +22,7 @@ import (
 	"strings"
 	"testing"
 
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/lint/support"
 )
 
@@ -81,3 +82,45 @@ func TestTemplateIntegrationHappyPath(t *testing.T) {
 		t.Fatalf("Expected no error, got %d, %v", len(res), res)
 	}
 }
+
+func TestValidateToYamlIndent(t *testing.T) {
+	values := chartutil.Values{
+		"Values": map[string]interface{}{
+			"resources": map[string]interface{}{
+				"limits": map[string]interface{}{"cpu": "100m", "memory": "128Mi"},
+			},
+			"tag": "1.0",
+		},
+	}
+
+	clean := []string{
+		"resources:\n  {{- toYaml .Values.resources | nindent 2 }}\n",
+		"resources:\n{{ toYaml .Values.resources | indent 2 }}\n",
+		"{{ toYaml .Values.resources }}\n",
+		"image:\n  tag: {{ toYaml .Values.tag }}\n",
+		"image:\n  tag: {{ .Values.missing | toYaml }}\n",
+		"{{- with .Values.resources }}\nresources:\n  {{ toYaml . }}\n{{- end }}\n",
+	}
+	for _, source := range clean {
+		if errs := validateToYamlIndent(source, values); len(errs) != 0 {
+			t.Errorf("Expected no warnings for %q, got %v", source, errs)
+		}
+	}
+
+	warned := []struct {
+		source string
+		line   string
+	}{
+		{"spec:\n  resources:\n    {{ toYaml .Values.resources }}\n", "line 3:"},
+		{"spec:\n  resources: {{ .Values.resources | toYaml }}\n", "line 2:"},
+	}
+	for _, tt := range warned {
+		errs := validateToYamlIndent(tt.source, values)
+		if len(errs) != 1 {
+			t.Fatalf("Expected one warning for %q, got %v", tt.source, errs)
+		}
+		if msg := errs[0].Error(); !strings.HasPrefix(msg, tt.line) || !strings.Contains(msg, "pipe it to nindent") {
+			t.Errorf("Unexpected warning for %q: %s", tt.source, msg)
+		}
+	}
+}
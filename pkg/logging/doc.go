@@ -0,0 +1,23 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package logging provides leveled, structured logging for Tiller.
+
+Every entry has a level, a message and a set of fields, and is written as a
+single line either as text (key=value pairs) or as a JSON object. Fields whose
+name suggests sensitive content, such as values or secrets, are redacted.
+*/
+package logging // import "k8s.io/helm/pkg/logging"
@@ -0,0 +1,272 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log entry.
+type Level int
+
+const (
+	// DebugLevel is for detailed information useful when debugging.
+	DebugLevel Level = iota
+	// InfoLevel is for the normal operation of the server.
+	InfoLevel
+	// WarnLevel is for unexpected situations that do not fail an operation.
+	WarnLevel
+	// ErrorLevel is for failed operations.
+	ErrorLevel
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+// String returns the name of the level.
+func (l Level) String() string {
+	if l < DebugLevel || l > ErrorLevel {
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+	return levelNames[l]
+}
+
+// ParseLevel parses the name of a level.
+func ParseLevel(s string) (Level, error) {
+	for i, name := range levelNames {
+		if strings.EqualFold(s, name) {
+			return Level(i), nil
+		}
+	}
+	return InfoLevel, fmt.Errorf("unknown log level %q", s)
+}
+
+// Format is the output format of a Logger.
+type Format string
+
+const (
+	// TextFormat writes entries as key=value pairs.
+	TextFormat Format = "text"
+	// JSONFormat writes entries as JSON objects.
+	JSONFormat Format = "json"
+)
+
+// ParseFormat parses the name of a format.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case TextFormat, JSONFormat:
+		return f, nil
+	}
+	return TextFormat, fmt.Errorf("unknown log format %q", s)
+}
+
+// Fields are the structured data of a log entry.
+type Fields map[string]interface{}
+
+// redacted replaces the value of sensitive fields.
+const redacted = "[REDACTED]"
+
+// sensitiveWords mark the names of fields whose values must never be logged.
+var sensitiveWords = []string{"value", "config", "secret", "password", "token", "manifest"}
+
+func sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, w := range sensitiveWords {
+		if strings.Contains(key, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// Logger writes leveled, structured log entries.
+//
+// A Logger is safe for concurrent use. Loggers derived with With share the
+// output of their parent.
+type Logger struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	format Format
+	level  Level
+	fields Fields
+
+	// now returns the time of an entry, and is replaced in tests.
+	now func() time.Time
+}
+
+// New creates a Logger writing entries of at least level to out.
+func New(out io.Writer, format Format, level Level) *Logger {
+	return &Logger{
+		mu:     &sync.Mutex{},
+		out:    out,
+		format: format,
+		level:  level,
+		fields: Fields{},
+		now:    time.Now,
+	}
+}
+
+// With returns a Logger adding fields to every entry.
+func (l *Logger) With(fields Fields) *Logger {
+	c := *l
+	c.fields = make(Fields, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		c.fields[k] = v
+	}
+	for k, v := range fields {
+		c.fields[k] = v
+	}
+	return &c
+}
+
+// Enabled reports whether entries of level are written.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.level
+}
+
+// Log writes an entry with the given level, message and fields.
+func (l *Logger) Log(level Level, msg string, fields Fields) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	entry := make(Fields, len(l.fields)+len(fields)+3)
+	for k, v := range l.fields {
+		entry[k] = v
+	}
+	for k, v := range fields {
+		entry[k] = v
+	}
+	for k := range entry {
+		if sensitive(k) {
+			entry[k] = redacted
+		}
+	}
+	for k, v := range entry {
+		if err, ok := v.(error); ok {
+			entry[k] = err.Error()
+		}
+	}
+	entry["time"] = l.now().UTC().Format(time.RFC3339)
+	entry["level"] = level.String()
+	entry["msg"] = msg
+
+	var line []byte
+	if l.format == JSONFormat {
+		b, err := json.Marshal(entry)
+		if err != nil {
+			b = []byte(fmt.Sprintf(`{"level":"error","msg":%q}`, "failed to encode log entry: "+err.Error()))
+		}
+		line = append(b, '\n')
+	} else {
+		line = formatText(entry)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.out.Write(line)
+}
+
+// Writer returns a writer that logs each line written to it as an entry of
+// level. It lets output of the standard log package, and of libraries using
+// it, go through the Logger.
+func (l *Logger) Writer(level Level) io.Writer {
+	return &lineWriter{logger: l, level: level}
+}
+
+type lineWriter struct {
+	logger *Logger
+	level  Level
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		if line != "" {
+			w.logger.Log(w.level, line, nil)
+		}
+	}
+	return len(p), nil
+}
+
+// formatText formats an entry as key=value pairs, starting with the time,
+// level and message and followed by the other fields sorted by name.
+func formatText(entry Fields) []byte {
+	keys := make([]string, 0, len(entry))
+	for k := range entry {
+		if k != "time" && k != "level" && k != "msg" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	keys = append([]string{"time", "level", "msg"}, keys...)
+
+	var buf bytes.Buffer
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(k)
+		buf.WriteByte('=')
+		s := fmt.Sprint(entry[k])
+		if s == "" || strings.ContainsAny(s, " =\"\t\n") {
+			s = strconv.Quote(s)
+		}
+		buf.WriteString(s)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+// Debugf writes a debug entry with a formatted message.
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.Log(DebugLevel, fmt.Sprintf(format, args...), nil)
+}
+
+// Infof writes an info entry with a formatted message.
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.Log(InfoLevel, fmt.Sprintf(format, args...), nil)
+}
+
+// Warnf writes a warning entry with a formatted message.
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.Log(WarnLevel, fmt.Sprintf(format, args...), nil)
+}
+
+// Errorf writes an error entry with a formatted message.
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.Log(ErrorLevel, fmt.Sprintf(format, args...), nil)
+}
+
+// Printf writes an info entry with a formatted message. It allows a Logger
+// to be used wherever a log.Logger's Printf is expected.
+func (l *Logger) Printf(format string, args ...interface{}) {
+	l.Infof(format, args...)
+}
+
+// Fatalf writes an error entry with a formatted message and exits.
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.Errorf(format, args...)
+	os.Exit(1)
+}
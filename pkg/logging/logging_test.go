@@ -0,0 +1,135 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLogger(format Format, level Level) (*Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	l := New(&buf, format, level)
+	l.now = func() time.Time { return time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC) }
+	return l, &buf
+}
+
+func TestText(t *testing.T) {
+	l, buf := newTestLogger(TextFormat, InfoLevel)
+	l.With(Fields{"component": "tiller"}).Log(InfoLevel, "upgraded release", Fields{
+		"release":  "angry-panda",
+		"duration": 1500 * time.Millisecond,
+		"error":    errors.New("it broke"),
+	})
+
+	expect := `time=2018-03-01T12:00:00Z level=info msg="upgraded release" component=tiller duration=1.5s error="it broke" release=angry-panda` + "\n"
+	if buf.String() != expect {
+		t.Errorf("Expected %q, got %q", expect, buf.String())
+	}
+}
+
+func TestJSON(t *testing.T) {
+	l, buf := newTestLogger(JSONFormat, InfoLevel)
+	l.Log(WarnLevel, "upgraded release", Fields{"release": "angry-panda", "namespace": "default"})
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode %q: %s", buf.String(), err)
+	}
+	expect := map[string]string{
+		"time":      "2018-03-01T12:00:00Z",
+		"level":     "warn",
+		"msg":       "upgraded release",
+		"release":   "angry-panda",
+		"namespace": "default",
+	}
+	for k, v := range expect {
+		if entry[k] != v {
+			t.Errorf("Expected %s to be %q, got %v", k, v, entry[k])
+		}
+	}
+}
+
+func TestLevel(t *testing.T) {
+	l, buf := newTestLogger(TextFormat, WarnLevel)
+	l.Debugf("debug")
+	l.Infof("info")
+	l.Printf("printf")
+	l.Warnf("warn")
+	l.Errorf("error")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 entries, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], "level=warn msg=warn") || !strings.Contains(lines[1], "level=error msg=error") {
+		t.Errorf("Unexpected entries %q", buf.String())
+	}
+}
+
+func TestWriter(t *testing.T) {
+	l, buf := newTestLogger(TextFormat, WarnLevel)
+	std := log.New(l.With(Fields{"component": "log"}).Writer(WarnLevel), "", 0)
+	std.Printf("Cannot patch %s: %q", "Deployment", "nginx")
+	std.Print("line one\nline two\n")
+	log.New(l.Writer(InfoLevel), "", 0).Print("dropped")
+
+	expect := `time=2018-03-01T12:00:00Z level=warn msg="Cannot patch Deployment: \"nginx\"" component=log` + "\n" +
+		`time=2018-03-01T12:00:00Z level=warn msg="line one" component=log` + "\n" +
+		`time=2018-03-01T12:00:00Z level=warn msg="line two" component=log` + "\n"
+	if buf.String() != expect {
+		t.Errorf("Expected %q, got %q", expect, buf.String())
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	l, buf := newTestLogger(JSONFormat, DebugLevel)
+	l.With(Fields{"token": "abc123"}).Log(InfoLevel, "installed release", Fields{
+		"release":  "angry-panda",
+		"values":   "password: hunter2",
+		"Manifest": "kind: Secret",
+	})
+
+	for _, s := range []string{"abc123", "hunter2", "kind: Secret"} {
+		if strings.Contains(buf.String(), s) {
+			t.Errorf("Expected %q to be redacted, got %s", s, buf.String())
+		}
+	}
+	if !strings.Contains(buf.String(), `"release":"angry-panda"`) {
+		t.Errorf("Expected the release to be logged, got %s", buf.String())
+	}
+}
+
+func TestParse(t *testing.T) {
+	if l, err := ParseLevel("WARN"); err != nil || l != WarnLevel {
+		t.Errorf("Expected warn level, got %s, %v", l, err)
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+	if f, err := ParseFormat("json"); err != nil || f != JSONFormat {
+		t.Errorf("Expected JSON format, got %s, %v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
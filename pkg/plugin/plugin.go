@@ -38,6 +38,17 @@ type Downloaders struct {
 	Command string `json:"command"`
 }
 
+// Decryptors represents the plugins capability if it can decrypt encrypted
+// values files.
+type Decryptors struct {
+	// Patterns are matched against the base name of a values file, like
+	// 'secrets.*.yaml'.
+	Patterns []string `json:"patterns"`
+	// Command is the executable path with which the plugin decrypts the
+	// values files matching Patterns
+	Command string `json:"command"`
+}
+
 // Metadata describes a plugin.
 //
 // This is the plugin equivalent of a chart.Metadata.
@@ -82,6 +93,10 @@ type Metadata struct {
 	// Downloaders field is used if the plugin supply downloader mechanism
 	// for special protocols.
 	Downloaders []Downloaders `json:"downloaders"`
+
+	// Decryptors field is used if the plugin supply decryption of encrypted
+	// values files.
+	Decryptors []Decryptors `json:"decryptors"`
 }
 
 // Plugin represents a plugin.
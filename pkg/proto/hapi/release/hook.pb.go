@@ -79,17 +79,20 @@ func (Hook_Event) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []i
 type Hook_DeletePolicy int32
 
 const (
-	Hook_SUCCEEDED Hook_DeletePolicy = 0
-	Hook_FAILED    Hook_DeletePolicy = 1
+	Hook_SUCCEEDED            Hook_DeletePolicy = 0
+	Hook_FAILED               Hook_DeletePolicy = 1
+	Hook_BEFORE_HOOK_CREATION Hook_DeletePolicy = 2
 )
 
 var Hook_DeletePolicy_name = map[int32]string{
 	0: "SUCCEEDED",
 	1: "FAILED",
+	2: "BEFORE_HOOK_CREATION",
 }
 var Hook_DeletePolicy_value = map[string]int32{
-	"SUCCEEDED": 0,
-	"FAILED":    1,
+	"SUCCEEDED":            0,
+	"FAILED":               1,
+	"BEFORE_HOOK_CREATION": 2,
 }
 
 func (x Hook_DeletePolicy) String() string {
@@ -186,32 +189,34 @@ func init() {
 func init() { proto.RegisterFile("hapi/release/hook.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 428 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xd1, 0x6e, 0xda, 0x30,
-	0x14, 0x86, 0x9b, 0x12, 0x12, 0x38, 0x50, 0xea, 0x59, 0xd3, 0x66, 0x71, 0x53, 0xc4, 0x15, 0xbb,
-	0x09, 0x53, 0xa7, 0x3d, 0x40, 0x4a, 0xce, 0xd6, 0xaa, 0x11, 0x20, 0x27, 0x68, 0xd2, 0x6e, 0xa2,
-	0x74, 0xb8, 0x10, 0x11, 0xe2, 0x88, 0x98, 0x4d, 0x7b, 0xa6, 0xbd, 0xce, 0x1e, 0x68, 0xb2, 0x09,
-	0x59, 0xa5, 0xed, 0xee, 0x9c, 0xef, 0x7c, 0x76, 0xce, 0x1f, 0xc3, 0xdb, 0x6d, 0x5a, 0x66, 0xd3,
-	0x83, 0xc8, 0x45, 0x5a, 0x89, 0xe9, 0x56, 0xca, 0x9d, 0x57, 0x1e, 0xa4, 0x92, 0xb4, 0xaf, 0x07,
-	0x5e, 0x3d, 0x18, 0xde, 0x6c, 0xa4, 0xdc, 0xe4, 0x62, 0x6a, 0x66, 0x4f, 0xc7, 0xe7, 0xa9, 0xca,
-	0xf6, 0xa2, 0x52, 0xe9, 0xbe, 0x3c, 0xe9, 0xe3, 0x5f, 0x36, 0xd8, 0xf7, 0x52, 0xee, 0x28, 0x05,
-	0xbb, 0x48, 0xf7, 0x82, 0x59, 0x23, 0x6b, 0xd2, 0xe5, 0xa6, 0xd6, 0x6c, 0x97, 0x15, 0x6b, 0x76,
-	0x79, 0x62, 0xba, 0xd6, 0xac, 0x4c, 0xd5, 0x96, 0xb5, 0x4e, 0x4c, 0xd7, 0x74, 0x08, 0x9d, 0x7d,
-	0x5a, 0x64, 0xcf, 0xa2, 0x52, 0xcc, 0x36, 0xbc, 0xe9, 0xe9, 0x7b, 0x70, 0xc4, 0x77, 0x51, 0xa8,
-	0x8a, 0xb5, 0x47, 0xad, 0xc9, 0xe0, 0x96, 0x79, 0x2f, 0x17, 0xf4, 0xf4, 0xb7, 0x3d, 0xd4, 0x02,
-	0xaf, 0x3d, 0xfa, 0x11, 0x3a, 0x79, 0x5a, 0xa9, 0xe4, 0x70, 0x2c, 0x98, 0x33, 0xb2, 0x26, 0xbd,
-	0xdb, 0xa1, 0x77, 0x8a, 0xe1, 0x9d, 0x63, 0x78, 0xf1, 0x39, 0x06, 0x77, 0xb5, 0xcb, 0x8f, 0x05,
-	0x7d, 0x03, 0xce, 0x0f, 0x91, 0x6d, 0xb6, 0x8a, 0xb9, 0x23, 0x6b, 0xd2, 0xe6, 0x75, 0x47, 0xef,
-	0xe1, 0x7a, 0x2d, 0x72, 0xa1, 0x44, 0x52, 0xca, 0x3c, 0xfb, 0x96, 0x89, 0x8a, 0x75, 0xcc, 0x26,
-	0x37, 0xff, 0xd9, 0x24, 0x30, 0xe6, 0x52, 0x8b, 0x3f, 0xf9, 0x60, 0xfd, 0xb7, 0xcb, 0x44, 0x35,
-	0xfe, 0x6d, 0x41, 0xdb, 0xac, 0x4a, 0x7b, 0xe0, 0xae, 0xe6, 0x8f, 0xf3, 0xc5, 0x97, 0x39, 0xb9,
-	0xa0, 0xd7, 0xd0, 0x5b, 0x72, 0x4c, 0x1e, 0xe6, 0x51, 0xec, 0x87, 0x21, 0xb1, 0x28, 0x81, 0xfe,
-	0x72, 0x11, 0xc5, 0x0d, 0xb9, 0xa4, 0x03, 0x00, 0xad, 0x04, 0x18, 0x62, 0x8c, 0xa4, 0x65, 0x8e,
-	0x68, 0xa3, 0x06, 0xf6, 0xf9, 0x8e, 0xd5, 0xf2, 0x33, 0xf7, 0x03, 0x24, 0xed, 0xe6, 0x8e, 0x33,
-	0x71, 0x0c, 0xe1, 0x98, 0xf0, 0x45, 0x18, 0xde, 0xf9, 0xb3, 0x47, 0xe2, 0xd2, 0x57, 0x70, 0x65,
-	0x9c, 0x06, 0x75, 0x28, 0x83, 0xd7, 0x1c, 0x43, 0xf4, 0x23, 0x4c, 0x62, 0x8c, 0xe2, 0x24, 0x5a,
-	0xcd, 0x66, 0x18, 0x45, 0xa4, 0xfb, 0xcf, 0xe4, 0x93, 0xff, 0x10, 0xae, 0x38, 0x12, 0x18, 0xbf,
-	0x83, 0xfe, 0xcb, 0xd8, 0xf4, 0x0a, 0xba, 0xe6, 0x18, 0x06, 0x18, 0x90, 0x0b, 0x0a, 0xe0, 0x68,
-	0x17, 0x03, 0x62, 0xdd, 0x75, 0xbf, 0xba, 0xf5, 0xef, 0x7a, 0x72, 0xcc, 0x5b, 0x7c, 0xf8, 0x13,
-	0x00, 0x00, 0xff, 0xff, 0xb9, 0x8a, 0xe1, 0xaf, 0x89, 0x02, 0x00, 0x00,
+	// 451 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x51, 0x8f, 0x9a, 0x40,
+	0x10, 0x80, 0xe5, 0x44, 0xd4, 0xd1, 0xf3, 0xb6, 0x9b, 0xa6, 0xdd, 0xf8, 0x72, 0xc6, 0x27, 0x9f,
+	0xb0, 0xb9, 0xa6, 0x3f, 0x00, 0x61, 0xae, 0x1a, 0x09, 0x98, 0x05, 0xd3, 0xa4, 0x2f, 0x84, 0xab,
+	0x7b, 0x4a, 0x44, 0x20, 0x82, 0x6d, 0xfa, 0x03, 0xfb, 0x07, 0x9a, 0xfe, 0x96, 0x3e, 0x37, 0xbb,
+	0xa2, 0xbd, 0xa4, 0x7d, 0x9b, 0xf9, 0xe6, 0x9b, 0x61, 0x86, 0x85, 0xb7, 0xbb, 0xb8, 0x48, 0xa6,
+	0x47, 0x91, 0x8a, 0xb8, 0x14, 0xd3, 0x5d, 0x9e, 0xef, 0xcd, 0xe2, 0x98, 0x57, 0x39, 0xed, 0xcb,
+	0x82, 0x59, 0x17, 0x86, 0xf7, 0xdb, 0x3c, 0xdf, 0xa6, 0x62, 0xaa, 0x6a, 0x4f, 0xa7, 0xe7, 0x69,
+	0x95, 0x1c, 0x44, 0x59, 0xc5, 0x87, 0xe2, 0xac, 0x8f, 0x7f, 0xe8, 0xa0, 0xcf, 0xf3, 0x7c, 0x4f,
+	0x29, 0xe8, 0x59, 0x7c, 0x10, 0x4c, 0x1b, 0x69, 0x93, 0x2e, 0x57, 0xb1, 0x64, 0xfb, 0x24, 0xdb,
+	0xb0, 0x9b, 0x33, 0x93, 0xb1, 0x64, 0x45, 0x5c, 0xed, 0x58, 0xf3, 0xcc, 0x64, 0x4c, 0x87, 0xd0,
+	0x39, 0xc4, 0x59, 0xf2, 0x2c, 0xca, 0x8a, 0xe9, 0x8a, 0x5f, 0x73, 0xfa, 0x0e, 0x0c, 0xf1, 0x55,
+	0x64, 0x55, 0xc9, 0x5a, 0xa3, 0xe6, 0x64, 0xf0, 0xc0, 0xcc, 0x97, 0x0b, 0x9a, 0xf2, 0xdb, 0x26,
+	0x4a, 0x81, 0xd7, 0x1e, 0xfd, 0x00, 0x9d, 0x34, 0x2e, 0xab, 0xe8, 0x78, 0xca, 0x98, 0x31, 0xd2,
+	0x26, 0xbd, 0x87, 0xa1, 0x79, 0x3e, 0xc3, 0xbc, 0x9c, 0x61, 0x86, 0x97, 0x33, 0x78, 0x5b, 0xba,
+	0xfc, 0x94, 0xd1, 0x37, 0x60, 0x7c, 0x13, 0xc9, 0x76, 0x57, 0xb1, 0xf6, 0x48, 0x9b, 0xb4, 0x78,
+	0x9d, 0xd1, 0x39, 0xdc, 0x6d, 0x44, 0x2a, 0x2a, 0x11, 0x15, 0x79, 0x9a, 0x7c, 0x49, 0x44, 0xc9,
+	0x3a, 0x6a, 0x93, 0xfb, 0xff, 0x6c, 0xe2, 0x28, 0x73, 0x25, 0xc5, 0xef, 0x7c, 0xb0, 0xf9, 0x9b,
+	0x25, 0xa2, 0x1c, 0xff, 0xd2, 0xa0, 0xa5, 0x56, 0xa5, 0x3d, 0x68, 0xaf, 0xbd, 0xa5, 0xe7, 0x7f,
+	0xf2, 0x48, 0x83, 0xde, 0x41, 0x6f, 0xc5, 0x31, 0x5a, 0x78, 0x41, 0x68, 0xb9, 0x2e, 0xd1, 0x28,
+	0x81, 0xfe, 0xca, 0x0f, 0xc2, 0x2b, 0xb9, 0xa1, 0x03, 0x00, 0xa9, 0x38, 0xe8, 0x62, 0x88, 0xa4,
+	0xa9, 0x5a, 0xa4, 0x51, 0x03, 0xfd, 0x32, 0x63, 0xbd, 0xfa, 0xc8, 0x2d, 0x07, 0x49, 0xeb, 0x3a,
+	0xe3, 0x42, 0x0c, 0x45, 0x38, 0x46, 0xdc, 0x77, 0xdd, 0x99, 0x65, 0x2f, 0x49, 0x9b, 0xbe, 0x82,
+	0x5b, 0xe5, 0x5c, 0x51, 0x87, 0x32, 0x78, 0xcd, 0xd1, 0x45, 0x2b, 0xc0, 0x28, 0xc4, 0x20, 0x8c,
+	0x82, 0xb5, 0x6d, 0x63, 0x10, 0x90, 0xee, 0x3f, 0x95, 0x47, 0x6b, 0xe1, 0xae, 0x39, 0x12, 0x18,
+	0xdb, 0xd0, 0x7f, 0x79, 0x36, 0xbd, 0x85, 0xae, 0x6a, 0x43, 0x07, 0x1d, 0xd2, 0xa0, 0x00, 0x86,
+	0x74, 0xd1, 0x21, 0x9a, 0x1c, 0x32, 0xc3, 0x47, 0x9f, 0x63, 0x34, 0xf7, 0xfd, 0x65, 0x64, 0x73,
+	0xb4, 0xc2, 0x85, 0xef, 0x91, 0x9b, 0x59, 0xf7, 0x73, 0xbb, 0xfe, 0x91, 0x4f, 0x86, 0x7a, 0xa5,
+	0xf7, 0x3f, 0x7f, 0x37, 0xf5, 0x4e, 0x83, 0x35, 0xfe, 0x0c, 0x00, 0x33, 0x9d, 0xef, 0x98, 0xab,
+	0x02, 0x00, 0x00,
 }
@@ -22,6 +22,10 @@ type Info struct {
 	Deleted *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=deleted" json:"deleted,omitempty"`
 	// Description is human-friendly "log entry" about this release.
 	Description string `protobuf:"bytes,5,opt,name=Description" json:"Description,omitempty"`
+	// Paused blocks upgrades of the release until it is resumed.
+	Paused bool `protobuf:"varint,6,opt,name=paused" json:"paused,omitempty"`
+	// BuildInfo records the build that produced this release.
+	BuildInfo *BuildInfo `protobuf:"bytes,7,opt,name=build_info,json=buildInfo" json:"build_info,omitempty"`
 }
 
 func (m *Info) Reset()                    { *m = Info{} }
@@ -64,27 +68,84 @@ func (m *Info) GetDescription() string {
 	return ""
 }
 
+func (m *Info) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+func (m *Info) GetBuildInfo() *BuildInfo {
+	if m != nil {
+		return m.BuildInfo
+	}
+	return nil
+}
+
+// BuildInfo describes the build, typically a CI run, that produced a release.
+type BuildInfo struct {
+	// Pipeline names the pipeline or job that ran the build.
+	Pipeline string `protobuf:"bytes,1,opt,name=pipeline" json:"pipeline,omitempty"`
+	// Commit is the source revision that was built.
+	Commit string `protobuf:"bytes,2,opt,name=commit" json:"commit,omitempty"`
+	// Actor is who, or what, triggered the build.
+	Actor string `protobuf:"bytes,3,opt,name=actor" json:"actor,omitempty"`
+}
+
+func (m *BuildInfo) Reset()                    { *m = BuildInfo{} }
+func (m *BuildInfo) String() string            { return proto.CompactTextString(m) }
+func (*BuildInfo) ProtoMessage()               {}
+func (*BuildInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+
+func (m *BuildInfo) GetPipeline() string {
+	if m != nil {
+		return m.Pipeline
+	}
+	return ""
+}
+
+func (m *BuildInfo) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *BuildInfo) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Info)(nil), "hapi.release.Info")
+	proto.RegisterType((*BuildInfo)(nil), "hapi.release.BuildInfo")
 }
 
 func init() { proto.RegisterFile("hapi/release/info.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 235 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x8f, 0x31, 0x4f, 0xc3, 0x30,
-	0x10, 0x85, 0x95, 0x52, 0x5a, 0xd5, 0x6d, 0x19, 0x2c, 0x24, 0x42, 0x16, 0x22, 0xa6, 0x0e, 0xc8,
-	0x91, 0x80, 0x1d, 0x81, 0xba, 0xb0, 0x06, 0x26, 0x16, 0xe4, 0xe2, 0x73, 0xb1, 0xe4, 0xe6, 0x2c,
-	0xfb, 0x3a, 0xf0, 0x2f, 0xf8, 0xc9, 0xa8, 0xb6, 0x83, 0xd2, 0xa9, 0xab, 0xbf, 0xf7, 0x3e, 0xbf,
-	0x63, 0x57, 0xdf, 0xd2, 0x99, 0xc6, 0x83, 0x05, 0x19, 0xa0, 0x31, 0x9d, 0x46, 0xe1, 0x3c, 0x12,
-	0xf2, 0xc5, 0x01, 0x88, 0x0c, 0xaa, 0x9b, 0x2d, 0xe2, 0xd6, 0x42, 0x13, 0xd9, 0x66, 0xaf, 0x1b,
-	0x32, 0x3b, 0x08, 0x24, 0x77, 0x2e, 0xc5, 0xab, 0xeb, 0x23, 0x4f, 0x20, 0x49, 0xfb, 0x90, 0xd0,
-	0xed, 0xef, 0x88, 0x8d, 0x5f, 0x3b, 0x8d, 0xfc, 0x8e, 0x4d, 0x12, 0x28, 0x8b, 0xba, 0x58, 0xcd,
-	0xef, 0x2f, 0xc5, 0xf0, 0x0f, 0xf1, 0x16, 0x59, 0x9b, 0x33, 0xfc, 0x99, 0x5d, 0x68, 0xe3, 0x03,
-	0x7d, 0x2a, 0x70, 0x16, 0x7f, 0x40, 0x95, 0xa3, 0xd8, 0xaa, 0x44, 0xda, 0x22, 0xfa, 0x2d, 0xe2,
-	0xbd, 0xdf, 0xd2, 0x2e, 0x63, 0x63, 0x9d, 0x0b, 0xfc, 0x89, 0x2d, 0xad, 0x1c, 0x1a, 0xce, 0x4e,
-	0x1a, 0x16, 0x87, 0xc2, 0xbf, 0xe0, 0x91, 0x4d, 0x15, 0x58, 0x20, 0x50, 0xe5, 0xf8, 0x64, 0xb5,
-	0x8f, 0xf2, 0x9a, 0xcd, 0xd7, 0x10, 0xbe, 0xbc, 0x71, 0x64, 0xb0, 0x2b, 0xcf, 0xeb, 0x62, 0x35,
-	0x6b, 0x87, 0x4f, 0x2f, 0xb3, 0x8f, 0x69, 0xbe, 0x7a, 0x33, 0x89, 0xa6, 0x87, 0xbf, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x1a, 0x52, 0x8f, 0x9c, 0x89, 0x01, 0x00, 0x00,
+	// 326 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x51, 0x3d, 0x6f, 0xab, 0x30,
+	0x14, 0x0d, 0xf9, 0x20, 0xc1, 0x49, 0xde, 0x60, 0x45, 0x2f, 0x7e, 0x2c, 0x0f, 0x65, 0xca, 0x50,
+	0x19, 0xa9, 0xad, 0xba, 0x56, 0x8d, 0xb2, 0x74, 0x75, 0xdb, 0xa5, 0x4b, 0x64, 0xc2, 0x25, 0xb5,
+	0x64, 0xb0, 0x85, 0xcd, 0xd0, 0xbf, 0xd9, 0x5f, 0xd0, 0x5f, 0xd1, 0xb9, 0xc2, 0x40, 0x44, 0xa6,
+	0x6c, 0x9c, 0x7b, 0xee, 0x39, 0xe7, 0x72, 0x8c, 0xd6, 0x1f, 0x5c, 0x8b, 0xb8, 0x04, 0x09, 0xdc,
+	0x40, 0x2c, 0x8a, 0x4c, 0x51, 0x5d, 0x2a, 0xab, 0xf0, 0xa2, 0x26, 0x68, 0x4b, 0x84, 0xff, 0x4f,
+	0x4a, 0x9d, 0x24, 0xc4, 0x8e, 0x4b, 0xaa, 0x2c, 0xb6, 0x22, 0x07, 0x63, 0x79, 0xae, 0x9b, 0xf5,
+	0xf0, 0xdf, 0x85, 0x8f, 0xb1, 0xdc, 0x56, 0xa6, 0xa1, 0x36, 0xdf, 0x43, 0x34, 0x7e, 0x2e, 0x32,
+	0x85, 0x6f, 0x90, 0xdf, 0x10, 0xc4, 0x8b, 0xbc, 0xed, 0xfc, 0x76, 0x45, 0xfb, 0x19, 0xf4, 0xc5,
+	0x71, 0xac, 0xdd, 0xc1, 0x4f, 0xe8, 0x4f, 0x26, 0x4a, 0x63, 0x0f, 0x29, 0x68, 0xa9, 0x3e, 0x21,
+	0x25, 0x43, 0xa7, 0x0a, 0x69, 0x73, 0x0b, 0xed, 0x6e, 0xa1, 0xaf, 0xdd, 0x2d, 0x6c, 0xe9, 0x14,
+	0xfb, 0x56, 0x80, 0x1f, 0xd1, 0x52, 0xf2, 0xbe, 0xc3, 0xe8, 0xaa, 0xc3, 0x42, 0xf2, 0x9e, 0xc1,
+	0x3d, 0x9a, 0xa6, 0x20, 0xc1, 0x42, 0x4a, 0xc6, 0x57, 0xa5, 0xdd, 0x2a, 0x8e, 0xd0, 0x7c, 0x0f,
+	0xe6, 0x58, 0x0a, 0x6d, 0x85, 0x2a, 0xc8, 0x24, 0xf2, 0xb6, 0x01, 0xeb, 0x8f, 0xf0, 0x5f, 0xe4,
+	0x6b, 0x5e, 0x19, 0x48, 0x89, 0x1f, 0x79, 0xdb, 0x19, 0x6b, 0x11, 0x7e, 0x40, 0x28, 0xa9, 0x84,
+	0x4c, 0x0f, 0xf5, 0x43, 0x90, 0xa9, 0x8b, 0x5c, 0x5f, 0xb6, 0xb4, 0xab, 0xf9, 0xba, 0x4e, 0x16,
+	0x24, 0xdd, 0xe7, 0xe6, 0x0d, 0x05, 0xe7, 0x39, 0x0e, 0xd1, 0x4c, 0x0b, 0x0d, 0x52, 0x14, 0xe0,
+	0x8a, 0x0e, 0xd8, 0x19, 0xd7, 0xc1, 0x47, 0x95, 0xe7, 0xc2, 0xba, 0x32, 0x03, 0xd6, 0x22, 0xbc,
+	0x42, 0x13, 0x7e, 0xb4, 0xaa, 0x74, 0x0d, 0x05, 0xac, 0x01, 0xbb, 0xe0, 0x7d, 0xda, 0xc6, 0x26,
+	0xbe, 0xfb, 0xe1, 0xbb, 0xaf, 0x9f, 0xd1, 0x78, 0x36, 0x20, 0x83, 0xdf, 0x01, 0x00, 0x1a, 0x54,
+	0x35, 0xc2, 0x38, 0x02, 0x00, 0x00,
 }
@@ -135,6 +135,12 @@ func (m *VersionReleaseResponse) GetVersion() string {
 
 type InstallReleaseRequest struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	// take_ownership adopts resources that already exist in the cluster,
+	// even if another release owns them.
+	TakeOwnership bool `protobuf:"varint,2,opt,name=take_ownership,json=takeOwnership" json:"take_ownership,omitempty"`
+	// continue_on_error attempts every resource of the release and reports
+	// the failures together, instead of stopping at the first failure.
+	ContinueOnError bool `protobuf:"varint,3,opt,name=continue_on_error,json=continueOnError" json:"continue_on_error,omitempty"`
 }
 
 func (m *InstallReleaseRequest) Reset()                    { *m = InstallReleaseRequest{} }
@@ -149,6 +155,20 @@ func (m *InstallReleaseRequest) GetRelease() *hapi_release5.Release {
 	return nil
 }
 
+func (m *InstallReleaseRequest) GetTakeOwnership() bool {
+	if m != nil {
+		return m.TakeOwnership
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetContinueOnError() bool {
+	if m != nil {
+		return m.ContinueOnError
+	}
+	return false
+}
+
 type InstallReleaseResponse struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
 	Result  *Result                `protobuf:"bytes,2,opt,name=result" json:"result,omitempty"`
@@ -175,6 +195,11 @@ func (m *InstallReleaseResponse) GetResult() *Result {
 
 type DeleteReleaseRequest struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	// cascade is the propagation policy for dependents of deleted resources:
+	// Foreground, Background or Orphan.
+	Cascade string `protobuf:"bytes,2,opt,name=cascade" json:"cascade,omitempty"`
+	// grace_period is the number of seconds each resource is given to terminate.
+	GracePeriod int64 `protobuf:"varint,3,opt,name=grace_period,json=gracePeriod" json:"grace_period,omitempty"`
 }
 
 func (m *DeleteReleaseRequest) Reset()                    { *m = DeleteReleaseRequest{} }
@@ -189,6 +214,20 @@ func (m *DeleteReleaseRequest) GetRelease() *hapi_release5.Release {
 	return nil
 }
 
+func (m *DeleteReleaseRequest) GetCascade() string {
+	if m != nil {
+		return m.Cascade
+	}
+	return ""
+}
+
+func (m *DeleteReleaseRequest) GetGracePeriod() int64 {
+	if m != nil {
+		return m.GracePeriod
+	}
+	return 0
+}
+
 type DeleteReleaseResponse struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
 	Result  *Result                `protobuf:"bytes,2,opt,name=result" json:"result,omitempty"`
@@ -220,6 +259,12 @@ type UpgradeReleaseRequest struct {
 	Wait     bool                   `protobuf:"varint,4,opt,name=Wait" json:"Wait,omitempty"`
 	Recreate bool                   `protobuf:"varint,5,opt,name=Recreate" json:"Recreate,omitempty"`
 	Force    bool                   `protobuf:"varint,6,opt,name=Force" json:"Force,omitempty"`
+	// take_ownership adopts resources that already exist in the cluster,
+	// even if another release owns them.
+	TakeOwnership bool `protobuf:"varint,7,opt,name=take_ownership,json=takeOwnership" json:"take_ownership,omitempty"`
+	// cleanup_on_fail deletes the resources created by the upgrade if
+	// it fails.
+	CleanupOnFail bool `protobuf:"varint,8,opt,name=cleanup_on_fail,json=cleanupOnFail" json:"cleanup_on_fail,omitempty"`
 }
 
 func (m *UpgradeReleaseRequest) Reset()                    { *m = UpgradeReleaseRequest{} }
@@ -269,6 +314,20 @@ func (m *UpgradeReleaseRequest) GetForce() bool {
 	return false
 }
 
+func (m *UpgradeReleaseRequest) GetTakeOwnership() bool {
+	if m != nil {
+		return m.TakeOwnership
+	}
+	return false
+}
+
+func (m *UpgradeReleaseRequest) GetCleanupOnFail() bool {
+	if m != nil {
+		return m.CleanupOnFail
+	}
+	return false
+}
+
 type UpgradeReleaseResponse struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
 	Result  *Result                `protobuf:"bytes,2,opt,name=result" json:"result,omitempty"`
@@ -680,43 +739,50 @@ var _ReleaseModuleService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("hapi/rudder/rudder.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 597 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0x5f, 0x8f, 0xd2, 0x4e,
-	0x14, 0xa5, 0xb0, 0x14, 0xb8, 0x64, 0x7f, 0x3f, 0x32, 0xa1, 0xd0, 0x34, 0x3e, 0x90, 0x3e, 0x18,
-	0xe2, 0xba, 0x25, 0x41, 0x1f, 0x7d, 0x51, 0x96, 0xfd, 0x13, 0x23, 0x9b, 0x0c, 0xe2, 0x26, 0xbe,
-	0x75, 0xe1, 0x82, 0xd5, 0xd2, 0xd6, 0xe9, 0x74, 0x1f, 0xd5, 0x4f, 0xe3, 0x57, 0xd2, 0x8f, 0x63,
-	0xda, 0x69, 0x89, 0xad, 0xd3, 0x88, 0x6b, 0xc2, 0x83, 0x4f, 0x9d, 0xe9, 0x3d, 0xdc, 0x39, 0xe7,
-	0xf4, 0xce, 0x09, 0xa0, 0xbf, 0xb3, 0x03, 0x67, 0xc4, 0xa2, 0xd5, 0x0a, 0x59, 0xfa, 0xb0, 0x02,
-	0xe6, 0x73, 0x9f, 0x74, 0xe3, 0x8a, 0x15, 0x22, 0xbb, 0x73, 0x96, 0x18, 0x5a, 0xa2, 0x66, 0xf4,
-	0x05, 0x1e, 0x5d, 0xb4, 0x43, 0x1c, 0x39, 0xde, 0xda, 0x17, 0x70, 0xc3, 0xc8, 0x15, 0xd2, 0xa7,
-	0xa8, 0x99, 0x2e, 0xa8, 0x14, 0xc3, 0xc8, 0xe5, 0x84, 0xc0, 0x51, 0xfc, 0x1b, 0x5d, 0x19, 0x28,
-	0xc3, 0x16, 0x4d, 0xd6, 0xa4, 0x03, 0x35, 0xd7, 0xdf, 0xe8, 0xd5, 0x41, 0x6d, 0xd8, 0xa2, 0xf1,
-	0xd2, 0x7c, 0x06, 0xea, 0x9c, 0xdb, 0x3c, 0x0a, 0x49, 0x1b, 0x1a, 0x8b, 0xd9, 0xcb, 0xd9, 0xf5,
-	0xcd, 0xac, 0x53, 0x89, 0x37, 0xf3, 0xc5, 0x64, 0x32, 0x9d, 0xcf, 0x3b, 0x0a, 0x39, 0x86, 0xd6,
-	0x62, 0x36, 0xb9, 0x7c, 0x3e, 0xbb, 0x98, 0x9e, 0x75, 0xaa, 0xa4, 0x05, 0xf5, 0x29, 0xa5, 0xd7,
-	0xb4, 0x53, 0x33, 0xfb, 0xa0, 0xbd, 0x41, 0x16, 0x3a, 0xbe, 0x47, 0x05, 0x0b, 0x8a, 0x1f, 0x23,
-	0x0c, 0xb9, 0x79, 0x0e, 0xbd, 0x62, 0x21, 0x0c, 0x7c, 0x2f, 0xc4, 0x98, 0x96, 0x67, 0x6f, 0x31,
-	0xa3, 0x15, 0xaf, 0x89, 0x0e, 0x8d, 0x3b, 0x81, 0xd6, 0xab, 0xc9, 0xeb, 0x6c, 0x6b, 0x5e, 0x82,
-	0x76, 0xe5, 0x85, 0xdc, 0x76, 0xdd, 0xfc, 0x01, 0x64, 0x04, 0x8d, 0x54, 0x78, 0xd2, 0xa9, 0x3d,
-	0xd6, 0xac, 0xc4, 0xc4, 0xcc, 0x8d, 0x0c, 0x9e, 0xa1, 0xcc, 0xcf, 0xd0, 0x2b, 0x76, 0x4a, 0x19,
-	0xfd, 0x69, 0x2b, 0xf2, 0x14, 0x54, 0x96, 0x78, 0x9c, 0xb0, 0x6d, 0x8f, 0x1f, 0x58, 0xb2, 0xef,
-	0x67, 0x89, 0xef, 0x40, 0x53, 0xac, 0x79, 0x01, 0xdd, 0x33, 0x74, 0x91, 0xe3, 0xdf, 0x2a, 0xf9,
-	0x04, 0x5a, 0xa1, 0xd1, 0x61, 0x85, 0x7c, 0x53, 0x40, 0x5b, 0x04, 0x1b, 0x66, 0xaf, 0x24, 0x52,
-	0x96, 0x11, 0x63, 0xe8, 0xf1, 0xdf, 0x10, 0x48, 0x51, 0xe4, 0x14, 0x54, 0x6e, 0xb3, 0x0d, 0x66,
-	0x04, 0x4a, 0xf0, 0x29, 0x28, 0x9e, 0x93, 0xd7, 0xce, 0x16, 0xfd, 0x88, 0xeb, 0xb5, 0x81, 0x32,
-	0xac, 0xd1, 0x6c, 0x1b, 0x4f, 0xd5, 0x8d, 0xed, 0x70, 0xfd, 0x68, 0xa0, 0x0c, 0x9b, 0x34, 0x59,
-	0x13, 0x03, 0x9a, 0x14, 0x97, 0x0c, 0x6d, 0x8e, 0x7a, 0x3d, 0x79, 0xbf, 0xdb, 0x93, 0x2e, 0xd4,
-	0xcf, 0x7d, 0xb6, 0x44, 0x5d, 0x4d, 0x0a, 0x62, 0x13, 0xcf, 0x48, 0x51, 0xd8, 0x61, 0xad, 0xfd,
-	0xae, 0x40, 0x8f, 0xfa, 0xae, 0x7b, 0x6b, 0x2f, 0x3f, 0xfc, 0x63, 0xde, 0x7e, 0x51, 0xa0, 0xff,
-	0x8b, 0xb4, 0x83, 0xdf, 0xc0, 0xb4, 0x93, 0x88, 0xbc, 0x7b, 0xdf, 0xc0, 0x00, 0xb4, 0x42, 0xa3,
-	0xfb, 0x0a, 0x79, 0x98, 0x86, 0xb4, 0x90, 0x41, 0xf2, 0xe8, 0x2b, 0x6f, 0xed, 0x8b, 0xe0, 0x1e,
-	0x7f, 0xad, 0xef, 0xb8, 0xbf, 0xf2, 0x57, 0x91, 0x8b, 0x73, 0x21, 0x95, 0xac, 0xa1, 0x91, 0x06,
-	0x2d, 0x39, 0x91, 0x9b, 0x20, 0x0d, 0x68, 0xe3, 0xf1, 0x7e, 0x60, 0xa1, 0xcb, 0xac, 0x90, 0x2d,
-	0xfc, 0x97, 0x8f, 0xcf, 0xb2, 0xe3, 0xa4, 0x71, 0x5d, 0x76, 0x9c, 0x3c, 0x91, 0xcd, 0x0a, 0x79,
-	0x0f, 0xc7, 0xb9, 0x8c, 0x23, 0x8f, 0xe4, 0x0d, 0x64, 0x89, 0x6a, 0x9c, 0xec, 0x85, 0xdd, 0x9d,
-	0x15, 0xc0, 0xff, 0x85, 0xc1, 0x24, 0x25, 0x74, 0xe5, 0x57, 0xd3, 0x38, 0xdd, 0x13, 0xfd, 0xb3,
-	0x99, 0xf9, 0x9c, 0x29, 0x33, 0x53, 0x1a, 0xb3, 0x65, 0x66, 0xca, 0xa3, 0x4b, 0x98, 0x99, 0x1b,
-	0xd7, 0x32, 0x33, 0x65, 0x97, 0xa3, 0xcc, 0x4c, 0xe9, 0xfc, 0x9b, 0x95, 0x17, 0xcd, 0xb7, 0xaa,
-	0x40, 0xdc, 0xaa, 0xc9, 0x1f, 0x92, 0x27, 0x3f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xb6, 0xa5, 0x37,
-	0x75, 0xf7, 0x08, 0x00, 0x00,
+	// 719 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0x4d, 0x6f, 0xd3, 0x4c,
+	0x10, 0xce, 0x47, 0xf3, 0x35, 0x79, 0xdb, 0xe6, 0x5d, 0x25, 0xad, 0x65, 0xbd, 0x87, 0xbe, 0x96,
+	0xa8, 0xaa, 0x7e, 0xa4, 0x52, 0xe1, 0xc8, 0x05, 0xda, 0xb4, 0x54, 0x88, 0x04, 0x39, 0x84, 0x4a,
+	0x5c, 0xa2, 0xad, 0x33, 0x49, 0x4d, 0xdd, 0x5d, 0xb3, 0x5e, 0x97, 0x1b, 0x70, 0xe0, 0x5f, 0x70,
+	0xef, 0x0f, 0xe2, 0xc4, 0x2f, 0xe1, 0x8c, 0xbc, 0x6b, 0x47, 0x75, 0x70, 0x44, 0x28, 0x52, 0x0f,
+	0x9c, 0xbc, 0x33, 0xf3, 0x64, 0xe7, 0x99, 0x67, 0x67, 0x67, 0x03, 0xc6, 0x05, 0xf5, 0xdd, 0x7d,
+	0x11, 0x8e, 0x46, 0x28, 0xe2, 0x4f, 0xdb, 0x17, 0x5c, 0x72, 0xd2, 0x8c, 0x22, 0xed, 0x00, 0xc5,
+	0xb5, 0xeb, 0x60, 0xd0, 0xd6, 0x31, 0x73, 0x5d, 0xe3, 0xd1, 0x43, 0x1a, 0xe0, 0xbe, 0xcb, 0xc6,
+	0x5c, 0xc3, 0x4d, 0x33, 0x15, 0x88, 0xbf, 0x3a, 0x66, 0x79, 0x50, 0xb6, 0x31, 0x08, 0x3d, 0x49,
+	0x08, 0x2c, 0x45, 0xbf, 0x31, 0xf2, 0x1b, 0xf9, 0xad, 0x9a, 0xad, 0xd6, 0xa4, 0x01, 0x45, 0x8f,
+	0x4f, 0x8c, 0xc2, 0x46, 0x71, 0xab, 0x66, 0x47, 0x4b, 0xeb, 0x31, 0x94, 0xfb, 0x92, 0xca, 0x30,
+	0x20, 0x75, 0xa8, 0x0c, 0xba, 0xcf, 0xbb, 0xbd, 0xb3, 0x6e, 0x23, 0x17, 0x19, 0xfd, 0xc1, 0xe1,
+	0x61, 0xa7, 0xdf, 0x6f, 0xe4, 0xc9, 0x32, 0xd4, 0x06, 0xdd, 0xc3, 0x67, 0x4f, 0xba, 0x27, 0x9d,
+	0xa3, 0x46, 0x81, 0xd4, 0xa0, 0xd4, 0xb1, 0xed, 0x9e, 0xdd, 0x28, 0x5a, 0xeb, 0xd0, 0x7a, 0x8d,
+	0x22, 0x70, 0x39, 0xb3, 0x35, 0x0b, 0x1b, 0xdf, 0x85, 0x18, 0x48, 0xeb, 0x18, 0xd6, 0x66, 0x03,
+	0x81, 0xcf, 0x59, 0x80, 0x11, 0x2d, 0x46, 0xaf, 0x30, 0xa1, 0x15, 0xad, 0x89, 0x01, 0x95, 0x6b,
+	0x8d, 0x36, 0x0a, 0xca, 0x9d, 0x98, 0xd6, 0x97, 0x3c, 0xb4, 0x4e, 0x59, 0x20, 0xa9, 0xe7, 0xa5,
+	0x33, 0x90, 0x7d, 0xa8, 0xc4, 0x95, 0xab, 0xad, 0xea, 0x07, 0xad, 0xb6, 0x52, 0x31, 0x91, 0x23,
+	0x81, 0x27, 0x28, 0xf2, 0x00, 0x56, 0x24, 0xbd, 0xc4, 0x21, 0x7f, 0xcf, 0x50, 0x04, 0x17, 0xae,
+	0xaf, 0x72, 0x55, 0xed, 0xe5, 0xc8, 0xdb, 0x4b, 0x9c, 0x64, 0x1b, 0xfe, 0x75, 0x38, 0x93, 0x2e,
+	0x0b, 0x71, 0xc8, 0xd9, 0x10, 0x85, 0xe0, 0xc2, 0x28, 0x2a, 0xe4, 0x6a, 0x12, 0xe8, 0xb1, 0x4e,
+	0xe4, 0xb6, 0x3e, 0xc2, 0xda, 0x2c, 0xb9, 0xb8, 0xca, 0xdf, 0x66, 0xf7, 0x08, 0xca, 0x42, 0x9d,
+	0x9b, 0x62, 0x55, 0x3f, 0xf8, 0xaf, 0x9d, 0xd5, 0x13, 0x6d, 0x7d, 0xb6, 0x76, 0x8c, 0xb5, 0x3e,
+	0xe7, 0xa1, 0x79, 0x84, 0x1e, 0x4a, 0xfc, 0x53, 0x75, 0x0c, 0xa8, 0x38, 0x34, 0x70, 0xe8, 0x08,
+	0x93, 0x23, 0x88, 0x4d, 0xf2, 0x3f, 0xfc, 0x33, 0x11, 0xd4, 0xc1, 0xa1, 0x8f, 0xc2, 0xe5, 0x23,
+	0xa5, 0x45, 0xd1, 0xae, 0x2b, 0xdf, 0x4b, 0xe5, 0xb2, 0x3e, 0x40, 0x6b, 0x86, 0xc5, 0xfd, 0xca,
+	0x70, 0x53, 0x80, 0xd6, 0xc0, 0x9f, 0x08, 0x3a, 0xca, 0xd0, 0xc1, 0x09, 0x85, 0x40, 0x26, 0x7f,
+	0x41, 0x20, 0x46, 0x91, 0x3d, 0x28, 0x4b, 0x2a, 0x26, 0x98, 0x10, 0x98, 0x83, 0x8f, 0x41, 0x91,
+	0x6c, 0xaf, 0xdc, 0x2b, 0xe4, 0xa1, 0x8c, 0x75, 0x49, 0xcc, 0xa8, 0xcf, 0xcf, 0xa8, 0x2b, 0x8d,
+	0x25, 0xd5, 0x3a, 0x6a, 0x4d, 0x4c, 0xa8, 0xda, 0xe8, 0x08, 0xa4, 0x12, 0x8d, 0x92, 0xf2, 0x4f,
+	0x6d, 0xd2, 0x84, 0xd2, 0x31, 0x17, 0x0e, 0x1a, 0x65, 0x15, 0xd0, 0x46, 0x46, 0xd3, 0x56, 0xb2,
+	0x9a, 0x76, 0x13, 0x56, 0x1d, 0x0f, 0x29, 0x0b, 0xfd, 0xa8, 0x67, 0xc7, 0xd4, 0xf5, 0x8c, 0xaa,
+	0xc6, 0xc5, 0xee, 0x1e, 0x3b, 0xa6, 0xae, 0x17, 0x35, 0xec, 0xac, 0x4e, 0xf7, 0x7b, 0x52, 0xdf,
+	0xf2, 0xb0, 0x66, 0x73, 0xcf, 0x3b, 0xa7, 0xce, 0xe5, 0xdf, 0x75, 0x54, 0xd6, 0xa7, 0x3c, 0xac,
+	0xff, 0x54, 0xda, 0xfd, 0xaa, 0x7b, 0x02, 0xcd, 0x78, 0x27, 0x3d, 0xd3, 0xef, 0x3a, 0x0d, 0x2c,
+	0x1f, 0x5a, 0x33, 0x1b, 0xdd, 0xb5, 0x90, 0xcd, 0xf8, 0x15, 0xd2, 0x65, 0x90, 0x34, 0xfa, 0x94,
+	0x8d, 0xb9, 0x7e, 0x99, 0x0e, 0x6e, 0x4a, 0x53, 0xee, 0x2f, 0xf8, 0x28, 0xf4, 0xb0, 0xaf, 0x4b,
+	0x25, 0x63, 0xa8, 0xc4, 0x2f, 0x09, 0xd9, 0xc9, 0x16, 0x21, 0xf3, 0x05, 0x32, 0x77, 0x17, 0x03,
+	0xeb, 0xba, 0xac, 0x1c, 0xb9, 0x82, 0x95, 0xf4, 0x2c, 0x9f, 0x97, 0x2e, 0xf3, 0x39, 0x32, 0x77,
+	0x17, 0x03, 0x4f, 0xd3, 0xbd, 0x85, 0xe5, 0xd4, 0xc8, 0x24, 0xdb, 0xd9, 0x1b, 0x64, 0x4d, 0x77,
+	0x73, 0x67, 0x21, 0xec, 0x34, 0x97, 0x0f, 0xab, 0x33, 0x8d, 0x49, 0xe6, 0xd0, 0xcd, 0xbe, 0x9a,
+	0xe6, 0xde, 0x82, 0xe8, 0xdb, 0x62, 0xa6, 0xe7, 0xcc, 0x3c, 0x31, 0x33, 0xa7, 0xb6, 0xb9, 0xbb,
+	0x18, 0xf8, 0xb6, 0x98, 0xa9, 0x76, 0x9d, 0x27, 0x66, 0xd6, 0xe5, 0x30, 0x77, 0x16, 0xc2, 0x26,
+	0xb9, 0x9e, 0x56, 0xdf, 0x94, 0x35, 0xe2, 0xbc, 0xac, 0xfe, 0x71, 0x3d, 0xfc, 0xfa, 0xbd, 0xb8,
+	0x54, 0xcd, 0x19, 0xb9, 0x1f, 0x03, 0x00, 0x6a, 0x09, 0x3e, 0x06, 0xe0, 0x09, 0x00, 0x00,
 }
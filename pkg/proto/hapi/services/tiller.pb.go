@@ -10,6 +10,7 @@ It is generated from these files:
 It has these top-level messages:
 	ListReleasesRequest
 	ListSort
+	ListSortKey
 	ListReleasesResponse
 	GetReleaseStatusRequest
 	GetReleaseStatusResponse
@@ -21,14 +22,31 @@ It has these top-level messages:
 	RollbackReleaseResponse
 	InstallReleaseRequest
 	InstallReleaseResponse
+	InstallReleaseEvent
+	HookPlan
+	HookPlanStep
 	UninstallReleaseRequest
 	UninstallReleaseResponse
+	DeleteReleasesRequest
+	DeleteReleasesResult
+	DeleteReleasesResponse
 	GetVersionRequest
 	GetVersionResponse
 	GetHistoryRequest
 	GetHistoryResponse
 	TestReleaseRequest
 	TestReleaseResponse
+	InspectChartRequest
+	InspectChartResponse
+	ChartFile
+	GetReleaseAllRequest
+	GetReleaseAllResponse
+	RenderReleaseRequest
+	RenderReleaseResponse
+	SetReleasePausedRequest
+	SetReleasePausedResponse
+	GetServerInfoRequest
+	GetServerInfoResponse
 */
 package services
 
@@ -37,11 +55,14 @@ import fmt "fmt"
 import math "math"
 import hapi_chart3 "k8s.io/helm/pkg/proto/hapi/chart"
 import hapi_chart "k8s.io/helm/pkg/proto/hapi/chart"
+import hapi_chart1 "k8s.io/helm/pkg/proto/hapi/chart"
+import hapi_release "k8s.io/helm/pkg/proto/hapi/release"
 import hapi_release5 "k8s.io/helm/pkg/proto/hapi/release"
 import hapi_release4 "k8s.io/helm/pkg/proto/hapi/release"
 import hapi_release1 "k8s.io/helm/pkg/proto/hapi/release"
 import hapi_release3 "k8s.io/helm/pkg/proto/hapi/release"
 import hapi_version "k8s.io/helm/pkg/proto/hapi/version"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/timestamp"
 
 import (
 	context "golang.org/x/net/context"
@@ -66,17 +87,26 @@ const (
 	ListSort_UNKNOWN       ListSort_SortBy = 0
 	ListSort_NAME          ListSort_SortBy = 1
 	ListSort_LAST_RELEASED ListSort_SortBy = 2
+	ListSort_NAMESPACE     ListSort_SortBy = 3
+	ListSort_CHART_NAME    ListSort_SortBy = 4
+	ListSort_REVISION      ListSort_SortBy = 5
 )
 
 var ListSort_SortBy_name = map[int32]string{
 	0: "UNKNOWN",
 	1: "NAME",
 	2: "LAST_RELEASED",
+	3: "NAMESPACE",
+	4: "CHART_NAME",
+	5: "REVISION",
 }
 var ListSort_SortBy_value = map[string]int32{
 	"UNKNOWN":       0,
 	"NAME":          1,
 	"LAST_RELEASED": 2,
+	"NAMESPACE":     3,
+	"CHART_NAME":    4,
+	"REVISION":      5,
 }
 
 func (x ListSort_SortBy) String() string {
@@ -131,6 +161,27 @@ type ListReleasesRequest struct {
 	StatusCodes []hapi_release3.Status_Code `protobuf:"varint,6,rep,packed,name=status_codes,json=statusCodes,enum=hapi.release.Status_Code" json:"status_codes,omitempty"`
 	// Namespace is the filter to select releases only from a specific namespace.
 	Namespace string `protobuf:"bytes,7,opt,name=namespace" json:"namespace,omitempty"`
+	// SortKeys are the keys that the ListReleases server should sort data by
+	// before returning. Releases are ordered by the first key, then by the
+	// next one where the previous keys are equal. If set, sort_by and
+	// sort_order are ignored.
+	SortKeys []*ListSortKey `protobuf:"bytes,8,rep,name=sort_keys,json=sortKeys" json:"sort_keys,omitempty"`
+	// Cursor is the next_cursor of a previous listing. The next listing
+	// operation will start with the release after the one the cursor points
+	// to, even if releases were added or removed in the meantime. If set,
+	// offset is ignored.
+	Cursor string `protobuf:"bytes,9,opt,name=cursor" json:"cursor,omitempty"`
+	// ChartName is the filter to select releases only of the chart with this name.
+	ChartName string `protobuf:"bytes,10,opt,name=chart_name,json=chartName" json:"chart_name,omitempty"`
+	// DeployedBefore is the filter to select releases only if they were last
+	// deployed before this time.
+	DeployedBefore *google_protobuf1.Timestamp `protobuf:"bytes,11,opt,name=deployed_before,json=deployedBefore" json:"deployed_before,omitempty"`
+	// DeployedAfter is the filter to select releases only if they were last
+	// deployed after this time.
+	DeployedAfter *google_protobuf1.Timestamp `protobuf:"bytes,12,opt,name=deployed_after,json=deployedAfter" json:"deployed_after,omitempty"`
+	// BuildCommit is the filter to select releases only if they were built
+	// from this commit.
+	BuildCommit string `protobuf:"bytes,13,opt,name=build_commit,json=buildCommit" json:"build_commit,omitempty"`
 }
 
 func (m *ListReleasesRequest) Reset()                    { *m = ListReleasesRequest{} }
@@ -187,6 +238,48 @@ func (m *ListReleasesRequest) GetNamespace() string {
 	return ""
 }
 
+func (m *ListReleasesRequest) GetSortKeys() []*ListSortKey {
+	if m != nil {
+		return m.SortKeys
+	}
+	return nil
+}
+
+func (m *ListReleasesRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *ListReleasesRequest) GetChartName() string {
+	if m != nil {
+		return m.ChartName
+	}
+	return ""
+}
+
+func (m *ListReleasesRequest) GetDeployedBefore() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.DeployedBefore
+	}
+	return nil
+}
+
+func (m *ListReleasesRequest) GetDeployedAfter() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.DeployedAfter
+	}
+	return nil
+}
+
+func (m *ListReleasesRequest) GetBuildCommit() string {
+	if m != nil {
+		return m.BuildCommit
+	}
+	return ""
+}
+
 // ListSort defines sorting fields on a release list.
 type ListSort struct {
 }
@@ -196,6 +289,31 @@ func (m *ListSort) String() string            { return proto.CompactTextString(m
 func (*ListSort) ProtoMessage()               {}
 func (*ListSort) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
+// ListSortKey is a sort field and the order to sort it in.
+type ListSortKey struct {
+	SortBy    ListSort_SortBy    `protobuf:"varint,1,opt,name=sort_by,json=sortBy,enum=hapi.services.tiller.ListSort_SortBy" json:"sort_by,omitempty"`
+	SortOrder ListSort_SortOrder `protobuf:"varint,2,opt,name=sort_order,json=sortOrder,enum=hapi.services.tiller.ListSort_SortOrder" json:"sort_order,omitempty"`
+}
+
+func (m *ListSortKey) Reset()                    { *m = ListSortKey{} }
+func (m *ListSortKey) String() string            { return proto.CompactTextString(m) }
+func (*ListSortKey) ProtoMessage()               {}
+func (*ListSortKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *ListSortKey) GetSortBy() ListSort_SortBy {
+	if m != nil {
+		return m.SortBy
+	}
+	return ListSort_UNKNOWN
+}
+
+func (m *ListSortKey) GetSortOrder() ListSort_SortOrder {
+	if m != nil {
+		return m.SortOrder
+	}
+	return ListSort_ASC
+}
+
 // ListReleasesResponse is a list of releases.
 type ListReleasesResponse struct {
 	// Count is the expected total number of releases to be returned.
@@ -207,12 +325,17 @@ type ListReleasesResponse struct {
 	Total int64 `protobuf:"varint,3,opt,name=total" json:"total,omitempty"`
 	// Releases is the list of found release objects.
 	Releases []*hapi_release5.Release `protobuf:"bytes,4,rep,name=releases" json:"releases,omitempty"`
+	// NextCursor is an opaque cursor pointing after the last returned
+	// release. If this is other than an empty string, it means there are more
+	// results, which can be listed by passing it as the cursor of the next
+	// request.
+	NextCursor string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor" json:"next_cursor,omitempty"`
 }
 
 func (m *ListReleasesResponse) Reset()                    { *m = ListReleasesResponse{} }
 func (m *ListReleasesResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListReleasesResponse) ProtoMessage()               {}
-func (*ListReleasesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*ListReleasesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 func (m *ListReleasesResponse) GetCount() int64 {
 	if m != nil {
@@ -242,6 +365,13 @@ func (m *ListReleasesResponse) GetReleases() []*hapi_release5.Release {
 	return nil
 }
 
+func (m *ListReleasesResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
 // GetReleaseStatusRequest is a request to get the status of a release.
 type GetReleaseStatusRequest struct {
 	// Name is the name of the release
@@ -253,7 +383,7 @@ type GetReleaseStatusRequest struct {
 func (m *GetReleaseStatusRequest) Reset()                    { *m = GetReleaseStatusRequest{} }
 func (m *GetReleaseStatusRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetReleaseStatusRequest) ProtoMessage()               {}
-func (*GetReleaseStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*GetReleaseStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *GetReleaseStatusRequest) GetName() string {
 	if m != nil {
@@ -277,12 +407,20 @@ type GetReleaseStatusResponse struct {
 	Info *hapi_release4.Info `protobuf:"bytes,2,opt,name=info" json:"info,omitempty"`
 	// Namesapce the release was released into
 	Namespace string `protobuf:"bytes,3,opt,name=namespace" json:"namespace,omitempty"`
+	// Version is the revision of the release.
+	Version int32 `protobuf:"varint,4,opt,name=version" json:"version,omitempty"`
+	// Config is the set of values supplied by the user. It is only set if a
+	// specific revision was requested.
+	Config *hapi_chart.Config `protobuf:"bytes,5,opt,name=config" json:"config,omitempty"`
+	// Manifest is the string representation of the rendered template. It is
+	// only set if a specific revision was requested.
+	Manifest string `protobuf:"bytes,6,opt,name=manifest" json:"manifest,omitempty"`
 }
 
 func (m *GetReleaseStatusResponse) Reset()                    { *m = GetReleaseStatusResponse{} }
 func (m *GetReleaseStatusResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetReleaseStatusResponse) ProtoMessage()               {}
-func (*GetReleaseStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*GetReleaseStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *GetReleaseStatusResponse) GetName() string {
 	if m != nil {
@@ -305,6 +443,27 @@ func (m *GetReleaseStatusResponse) GetNamespace() string {
 	return ""
 }
 
+func (m *GetReleaseStatusResponse) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GetReleaseStatusResponse) GetConfig() *hapi_chart.Config {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *GetReleaseStatusResponse) GetManifest() string {
+	if m != nil {
+		return m.Manifest
+	}
+	return ""
+}
+
 // GetReleaseContentRequest is a request to get the contents of a release.
 type GetReleaseContentRequest struct {
 	// The name of the release
@@ -316,7 +475,7 @@ type GetReleaseContentRequest struct {
 func (m *GetReleaseContentRequest) Reset()                    { *m = GetReleaseContentRequest{} }
 func (m *GetReleaseContentRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetReleaseContentRequest) ProtoMessage()               {}
-func (*GetReleaseContentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*GetReleaseContentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *GetReleaseContentRequest) GetName() string {
 	if m != nil {
@@ -341,7 +500,7 @@ type GetReleaseContentResponse struct {
 func (m *GetReleaseContentResponse) Reset()                    { *m = GetReleaseContentResponse{} }
 func (m *GetReleaseContentResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetReleaseContentResponse) ProtoMessage()               {}
-func (*GetReleaseContentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*GetReleaseContentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *GetReleaseContentResponse) GetRelease() *hapi_release5.Release {
 	if m != nil {
@@ -376,12 +535,55 @@ type UpdateReleaseRequest struct {
 	ReuseValues bool `protobuf:"varint,10,opt,name=reuse_values,json=reuseValues" json:"reuse_values,omitempty"`
 	// Force resource update through delete/recreate if needed.
 	Force bool `protobuf:"varint,11,opt,name=force" json:"force,omitempty"`
+	// Description, if set, is stored on the release instead of the default
+	// "Upgrade complete".
+	Description string `protobuf:"bytes,12,opt,name=description" json:"description,omitempty"`
+	// CommonLabels, if true, adds the recommended app.kubernetes.io labels to
+	// every resource that does not already set them.
+	CommonLabels bool `protobuf:"varint,13,opt,name=common_labels,json=commonLabels" json:"common_labels,omitempty"`
+	// ForcePaused upgrades the release even if it is paused.
+	ForcePaused bool `protobuf:"varint,14,opt,name=force_paused,json=forcePaused" json:"force_paused,omitempty"`
+	// ValuesFrom references a ConfigMap, as configmap/NAMESPACE/NAME[:KEY],
+	// whose values are merged beneath the values of this request, or those
+	// carried over from the current release. It must be in the namespace of
+	// the release.
+	ValuesFrom string `protobuf:"bytes,15,opt,name=values_from,json=valuesFrom" json:"values_from,omitempty"`
+	// Patches set fields of individual rendered resources before they are
+	// applied, as KIND/NAME:PATH=VALUE.
+	Patches []string `protobuf:"bytes,16,rep,name=patches" json:"patches,omitempty"`
+	// BuildInfo, if set, is recorded on the release. Otherwise the build info
+	// of the release being upgraded is kept.
+	BuildInfo *hapi_release4.BuildInfo `protobuf:"bytes,17,opt,name=build_info,json=buildInfo" json:"build_info,omitempty"`
+	// CleanupOnFail, if true, deletes the resources created by the upgrade if
+	// it fails. Resources that already existed are left as they are.
+	CleanupOnFail bool `protobuf:"varint,18,opt,name=cleanup_on_fail,json=cleanupOnFail" json:"cleanup_on_fail,omitempty"`
+	// FailOnEmpty, if true, fails the upgrade if a template renders to nothing
+	// but whitespace and comments, unless it is marked with the comment
+	// "# helm.sh/allow-empty".
+	FailOnEmpty bool `protobuf:"varint,19,opt,name=fail_on_empty,json=failOnEmpty" json:"fail_on_empty,omitempty"`
+	// PostWaitSettle, if set along with wait, is the number of seconds to
+	// wait after the resources are ready before checking that they still are.
+	// Pods that stopped being ready or restarted fail the upgrade.
+	PostWaitSettle int64 `protobuf:"varint,20,opt,name=post_wait_settle,json=postWaitSettle" json:"post_wait_settle,omitempty"`
+	// SkipUnchanged, if true, does not create a new revision when the
+	// rendered manifests, hooks and values are identical to those of the
+	// deployed release. The deployed release's timestamp is updated instead.
+	// It has no effect together with force.
+	SkipUnchanged bool `protobuf:"varint,21,opt,name=skip_unchanged,json=skipUnchanged" json:"skip_unchanged,omitempty"`
+	// TakeOwnership, if true, adopts resources that already exist in the
+	// cluster, even if they are labeled as owned by another release, instead
+	// of failing.
+	TakeOwnership bool `protobuf:"varint,22,opt,name=take_ownership,json=takeOwnership" json:"take_ownership,omitempty"`
+	// OrderedApply, if true, applies the resources in the order of their
+	// template files instead of by kind. Namespaces and
+	// CustomResourceDefinitions are still applied first.
+	OrderedApply bool `protobuf:"varint,23,opt,name=ordered_apply,json=orderedApply" json:"ordered_apply,omitempty"`
 }
 
 func (m *UpdateReleaseRequest) Reset()                    { *m = UpdateReleaseRequest{} }
 func (m *UpdateReleaseRequest) String() string            { return proto.CompactTextString(m) }
 func (*UpdateReleaseRequest) ProtoMessage()               {}
-func (*UpdateReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*UpdateReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *UpdateReleaseRequest) GetName() string {
 	if m != nil {
@@ -460,6 +662,90 @@ func (m *UpdateReleaseRequest) GetForce() bool {
 	return false
 }
 
+func (m *UpdateReleaseRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *UpdateReleaseRequest) GetCommonLabels() bool {
+	if m != nil {
+		return m.CommonLabels
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetForcePaused() bool {
+	if m != nil {
+		return m.ForcePaused
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetValuesFrom() string {
+	if m != nil {
+		return m.ValuesFrom
+	}
+	return ""
+}
+
+func (m *UpdateReleaseRequest) GetPatches() []string {
+	if m != nil {
+		return m.Patches
+	}
+	return nil
+}
+
+func (m *UpdateReleaseRequest) GetBuildInfo() *hapi_release4.BuildInfo {
+	if m != nil {
+		return m.BuildInfo
+	}
+	return nil
+}
+
+func (m *UpdateReleaseRequest) GetCleanupOnFail() bool {
+	if m != nil {
+		return m.CleanupOnFail
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetFailOnEmpty() bool {
+	if m != nil {
+		return m.FailOnEmpty
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetPostWaitSettle() int64 {
+	if m != nil {
+		return m.PostWaitSettle
+	}
+	return 0
+}
+
+func (m *UpdateReleaseRequest) GetSkipUnchanged() bool {
+	if m != nil {
+		return m.SkipUnchanged
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetTakeOwnership() bool {
+	if m != nil {
+		return m.TakeOwnership
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetOrderedApply() bool {
+	if m != nil {
+		return m.OrderedApply
+	}
+	return false
+}
+
 // UpdateReleaseResponse is the response to an update request.
 type UpdateReleaseResponse struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
@@ -468,7 +754,7 @@ type UpdateReleaseResponse struct {
 func (m *UpdateReleaseResponse) Reset()                    { *m = UpdateReleaseResponse{} }
 func (m *UpdateReleaseResponse) String() string            { return proto.CompactTextString(m) }
 func (*UpdateReleaseResponse) ProtoMessage()               {}
-func (*UpdateReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*UpdateReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *UpdateReleaseResponse) GetRelease() *hapi_release5.Release {
 	if m != nil {
@@ -495,12 +781,15 @@ type RollbackReleaseRequest struct {
 	Wait bool `protobuf:"varint,7,opt,name=wait" json:"wait,omitempty"`
 	// Force resource update through delete/recreate if needed.
 	Force bool `protobuf:"varint,8,opt,name=force" json:"force,omitempty"`
+	// Description, if set, is stored on the release instead of the default
+	// "Rollback to <revision>".
+	Description string `protobuf:"bytes,9,opt,name=description" json:"description,omitempty"`
 }
 
 func (m *RollbackReleaseRequest) Reset()                    { *m = RollbackReleaseRequest{} }
 func (m *RollbackReleaseRequest) String() string            { return proto.CompactTextString(m) }
 func (*RollbackReleaseRequest) ProtoMessage()               {}
-func (*RollbackReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*RollbackReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *RollbackReleaseRequest) GetName() string {
 	if m != nil {
@@ -558,6 +847,13 @@ func (m *RollbackReleaseRequest) GetForce() bool {
 	return false
 }
 
+func (m *RollbackReleaseRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
 // RollbackReleaseResponse is the response to an update request.
 type RollbackReleaseResponse struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
@@ -566,7 +862,7 @@ type RollbackReleaseResponse struct {
 func (m *RollbackReleaseResponse) Reset()                    { *m = RollbackReleaseResponse{} }
 func (m *RollbackReleaseResponse) String() string            { return proto.CompactTextString(m) }
 func (*RollbackReleaseResponse) ProtoMessage()               {}
-func (*RollbackReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*RollbackReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *RollbackReleaseResponse) GetRelease() *hapi_release5.Release {
 	if m != nil {
@@ -600,12 +896,66 @@ type InstallReleaseRequest struct {
 	// wait, if true, will wait until all Pods, PVCs, and Services are in a ready state
 	// before marking the release as successful. It will wait for as long as timeout
 	Wait bool `protobuf:"varint,9,opt,name=wait" json:"wait,omitempty"`
+	// atomic, if true, will remove the release and its hooks' resources if the
+	// install fails.
+	Atomic bool `protobuf:"varint,10,opt,name=atomic" json:"atomic,omitempty"`
+	// Description, if set, is stored on the release instead of the default
+	// "Install complete".
+	Description string `protobuf:"bytes,11,opt,name=description" json:"description,omitempty"`
+	// CommonLabels, if true, adds the recommended app.kubernetes.io labels to
+	// every resource that does not already set them.
+	CommonLabels bool `protobuf:"varint,12,opt,name=common_labels,json=commonLabels" json:"common_labels,omitempty"`
+	// SkipCrds, if true, leaves out the CustomResourceDefinitions rendered by
+	// the chart. By default they are created first, and the rest of the
+	// release is only created once they are established.
+	SkipCrds bool `protobuf:"varint,13,opt,name=skip_crds,json=skipCrds" json:"skip_crds,omitempty"`
+	// ValuesFrom references a ConfigMap, as configmap/NAMESPACE/NAME[:KEY],
+	// whose values are merged beneath the values of this request. It must be
+	// in the namespace of the release.
+	ValuesFrom string `protobuf:"bytes,14,opt,name=values_from,json=valuesFrom" json:"values_from,omitempty"`
+	// Patches set fields of individual rendered resources before they are
+	// created, as KIND/NAME:PATH=VALUE.
+	Patches []string `protobuf:"bytes,15,rep,name=patches" json:"patches,omitempty"`
+	// ContinueOnError, if true, attempts to create every resource of the
+	// release even if some of them fail. The release is still marked FAILED.
+	ContinueOnError bool `protobuf:"varint,16,opt,name=continue_on_error,json=continueOnError" json:"continue_on_error,omitempty"`
+	// RenderSubchartNotes, if true, appends the NOTES.txt of every subchart
+	// to the notes of the release, beneath the notes of the chart.
+	RenderSubchartNotes bool `protobuf:"varint,17,opt,name=render_subchart_notes,json=renderSubchartNotes" json:"render_subchart_notes,omitempty"`
+	// BuildInfo, if set, is recorded on the release.
+	BuildInfo *hapi_release4.BuildInfo `protobuf:"bytes,18,opt,name=build_info,json=buildInfo" json:"build_info,omitempty"`
+	// QuotaCheck, if "warn" or "fail", compares the resource requests of the
+	// rendered workloads with the remaining ResourceQuota of the namespace
+	// before installing. Insufficient quota is reported as a warning, or fails
+	// the install.
+	QuotaCheck string `protobuf:"bytes,19,opt,name=quota_check,json=quotaCheck" json:"quota_check,omitempty"`
+	// FailOnEmpty, if true, fails the install if a template renders to nothing
+	// but whitespace and comments, unless it is marked with the comment
+	// "# helm.sh/allow-empty".
+	FailOnEmpty bool `protobuf:"varint,20,opt,name=fail_on_empty,json=failOnEmpty" json:"fail_on_empty,omitempty"`
+	// APICheck, if "warn" or "fail", compares the apiVersions of the rendered
+	// resources with the APIs deprecated or removed in the Kubernetes version
+	// of the cluster before installing. Uses of such APIs are reported as
+	// warnings, or fail the install.
+	ApiCheck string `protobuf:"bytes,21,opt,name=api_check,json=apiCheck" json:"api_check,omitempty"`
+	// PostWaitSettle, if set along with wait, is the number of seconds to
+	// wait after the resources are ready before checking that they still are.
+	// Pods that stopped being ready or restarted fail the install.
+	PostWaitSettle int64 `protobuf:"varint,22,opt,name=post_wait_settle,json=postWaitSettle" json:"post_wait_settle,omitempty"`
+	// OrderedApply, if true, applies the resources in the order of their
+	// template files, and of the documents within each file, instead of by
+	// kind. Namespaces and CustomResourceDefinitions still go first.
+	OrderedApply bool `protobuf:"varint,23,opt,name=ordered_apply,json=orderedApply" json:"ordered_apply,omitempty"`
+	// TakeOwnership, if true, adopts resources that already exist in the
+	// cluster, even if they are labeled as owned by another release, instead
+	// of failing.
+	TakeOwnership bool `protobuf:"varint,24,opt,name=take_ownership,json=takeOwnership" json:"take_ownership,omitempty"`
 }
 
 func (m *InstallReleaseRequest) Reset()                    { *m = InstallReleaseRequest{} }
 func (m *InstallReleaseRequest) String() string            { return proto.CompactTextString(m) }
 func (*InstallReleaseRequest) ProtoMessage()               {}
-func (*InstallReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*InstallReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *InstallReleaseRequest) GetChart() *hapi_chart3.Chart {
 	if m != nil {
@@ -670,15 +1020,125 @@ func (m *InstallReleaseRequest) GetWait() bool {
 	return false
 }
 
+func (m *InstallReleaseRequest) GetAtomic() bool {
+	if m != nil {
+		return m.Atomic
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *InstallReleaseRequest) GetCommonLabels() bool {
+	if m != nil {
+		return m.CommonLabels
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetSkipCrds() bool {
+	if m != nil {
+		return m.SkipCrds
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetValuesFrom() string {
+	if m != nil {
+		return m.ValuesFrom
+	}
+	return ""
+}
+
+func (m *InstallReleaseRequest) GetPatches() []string {
+	if m != nil {
+		return m.Patches
+	}
+	return nil
+}
+
+func (m *InstallReleaseRequest) GetContinueOnError() bool {
+	if m != nil {
+		return m.ContinueOnError
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetRenderSubchartNotes() bool {
+	if m != nil {
+		return m.RenderSubchartNotes
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetBuildInfo() *hapi_release4.BuildInfo {
+	if m != nil {
+		return m.BuildInfo
+	}
+	return nil
+}
+
+func (m *InstallReleaseRequest) GetQuotaCheck() string {
+	if m != nil {
+		return m.QuotaCheck
+	}
+	return ""
+}
+
+func (m *InstallReleaseRequest) GetFailOnEmpty() bool {
+	if m != nil {
+		return m.FailOnEmpty
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetApiCheck() string {
+	if m != nil {
+		return m.ApiCheck
+	}
+	return ""
+}
+
+func (m *InstallReleaseRequest) GetPostWaitSettle() int64 {
+	if m != nil {
+		return m.PostWaitSettle
+	}
+	return 0
+}
+
+func (m *InstallReleaseRequest) GetOrderedApply() bool {
+	if m != nil {
+		return m.OrderedApply
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetTakeOwnership() bool {
+	if m != nil {
+		return m.TakeOwnership
+	}
+	return false
+}
+
 // InstallReleaseResponse is the response from a release installation.
 type InstallReleaseResponse struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	// HookPlan, set on a dry run, lists the hooks the install would run.
+	HookPlan *HookPlan `protobuf:"bytes,2,opt,name=hook_plan,json=hookPlan" json:"hook_plan,omitempty"`
+	// Warnings are the findings of the checks run before the install that did
+	// not fail it.
+	Warnings []string `protobuf:"bytes,3,rep,name=warnings" json:"warnings,omitempty"`
 }
 
 func (m *InstallReleaseResponse) Reset()                    { *m = InstallReleaseResponse{} }
 func (m *InstallReleaseResponse) String() string            { return proto.CompactTextString(m) }
 func (*InstallReleaseResponse) ProtoMessage()               {}
-func (*InstallReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*InstallReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *InstallReleaseResponse) GetRelease() *hapi_release5.Release {
 	if m != nil {
@@ -687,211 +1147,969 @@ func (m *InstallReleaseResponse) GetRelease() *hapi_release5.Release {
 	return nil
 }
 
-// UninstallReleaseRequest represents a request to uninstall a named release.
-type UninstallReleaseRequest struct {
-	// Name is the name of the release to delete.
-	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	// DisableHooks causes the server to skip running any hooks for the uninstall.
-	DisableHooks bool `protobuf:"varint,2,opt,name=disable_hooks,json=disableHooks" json:"disable_hooks,omitempty"`
-	// Purge removes the release from the store and make its name free for later use.
-	Purge bool `protobuf:"varint,3,opt,name=purge" json:"purge,omitempty"`
-	// timeout specifies the max amount of time any kubernetes client command can run.
-	Timeout int64 `protobuf:"varint,4,opt,name=timeout" json:"timeout,omitempty"`
+func (m *InstallReleaseResponse) GetHookPlan() *HookPlan {
+	if m != nil {
+		return m.HookPlan
+	}
+	return nil
 }
 
-func (m *UninstallReleaseRequest) Reset()                    { *m = UninstallReleaseRequest{} }
-func (m *UninstallReleaseRequest) String() string            { return proto.CompactTextString(m) }
-func (*UninstallReleaseRequest) ProtoMessage()               {}
-func (*UninstallReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (m *InstallReleaseResponse) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
 
-func (m *UninstallReleaseRequest) GetName() string {
+// InstallReleaseEvent reports a step of an install streamed by
+// InstallReleaseStream. Events are sent as each step starts.
+type InstallReleaseEvent struct {
+	// Stage is the step of the install: "rendered", "pre-install",
+	// "applying", "waiting", "post-install" or, for the last event, "done".
+	Stage string `protobuf:"bytes,1,opt,name=stage" json:"stage,omitempty"`
+	// Message describes the step, such as the resource being applied.
+	Message string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+	// Response, set on the last event only, is the result of the install. It
+	// is sent even if the install failed, as long as a release was created.
+	Response *InstallReleaseResponse `protobuf:"bytes,3,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *InstallReleaseEvent) Reset()                    { *m = InstallReleaseEvent{} }
+func (m *InstallReleaseEvent) String() string            { return proto.CompactTextString(m) }
+func (*InstallReleaseEvent) ProtoMessage()               {}
+func (*InstallReleaseEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *InstallReleaseEvent) GetStage() string {
 	if m != nil {
-		return m.Name
+		return m.Stage
 	}
 	return ""
 }
 
-func (m *UninstallReleaseRequest) GetDisableHooks() bool {
+func (m *InstallReleaseEvent) GetMessage() string {
 	if m != nil {
-		return m.DisableHooks
+		return m.Message
 	}
-	return false
+	return ""
 }
 
-func (m *UninstallReleaseRequest) GetPurge() bool {
+func (m *InstallReleaseEvent) GetResponse() *InstallReleaseResponse {
 	if m != nil {
-		return m.Purge
+		return m.Response
 	}
-	return false
+	return nil
 }
 
-func (m *UninstallReleaseRequest) GetTimeout() int64 {
+// HookPlan lists the hooks that an operation would run, in the order it would
+// run them.
+type HookPlan struct {
+	Steps []*HookPlanStep `protobuf:"bytes,1,rep,name=steps" json:"steps,omitempty"`
+	// Warnings describe the malformed hook annotations of the chart.
+	Warnings []string `protobuf:"bytes,2,rep,name=warnings" json:"warnings,omitempty"`
+}
+
+func (m *HookPlan) Reset()                    { *m = HookPlan{} }
+func (m *HookPlan) String() string            { return proto.CompactTextString(m) }
+func (*HookPlan) ProtoMessage()               {}
+func (*HookPlan) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *HookPlan) GetSteps() []*HookPlanStep {
 	if m != nil {
-		return m.Timeout
+		return m.Steps
 	}
-	return 0
+	return nil
 }
 
-// UninstallReleaseResponse represents a successful response to an uninstall request.
-type UninstallReleaseResponse struct {
-	// Release is the release that was marked deleted.
-	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
-	// Info is an uninstall message
-	Info string `protobuf:"bytes,2,opt,name=info" json:"info,omitempty"`
+func (m *HookPlan) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
+// HookPlanStep is a hook that runs on an event of an operation.
+type HookPlanStep struct {
+	Event          hapi_release.Hook_Event          `protobuf:"varint,1,opt,name=event,enum=hapi.release.Hook_Event" json:"event,omitempty"`
+	Name           string                           `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Kind           string                           `protobuf:"bytes,3,opt,name=kind" json:"kind,omitempty"`
+	Path           string                           `protobuf:"bytes,4,opt,name=path" json:"path,omitempty"`
+	Weight         int32                            `protobuf:"varint,5,opt,name=weight" json:"weight,omitempty"`
+	DeletePolicies []hapi_release.Hook_DeletePolicy `protobuf:"varint,6,rep,packed,name=delete_policies,json=deletePolicies,enum=hapi.release.Hook_DeletePolicy" json:"delete_policies,omitempty"`
+}
+
+func (m *HookPlanStep) Reset()                    { *m = HookPlanStep{} }
+func (m *HookPlanStep) String() string            { return proto.CompactTextString(m) }
+func (*HookPlanStep) ProtoMessage()               {}
+func (*HookPlanStep) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *HookPlanStep) GetEvent() hapi_release.Hook_Event {
+	if m != nil {
+		return m.Event
+	}
+	return hapi_release.Hook_UNKNOWN
+}
+
+func (m *HookPlanStep) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HookPlanStep) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *HookPlanStep) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *HookPlanStep) GetWeight() int32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *HookPlanStep) GetDeletePolicies() []hapi_release.Hook_DeletePolicy {
+	if m != nil {
+		return m.DeletePolicies
+	}
+	return nil
+}
+
+// UninstallReleaseRequest represents a request to uninstall a named release.
+type UninstallReleaseRequest struct {
+	// Name is the name of the release to delete.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// DisableHooks causes the server to skip running any hooks for the uninstall.
+	DisableHooks bool `protobuf:"varint,2,opt,name=disable_hooks,json=disableHooks" json:"disable_hooks,omitempty"`
+	// Purge removes the release from the store and make its name free for later use.
+	Purge bool `protobuf:"varint,3,opt,name=purge" json:"purge,omitempty"`
+	// timeout specifies the max amount of time any kubernetes client command can run.
+	Timeout int64 `protobuf:"varint,4,opt,name=timeout" json:"timeout,omitempty"`
+	// cascade is the propagation policy for dependents of deleted resources:
+	// Foreground, Background or Orphan.
+	Cascade string `protobuf:"bytes,5,opt,name=cascade" json:"cascade,omitempty"`
+	// grace_period is the number of seconds each resource is given to terminate.
+	GracePeriod int64 `protobuf:"varint,6,opt,name=grace_period,json=gracePeriod" json:"grace_period,omitempty"`
+	// wait, if true, waits for pre-delete and post-delete hook Pods and Jobs
+	// to run to completion.
+	Wait bool `protobuf:"varint,7,opt,name=wait" json:"wait,omitempty"`
+	// continue_on_hook_failure, if true, deletes the release even if a
+	// pre-delete hook fails. By default a failing pre-delete hook aborts the
+	// deletion.
+	ContinueOnHookFailure bool `protobuf:"varint,8,opt,name=continue_on_hook_failure,json=continueOnHookFailure" json:"continue_on_hook_failure,omitempty"`
+}
+
+func (m *UninstallReleaseRequest) Reset()                    { *m = UninstallReleaseRequest{} }
+func (m *UninstallReleaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*UninstallReleaseRequest) ProtoMessage()               {}
+func (*UninstallReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *UninstallReleaseRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UninstallReleaseRequest) GetDisableHooks() bool {
+	if m != nil {
+		return m.DisableHooks
+	}
+	return false
+}
+
+func (m *UninstallReleaseRequest) GetPurge() bool {
+	if m != nil {
+		return m.Purge
+	}
+	return false
+}
+
+func (m *UninstallReleaseRequest) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *UninstallReleaseRequest) GetCascade() string {
+	if m != nil {
+		return m.Cascade
+	}
+	return ""
+}
+
+func (m *UninstallReleaseRequest) GetGracePeriod() int64 {
+	if m != nil {
+		return m.GracePeriod
+	}
+	return 0
+}
+
+func (m *UninstallReleaseRequest) GetWait() bool {
+	if m != nil {
+		return m.Wait
+	}
+	return false
+}
+
+func (m *UninstallReleaseRequest) GetContinueOnHookFailure() bool {
+	if m != nil {
+		return m.ContinueOnHookFailure
+	}
+	return false
+}
+
+// UninstallReleaseResponse represents a successful response to an uninstall request.
+type UninstallReleaseResponse struct {
+	// Release is the release that was marked deleted.
+	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	// Info is an uninstall message
+	Info string `protobuf:"bytes,2,opt,name=info" json:"info,omitempty"`
 }
 
 func (m *UninstallReleaseResponse) Reset()                    { *m = UninstallReleaseResponse{} }
 func (m *UninstallReleaseResponse) String() string            { return proto.CompactTextString(m) }
 func (*UninstallReleaseResponse) ProtoMessage()               {}
-func (*UninstallReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*UninstallReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *UninstallReleaseResponse) GetRelease() *hapi_release5.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+func (m *UninstallReleaseResponse) GetInfo() string {
+	if m != nil {
+		return m.Info
+	}
+	return ""
+}
+
+// DeleteReleasesRequest requests the deletion of several releases, by name or
+// by filter.
+type DeleteReleasesRequest struct {
+	// Names are the names of the releases to delete.
+	Names []string `protobuf:"bytes,1,rep,name=names" json:"names,omitempty"`
+	// Filter is a regular expression. Releases whose names match it are
+	// deleted as well, unless they are already deleted.
+	Filter string `protobuf:"bytes,2,opt,name=filter" json:"filter,omitempty"`
+	// Namespace, if set, limits the releases matched by the filter to those
+	// in this namespace.
+	Namespace string `protobuf:"bytes,3,opt,name=namespace" json:"namespace,omitempty"`
+	// Options apply to the deletion of every release. Their name is ignored.
+	Options *UninstallReleaseRequest `protobuf:"bytes,4,opt,name=options" json:"options,omitempty"`
+	// DryRun, if true, reports the releases that would be deleted without
+	// deleting them.
+	DryRun bool `protobuf:"varint,5,opt,name=dry_run,json=dryRun" json:"dry_run,omitempty"`
+	// Selector is a comma-separated list of key=value storage labels, such as
+	// STATUS=FAILED. Releases whose last revision is stored with all of them
+	// are deleted as well.
+	Selector string `protobuf:"bytes,6,opt,name=selector" json:"selector,omitempty"`
+}
+
+func (m *DeleteReleasesRequest) Reset()                    { *m = DeleteReleasesRequest{} }
+func (m *DeleteReleasesRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteReleasesRequest) ProtoMessage()               {}
+func (*DeleteReleasesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *DeleteReleasesRequest) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+func (m *DeleteReleasesRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *DeleteReleasesRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *DeleteReleasesRequest) GetOptions() *UninstallReleaseRequest {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *DeleteReleasesRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *DeleteReleasesRequest) GetSelector() string {
+	if m != nil {
+		return m.Selector
+	}
+	return ""
+}
+
+// DeleteReleasesResult is the result of deleting one release.
+type DeleteReleasesResult struct {
+	// Name is the name of the release.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Response is the response to the deletion of the release, if it succeeded.
+	Response *UninstallReleaseResponse `protobuf:"bytes,2,opt,name=response" json:"response,omitempty"`
+	// Error is the reason the release could not be deleted, if it failed.
+	Error string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *DeleteReleasesResult) Reset()                    { *m = DeleteReleasesResult{} }
+func (m *DeleteReleasesResult) String() string            { return proto.CompactTextString(m) }
+func (*DeleteReleasesResult) ProtoMessage()               {}
+func (*DeleteReleasesResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *DeleteReleasesResult) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeleteReleasesResult) GetResponse() *UninstallReleaseResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *DeleteReleasesResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// DeleteReleasesResponse reports the result of deleting each release, in
+// the order the releases were deleted.
+type DeleteReleasesResponse struct {
+	Results []*DeleteReleasesResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *DeleteReleasesResponse) Reset()                    { *m = DeleteReleasesResponse{} }
+func (m *DeleteReleasesResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteReleasesResponse) ProtoMessage()               {}
+func (*DeleteReleasesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *DeleteReleasesResponse) GetResults() []*DeleteReleasesResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// GetVersionRequest requests for version information.
+type GetVersionRequest struct {
+}
+
+func (m *GetVersionRequest) Reset()                    { *m = GetVersionRequest{} }
+func (m *GetVersionRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetVersionRequest) ProtoMessage()               {}
+func (*GetVersionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+type GetVersionResponse struct {
+	Version *hapi_version.Version `protobuf:"bytes,1,opt,name=Version" json:"Version,omitempty"`
+}
+
+func (m *GetVersionResponse) Reset()                    { *m = GetVersionResponse{} }
+func (m *GetVersionResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetVersionResponse) ProtoMessage()               {}
+func (*GetVersionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *GetVersionResponse) GetVersion() *hapi_version.Version {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+// GetHistoryRequest requests a release's history.
+type GetHistoryRequest struct {
+	// The name of the release.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// The maximum number of releases to include.
+	Max int32 `protobuf:"varint,2,opt,name=max" json:"max,omitempty"`
+}
+
+func (m *GetHistoryRequest) Reset()                    { *m = GetHistoryRequest{} }
+func (m *GetHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetHistoryRequest) ProtoMessage()               {}
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *GetHistoryRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetHistoryRequest) GetMax() int32 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+// GetHistoryResponse is received in response to a GetHistory rpc.
+type GetHistoryResponse struct {
+	Releases []*hapi_release5.Release `protobuf:"bytes,1,rep,name=releases" json:"releases,omitempty"`
+}
+
+func (m *GetHistoryResponse) Reset()                    { *m = GetHistoryResponse{} }
+func (m *GetHistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetHistoryResponse) ProtoMessage()               {}
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *GetHistoryResponse) GetReleases() []*hapi_release5.Release {
+	if m != nil {
+		return m.Releases
+	}
+	return nil
+}
+
+// TestReleaseRequest is a request to get the status of a release.
+type TestReleaseRequest struct {
+	// Name is the name of the release
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// timeout specifies the max amount of time any kubernetes client command can run.
+	Timeout int64 `protobuf:"varint,2,opt,name=timeout" json:"timeout,omitempty"`
+	// cleanup specifies whether or not to attempt pod deletion after test completes
+	Cleanup bool `protobuf:"varint,3,opt,name=cleanup" json:"cleanup,omitempty"`
+	// filters restricts the tests run to those whose name matches one of the
+	// filters, either "name=GLOB" or "name=~REGEX"
+	Filters []string `protobuf:"bytes,4,rep,name=filters" json:"filters,omitempty"`
+}
+
+func (m *TestReleaseRequest) Reset()                    { *m = TestReleaseRequest{} }
+func (m *TestReleaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*TestReleaseRequest) ProtoMessage()               {}
+func (*TestReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *TestReleaseRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TestReleaseRequest) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *TestReleaseRequest) GetCleanup() bool {
+	if m != nil {
+		return m.Cleanup
+	}
+	return false
+}
+
+func (m *TestReleaseRequest) GetFilters() []string {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
+// TestReleaseResponse represents a message from executing a test
+type TestReleaseResponse struct {
+	Msg    string                       `protobuf:"bytes,1,opt,name=msg" json:"msg,omitempty"`
+	Status hapi_release1.TestRun_Status `protobuf:"varint,2,opt,name=status,enum=hapi.release.TestRun_Status" json:"status,omitempty"`
+}
+
+func (m *TestReleaseResponse) Reset()                    { *m = TestReleaseResponse{} }
+func (m *TestReleaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*TestReleaseResponse) ProtoMessage()               {}
+func (*TestReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *TestReleaseResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *TestReleaseResponse) GetStatus() hapi_release1.TestRun_Status {
+	if m != nil {
+		return m.Status
+	}
+	return hapi_release1.TestRun_UNKNOWN
+}
+
+// InspectChartRequest is a request to describe the contents of a chart.
+type InspectChartRequest struct {
+	// Chart is the protobuf representation of a chart.
+	Chart *hapi_chart3.Chart `protobuf:"bytes,1,opt,name=chart" json:"chart,omitempty"`
+}
+
+func (m *InspectChartRequest) Reset()                    { *m = InspectChartRequest{} }
+func (m *InspectChartRequest) String() string            { return proto.CompactTextString(m) }
+func (*InspectChartRequest) ProtoMessage()               {}
+func (*InspectChartRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *InspectChartRequest) GetChart() *hapi_chart3.Chart {
+	if m != nil {
+		return m.Chart
+	}
+	return nil
+}
+
+// InspectChartResponse describes the contents of a chart.
+type InspectChartResponse struct {
+	// Metadata is the contents of the chart's Chart.yaml.
+	Metadata *hapi_chart1.Metadata `protobuf:"bytes,1,opt,name=metadata" json:"metadata,omitempty"`
+	// Templates are the names of the chart's template files.
+	Templates []string `protobuf:"bytes,2,rep,name=templates" json:"templates,omitempty"`
+	// Values are the chart's default values.
+	Values *hapi_chart.Config `protobuf:"bytes,3,opt,name=values" json:"values,omitempty"`
+	// Readme is the content of the chart's README, if it has one.
+	Readme string `protobuf:"bytes,4,opt,name=readme" json:"readme,omitempty"`
+	// Files summarizes the chart's other files.
+	Files []*ChartFile `protobuf:"bytes,5,rep,name=files" json:"files,omitempty"`
+}
+
+func (m *InspectChartResponse) Reset()                    { *m = InspectChartResponse{} }
+func (m *InspectChartResponse) String() string            { return proto.CompactTextString(m) }
+func (*InspectChartResponse) ProtoMessage()               {}
+func (*InspectChartResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *InspectChartResponse) GetMetadata() *hapi_chart1.Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *InspectChartResponse) GetTemplates() []string {
+	if m != nil {
+		return m.Templates
+	}
+	return nil
+}
+
+func (m *InspectChartResponse) GetValues() *hapi_chart.Config {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *InspectChartResponse) GetReadme() string {
+	if m != nil {
+		return m.Readme
+	}
+	return ""
+}
+
+func (m *InspectChartResponse) GetFiles() []*ChartFile {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+// ChartFile summarizes a non-template file in a chart. File contents are not
+// included.
+type ChartFile struct {
+	// Name is the path of the file within the chart.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Size is the size of the file in bytes.
+	Size int64 `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
+	// Binary is true if the file does not appear to contain text.
+	Binary bool `protobuf:"varint,3,opt,name=binary" json:"binary,omitempty"`
+}
+
+func (m *ChartFile) Reset()                    { *m = ChartFile{} }
+func (m *ChartFile) String() string            { return proto.CompactTextString(m) }
+func (*ChartFile) ProtoMessage()               {}
+func (*ChartFile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *ChartFile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ChartFile) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *ChartFile) GetBinary() bool {
+	if m != nil {
+		return m.Binary
+	}
+	return false
+}
+
+// GetReleaseAllRequest is a request to get everything known about a release.
+type GetReleaseAllRequest struct {
+	// The name of the release
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Version is the version of the release
+	Version int32 `protobuf:"varint,2,opt,name=version" json:"version,omitempty"`
+	// SkipManifest leaves the manifest out of the response.
+	SkipManifest bool `protobuf:"varint,3,opt,name=skip_manifest,json=skipManifest" json:"skip_manifest,omitempty"`
+	// SkipHooks leaves the hooks out of the response.
+	SkipHooks bool `protobuf:"varint,4,opt,name=skip_hooks,json=skipHooks" json:"skip_hooks,omitempty"`
+	// SkipNotes leaves the notes out of the response.
+	SkipNotes bool `protobuf:"varint,5,opt,name=skip_notes,json=skipNotes" json:"skip_notes,omitempty"`
+}
+
+func (m *GetReleaseAllRequest) Reset()                    { *m = GetReleaseAllRequest{} }
+func (m *GetReleaseAllRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetReleaseAllRequest) ProtoMessage()               {}
+func (*GetReleaseAllRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *GetReleaseAllRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetReleaseAllRequest) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GetReleaseAllRequest) GetSkipManifest() bool {
+	if m != nil {
+		return m.SkipManifest
+	}
+	return false
+}
+
+func (m *GetReleaseAllRequest) GetSkipHooks() bool {
+	if m != nil {
+		return m.SkipHooks
+	}
+	return false
+}
+
+func (m *GetReleaseAllRequest) GetSkipNotes() bool {
+	if m != nil {
+		return m.SkipNotes
+	}
+	return false
+}
+
+// GetReleaseAllResponse describes a release, combining what GetReleaseContent
+// and GetReleaseStatus report and the values computed from the chart.
+type GetReleaseAllResponse struct {
+	// Name is the name of the release.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Namespace is the kubernetes namespace of the release.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	// Version is the revision of the release.
+	Version int32 `protobuf:"varint,3,opt,name=version" json:"version,omitempty"`
+	// Info contains information about the release. The notes are reported
+	// separately.
+	Info *hapi_release4.Info `protobuf:"bytes,4,opt,name=info" json:"info,omitempty"`
+	// Chart is the metadata of the chart that was released.
+	Chart *hapi_chart1.Metadata `protobuf:"bytes,5,opt,name=chart" json:"chart,omitempty"`
+	// Config is the set of values supplied by the user.
+	Config *hapi_chart.Config `protobuf:"bytes,6,opt,name=config" json:"config,omitempty"`
+	// ComputedValues are the user supplied values coalesced with the chart's
+	// default values.
+	ComputedValues *hapi_chart.Config `protobuf:"bytes,7,opt,name=computed_values,json=computedValues" json:"computed_values,omitempty"`
+	// Manifest is the string representation of the rendered template.
+	Manifest string `protobuf:"bytes,8,opt,name=manifest" json:"manifest,omitempty"`
+	// Hooks are all of the hooks declared for this release.
+	Hooks []*hapi_release.Hook `protobuf:"bytes,9,rep,name=hooks" json:"hooks,omitempty"`
+	// Notes are the rendered notes of the chart.
+	Notes string `protobuf:"bytes,10,opt,name=notes" json:"notes,omitempty"`
+}
+
+func (m *GetReleaseAllResponse) Reset()                    { *m = GetReleaseAllResponse{} }
+func (m *GetReleaseAllResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetReleaseAllResponse) ProtoMessage()               {}
+func (*GetReleaseAllResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *GetReleaseAllResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetReleaseAllResponse) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *GetReleaseAllResponse) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GetReleaseAllResponse) GetInfo() *hapi_release4.Info {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *GetReleaseAllResponse) GetChart() *hapi_chart1.Metadata {
+	if m != nil {
+		return m.Chart
+	}
+	return nil
+}
+
+func (m *GetReleaseAllResponse) GetConfig() *hapi_chart.Config {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *GetReleaseAllResponse) GetComputedValues() *hapi_chart.Config {
+	if m != nil {
+		return m.ComputedValues
+	}
+	return nil
+}
+
+func (m *GetReleaseAllResponse) GetManifest() string {
+	if m != nil {
+		return m.Manifest
+	}
+	return ""
+}
+
+func (m *GetReleaseAllResponse) GetHooks() []*hapi_release.Hook {
+	if m != nil {
+		return m.Hooks
+	}
+	return nil
+}
+
+func (m *GetReleaseAllResponse) GetNotes() string {
+	if m != nil {
+		return m.Notes
+	}
+	return ""
+}
+
+// RenderReleaseRequest requests the rendering of the chart of a release with
+// new values.
+type RenderReleaseRequest struct {
+	// Name is the name of the release.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Version is the revision of the release whose chart is rendered. If it is
+	// not set, the deployed revision is used.
+	Version int32 `protobuf:"varint,2,opt,name=version" json:"version,omitempty"`
+	// Values are the values to render the chart with. If they are empty, the
+	// values of the release are used, as an upgrade would.
+	Values *hapi_chart.Config `protobuf:"bytes,3,opt,name=values" json:"values,omitempty"`
+	// ReuseValues, if true, merges the values over those of the release.
+	ReuseValues bool `protobuf:"varint,4,opt,name=reuse_values,json=reuseValues" json:"reuse_values,omitempty"`
+}
+
+func (m *RenderReleaseRequest) Reset()                    { *m = RenderReleaseRequest{} }
+func (m *RenderReleaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*RenderReleaseRequest) ProtoMessage()               {}
+func (*RenderReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *RenderReleaseRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RenderReleaseRequest) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *RenderReleaseRequest) GetValues() *hapi_chart.Config {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *RenderReleaseRequest) GetReuseValues() bool {
+	if m != nil {
+		return m.ReuseValues
+	}
+	return false
+}
+
+// RenderReleaseResponse holds what the chart of a release renders to.
+type RenderReleaseResponse struct {
+	// Manifest is the rendered manifest.
+	Manifest string `protobuf:"bytes,1,opt,name=manifest" json:"manifest,omitempty"`
+	// Hooks are the rendered hooks.
+	Hooks []*hapi_release.Hook `protobuf:"bytes,2,rep,name=hooks" json:"hooks,omitempty"`
+	// Notes are the rendered notes of the chart.
+	Notes string `protobuf:"bytes,3,opt,name=notes" json:"notes,omitempty"`
+	// Config is the set of values the chart was rendered with.
+	Config *hapi_chart.Config `protobuf:"bytes,4,opt,name=config" json:"config,omitempty"`
+}
+
+func (m *RenderReleaseResponse) Reset()                    { *m = RenderReleaseResponse{} }
+func (m *RenderReleaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*RenderReleaseResponse) ProtoMessage()               {}
+func (*RenderReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *RenderReleaseResponse) GetManifest() string {
+	if m != nil {
+		return m.Manifest
+	}
+	return ""
+}
 
-func (m *UninstallReleaseResponse) GetRelease() *hapi_release5.Release {
+func (m *RenderReleaseResponse) GetHooks() []*hapi_release.Hook {
 	if m != nil {
-		return m.Release
+		return m.Hooks
 	}
 	return nil
 }
 
-func (m *UninstallReleaseResponse) GetInfo() string {
+func (m *RenderReleaseResponse) GetNotes() string {
 	if m != nil {
-		return m.Info
+		return m.Notes
 	}
 	return ""
 }
 
-// GetVersionRequest requests for version information.
-type GetVersionRequest struct {
-}
-
-func (m *GetVersionRequest) Reset()                    { *m = GetVersionRequest{} }
-func (m *GetVersionRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetVersionRequest) ProtoMessage()               {}
-func (*GetVersionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
-
-type GetVersionResponse struct {
-	Version *hapi_version.Version `protobuf:"bytes,1,opt,name=Version" json:"Version,omitempty"`
-}
-
-func (m *GetVersionResponse) Reset()                    { *m = GetVersionResponse{} }
-func (m *GetVersionResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetVersionResponse) ProtoMessage()               {}
-func (*GetVersionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
-
-func (m *GetVersionResponse) GetVersion() *hapi_version.Version {
+func (m *RenderReleaseResponse) GetConfig() *hapi_chart.Config {
 	if m != nil {
-		return m.Version
+		return m.Config
 	}
 	return nil
 }
 
-// GetHistoryRequest requests a release's history.
-type GetHistoryRequest struct {
-	// The name of the release.
+// SetReleasePausedRequest is a request to pause or resume a release.
+type SetReleasePausedRequest struct {
+	// Name is the name of the release.
 	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	// The maximum number of releases to include.
-	Max int32 `protobuf:"varint,2,opt,name=max" json:"max,omitempty"`
+	// Paused is whether the release is paused.
+	Paused bool `protobuf:"varint,2,opt,name=paused" json:"paused,omitempty"`
 }
 
-func (m *GetHistoryRequest) Reset()                    { *m = GetHistoryRequest{} }
-func (m *GetHistoryRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetHistoryRequest) ProtoMessage()               {}
-func (*GetHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (m *SetReleasePausedRequest) Reset()                    { *m = SetReleasePausedRequest{} }
+func (m *SetReleasePausedRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetReleasePausedRequest) ProtoMessage()               {}
+func (*SetReleasePausedRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
-func (m *GetHistoryRequest) GetName() string {
+func (m *SetReleasePausedRequest) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-func (m *GetHistoryRequest) GetMax() int32 {
+func (m *SetReleasePausedRequest) GetPaused() bool {
 	if m != nil {
-		return m.Max
+		return m.Paused
 	}
-	return 0
+	return false
 }
 
-// GetHistoryResponse is received in response to a GetHistory rpc.
-type GetHistoryResponse struct {
-	Releases []*hapi_release5.Release `protobuf:"bytes,1,rep,name=releases" json:"releases,omitempty"`
+// SetReleasePausedResponse is the response to a request to pause or resume a release.
+type SetReleasePausedResponse struct {
+	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
 }
 
-func (m *GetHistoryResponse) Reset()                    { *m = GetHistoryResponse{} }
-func (m *GetHistoryResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetHistoryResponse) ProtoMessage()               {}
-func (*GetHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (m *SetReleasePausedResponse) Reset()                    { *m = SetReleasePausedResponse{} }
+func (m *SetReleasePausedResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetReleasePausedResponse) ProtoMessage()               {}
+func (*SetReleasePausedResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
-func (m *GetHistoryResponse) GetReleases() []*hapi_release5.Release {
+func (m *SetReleasePausedResponse) GetRelease() *hapi_release5.Release {
 	if m != nil {
-		return m.Releases
+		return m.Release
 	}
 	return nil
 }
 
-// TestReleaseRequest is a request to get the status of a release.
-type TestReleaseRequest struct {
-	// Name is the name of the release
-	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	// timeout specifies the max amount of time any kubernetes client command can run.
-	Timeout int64 `protobuf:"varint,2,opt,name=timeout" json:"timeout,omitempty"`
-	// cleanup specifies whether or not to attempt pod deletion after test completes
-	Cleanup bool `protobuf:"varint,3,opt,name=cleanup" json:"cleanup,omitempty"`
+// GetServerInfoRequest requests the version and features of the server.
+type GetServerInfoRequest struct {
 }
 
-func (m *TestReleaseRequest) Reset()                    { *m = TestReleaseRequest{} }
-func (m *TestReleaseRequest) String() string            { return proto.CompactTextString(m) }
-func (*TestReleaseRequest) ProtoMessage()               {}
-func (*TestReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (m *GetServerInfoRequest) Reset()                    { *m = GetServerInfoRequest{} }
+func (m *GetServerInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetServerInfoRequest) ProtoMessage()               {}
+func (*GetServerInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
 
-func (m *TestReleaseRequest) GetName() string {
-	if m != nil {
-		return m.Name
-	}
-	return ""
+// GetServerInfoResponse describes the server.
+type GetServerInfoResponse struct {
+	Version *hapi_version.Version `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	// StorageDriver is the name of the driver the server stores releases with.
+	StorageDriver string `protobuf:"bytes,2,opt,name=storage_driver,json=storageDriver" json:"storage_driver,omitempty"`
+	// Features are the names of the optional features the server supports,
+	// in alphabetical order.
+	Features []string `protobuf:"bytes,3,rep,name=features" json:"features,omitempty"`
 }
 
-func (m *TestReleaseRequest) GetTimeout() int64 {
-	if m != nil {
-		return m.Timeout
-	}
-	return 0
-}
+func (m *GetServerInfoResponse) Reset()                    { *m = GetServerInfoResponse{} }
+func (m *GetServerInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetServerInfoResponse) ProtoMessage()               {}
+func (*GetServerInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
-func (m *TestReleaseRequest) GetCleanup() bool {
+func (m *GetServerInfoResponse) GetVersion() *hapi_version.Version {
 	if m != nil {
-		return m.Cleanup
+		return m.Version
 	}
-	return false
-}
-
-// TestReleaseResponse represents a message from executing a test
-type TestReleaseResponse struct {
-	Msg    string                       `protobuf:"bytes,1,opt,name=msg" json:"msg,omitempty"`
-	Status hapi_release1.TestRun_Status `protobuf:"varint,2,opt,name=status,enum=hapi.release.TestRun_Status" json:"status,omitempty"`
+	return nil
 }
 
-func (m *TestReleaseResponse) Reset()                    { *m = TestReleaseResponse{} }
-func (m *TestReleaseResponse) String() string            { return proto.CompactTextString(m) }
-func (*TestReleaseResponse) ProtoMessage()               {}
-func (*TestReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
-
-func (m *TestReleaseResponse) GetMsg() string {
+func (m *GetServerInfoResponse) GetStorageDriver() string {
 	if m != nil {
-		return m.Msg
+		return m.StorageDriver
 	}
 	return ""
 }
 
-func (m *TestReleaseResponse) GetStatus() hapi_release1.TestRun_Status {
+func (m *GetServerInfoResponse) GetFeatures() []string {
 	if m != nil {
-		return m.Status
+		return m.Features
 	}
-	return hapi_release1.TestRun_UNKNOWN
+	return nil
 }
 
 func init() {
 	proto.RegisterType((*ListReleasesRequest)(nil), "hapi.services.tiller.ListReleasesRequest")
 	proto.RegisterType((*ListSort)(nil), "hapi.services.tiller.ListSort")
+	proto.RegisterType((*ListSortKey)(nil), "hapi.services.tiller.ListSortKey")
 	proto.RegisterType((*ListReleasesResponse)(nil), "hapi.services.tiller.ListReleasesResponse")
 	proto.RegisterType((*GetReleaseStatusRequest)(nil), "hapi.services.tiller.GetReleaseStatusRequest")
 	proto.RegisterType((*GetReleaseStatusResponse)(nil), "hapi.services.tiller.GetReleaseStatusResponse")
@@ -903,14 +2121,31 @@ func init() {
 	proto.RegisterType((*RollbackReleaseResponse)(nil), "hapi.services.tiller.RollbackReleaseResponse")
 	proto.RegisterType((*InstallReleaseRequest)(nil), "hapi.services.tiller.InstallReleaseRequest")
 	proto.RegisterType((*InstallReleaseResponse)(nil), "hapi.services.tiller.InstallReleaseResponse")
+	proto.RegisterType((*InstallReleaseEvent)(nil), "hapi.services.tiller.InstallReleaseEvent")
+	proto.RegisterType((*HookPlan)(nil), "hapi.services.tiller.HookPlan")
+	proto.RegisterType((*HookPlanStep)(nil), "hapi.services.tiller.HookPlanStep")
 	proto.RegisterType((*UninstallReleaseRequest)(nil), "hapi.services.tiller.UninstallReleaseRequest")
 	proto.RegisterType((*UninstallReleaseResponse)(nil), "hapi.services.tiller.UninstallReleaseResponse")
+	proto.RegisterType((*DeleteReleasesRequest)(nil), "hapi.services.tiller.DeleteReleasesRequest")
+	proto.RegisterType((*DeleteReleasesResult)(nil), "hapi.services.tiller.DeleteReleasesResult")
+	proto.RegisterType((*DeleteReleasesResponse)(nil), "hapi.services.tiller.DeleteReleasesResponse")
 	proto.RegisterType((*GetVersionRequest)(nil), "hapi.services.tiller.GetVersionRequest")
 	proto.RegisterType((*GetVersionResponse)(nil), "hapi.services.tiller.GetVersionResponse")
 	proto.RegisterType((*GetHistoryRequest)(nil), "hapi.services.tiller.GetHistoryRequest")
 	proto.RegisterType((*GetHistoryResponse)(nil), "hapi.services.tiller.GetHistoryResponse")
 	proto.RegisterType((*TestReleaseRequest)(nil), "hapi.services.tiller.TestReleaseRequest")
 	proto.RegisterType((*TestReleaseResponse)(nil), "hapi.services.tiller.TestReleaseResponse")
+	proto.RegisterType((*InspectChartRequest)(nil), "hapi.services.tiller.InspectChartRequest")
+	proto.RegisterType((*InspectChartResponse)(nil), "hapi.services.tiller.InspectChartResponse")
+	proto.RegisterType((*ChartFile)(nil), "hapi.services.tiller.ChartFile")
+	proto.RegisterType((*GetReleaseAllRequest)(nil), "hapi.services.tiller.GetReleaseAllRequest")
+	proto.RegisterType((*GetReleaseAllResponse)(nil), "hapi.services.tiller.GetReleaseAllResponse")
+	proto.RegisterType((*RenderReleaseRequest)(nil), "hapi.services.tiller.RenderReleaseRequest")
+	proto.RegisterType((*RenderReleaseResponse)(nil), "hapi.services.tiller.RenderReleaseResponse")
+	proto.RegisterType((*SetReleasePausedRequest)(nil), "hapi.services.tiller.SetReleasePausedRequest")
+	proto.RegisterType((*SetReleasePausedResponse)(nil), "hapi.services.tiller.SetReleasePausedResponse")
+	proto.RegisterType((*GetServerInfoRequest)(nil), "hapi.services.tiller.GetServerInfoRequest")
+	proto.RegisterType((*GetServerInfoResponse)(nil), "hapi.services.tiller.GetServerInfoResponse")
 	proto.RegisterEnum("hapi.services.tiller.ListSort_SortBy", ListSort_SortBy_name, ListSort_SortBy_value)
 	proto.RegisterEnum("hapi.services.tiller.ListSort_SortOrder", ListSort_SortOrder_name, ListSort_SortOrder_value)
 }
@@ -949,6 +2184,26 @@ type ReleaseServiceClient interface {
 	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
 	// RunReleaseTest executes the tests defined of a named release
 	RunReleaseTest(ctx context.Context, in *TestReleaseRequest, opts ...grpc.CallOption) (ReleaseService_RunReleaseTestClient, error)
+	// InspectChart describes the contents of a chart without installing it.
+	InspectChart(ctx context.Context, in *InspectChartRequest, opts ...grpc.CallOption) (*InspectChartResponse, error)
+	// GetReleaseAll retrieves the metadata, values, manifest, hooks and notes
+	// of a release in one call.
+	GetReleaseAll(ctx context.Context, in *GetReleaseAllRequest, opts ...grpc.CallOption) (*GetReleaseAllResponse, error)
+	// SetReleasePaused pauses or resumes a release. A paused release cannot be
+	// upgraded unless the upgrade request sets force_paused.
+	SetReleasePaused(ctx context.Context, in *SetReleasePausedRequest, opts ...grpc.CallOption) (*SetReleasePausedResponse, error)
+	// GetServerInfo returns the version of the server, along with the
+	// features it supports, so that clients can check for them.
+	GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error)
+	// DeleteReleases deletes several releases in one call. The failure to
+	// delete one release does not stop the others from being deleted.
+	DeleteReleases(ctx context.Context, in *DeleteReleasesRequest, opts ...grpc.CallOption) (*DeleteReleasesResponse, error)
+	// InstallReleaseStream installs a release like InstallRelease, streaming
+	// the progress of the install. The last event carries the response.
+	InstallReleaseStream(ctx context.Context, in *InstallReleaseRequest, opts ...grpc.CallOption) (ReleaseService_InstallReleaseStreamClient, error)
+	// RenderRelease renders the chart stored in a release with new values,
+	// without installing anything.
+	RenderRelease(ctx context.Context, in *RenderReleaseRequest, opts ...grpc.CallOption) (*RenderReleaseResponse, error)
 	// PingTiller sends a test/ping signal to Tiller to ensure that it's up
 	PingTiller(ctx context.Context) error
 }
@@ -1105,6 +2360,92 @@ func (x *releaseServiceRunReleaseTestClient) Recv() (*TestReleaseResponse, error
 	return m, nil
 }
 
+func (c *releaseServiceClient) InspectChart(ctx context.Context, in *InspectChartRequest, opts ...grpc.CallOption) (*InspectChartResponse, error) {
+	out := new(InspectChartResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/InspectChart", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) GetReleaseAll(ctx context.Context, in *GetReleaseAllRequest, opts ...grpc.CallOption) (*GetReleaseAllResponse, error) {
+	out := new(GetReleaseAllResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/GetReleaseAll", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) SetReleasePaused(ctx context.Context, in *SetReleasePausedRequest, opts ...grpc.CallOption) (*SetReleasePausedResponse, error) {
+	out := new(SetReleasePausedResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/SetReleasePaused", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error) {
+	out := new(GetServerInfoResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/GetServerInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) DeleteReleases(ctx context.Context, in *DeleteReleasesRequest, opts ...grpc.CallOption) (*DeleteReleasesResponse, error) {
+	out := new(DeleteReleasesResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/DeleteReleases", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) InstallReleaseStream(ctx context.Context, in *InstallReleaseRequest, opts ...grpc.CallOption) (ReleaseService_InstallReleaseStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ReleaseService_serviceDesc.Streams[2], c.cc, "/hapi.services.tiller.ReleaseService/InstallReleaseStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &releaseServiceInstallReleaseStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReleaseService_InstallReleaseStreamClient interface {
+	Recv() (*InstallReleaseEvent, error)
+	grpc.ClientStream
+}
+
+type releaseServiceInstallReleaseStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *releaseServiceInstallReleaseStreamClient) Recv() (*InstallReleaseEvent, error) {
+	m := new(InstallReleaseEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *releaseServiceClient) RenderRelease(ctx context.Context, in *RenderReleaseRequest, opts ...grpc.CallOption) (*RenderReleaseResponse, error) {
+	out := new(RenderReleaseResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/RenderRelease", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ReleaseService service
 
 type ReleaseServiceServer interface {
@@ -1131,6 +2472,26 @@ type ReleaseServiceServer interface {
 	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
 	// RunReleaseTest executes the tests defined of a named release
 	RunReleaseTest(*TestReleaseRequest, ReleaseService_RunReleaseTestServer) error
+	// InspectChart describes the contents of a chart without installing it.
+	InspectChart(context.Context, *InspectChartRequest) (*InspectChartResponse, error)
+	// GetReleaseAll retrieves the metadata, values, manifest, hooks and notes
+	// of a release in one call.
+	GetReleaseAll(context.Context, *GetReleaseAllRequest) (*GetReleaseAllResponse, error)
+	// SetReleasePaused pauses or resumes a release. A paused release cannot be
+	// upgraded unless the upgrade request sets force_paused.
+	SetReleasePaused(context.Context, *SetReleasePausedRequest) (*SetReleasePausedResponse, error)
+	// GetServerInfo returns the version of the server, along with the
+	// features it supports, so that clients can check for them.
+	GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error)
+	// DeleteReleases deletes several releases in one call. The failure to
+	// delete one release does not stop the others from being deleted.
+	DeleteReleases(context.Context, *DeleteReleasesRequest) (*DeleteReleasesResponse, error)
+	// InstallReleaseStream installs a release like InstallRelease, streaming
+	// the progress of the install. The last event carries the response.
+	InstallReleaseStream(*InstallReleaseRequest, ReleaseService_InstallReleaseStreamServer) error
+	// RenderRelease renders the chart stored in a release with new values,
+	// without installing anything.
+	RenderRelease(context.Context, *RenderReleaseRequest) (*RenderReleaseResponse, error)
 }
 
 func RegisterReleaseServiceServer(s *grpc.Server, srv ReleaseServiceServer) {
@@ -1327,6 +2688,135 @@ func (x *releaseServiceRunReleaseTestServer) Send(m *TestReleaseResponse) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ReleaseService_InspectChart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectChartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).InspectChart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/InspectChart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).InspectChart(ctx, req.(*InspectChartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_GetReleaseAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReleaseAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).GetReleaseAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/GetReleaseAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).GetReleaseAll(ctx, req.(*GetReleaseAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_SetReleasePaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReleasePausedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).SetReleasePaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/SetReleasePaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).SetReleasePaused(ctx, req.(*SetReleasePausedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).GetServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/GetServerInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).GetServerInfo(ctx, req.(*GetServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_DeleteReleases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteReleasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).DeleteReleases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/DeleteReleases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).DeleteReleases(ctx, req.(*DeleteReleasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_InstallReleaseStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InstallReleaseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReleaseServiceServer).InstallReleaseStream(m, &releaseServiceInstallReleaseStreamServer{stream})
+}
+
+type ReleaseService_InstallReleaseStreamServer interface {
+	Send(*InstallReleaseEvent) error
+	grpc.ServerStream
+}
+
+type releaseServiceInstallReleaseStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *releaseServiceInstallReleaseStreamServer) Send(m *InstallReleaseEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ReleaseService_RenderRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).RenderRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/RenderRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).RenderRelease(ctx, req.(*RenderReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hapi.services.tiller.ReleaseService",
 	HandlerType: (*ReleaseServiceServer)(nil),
@@ -1367,6 +2857,30 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PingTiller",
 			Handler:    _ReleaseService_Ping_Handler,
 		},
+		{
+			MethodName: "InspectChart",
+			Handler:    _ReleaseService_InspectChart_Handler,
+		},
+		{
+			MethodName: "GetReleaseAll",
+			Handler:    _ReleaseService_GetReleaseAll_Handler,
+		},
+		{
+			MethodName: "SetReleasePaused",
+			Handler:    _ReleaseService_SetReleasePaused_Handler,
+		},
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _ReleaseService_GetServerInfo_Handler,
+		},
+		{
+			MethodName: "DeleteReleases",
+			Handler:    _ReleaseService_DeleteReleases_Handler,
+		},
+		{
+			MethodName: "RenderRelease",
+			Handler:    _ReleaseService_RenderRelease_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1379,6 +2893,11 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _ReleaseService_RunReleaseTest_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "InstallReleaseStream",
+			Handler:       _ReleaseService_InstallReleaseStream_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "hapi/services/tiller.proto",
 }
@@ -1386,82 +2905,179 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("hapi/services/tiller.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1217 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0xdd, 0x6e, 0xe3, 0xc4,
-	0x17, 0xaf, 0xf3, 0x9d, 0x93, 0x36, 0xff, 0x74, 0x9a, 0xb6, 0xae, 0xff, 0x0b, 0x2a, 0x46, 0xb0,
-	0xd9, 0x85, 0x4d, 0x21, 0x70, 0x83, 0x84, 0x90, 0xba, 0xdd, 0xa8, 0x2d, 0x94, 0xae, 0xe4, 0x6c,
-	0x17, 0x09, 0x01, 0x91, 0x9b, 0x4c, 0x5a, 0xb3, 0x8e, 0x27, 0x78, 0xc6, 0x65, 0x7b, 0xcb, 0x1d,
-	0x8f, 0xc2, 0x5b, 0xf0, 0x1e, 0x5c, 0xc2, 0x83, 0x20, 0xcf, 0x87, 0xeb, 0x49, 0xed, 0xd6, 0xf4,
-	0x26, 0x9e, 0x99, 0xf3, 0xfd, 0x3b, 0x67, 0xce, 0x9c, 0x80, 0x75, 0xe9, 0x2e, 0xbc, 0x3d, 0x8a,
-	0xc3, 0x2b, 0x6f, 0x82, 0xe9, 0x1e, 0xf3, 0x7c, 0x1f, 0x87, 0xfd, 0x45, 0x48, 0x18, 0x41, 0xdd,
-	0x98, 0xd6, 0x57, 0xb4, 0xbe, 0xa0, 0x59, 0x5b, 0x5c, 0x62, 0x72, 0xe9, 0x86, 0x4c, 0xfc, 0x0a,
-	0x6e, 0x6b, 0x3b, 0x7d, 0x4e, 0x82, 0x99, 0x77, 0x21, 0x09, 0xc2, 0x44, 0x88, 0x7d, 0xec, 0x52,
-	0xac, 0xbe, 0x9a, 0x90, 0xa2, 0x79, 0xc1, 0x8c, 0x48, 0xc2, 0xff, 0x35, 0x02, 0xc3, 0x94, 0x8d,
-	0xc3, 0x28, 0x90, 0xc4, 0x1d, 0x8d, 0x48, 0x99, 0xcb, 0x22, 0xaa, 0x19, 0xbb, 0xc2, 0x21, 0xf5,
-	0x48, 0xa0, 0xbe, 0x82, 0x66, 0xff, 0x59, 0x82, 0x8d, 0x13, 0x8f, 0x32, 0x47, 0x08, 0x52, 0x07,
-	0xff, 0x12, 0x61, 0xca, 0x50, 0x17, 0xaa, 0xbe, 0x37, 0xf7, 0x98, 0x69, 0xec, 0x1a, 0xbd, 0xb2,
-	0x23, 0x36, 0x68, 0x0b, 0x6a, 0x64, 0x36, 0xa3, 0x98, 0x99, 0xa5, 0x5d, 0xa3, 0xd7, 0x74, 0xe4,
-	0x0e, 0x7d, 0x05, 0x75, 0x4a, 0x42, 0x36, 0x3e, 0xbf, 0x36, 0xcb, 0xbb, 0x46, 0xaf, 0x3d, 0xf8,
-	0xa0, 0x9f, 0x85, 0x53, 0x3f, 0xb6, 0x34, 0x22, 0x21, 0xeb, 0xc7, 0x3f, 0xcf, 0xaf, 0x9d, 0x1a,
-	0xe5, 0xdf, 0x58, 0xef, 0xcc, 0xf3, 0x19, 0x0e, 0xcd, 0x8a, 0xd0, 0x2b, 0x76, 0xe8, 0x10, 0x80,
-	0xeb, 0x25, 0xe1, 0x14, 0x87, 0x66, 0x95, 0xab, 0xee, 0x15, 0x50, 0xfd, 0x32, 0xe6, 0x77, 0x9a,
-	0x54, 0x2d, 0xd1, 0x97, 0xb0, 0x2a, 0x20, 0x19, 0x4f, 0xc8, 0x14, 0x53, 0xb3, 0xb6, 0x5b, 0xee,
-	0xb5, 0x07, 0x3b, 0x42, 0x95, 0x82, 0x7f, 0x24, 0x40, 0x3b, 0x20, 0x53, 0xec, 0xb4, 0x04, 0x7b,
-	0xbc, 0xa6, 0xe8, 0x11, 0x34, 0x03, 0x77, 0x8e, 0xe9, 0xc2, 0x9d, 0x60, 0xb3, 0xce, 0x3d, 0xbc,
-	0x39, 0xb0, 0x7f, 0x82, 0x86, 0x32, 0x6e, 0x0f, 0xa0, 0x26, 0x42, 0x43, 0x2d, 0xa8, 0x9f, 0x9d,
-	0x7e, 0x73, 0xfa, 0xf2, 0xbb, 0xd3, 0xce, 0x0a, 0x6a, 0x40, 0xe5, 0x74, 0xff, 0xdb, 0x61, 0xc7,
-	0x40, 0xeb, 0xb0, 0x76, 0xb2, 0x3f, 0x7a, 0x35, 0x76, 0x86, 0x27, 0xc3, 0xfd, 0xd1, 0xf0, 0x45,
-	0xa7, 0x64, 0xbf, 0x0b, 0xcd, 0xc4, 0x67, 0x54, 0x87, 0xf2, 0xfe, 0xe8, 0x40, 0x88, 0xbc, 0x18,
-	0x8e, 0x0e, 0x3a, 0x86, 0xfd, 0xbb, 0x01, 0x5d, 0x3d, 0x45, 0x74, 0x41, 0x02, 0x8a, 0xe3, 0x1c,
-	0x4d, 0x48, 0x14, 0x24, 0x39, 0xe2, 0x1b, 0x84, 0xa0, 0x12, 0xe0, 0xb7, 0x2a, 0x43, 0x7c, 0x1d,
-	0x73, 0x32, 0xc2, 0x5c, 0x9f, 0x67, 0xa7, 0xec, 0x88, 0x0d, 0xfa, 0x14, 0x1a, 0x32, 0x74, 0x6a,
-	0x56, 0x76, 0xcb, 0xbd, 0xd6, 0x60, 0x53, 0x07, 0x44, 0x5a, 0x74, 0x12, 0x36, 0xfb, 0x10, 0xb6,
-	0x0f, 0xb1, 0xf2, 0x44, 0xe0, 0xa5, 0x2a, 0x26, 0xb6, 0xeb, 0xce, 0x31, 0x77, 0x26, 0xb6, 0xeb,
-	0xce, 0x31, 0x32, 0xa1, 0x2e, 0xcb, 0x8d, 0xbb, 0x53, 0x75, 0xd4, 0xd6, 0x66, 0x60, 0xde, 0x56,
-	0x24, 0xe3, 0xca, 0xd2, 0xf4, 0x21, 0x54, 0xe2, 0x9b, 0xc0, 0xd5, 0xb4, 0x06, 0x48, 0xf7, 0xf3,
-	0x38, 0x98, 0x11, 0x87, 0xd3, 0xf5, 0x54, 0x95, 0x97, 0x53, 0x75, 0x94, 0xb6, 0x7a, 0x40, 0x02,
-	0x86, 0x03, 0xf6, 0x30, 0xff, 0x4f, 0x60, 0x27, 0x43, 0x93, 0x0c, 0x60, 0x0f, 0xea, 0xd2, 0x35,
-	0xae, 0x2d, 0x17, 0x57, 0xc5, 0x65, 0xff, 0x5d, 0x82, 0xee, 0xd9, 0x62, 0xea, 0x32, 0xac, 0x48,
-	0x77, 0x38, 0xf5, 0x18, 0xaa, 0xbc, 0xa3, 0x48, 0x2c, 0xd6, 0x85, 0x6e, 0xd1, 0x76, 0x0e, 0xe2,
-	0x5f, 0x47, 0xd0, 0xd1, 0x53, 0xa8, 0x5d, 0xb9, 0x7e, 0x84, 0x29, 0x07, 0x22, 0x41, 0x4d, 0x72,
-	0xf2, 0x76, 0xe4, 0x48, 0x0e, 0xb4, 0x0d, 0xf5, 0x69, 0x78, 0x1d, 0xf7, 0x13, 0x7e, 0x05, 0x1b,
-	0x4e, 0x6d, 0x1a, 0x5e, 0x3b, 0x51, 0x80, 0xde, 0x87, 0xb5, 0xa9, 0x47, 0xdd, 0x73, 0x1f, 0x8f,
-	0x2f, 0x09, 0x79, 0x43, 0xf9, 0x2d, 0x6c, 0x38, 0xab, 0xf2, 0xf0, 0x28, 0x3e, 0x43, 0x56, 0x5c,
-	0x49, 0x93, 0x10, 0xbb, 0x0c, 0x9b, 0x35, 0x4e, 0x4f, 0xf6, 0x31, 0x86, 0xcc, 0x9b, 0x63, 0x12,
-	0x31, 0x7e, 0x75, 0xca, 0x8e, 0xda, 0xa2, 0xf7, 0x60, 0x35, 0xc4, 0x14, 0xb3, 0xb1, 0xf4, 0xb2,
-	0xc1, 0x25, 0x5b, 0xfc, 0xec, 0xb5, 0x70, 0x0b, 0x41, 0xe5, 0x57, 0xd7, 0x63, 0x66, 0x93, 0x93,
-	0xf8, 0x5a, 0x88, 0x45, 0x14, 0x2b, 0x31, 0x50, 0x62, 0x11, 0xc5, 0x52, 0xac, 0x0b, 0xd5, 0x19,
-	0x09, 0x27, 0xd8, 0x6c, 0x71, 0x9a, 0xd8, 0xd8, 0x47, 0xb0, 0xb9, 0x04, 0xf2, 0x43, 0xf3, 0xf5,
-	0x8f, 0x01, 0x5b, 0x0e, 0xf1, 0xfd, 0x73, 0x77, 0xf2, 0xa6, 0x40, 0xc6, 0x52, 0xe0, 0x96, 0xee,
-	0x06, 0xb7, 0x9c, 0x01, 0x6e, 0xaa, 0x08, 0x2b, 0x5a, 0x11, 0x6a, 0xb0, 0x57, 0xf3, 0x61, 0xaf,
-	0xe9, 0xb0, 0x2b, 0x4c, 0xeb, 0x29, 0x4c, 0x13, 0xc0, 0x1a, 0x69, 0xc0, 0xbe, 0x86, 0xed, 0x5b,
-	0x51, 0x3e, 0x14, 0xb2, 0x3f, 0x4a, 0xb0, 0x79, 0x1c, 0x50, 0xe6, 0xfa, 0xfe, 0x12, 0x62, 0x49,
-	0x3d, 0x1b, 0x85, 0xeb, 0xb9, 0xf4, 0x5f, 0xea, 0xb9, 0xac, 0x41, 0xae, 0xf2, 0x53, 0x49, 0xe5,
-	0xa7, 0x50, 0x8d, 0x6b, 0x9d, 0xa5, 0xb6, 0xd4, 0x59, 0xd0, 0x3b, 0x00, 0xa2, 0x28, 0xb9, 0x72,
-	0x01, 0x6d, 0x93, 0x9f, 0x9c, 0xca, 0x46, 0xa2, 0xb2, 0xd1, 0xc8, 0xce, 0x46, 0xaa, 0xc2, 0xed,
-	0x63, 0xd8, 0x5a, 0x86, 0xea, 0xa1, 0xb0, 0xff, 0x66, 0xc0, 0xf6, 0x59, 0xe0, 0x65, 0x02, 0x9f,
-	0x55, 0xaa, 0xb7, 0xa0, 0x28, 0x65, 0x40, 0xd1, 0x85, 0xea, 0x22, 0x0a, 0x2f, 0xb0, 0x84, 0x56,
-	0x6c, 0xd2, 0x31, 0x56, 0xb4, 0x18, 0xed, 0x31, 0x98, 0xb7, 0x7d, 0x78, 0x60, 0x44, 0xb1, 0xd7,
-	0xc9, 0x4b, 0xd0, 0x14, 0x5d, 0xdf, 0xde, 0x80, 0xf5, 0x43, 0xcc, 0x5e, 0x8b, 0x6b, 0x21, 0xc3,
-	0xb3, 0x87, 0x80, 0xd2, 0x87, 0x37, 0xf6, 0xe4, 0x91, 0x6e, 0x4f, 0x8d, 0x45, 0x8a, 0x5f, 0x71,
-	0xd9, 0x5f, 0x70, 0xdd, 0x47, 0x1e, 0x65, 0x24, 0xbc, 0xbe, 0x0b, 0xba, 0x0e, 0x94, 0xe7, 0xee,
-	0x5b, 0xf9, 0x50, 0xc4, 0x4b, 0xfb, 0x90, 0x7b, 0x90, 0x88, 0x4a, 0x0f, 0xd2, 0xcf, 0xae, 0x51,
-	0xec, 0xd9, 0xfd, 0x01, 0xd0, 0x2b, 0x9c, 0x4c, 0x00, 0xf7, 0xbc, 0x58, 0x2a, 0x09, 0x25, 0xbd,
-	0xd0, 0x4c, 0xa8, 0x4f, 0x7c, 0xec, 0x06, 0xd1, 0x42, 0xa6, 0x4d, 0x6d, 0xed, 0x1f, 0x61, 0x43,
-	0xd3, 0x2e, 0xfd, 0x8c, 0xe3, 0xa1, 0x17, 0x52, 0x7b, 0xbc, 0x44, 0x9f, 0x43, 0x4d, 0x8c, 0x45,
-	0x5c, 0x77, 0x7b, 0xf0, 0x48, 0xf7, 0x9b, 0x2b, 0x89, 0x02, 0x39, 0x47, 0x39, 0x92, 0x77, 0xf0,
-	0x57, 0x03, 0xda, 0xea, 0xa1, 0x17, 0x43, 0x1b, 0xf2, 0x60, 0x35, 0x3d, 0xd1, 0xa0, 0x27, 0xf9,
-	0x33, 0xdd, 0xd2, 0x60, 0x6a, 0x3d, 0x2d, 0xc2, 0x2a, 0x22, 0xb0, 0x57, 0x3e, 0x31, 0x10, 0x85,
-	0xce, 0xf2, 0xa0, 0x81, 0x9e, 0x65, 0xeb, 0xc8, 0x99, 0x6c, 0xac, 0x7e, 0x51, 0x76, 0x65, 0x16,
-	0x5d, 0xf1, 0x9a, 0xd1, 0xa7, 0x03, 0x74, 0xaf, 0x1a, 0x7d, 0x20, 0xb1, 0xf6, 0x0a, 0xf3, 0x27,
-	0x76, 0x7f, 0x86, 0x35, 0xed, 0x85, 0x43, 0x39, 0x68, 0x65, 0xcd, 0x1a, 0xd6, 0x47, 0x85, 0x78,
-	0x13, 0x5b, 0x73, 0x68, 0xeb, 0x4d, 0x0a, 0xe5, 0x28, 0xc8, 0xec, 0xfa, 0xd6, 0xc7, 0xc5, 0x98,
-	0x13, 0x73, 0x14, 0x3a, 0xcb, 0x3d, 0x24, 0x2f, 0x8f, 0x39, 0xfd, 0x2e, 0x2f, 0x8f, 0x79, 0xad,
-	0xc9, 0x5e, 0x41, 0x2e, 0xc0, 0x4d, 0x0b, 0x41, 0x8f, 0x73, 0x13, 0xa2, 0x77, 0x1e, 0xab, 0x77,
-	0x3f, 0x63, 0x62, 0x62, 0x01, 0xff, 0x5b, 0x7a, 0x63, 0x51, 0x0e, 0x34, 0xd9, 0x03, 0x87, 0xf5,
-	0xac, 0x20, 0xf7, 0x52, 0x50, 0xb2, 0x2b, 0xdd, 0x11, 0x94, 0xde, 0xf2, 0xee, 0x08, 0x6a, 0xa9,
-	0xc1, 0xd9, 0x2b, 0xc8, 0x83, 0xb6, 0x13, 0x05, 0xd2, 0x74, 0xdc, 0x16, 0x50, 0x8e, 0xf4, 0xed,
-	0xae, 0x66, 0x3d, 0x29, 0xc0, 0x79, 0x73, 0xbf, 0x9f, 0xc3, 0xf7, 0x0d, 0xc5, 0x7a, 0x5e, 0xe3,
-	0xff, 0x69, 0x3f, 0xfb, 0x37, 0x00, 0x00, 0xff, 0xff, 0xf3, 0x7c, 0x9c, 0x49, 0xc1, 0x0f, 0x00,
-	0x00,
+	// 2784 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x5a, 0x4b, 0x6f, 0xdc, 0xd6,
+	0x15, 0x36, 0xe7, 0x25, 0xce, 0x19, 0xbd, 0x7c, 0x3d, 0x92, 0x98, 0xc9, 0xc3, 0x0a, 0x8b, 0x24,
+	0x8a, 0x9d, 0x8c, 0x52, 0xf5, 0x8d, 0x14, 0x01, 0x64, 0x59, 0xb6, 0x1c, 0x3b, 0x92, 0xc1, 0xb1,
+	0x1d, 0xa0, 0x40, 0xcb, 0x5e, 0x71, 0xee, 0x48, 0x8c, 0x38, 0x24, 0xc3, 0x7b, 0x29, 0x47, 0xfd,
+	0x01, 0xdd, 0x24, 0x40, 0x17, 0x05, 0xba, 0x2d, 0xd0, 0x4d, 0xd1, 0xfe, 0x9a, 0xa2, 0x8b, 0xae,
+	0xba, 0x68, 0xfb, 0x23, 0xba, 0xea, 0xa2, 0xb8, 0x2f, 0x8a, 0x9c, 0xe1, 0x8c, 0x28, 0x6d, 0x34,
+	0x3c, 0xe7, 0x9e, 0xfb, 0x3a, 0x8f, 0xef, 0x9c, 0x7b, 0x6c, 0xe8, 0x9d, 0xe2, 0xd8, 0xdf, 0xa6,
+	0x24, 0x39, 0xf7, 0x3d, 0x42, 0xb7, 0x99, 0x1f, 0x04, 0x24, 0xe9, 0xc7, 0x49, 0xc4, 0x22, 0xd4,
+	0xe5, 0x63, 0x7d, 0x3d, 0xd6, 0x97, 0x63, 0xbd, 0x75, 0x31, 0xc3, 0x3b, 0xc5, 0x09, 0x93, 0x7f,
+	0xa5, 0x74, 0x6f, 0x23, 0xcf, 0x8f, 0xc2, 0x91, 0x7f, 0xa2, 0x06, 0xde, 0xc8, 0x0d, 0x8c, 0x09,
+	0xc3, 0x43, 0xcc, 0x70, 0x61, 0x4e, 0x42, 0x02, 0x82, 0x29, 0xd9, 0x3e, 0x8d, 0xa2, 0x33, 0x35,
+	0xd0, 0x2b, 0x0c, 0xa8, 0xdf, 0xd2, 0x49, 0x7e, 0x38, 0x8a, 0xd4, 0xc0, 0x9b, 0x85, 0x01, 0x46,
+	0x28, 0x73, 0x93, 0x34, 0x2c, 0x9c, 0x42, 0x0f, 0x52, 0x86, 0x59, 0x4a, 0x0b, 0x9b, 0x9d, 0x93,
+	0x84, 0xfa, 0x51, 0xa8, 0x7f, 0xd5, 0xd8, 0xdd, 0x93, 0x28, 0x3a, 0x09, 0xc8, 0xb6, 0xa0, 0x8e,
+	0xd3, 0xd1, 0x36, 0xf3, 0xc7, 0x84, 0x32, 0x3c, 0x8e, 0xa5, 0x80, 0xfd, 0x9f, 0x06, 0xdc, 0x79,
+	0xe6, 0x53, 0xe6, 0xc8, 0x95, 0xa9, 0x43, 0xbe, 0x4e, 0x09, 0x65, 0xa8, 0x0b, 0xcd, 0xc0, 0x1f,
+	0xfb, 0xcc, 0x32, 0x36, 0x8d, 0xad, 0xba, 0x23, 0x09, 0xb4, 0x0e, 0xad, 0x68, 0x34, 0xa2, 0x84,
+	0x59, 0xb5, 0x4d, 0x63, 0xab, 0xed, 0x28, 0x0a, 0x7d, 0x06, 0x0b, 0x34, 0x4a, 0x98, 0x7b, 0x7c,
+	0x61, 0xd5, 0x37, 0x8d, 0xad, 0xe5, 0x9d, 0xf7, 0xfa, 0x65, 0xca, 0xef, 0xf3, 0x9d, 0x06, 0x51,
+	0xc2, 0xfa, 0xfc, 0xcf, 0x83, 0x0b, 0xa7, 0x45, 0xc5, 0x2f, 0x5f, 0x77, 0xe4, 0x07, 0x8c, 0x24,
+	0x56, 0x43, 0xae, 0x2b, 0x29, 0xf4, 0x18, 0x40, 0xac, 0x1b, 0x25, 0x43, 0x92, 0x58, 0x4d, 0xb1,
+	0xf4, 0x56, 0x85, 0xa5, 0x8f, 0xb8, 0xbc, 0xd3, 0xa6, 0xfa, 0x13, 0xfd, 0x1c, 0x16, 0xa5, 0xce,
+	0x5c, 0x2f, 0x1a, 0x12, 0x6a, 0xb5, 0x36, 0xeb, 0x5b, 0xcb, 0x3b, 0x6f, 0xc8, 0xa5, 0xb4, 0x7d,
+	0x06, 0x52, 0xab, 0x7b, 0xd1, 0x90, 0x38, 0x1d, 0x29, 0xce, 0xbf, 0x29, 0x7a, 0x0b, 0xda, 0x21,
+	0x1e, 0x13, 0x1a, 0x63, 0x8f, 0x58, 0x0b, 0xe2, 0x84, 0x97, 0x0c, 0xf4, 0x19, 0x88, 0x8d, 0xdc,
+	0x33, 0x72, 0x41, 0x2d, 0x73, 0xb3, 0xbe, 0xd5, 0xd9, 0x79, 0x77, 0xfe, 0x19, 0x9f, 0x92, 0x0b,
+	0xc7, 0xa4, 0xf2, 0x83, 0xf2, 0xcb, 0x7b, 0x69, 0x42, 0xa3, 0xc4, 0x6a, 0xcb, 0xcb, 0x4b, 0x0a,
+	0xbd, 0x0d, 0x20, 0xbc, 0xce, 0xe5, 0x5b, 0x59, 0x20, 0xb7, 0x15, 0x9c, 0x43, 0x3c, 0x26, 0x68,
+	0x0f, 0x56, 0x86, 0x24, 0x0e, 0xa2, 0x0b, 0x32, 0x74, 0x8f, 0xc9, 0x28, 0x4a, 0x88, 0xd5, 0xd9,
+	0x34, 0xb6, 0x3a, 0x3b, 0xbd, 0xbe, 0x34, 0x7a, 0x5f, 0x1b, 0xbd, 0xff, 0x42, 0x1b, 0xdd, 0x59,
+	0xd6, 0x53, 0x1e, 0x88, 0x19, 0x68, 0x17, 0x32, 0x8e, 0x8b, 0x47, 0xdc, 0x00, 0x8b, 0x57, 0xae,
+	0xb1, 0xa4, 0x67, 0xec, 0xf2, 0x09, 0xe8, 0x5d, 0x58, 0x3c, 0x4e, 0xfd, 0x60, 0xe8, 0x7a, 0xd1,
+	0x98, 0x3b, 0xcc, 0x92, 0x38, 0x68, 0x47, 0xf0, 0xf6, 0x04, 0xcb, 0xfe, 0xd6, 0x00, 0x53, 0xdf,
+	0xdd, 0x76, 0xa1, 0x25, 0xad, 0x8f, 0x3a, 0xb0, 0xf0, 0xf2, 0xf0, 0xe9, 0xe1, 0xd1, 0x97, 0x87,
+	0xab, 0xb7, 0x90, 0x09, 0x8d, 0xc3, 0xdd, 0x2f, 0xf6, 0x57, 0x0d, 0x74, 0x1b, 0x96, 0x9e, 0xed,
+	0x0e, 0x5e, 0xb8, 0xce, 0xfe, 0xb3, 0xfd, 0xdd, 0xc1, 0xfe, 0xc3, 0xd5, 0x1a, 0x5a, 0x82, 0x36,
+	0x1f, 0x1c, 0x3c, 0xdf, 0xdd, 0xdb, 0x5f, 0xad, 0xa3, 0x65, 0x80, 0xbd, 0x83, 0x5d, 0xe7, 0x85,
+	0x2b, 0x66, 0x34, 0xd0, 0x22, 0x98, 0xce, 0xfe, 0xab, 0x27, 0x83, 0x27, 0x47, 0x87, 0xab, 0x4d,
+	0xfb, 0x1d, 0x68, 0x67, 0x3e, 0x80, 0x16, 0xa0, 0xbe, 0x3b, 0xd8, 0x93, 0xeb, 0x3f, 0xdc, 0x1f,
+	0xec, 0xad, 0x1a, 0xf6, 0x1f, 0x0c, 0xe8, 0xe4, 0x2c, 0x91, 0x77, 0x5e, 0xe3, 0x26, 0xce, 0x5b,
+	0x74, 0xd2, 0xda, 0x8d, 0x9d, 0xd4, 0xfe, 0xab, 0x01, 0xdd, 0x62, 0x2c, 0xd2, 0x38, 0x0a, 0x29,
+	0xe1, 0xc1, 0xe8, 0x45, 0x69, 0x98, 0x05, 0xa3, 0x20, 0x10, 0x82, 0x46, 0x48, 0xbe, 0xd1, 0xa1,
+	0x28, 0xbe, 0xb9, 0x24, 0x8b, 0x18, 0x0e, 0x44, 0x18, 0xd6, 0x1d, 0x49, 0xa0, 0xef, 0x83, 0xa9,
+	0x7c, 0x9c, 0x5a, 0x0d, 0xe1, 0xa0, 0x6b, 0x45, 0xcf, 0x57, 0x3b, 0x3a, 0x99, 0x18, 0xba, 0x0b,
+	0x1d, 0xbe, 0xa0, 0xab, 0x3c, 0xb3, 0x29, 0xf6, 0x00, 0xce, 0xda, 0x13, 0x1c, 0xfb, 0x31, 0x6c,
+	0x3c, 0x26, 0xfa, 0xa8, 0x32, 0x72, 0x34, 0x76, 0xf0, 0x83, 0x71, 0x97, 0x35, 0xd4, 0xc1, 0xb8,
+	0xb7, 0x5a, 0xb0, 0xa0, 0x90, 0x49, 0x9c, 0xb7, 0xe9, 0x68, 0xd2, 0xfe, 0x87, 0x01, 0xd6, 0xf4,
+	0x4a, 0xea, 0xe6, 0x65, 0x4b, 0xbd, 0x0f, 0x0d, 0x8e, 0x9a, 0x62, 0x9d, 0xce, 0x0e, 0x2a, 0xde,
+	0xe4, 0x49, 0x38, 0x8a, 0x1c, 0x31, 0x5e, 0x8c, 0xda, 0xfa, 0x64, 0xd4, 0xe6, 0x0e, 0xd4, 0x28,
+	0x1c, 0x08, 0xdd, 0x83, 0x96, 0x4c, 0x00, 0x56, 0x33, 0xbf, 0x83, 0x4c, 0x16, 0x7b, 0x62, 0xc4,
+	0x51, 0x12, 0xa8, 0x07, 0xe6, 0x18, 0x87, 0xfe, 0x88, 0x50, 0x66, 0xb5, 0xc4, 0x16, 0x19, 0x6d,
+	0x1f, 0xe4, 0xef, 0xb5, 0x17, 0x85, 0x8c, 0x84, 0xec, 0x66, 0x2a, 0x7a, 0x06, 0x6f, 0x94, 0xac,
+	0xa4, 0x54, 0xb4, 0x0d, 0x0b, 0xea, 0xf2, 0x62, 0xb5, 0x99, 0xb6, 0xd5, 0x52, 0xf6, 0x5f, 0x5a,
+	0xd0, 0x7d, 0x19, 0x0f, 0x31, 0x23, 0x7a, 0x68, 0xce, 0xa1, 0x3e, 0x80, 0xa6, 0xb8, 0xb8, 0xd2,
+	0xf6, 0xed, 0x82, 0x2e, 0xf8, 0x5f, 0x47, 0x8e, 0x73, 0xad, 0x9d, 0xe3, 0x20, 0x25, 0xd4, 0xaa,
+	0xcf, 0xd6, 0x9a, 0x94, 0x40, 0x1b, 0xb0, 0x30, 0x4c, 0x2e, 0x78, 0x76, 0x13, 0xba, 0x37, 0x9d,
+	0xd6, 0x30, 0xb9, 0x70, 0xd2, 0x10, 0x7d, 0x0f, 0x96, 0x86, 0x3e, 0xc5, 0xc7, 0x01, 0x71, 0x79,
+	0x36, 0xa5, 0xc2, 0x02, 0xa6, 0xb3, 0xa8, 0x98, 0x07, 0x9c, 0xc7, 0x75, 0x9e, 0x10, 0x2f, 0x21,
+	0x98, 0x11, 0xa1, 0x73, 0xd3, 0xc9, 0x68, 0xae, 0x43, 0x9e, 0xe1, 0xa2, 0x94, 0x09, 0x9c, 0xae,
+	0x3b, 0x9a, 0xe4, 0x30, 0x95, 0x10, 0x4a, 0x98, 0xab, 0x4e, 0x69, 0x8a, 0x99, 0x1d, 0xc1, 0x7b,
+	0x25, 0x8f, 0x85, 0xa0, 0xf1, 0x1a, 0xfb, 0x4c, 0xc0, 0xb0, 0xe9, 0x88, 0x6f, 0x39, 0x2d, 0xa5,
+	0x44, 0x4f, 0x03, 0x3d, 0x2d, 0xa5, 0x44, 0x4d, 0xeb, 0x42, 0x73, 0x14, 0x25, 0x9e, 0x84, 0x5f,
+	0xd3, 0x91, 0x04, 0xda, 0x84, 0xce, 0x90, 0x50, 0x2f, 0xf1, 0x63, 0xc6, 0x2d, 0xba, 0x28, 0x51,
+	0x31, 0xc7, 0xe2, 0x97, 0xe5, 0x90, 0x19, 0x85, 0x6e, 0x80, 0x8f, 0x49, 0x40, 0x05, 0x72, 0x9a,
+	0xce, 0xa2, 0x64, 0x3e, 0x13, 0x3c, 0xbe, 0xbf, 0x58, 0xcf, 0x8d, 0x71, 0x4a, 0xc9, 0xd0, 0x5a,
+	0x96, 0xfb, 0x0b, 0xde, 0x73, 0xc1, 0xe2, 0xa1, 0x2a, 0x0f, 0xe7, 0x8e, 0x92, 0x68, 0x6c, 0xad,
+	0xc8, 0x50, 0x95, 0xac, 0x47, 0x49, 0x34, 0xe6, 0x4a, 0x89, 0x31, 0xf3, 0x4e, 0x09, 0xb5, 0x56,
+	0x37, 0xeb, 0x5b, 0x6d, 0x47, 0x93, 0xe8, 0xc7, 0x00, 0x12, 0xbb, 0x45, 0x40, 0xdd, 0x16, 0x86,
+	0xdb, 0x28, 0xba, 0xcf, 0x03, 0x3e, 0x2e, 0xa2, 0xaa, 0x7d, 0xac, 0x3f, 0xd1, 0xfb, 0xb0, 0xe2,
+	0x05, 0x04, 0x87, 0x69, 0xec, 0x46, 0xa1, 0x3b, 0xc2, 0x7e, 0x60, 0x21, 0x71, 0xb0, 0x25, 0xc5,
+	0x3e, 0x0a, 0x1f, 0x61, 0x3f, 0x40, 0x36, 0x2c, 0xf1, 0x41, 0x2e, 0x44, 0xc6, 0x31, 0xbb, 0xb0,
+	0xee, 0xa8, 0xe3, 0x63, 0x3f, 0x38, 0x0a, 0xf7, 0x39, 0x0b, 0x6d, 0xc1, 0x6a, 0x1c, 0x51, 0xe6,
+	0x72, 0x75, 0xbb, 0x94, 0x30, 0x16, 0x10, 0xab, 0x2b, 0x6c, 0xb7, 0xcc, 0xf9, 0x5f, 0x62, 0x9f,
+	0x0d, 0x04, 0x17, 0xbd, 0x07, 0xcb, 0xf4, 0xcc, 0x8f, 0xdd, 0x34, 0xf4, 0x4e, 0x71, 0x78, 0x42,
+	0x86, 0xd6, 0x9a, 0xdc, 0x94, 0x73, 0x5f, 0x6a, 0x26, 0x17, 0x63, 0xf8, 0x8c, 0xb8, 0xd1, 0xeb,
+	0x90, 0x24, 0xf4, 0xd4, 0x8f, 0xad, 0x75, 0x29, 0xc6, 0xb9, 0x47, 0x9a, 0xc9, 0xd5, 0x2f, 0x10,
+	0x9b, 0x67, 0xbe, 0x38, 0x0e, 0x2e, 0xac, 0x0d, 0xa9, 0x7e, 0xc5, 0xdc, 0xe5, 0x3c, 0xfb, 0x00,
+	0xd6, 0x26, 0x42, 0xe5, 0xa6, 0x51, 0xf7, 0xdb, 0x1a, 0xac, 0x3b, 0x51, 0x10, 0x1c, 0x63, 0xef,
+	0xac, 0x42, 0xdc, 0xe5, 0x42, 0xa4, 0x36, 0x3f, 0x44, 0xea, 0x25, 0x21, 0x32, 0x1b, 0xdc, 0xf2,
+	0xc1, 0xd3, 0x9c, 0x1d, 0x3c, 0xad, 0x62, 0xf0, 0xe8, 0xc8, 0x58, 0xc8, 0x45, 0x46, 0xe6, 0xf6,
+	0xe6, 0x1c, 0xb7, 0x6f, 0x4f, 0xb9, 0xbd, 0xfd, 0x39, 0x6c, 0x4c, 0xe9, 0xe1, 0xa6, 0x4a, 0xfd,
+	0x5b, 0x0b, 0xd6, 0x9e, 0x84, 0x94, 0xe1, 0x20, 0x98, 0xd0, 0x69, 0x86, 0x5b, 0x46, 0x65, 0xdc,
+	0xaa, 0x5d, 0x07, 0xb7, 0xea, 0x05, 0xa3, 0x68, 0x0b, 0x36, 0x72, 0x16, 0xac, 0x84, 0x65, 0x85,
+	0x1c, 0xd5, 0x9a, 0xcc, 0x51, 0x6f, 0x03, 0x48, 0xf0, 0x11, 0x8b, 0x4b, 0xe5, 0xb7, 0x05, 0xe7,
+	0x50, 0x25, 0x0c, 0x6d, 0x2f, 0xb3, 0xdc, 0x5e, 0x79, 0x24, 0x5b, 0x87, 0x16, 0x66, 0xd1, 0xd8,
+	0xf7, 0x14, 0x86, 0x29, 0x6a, 0xd2, 0x62, 0x9d, 0x0a, 0x40, 0xb5, 0x58, 0x02, 0x54, 0x6f, 0x42,
+	0x5b, 0x04, 0xa7, 0x97, 0x0c, 0x35, 0x92, 0x99, 0x9c, 0xb1, 0x97, 0x0c, 0xe9, 0x24, 0x44, 0x2d,
+	0xcf, 0x83, 0xa8, 0x95, 0x22, 0x44, 0xdd, 0x83, 0xdb, 0x5e, 0x14, 0x32, 0x3f, 0x4c, 0x89, 0x80,
+	0x91, 0x24, 0x89, 0x12, 0x6b, 0x55, 0xac, 0xbf, 0xa2, 0x07, 0x8e, 0xc2, 0x7d, 0xce, 0x46, 0x3b,
+	0xb0, 0x96, 0x90, 0x70, 0x48, 0x12, 0x97, 0xa6, 0xc7, 0xaa, 0x76, 0x8e, 0x18, 0xa1, 0x02, 0xd9,
+	0x4c, 0xe7, 0x8e, 0x1c, 0x1c, 0xa8, 0xb1, 0xc3, 0x88, 0x4d, 0x41, 0x20, 0xaa, 0x0c, 0x81, 0x77,
+	0xa1, 0xf3, 0x75, 0x1a, 0x31, 0xec, 0x7a, 0xa7, 0xc4, 0x3b, 0x13, 0xc0, 0xd6, 0x76, 0x40, 0xb0,
+	0xf6, 0x38, 0x67, 0x1a, 0xfb, 0xba, 0xd3, 0xd8, 0xf7, 0x26, 0xb4, 0x71, 0xec, 0xab, 0x25, 0xd6,
+	0x64, 0xfd, 0x80, 0x63, 0x5f, 0x2e, 0x50, 0x06, 0x8c, 0xeb, 0xa5, 0xc0, 0x58, 0x05, 0xca, 0x4a,
+	0x60, 0xd1, 0x2a, 0x81, 0x45, 0xfb, 0x4f, 0x06, 0xac, 0x4f, 0x86, 0xd4, 0x0d, 0xc3, 0x13, 0x7d,
+	0x0a, 0x6d, 0xee, 0xfa, 0x6e, 0x1c, 0xe0, 0x50, 0x85, 0xd7, 0x3b, 0xe5, 0x85, 0x31, 0x8f, 0x86,
+	0xe7, 0x01, 0x0e, 0x1d, 0xf3, 0x54, 0x7d, 0x71, 0xa4, 0x7a, 0x8d, 0x93, 0xd0, 0x0f, 0x4f, 0x38,
+	0xc6, 0x71, 0x9f, 0xc8, 0x68, 0xfb, 0x77, 0x06, 0xdc, 0x29, 0x1e, 0x72, 0xff, 0x9c, 0x84, 0x02,
+	0x93, 0x28, 0xc3, 0x27, 0x1a, 0x4a, 0x25, 0xc1, 0x9d, 0x6b, 0x4c, 0x28, 0xe5, 0x7c, 0x59, 0x2b,
+	0x6b, 0x12, 0x1d, 0x70, 0x34, 0x94, 0xb7, 0x53, 0x65, 0xcb, 0x47, 0xe5, 0xe7, 0x2b, 0xd7, 0x88,
+	0x93, 0xcd, 0xb6, 0x7f, 0x0d, 0xa6, 0xbe, 0x03, 0xfa, 0x29, 0x3f, 0x05, 0x89, 0xa9, 0x65, 0x88,
+	0x5a, 0xdb, 0x9e, 0x7f, 0xe5, 0x01, 0x23, 0xb1, 0x23, 0x27, 0x14, 0xee, 0x5c, 0x9b, 0xb8, 0xf3,
+	0x3f, 0x0d, 0x58, 0xcc, 0xcf, 0x41, 0x7d, 0x68, 0x12, 0x7e, 0x6b, 0xf5, 0x6a, 0xb1, 0x8a, 0xc6,
+	0xe0, 0xa2, 0x7d, 0xa1, 0x15, 0x47, 0x8a, 0x65, 0x20, 0x55, 0xcb, 0x81, 0x14, 0x82, 0xc6, 0x99,
+	0x1f, 0x0e, 0x55, 0x79, 0x2c, 0xbe, 0x39, 0x2f, 0xc6, 0xec, 0x54, 0x83, 0x19, 0xff, 0xe6, 0xe0,
+	0xf1, 0x9a, 0xf8, 0x27, 0xa7, 0x4c, 0xa0, 0x58, 0xd3, 0x51, 0x14, 0x3a, 0xe0, 0x8f, 0xd0, 0x80,
+	0x30, 0xe2, 0xc6, 0x51, 0xe0, 0x7b, 0x7e, 0xf6, 0xb4, 0xbe, 0x5b, 0x72, 0x9a, 0x87, 0x42, 0xf2,
+	0x39, 0x17, 0xbc, 0xe0, 0x2f, 0xd1, 0x8c, 0xf2, 0x09, 0xb5, 0xbf, 0xab, 0xc1, 0xc6, 0xcb, 0xd0,
+	0x2f, 0x05, 0xf3, 0xb2, 0x04, 0x39, 0x05, 0xaf, 0xb5, 0x12, 0x78, 0xed, 0x42, 0x33, 0x4e, 0x93,
+	0x13, 0xa2, 0xe0, 0x5a, 0x12, 0x79, 0xdc, 0x6c, 0x14, 0x71, 0xd3, 0x82, 0x05, 0x0f, 0x53, 0x0f,
+	0x0f, 0x89, 0x7a, 0xf1, 0x68, 0x92, 0xd7, 0x61, 0x27, 0x09, 0xe6, 0x75, 0x18, 0x49, 0xfc, 0x68,
+	0xa8, 0x12, 0x64, 0x47, 0xf0, 0x9e, 0x0b, 0x56, 0x69, 0x92, 0xfc, 0x09, 0x58, 0x79, 0xf4, 0x12,
+	0xd1, 0xc0, 0x01, 0x20, 0x4d, 0x74, 0xde, 0x5c, 0xbb, 0x04, 0x31, 0x7e, 0xe6, 0x47, 0x72, 0xd0,
+	0x76, 0xc1, 0x9a, 0xd6, 0xc6, 0x4d, 0xe3, 0x10, 0xe5, 0x5e, 0x4c, 0x6d, 0xf9, 0x3a, 0xb2, 0xff,
+	0x6d, 0xc0, 0x9a, 0x34, 0x48, 0x49, 0xeb, 0x47, 0xa4, 0x20, 0xe1, 0xbe, 0x6d, 0x47, 0x12, 0xb9,
+	0x16, 0x4d, 0xad, 0xd0, 0xa2, 0x99, 0xff, 0xca, 0x7a, 0x0c, 0x0b, 0x91, 0x48, 0x22, 0x54, 0xa8,
+	0xba, 0xb3, 0xf3, 0x71, 0x79, 0x30, 0xcc, 0xb0, 0xbc, 0xa3, 0x67, 0xe7, 0x53, 0x6f, 0xb3, 0x90,
+	0x7a, 0x7b, 0x60, 0x52, 0x12, 0x10, 0x8f, 0x45, 0x89, 0x7e, 0x81, 0x69, 0xda, 0xfe, 0xce, 0x80,
+	0xee, 0xe4, 0x1d, 0x69, 0x1a, 0x94, 0x3b, 0xd4, 0xe7, 0x39, 0x2c, 0x90, 0x58, 0xd5, 0xaf, 0x7a,
+	0xd6, 0x49, 0x34, 0xe0, 0x2a, 0x94, 0x89, 0x4a, 0x2a, 0x44, 0x12, 0xf6, 0xaf, 0x60, 0x7d, 0xea,
+	0x34, 0x52, 0xfe, 0x21, 0xb7, 0x28, 0x3f, 0x99, 0xc6, 0x8c, 0x7b, 0xe5, 0x5b, 0x97, 0x5d, 0xc6,
+	0xd1, 0x53, 0xed, 0x3b, 0x70, 0xfb, 0x31, 0x61, 0xaf, 0x64, 0xa5, 0xa7, 0x34, 0x68, 0xef, 0x03,
+	0xca, 0x33, 0x2f, 0x5d, 0x48, 0xb1, 0x8a, 0x2e, 0xa4, 0xbb, 0x87, 0x5a, 0x5e, 0x4b, 0xd9, 0x3f,
+	0x13, 0x6b, 0x1f, 0xf8, 0x94, 0x45, 0xc9, 0xc5, 0xbc, 0xb8, 0x5c, 0x85, 0xfa, 0x18, 0x7f, 0xa3,
+	0x5e, 0xb0, 0xfc, 0xd3, 0x7e, 0x0c, 0x28, 0x3f, 0x55, 0x9d, 0x20, 0xdf, 0x93, 0x30, 0x2a, 0xf5,
+	0x24, 0xec, 0x73, 0x40, 0x2f, 0x48, 0xd6, 0x1e, 0xb9, 0xe2, 0x29, 0xad, 0x23, 0xbc, 0x36, 0x1d,
+	0xe1, 0xf2, 0x89, 0xa2, 0x30, 0x41, 0x93, 0x7c, 0x44, 0xba, 0xb4, 0xec, 0x91, 0xb4, 0x1d, 0x4d,
+	0xda, 0xbf, 0x84, 0x3b, 0x85, 0x7d, 0xd5, 0x0d, 0xf8, 0x4d, 0xe9, 0x89, 0xda, 0x97, 0x7f, 0xa2,
+	0x1f, 0x42, 0x4b, 0xb6, 0x0d, 0x55, 0x17, 0xe8, 0xad, 0xe2, 0x8d, 0xc4, 0x22, 0x69, 0xa8, 0xfa,
+	0x8c, 0x8e, 0x92, 0xb5, 0x3f, 0x13, 0xb9, 0x2c, 0x26, 0x1e, 0x93, 0x85, 0xe9, 0x35, 0x2b, 0x58,
+	0x1e, 0xc9, 0xdd, 0xe2, 0x02, 0xea, 0x80, 0x9f, 0x80, 0xa9, 0x1b, 0xd6, 0x6a, 0x91, 0x6e, 0x7e,
+	0x91, 0x2f, 0xd4, 0x98, 0x93, 0x49, 0xf1, 0x60, 0x66, 0x64, 0x1c, 0x07, 0x98, 0x11, 0x9d, 0x80,
+	0x2e, 0x19, 0xd7, 0x7a, 0xe2, 0xaf, 0x43, 0x2b, 0x21, 0x78, 0x98, 0xd5, 0xc4, 0x8a, 0x42, 0x3f,
+	0x82, 0xe6, 0xc8, 0x0f, 0x08, 0xaf, 0x86, 0xb9, 0xcd, 0xef, 0x96, 0xfb, 0xb9, 0xb8, 0xc7, 0x23,
+	0x3f, 0x20, 0x8e, 0x94, 0xb6, 0x9f, 0x42, 0x3b, 0xe3, 0x95, 0x5a, 0x1c, 0x41, 0x83, 0xfa, 0xbf,
+	0x21, 0xca, 0xdc, 0xe2, 0x9b, 0x9f, 0xe1, 0xd8, 0x0f, 0x71, 0x72, 0xa1, 0xab, 0x75, 0x49, 0xd9,
+	0x7f, 0x36, 0xa0, 0x7b, 0xd9, 0x4f, 0xd9, 0x0d, 0x02, 0xad, 0xf2, 0x6b, 0x75, 0x65, 0x78, 0x06,
+	0x12, 0x15, 0x6f, 0xd6, 0x00, 0x52, 0x2f, 0x31, 0xce, 0xfc, 0x42, 0xf1, 0x78, 0x09, 0x2f, 0x84,
+	0x64, 0x8e, 0x92, 0xdd, 0x0e, 0x51, 0x28, 0xcb, 0x04, 0xa5, 0x87, 0x65, 0x99, 0xda, 0xbc, 0x1c,
+	0x16, 0xc5, 0xa9, 0xfd, 0xbf, 0x1a, 0xac, 0x4d, 0x9c, 0x74, 0x4e, 0x63, 0xac, 0x00, 0xc5, 0xb5,
+	0x39, 0x0d, 0xaf, 0x7a, 0xf1, 0x22, 0xba, 0xa1, 0xd6, 0xb8, 0xa2, 0xa1, 0x76, 0x4f, 0x7b, 0x64,
+	0x73, 0x8e, 0x33, 0x5d, 0x3e, 0xab, 0x54, 0x13, 0xad, 0x75, 0x65, 0x13, 0xed, 0x53, 0x58, 0xf1,
+	0xa2, 0x71, 0x9c, 0x32, 0x32, 0xd4, 0x6d, 0x96, 0x85, 0x99, 0x93, 0x96, 0xb5, 0xa8, 0xea, 0xbe,
+	0xe4, 0x3b, 0x70, 0x66, 0xb1, 0x03, 0x87, 0xb6, 0xa0, 0x29, 0xf5, 0xde, 0xde, 0xac, 0x5f, 0x2e,
+	0x97, 0xaf, 0x49, 0x1c, 0x29, 0x20, 0x72, 0x9e, 0x30, 0x81, 0x6c, 0xb3, 0x4b, 0xc2, 0xfe, 0xbd,
+	0x01, 0x5d, 0x47, 0xbc, 0x19, 0xaa, 0x61, 0xce, 0x0c, 0x47, 0xb9, 0x4e, 0xdc, 0x4c, 0xf6, 0x9b,
+	0x1a, 0x53, 0xfd, 0x26, 0xfb, 0x8f, 0x06, 0xac, 0x4d, 0x9c, 0x4a, 0x39, 0x45, 0x5e, 0x17, 0xc6,
+	0x2c, 0x5d, 0xd4, 0x2a, 0xeb, 0xa2, 0x9e, 0xd3, 0x45, 0xce, 0xa0, 0x8d, 0xab, 0x0c, 0x6a, 0xef,
+	0xc3, 0xc6, 0x20, 0xf3, 0x5a, 0xd9, 0xa5, 0x9a, 0xa7, 0xb9, 0x75, 0x68, 0xa9, 0xee, 0x96, 0x6a,
+	0x75, 0x48, 0xca, 0x7e, 0x0a, 0xd6, 0xf4, 0x32, 0x37, 0x6d, 0x15, 0xac, 0x8b, 0x98, 0x1f, 0x90,
+	0xe4, 0x9c, 0x24, 0xc2, 0xa7, 0x55, 0x7e, 0xfc, 0xd6, 0x80, 0xb5, 0x89, 0x81, 0xcb, 0x2d, 0xce,
+	0x2b, 0xe5, 0x48, 0x6d, 0x67, 0xde, 0x9f, 0x62, 0x51, 0x82, 0x4f, 0x88, 0x3b, 0x4c, 0xfc, 0xf3,
+	0xac, 0x54, 0x5a, 0x52, 0xdc, 0x87, 0x82, 0xc9, 0xad, 0x34, 0x22, 0x98, 0xa5, 0x09, 0xc9, 0x1e,
+	0x36, 0x9a, 0xde, 0xf9, 0xd7, 0x32, 0x2c, 0xeb, 0x4e, 0xb8, 0xc4, 0x44, 0xe4, 0xc3, 0x62, 0xfe,
+	0x1f, 0x05, 0xd0, 0x87, 0xb3, 0xff, 0x69, 0x61, 0xa2, 0x92, 0xeb, 0xdd, 0xab, 0x22, 0xaa, 0x1e,
+	0x30, 0xb7, 0x3e, 0x31, 0x10, 0x85, 0xd5, 0xc9, 0x4e, 0x3c, 0x9a, 0x51, 0xb0, 0xcd, 0xe8, 0xfd,
+	0xf7, 0xfa, 0x55, 0xc5, 0xf5, 0xb6, 0xe8, 0x1c, 0x6e, 0x5f, 0x8e, 0xaa, 0xe6, 0x36, 0xba, 0x72,
+	0x99, 0x62, 0x3f, 0xbd, 0xb7, 0x5d, 0x59, 0x3e, 0xdb, 0xf7, 0x2b, 0x58, 0x2a, 0xb4, 0xf6, 0xd0,
+	0x0c, 0x6d, 0x95, 0xb5, 0xca, 0x7b, 0xf7, 0x2b, 0xc9, 0x66, 0x7b, 0x8d, 0x61, 0xb9, 0xf8, 0x82,
+	0x44, 0xf7, 0xab, 0xbd, 0x33, 0xe5, 0x6e, 0xd7, 0x7a, 0x94, 0xda, 0xb7, 0xb8, 0x1d, 0x27, 0x8b,
+	0x54, 0x74, 0xbd, 0xc2, 0xbb, 0x77, 0xcd, 0xda, 0xd7, 0xbe, 0x85, 0x30, 0xc0, 0x65, 0xa1, 0x89,
+	0x3e, 0x98, 0x69, 0x90, 0x62, 0x7d, 0xda, 0xdb, 0xba, 0x5a, 0x30, 0xdb, 0x22, 0x86, 0x95, 0x89,
+	0xd6, 0x21, 0x9a, 0xa1, 0x9a, 0xf2, 0x4e, 0x6b, 0xef, 0xe3, 0x8a, 0xd2, 0x13, 0x97, 0x52, 0xb5,
+	0xeb, 0x9c, 0x4b, 0x15, 0x0b, 0xe3, 0xde, 0xd6, 0xd5, 0x82, 0xd9, 0x16, 0x3e, 0x2c, 0x3b, 0x69,
+	0xa8, 0xb6, 0x7e, 0x21, 0xa0, 0xba, 0x7c, 0xf6, 0x74, 0xed, 0xdb, 0xfb, 0xb0, 0x82, 0x64, 0x2e,
+	0xbe, 0x4f, 0x60, 0x31, 0x5f, 0x28, 0xce, 0x82, 0x92, 0x92, 0x6a, 0xb4, 0x77, 0xaf, 0x8a, 0x68,
+	0x3e, 0xb6, 0x0a, 0x65, 0xcb, 0xac, 0xd8, 0x2a, 0xab, 0xc2, 0x7a, 0xf7, 0x2b, 0xc9, 0xe6, 0x9d,
+	0x7d, 0x32, 0x4b, 0xcc, 0x72, 0xf6, 0x19, 0x49, 0xa9, 0xd7, 0xaf, 0x2a, 0x3e, 0x71, 0xc1, 0xcb,
+	0xa4, 0x31, 0xe7, 0x82, 0x53, 0x29, 0xa7, 0x77, 0xbf, 0x92, 0x6c, 0x1e, 0x3c, 0x8a, 0xef, 0xbe,
+	0x59, 0xe0, 0x51, 0xfa, 0x9c, 0xef, 0x7d, 0x54, 0x4d, 0x38, 0xdb, 0x2e, 0x11, 0xaf, 0x89, 0x5c,
+	0x8c, 0x0f, 0x58, 0x42, 0xf0, 0xf8, 0x7a, 0x88, 0xf5, 0x61, 0x15, 0x61, 0xd1, 0x9d, 0x12, 0x8e,
+	0xf9, 0x15, 0x2c, 0x15, 0x2a, 0x9a, 0x59, 0xea, 0x2c, 0x2b, 0xc6, 0x7a, 0xf7, 0x2b, 0xc9, 0xea,
+	0xfb, 0x3d, 0x80, 0x5f, 0x98, 0x5a, 0xf4, 0xb8, 0x25, 0xfe, 0x7b, 0xc3, 0x0f, 0xfe, 0xfe, 0xdf,
+	0x7a, 0xc3, 0xbc, 0x65, 0xdd, 0xfa, 0xff, 0x00, 0x97, 0x5e, 0x9a, 0x57, 0x4f, 0x24, 0x00, 0x00,
 }
@@ -62,6 +62,12 @@ type Verification struct {
 	FileHash string
 	// FileName is the name of the file that FileHash verifies.
 	FileName string
+	// LogEntry is the UUID of the transparency log entry of the signature. It
+	// is empty if no transparency log was checked.
+	LogEntry string
+	// LogUnreachable is the error met querying the transparency log, if it
+	// could not be reached. The chart is then verified by the keyring only.
+	LogUnreachable error
 }
 
 // Signatory signs things.
@@ -76,6 +82,9 @@ type Signatory struct {
 	Entity *openpgp.Entity
 	// The keyring for this instance of Helm. This is used for verification.
 	KeyRing openpgp.EntityList
+	// Log, if set, is checked for the signature when verifying. If it cannot
+	// be reached, verification falls back to the keyring alone.
+	Log *TransparencyLog
 }
 
 // NewFromFiles constructs a new Signatory from the PGP key in the given filename.
@@ -274,6 +283,23 @@ func (s *Signatory) Verify(chartpath, sigpath string) (*Verification, error) {
 	ver.FileHash = sum
 	ver.FileName = basename
 
+	// Third, check that the signature is in the transparency log.
+	if s.Log != nil {
+		data, err := ioutil.ReadFile(sigpath)
+		if err != nil {
+			return ver, err
+		}
+		entry, err := s.Log.Lookup(data)
+		switch {
+		case err == nil:
+			ver.LogEntry = entry
+		case IsUnreachable(err):
+			ver.LogUnreachable = err
+		default:
+			return ver, err
+		}
+	}
+
 	// TODO: when image signing is added, verify that here.
 
 	return ver, nil
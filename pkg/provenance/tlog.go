@@ -0,0 +1,259 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultLogTimeout bounds each request to a transparency log when no HTTP
+// client is given.
+const defaultLogTimeout = 10 * time.Second
+
+// ErrNotInLog indicates that a transparency log holds no entry for a signature.
+var ErrNotInLog = errors.New("signature not found in transparency log")
+
+// TransparencyLog checks that signatures are recorded in an append-only
+// transparency log with a Rekor-style API.
+//
+// A signature is looked up by the SHA256 digest of its provenance file, and
+// the entry found must come with an inclusion proof that verifies against the
+// root hash of the log.
+type TransparencyLog struct {
+	// URL is the base URL of the log, such as https://rekor.example.com.
+	URL string
+	// Client is used to query the log. If nil, a client with a short timeout
+	// is used.
+	Client *http.Client
+}
+
+// logEntry is an entry of a transparency log.
+type logEntry struct {
+	Body         string `json:"body"`
+	LogIndex     int64  `json:"logIndex"`
+	Verification struct {
+		InclusionProof *inclusionProof `json:"inclusionProof"`
+	} `json:"verification"`
+}
+
+// inclusionProof proves that an entry is in a log of a given size and root.
+type inclusionProof struct {
+	LogIndex int64    `json:"logIndex"`
+	TreeSize int64    `json:"treeSize"`
+	RootHash string   `json:"rootHash"`
+	Hashes   []string `json:"hashes"`
+}
+
+// entryBody is the part of the body of an entry that names what was logged.
+type entryBody struct {
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+	} `json:"spec"`
+}
+
+// unreachableError is returned when a log could not be queried.
+type unreachableError struct {
+	err error
+}
+
+func (e unreachableError) Error() string {
+	return fmt.Sprintf("transparency log unreachable: %s", e.err)
+}
+
+// IsUnreachable reports whether err means that a transparency log could not be
+// reached, as opposed to the log not recording a signature.
+func IsUnreachable(err error) bool {
+	_, ok := err.(unreachableError)
+	return ok
+}
+
+// Lookup finds the entry of the provenance file data in the log and verifies
+// its inclusion proof, returning the UUID of the entry.
+//
+// It returns ErrNotInLog if the log has no entry for the data, and an error
+// for which IsUnreachable is true if the log could not be queried.
+func (l *TransparencyLog) Lookup(data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+
+	var uuids []string
+	req, err := json.Marshal(map[string]string{"hash": "sha256:" + digest})
+	if err != nil {
+		return "", err
+	}
+	if err := l.do("POST", "api/v1/index/retrieve", req, &uuids); err != nil {
+		return "", err
+	}
+
+	for _, uuid := range uuids {
+		entries := map[string]logEntry{}
+		if err := l.do("GET", "api/v1/log/entries/"+url.PathEscape(uuid), nil, &entries); err != nil {
+			if err == ErrNotInLog {
+				continue
+			}
+			return "", err
+		}
+		entry, ok := entries[uuid]
+		if !ok {
+			continue
+		}
+		if err := entry.verify(digest); err != nil {
+			return "", fmt.Errorf("transparency log entry %s: %s", uuid, err)
+		}
+		return uuid, nil
+	}
+	return "", ErrNotInLog
+}
+
+// do sends a request to the log and decodes its JSON response into v. A
+// response of 404 Not Found is ErrNotInLog.
+func (l *TransparencyLog) do(method, path string, body []byte, v interface{}) error {
+	client := l.Client
+	if client == nil {
+		client = &http.Client{Timeout: defaultLogTimeout}
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(l.URL, "/")+"/"+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return unreachableError{err}
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotInLog
+	case resp.StatusCode >= 500:
+		return unreachableError{fmt.Errorf("%s %s: %s", method, path, resp.Status)}
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("transparency log: %s %s: %s", method, path, resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return unreachableError{err}
+	}
+	return json.Unmarshal(data, v)
+}
+
+// verify checks that the entry records the digest and that its inclusion
+// proof is valid.
+func (e *logEntry) verify(digest string) error {
+	body, err := base64.StdEncoding.DecodeString(e.Body)
+	if err != nil {
+		return fmt.Errorf("invalid body: %s", err)
+	}
+	var b entryBody
+	if err := json.Unmarshal(body, &b); err != nil {
+		return fmt.Errorf("invalid body: %s", err)
+	}
+	if h := b.Spec.Data.Hash; h.Algorithm != "sha256" || h.Value != digest {
+		return fmt.Errorf("records %s:%s, not sha256:%s", h.Algorithm, h.Value, digest)
+	}
+
+	p := e.Verification.InclusionProof
+	if p == nil {
+		return errors.New("no inclusion proof")
+	}
+	root, err := hex.DecodeString(p.RootHash)
+	if err != nil {
+		return fmt.Errorf("invalid root hash: %s", err)
+	}
+	proof := make([][]byte, len(p.Hashes))
+	for i, h := range p.Hashes {
+		if proof[i], err = hex.DecodeString(h); err != nil {
+			return fmt.Errorf("invalid inclusion proof hash: %s", err)
+		}
+	}
+	return verifyInclusion(p.LogIndex, p.TreeSize, leafHash(body), proof, root)
+}
+
+// leafHash is the hash of a leaf of a Merkle tree, as defined by RFC 6962.
+func leafHash(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// nodeHash is the hash of an interior node of a Merkle tree, as defined by
+// RFC 6962.
+func nodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{1})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// verifyInclusion verifies that the leaf is at index in the Merkle tree of
+// size leaves with root, following the algorithm of RFC 9162, section 2.1.3.2.
+func verifyInclusion(index, size int64, leaf []byte, proof [][]byte, root []byte) error {
+	if index < 0 || index >= size {
+		return fmt.Errorf("inclusion proof index %d is outside of a log of size %d", index, size)
+	}
+
+	fn, sn := index, size-1
+	r := leaf
+	for _, p := range proof {
+		if sn == 0 {
+			return errors.New("inclusion proof is too long")
+		}
+		if fn&1 == 1 || fn == sn {
+			r = nodeHash(p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = nodeHash(r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 {
+		return errors.New("inclusion proof is too short")
+	}
+	if !bytes.Equal(r, root) {
+		return errors.New("inclusion proof does not match the root hash of the log")
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// merkleRoot is the root hash of the Merkle tree of leaves, as defined by
+// RFC 6962.
+func merkleRoot(leaves [][]byte) []byte {
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	k := splitPoint(len(leaves))
+	return nodeHash(merkleRoot(leaves[:k]), merkleRoot(leaves[k:]))
+}
+
+// merklePath is the inclusion proof of leaf m in the Merkle tree of leaves,
+// as defined by RFC 6962.
+func merklePath(m int, leaves [][]byte) [][]byte {
+	if len(leaves) == 1 {
+		return nil
+	}
+	k := splitPoint(len(leaves))
+	if m < k {
+		return append(merklePath(m, leaves[:k]), merkleRoot(leaves[k:]))
+	}
+	return append(merklePath(m-k, leaves[k:]), merkleRoot(leaves[:k]))
+}
+
+// splitPoint is the largest power of two smaller than n.
+func splitPoint(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
+
+// fakeLog serves a transparency log holding an entry for each of digests.
+type fakeLog struct {
+	bodies [][]byte
+	// badProof, if set, serves inclusion proofs with a wrong root hash.
+	badProof bool
+}
+
+func newFakeLog(digests ...string) *fakeLog {
+	l := &fakeLog{}
+	for _, d := range digests {
+		l.bodies = append(l.bodies, []byte(fmt.Sprintf(`{"spec":{"data":{"hash":{"algorithm":"sha256","value":%q}}}}`, d)))
+	}
+	return l
+}
+
+func (l *fakeLog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	leaves := make([][]byte, len(l.bodies))
+	for i, b := range l.bodies {
+		leaves[i] = leafHash(b)
+	}
+	uuid := func(i int) string { return hex.EncodeToString(leaves[i]) }
+
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/api/v1/index/retrieve":
+		var req struct {
+			Hash string `json:"hash"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		uuids := []string{}
+		for i, b := range l.bodies {
+			if strings.Contains(string(b), strings.TrimPrefix(req.Hash, "sha256:")) {
+				uuids = append(uuids, uuid(i))
+			}
+		}
+		json.NewEncoder(w).Encode(uuids)
+	case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/v1/log/entries/"):
+		for i, b := range l.bodies {
+			if uuid(i) != strings.TrimPrefix(r.URL.Path, "/api/v1/log/entries/") {
+				continue
+			}
+			root := merkleRoot(leaves)
+			if l.badProof {
+				root = leafHash(root)
+			}
+			var hashes []string
+			for _, h := range merklePath(i, leaves) {
+				hashes = append(hashes, hex.EncodeToString(h))
+			}
+			entry := map[string]interface{}{
+				"body":     base64.StdEncoding.EncodeToString(b),
+				"logIndex": i,
+				"verification": map[string]interface{}{
+					"inclusionProof": map[string]interface{}{
+						"logIndex": i,
+						"treeSize": len(leaves),
+						"rootHash": hex.EncodeToString(root),
+						"hashes":   hashes,
+					},
+				},
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{uuid(i): entry})
+			return
+		}
+		http.NotFound(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func sigBlockDigest(t *testing.T) string {
+	sum, err := DigestFile(testSigBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sum
+}
+
+func TestVerifyInclusion(t *testing.T) {
+	for size := 1; size <= 9; size++ {
+		var leaves [][]byte
+		for i := 0; i < size; i++ {
+			leaves = append(leaves, leafHash([]byte{byte(i)}))
+		}
+		root := merkleRoot(leaves)
+		for i := range leaves {
+			proof := merklePath(i, leaves)
+			if err := verifyInclusion(int64(i), int64(size), leaves[i], proof, root); err != nil {
+				t.Errorf("leaf %d of %d: %s", i, size, err)
+			}
+			if err := verifyInclusion(int64(i), int64(size), leafHash([]byte("other")), proof, root); err == nil {
+				t.Errorf("leaf %d of %d: expected an unknown leaf to fail", i, size)
+			}
+			if size > 1 {
+				if err := verifyInclusion(int64(i), int64(size), leaves[i], proof[1:], root); err == nil {
+					t.Errorf("leaf %d of %d: expected a short proof to fail", i, size)
+				}
+			}
+		}
+	}
+}
+
+func TestVerifyTransparencyLog(t *testing.T) {
+	digest := sigBlockDigest(t)
+
+	tests := []struct {
+		name        string
+		log         *fakeLog
+		err         error
+		inLog       bool
+		unreachable bool
+	}{
+		{"included", newFakeLog("0a0a", digest, "0b0b", "0c0c"), nil, true, false},
+		{"only entry", newFakeLog(digest), nil, true, false},
+		{"not found", newFakeLog("0a0a", "0b0b"), ErrNotInLog, false, false},
+		{"invalid proof", &fakeLog{bodies: newFakeLog("0a0a", digest, "0b0b").bodies, badProof: true}, nil, false, false},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(tt.log)
+		signer, err := NewFromFiles(testKeyfile, testPubfile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer.Log = &TransparencyLog{URL: srv.URL}
+
+		ver, err := signer.Verify(testChartfile, testSigBlock)
+		srv.Close()
+		switch {
+		case tt.inLog:
+			if err != nil {
+				t.Errorf("%s: failed to verify: %s", tt.name, err)
+			} else if ver.LogEntry == "" {
+				t.Errorf("%s: expected a log entry", tt.name)
+			}
+		case tt.err != nil:
+			if err != tt.err {
+				t.Errorf("%s: expected error %q, got %v", tt.name, tt.err, err)
+			}
+		default:
+			if err == nil || IsUnreachable(err) {
+				t.Errorf("%s: expected a verification error, got %v", tt.name, err)
+			}
+		}
+	}
+}
+
+func TestVerifyTransparencyLogUnreachable(t *testing.T) {
+	srv := httptest.NewServer(newFakeLog(sigBlockDigest(t)))
+	srv.Close()
+
+	signer, err := NewFromFiles(testKeyfile, testPubfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer.Log = &TransparencyLog{URL: srv.URL}
+
+	ver, err := signer.Verify(testChartfile, testSigBlock)
+	if err != nil {
+		t.Fatalf("Expected to fall back to the keyring, got %s", err)
+	}
+	if ver.LogEntry != "" {
+		t.Errorf("Expected no log entry, got %q", ver.LogEntry)
+	}
+	if !IsUnreachable(ver.LogUnreachable) {
+		t.Errorf("Expected the log to be unreachable, got %v", ver.LogUnreachable)
+	}
+}
@@ -18,6 +18,8 @@ package releasetesting
 
 import (
 	"fmt"
+	"path"
+	"regexp"
 	"strings"
 
 	"github.com/ghodss/yaml"
@@ -60,6 +62,71 @@ func NewTestSuite(rel *release.Release) (*TestSuite, error) {
 	}, nil
 }
 
+// Filter keeps only the tests whose name matches at least one of filters.
+//
+// A filter is either "name=GLOB", matching test names with shell file name
+// patterns, or "name=~REGEX", matching test names against a regular
+// expression. Without filters all tests are kept. It is an error if the suite
+// has tests but none of them matches.
+func (ts *TestSuite) Filter(filters []string) error {
+	if len(filters) == 0 {
+		return nil
+	}
+
+	matchers := make([]func(string) bool, 0, len(filters))
+	for _, f := range filters {
+		m, err := parseFilter(f)
+		if err != nil {
+			return err
+		}
+		matchers = append(matchers, m)
+	}
+
+	kept := []string{}
+	for _, manifest := range ts.TestManifests {
+		name, err := testName(manifest)
+		if err != nil {
+			return err
+		}
+		for _, match := range matchers {
+			if match(name) {
+				kept = append(kept, manifest)
+				break
+			}
+		}
+	}
+
+	if len(kept) == 0 && len(ts.TestManifests) > 0 {
+		return fmt.Errorf("no tests match the filters %s", strings.Join(filters, ", "))
+	}
+	ts.TestManifests = kept
+	return nil
+}
+
+// parseFilter parses a test filter into a function matching test names.
+func parseFilter(filter string) (func(string) bool, error) {
+	if !strings.HasPrefix(filter, "name=") {
+		return nil, fmt.Errorf("invalid test filter %q: expected name=GLOB or name=~REGEX", filter)
+	}
+	pattern := strings.TrimPrefix(filter, "name=")
+
+	if strings.HasPrefix(pattern, "~") {
+		re, err := regexp.Compile(strings.TrimPrefix(pattern, "~"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid test filter %q: %s", filter, err)
+		}
+		return re.MatchString, nil
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid test filter %q: %s", filter, err)
+	}
+	return func(name string) bool {
+		ok, _ := path.Match(pattern, name)
+		return ok
+	}, nil
+}
+
 // Run executes tests in a test suite and stores a result within a given environment
 func (ts *TestSuite) Run(env *Environment) error {
 	ts.StartedAt = timeconv.Now()
@@ -165,6 +232,18 @@ func extractTestManifestsFromHooks(h []*release.Hook) ([]string, error) {
 	return tests, nil
 }
 
+// testName returns the name of the test defined by a manifest.
+func testName(testManifest string) (string, error) {
+	var sh util.SimpleHead
+	if err := yaml.Unmarshal([]byte(testManifest), &sh); err != nil {
+		return "", err
+	}
+	if sh.Metadata == nil {
+		return "", fmt.Errorf("test manifest has no metadata")
+	}
+	return strings.TrimSuffix(sh.Metadata.Name, ","), nil
+}
+
 func newTest(testManifest string) (*test, error) {
 	var sh util.SimpleHead
 	err := yaml.Unmarshal([]byte(testManifest), &sh)
@@ -19,6 +19,8 @@ package releasetesting
 import (
 	"io"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -220,6 +222,41 @@ func TestExtractTestManifestsFromHooks(t *testing.T) {
 	}
 }
 
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters []string
+		kept    []string
+		err     string
+	}{
+		{"no filters", nil, []string{manifestWithTestSuccessHook, manifestWithTestFailureHook}, ""},
+		{"regex", []string{"name=~nemo"}, []string{manifestWithTestSuccessHook}, ""},
+		{"glob", []string{"name=gold-*"}, []string{manifestWithTestFailureHook}, ""},
+		{"any filter", []string{"name=~nemo", "name=gold-*"}, []string{manifestWithTestSuccessHook, manifestWithTestFailureHook}, ""},
+		{"no match", []string{"name=~smoke"}, nil, "no tests match the filters name=~smoke"},
+		{"unknown field", []string{"kind=Pod"}, nil, "invalid test filter"},
+		{"invalid regex", []string{"name=~("}, nil, "invalid test filter"},
+	}
+
+	for _, tt := range tests {
+		ts := testSuiteFixture([]string{manifestWithTestSuccessHook, manifestWithTestFailureHook})
+		err := ts.Filter(tt.filters)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(ts.TestManifests, tt.kept) {
+			t.Errorf("%s: expected %d test manifests, got %d", tt.name, len(tt.kept), len(ts.TestManifests))
+		}
+	}
+}
+
 func chartStub() *chart.Chart {
 	return &chart.Chart{
 		Metadata: &chart.Metadata{
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/ghodss/yaml"
 )
 
 // SimpleHead defines what the structure of the head of a manifest file
@@ -57,3 +59,62 @@ func SplitManifests(bigFile string) map[string]string {
 	}
 	return res
 }
+
+// SourcedManifest is a single document of a manifest, along with the path of
+// the template it was rendered from.
+type SourcedManifest struct {
+	// Source is the template path from the "# Source:" comment of the
+	// document, or empty if the document does not have one.
+	Source string
+	// Content is the document.
+	Content string
+}
+
+// SplitManifestsWithSource splits a manifest into its documents, in order,
+// tagging each with the template it was rendered from.
+func SplitManifestsWithSource(bigFile string) []SourcedManifest {
+	var res []SourcedManifest
+	for _, d := range sep.Split(strings.TrimSpace(bigFile), -1) {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		res = append(res, SourcedManifest{Source: manifestSource(d), Content: d})
+	}
+	return res
+}
+
+// FilterManifestsByKind splits a manifest into its documents, in order, and
+// keeps those whose kind is one of kinds. Kinds are compared case-insensitively.
+// Documents that cannot be parsed are dropped.
+func FilterManifestsByKind(bigFile string, kinds ...string) []SourcedManifest {
+	var res []SourcedManifest
+	for _, m := range SplitManifestsWithSource(bigFile) {
+		var head SimpleHead
+		if err := yaml.Unmarshal([]byte(m.Content), &head); err != nil {
+			continue
+		}
+		for _, k := range kinds {
+			if strings.EqualFold(head.Kind, k) {
+				res = append(res, m)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// manifestSource returns the template path from the "# Source:" comment at
+// the head of a document.
+func manifestSource(doc string) string {
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		if strings.HasPrefix(line, "# Source:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "# Source:"))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil // import "k8s.io/helm/pkg/releaseutil"
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// ManifestChangeType describes how a resource differs between two manifests.
+type ManifestChangeType string
+
+// Types of manifest changes
+const (
+	ManifestAdded    ManifestChangeType = "added"
+	ManifestRemoved  ManifestChangeType = "removed"
+	ManifestModified ManifestChangeType = "modified"
+)
+
+// ManifestDiff is the difference of a single resource between two manifests.
+type ManifestDiff struct {
+	// Name identifies the resource as "Kind/name", or as
+	// "Kind/namespace/name" if the resource sets its namespace.
+	Name string
+	Type ManifestChangeType
+	// Diff is the line diff of the resource. Every line is prefixed with
+	// '+' if it was added, '-' if it was removed, or ' ' if it is unchanged.
+	Diff string
+}
+
+// DiffManifests returns the per-resource differences between two rendered
+// manifests, sorted by resource name. Resources that did not change are
+// omitted.
+func DiffManifests(from, to string) []ManifestDiff {
+	old, new := resourcesByName(from), resourcesByName(to)
+
+	var diffs []ManifestDiff
+	for name, o := range old {
+		n, ok := new[name]
+		switch {
+		case !ok:
+			diffs = append(diffs, ManifestDiff{Name: name, Type: ManifestRemoved, Diff: diffLines(o, "")})
+		case o != n:
+			diffs = append(diffs, ManifestDiff{Name: name, Type: ManifestModified, Diff: diffLines(o, n)})
+		}
+	}
+	for name, n := range new {
+		if _, ok := old[name]; !ok {
+			diffs = append(diffs, ManifestDiff{Name: name, Type: ManifestAdded, Diff: diffLines("", n)})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
+
+// resourceHead holds the fields that identify a resource.
+type resourceHead struct {
+	Kind     string `json:"kind"`
+	Metadata *struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+}
+
+// resourcesByName splits a manifest into its resources, keyed by "Kind/name",
+// or by "Kind/namespace/name" if the resource sets its namespace, so that
+// resources of the same name in different namespaces are kept apart.
+//
+// Documents that cannot be parsed keep the placeholder name they were given
+// by SplitManifests.
+func resourcesByName(manifest string) map[string]string {
+	res := map[string]string{}
+	for k, m := range SplitManifests(manifest) {
+		var head resourceHead
+		if err := yaml.Unmarshal([]byte(m), &head); err == nil && head.Metadata != nil && head.Kind != "" {
+			if ns := head.Metadata.Namespace; ns != "" {
+				k = fmt.Sprintf("%s/%s/%s", head.Kind, ns, head.Metadata.Name)
+			} else {
+				k = fmt.Sprintf("%s/%s", head.Kind, head.Metadata.Name)
+			}
+		}
+		res[k] = m
+	}
+	return res
+}
+
+// diffLines returns a line diff of two texts based on their longest common
+// subsequence of lines.
+func diffLines(a, b string) string {
+	x, y := splitLines(a), splitLines(b)
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			fmt.Fprintf(&buf, " %s\n", x[i])
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(&buf, "-%s\n", x[i])
+			i++
+		default:
+			fmt.Fprintf(&buf, "+%s\n", y[j])
+			j++
+		}
+	}
+	return buf.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
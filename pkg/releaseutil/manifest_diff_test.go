@@ -0,0 +1,113 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil // import "k8s.io/helm/pkg/releaseutil"
+
+import (
+	"reflect"
+	"testing"
+)
+
+const diffFrom = `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  level: info
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`
+
+const diffTo = `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  level: debug
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: token
+`
+
+func TestDiffManifests(t *testing.T) {
+	expect := []ManifestDiff{
+		{
+			Name: "ConfigMap/settings",
+			Type: ManifestModified,
+			Diff: " apiVersion: v1\n kind: ConfigMap\n metadata:\n   name: settings\n data:\n-  level: info\n+  level: debug\n",
+		},
+		{
+			Name: "Secret/token",
+			Type: ManifestAdded,
+			Diff: "+apiVersion: v1\n+kind: Secret\n+metadata:\n+  name: token\n",
+		},
+		{
+			Name: "Service/web",
+			Type: ManifestRemoved,
+			Diff: "-apiVersion: v1\n-kind: Service\n-metadata:\n-  name: web\n",
+		},
+	}
+
+	diffs := DiffManifests(diffFrom, diffTo)
+	if !reflect.DeepEqual(diffs, expect) {
+		t.Errorf("Expected %v, got %v", expect, diffs)
+	}
+
+	if diffs := DiffManifests(diffFrom, diffFrom); len(diffs) != 0 {
+		t.Errorf("Expected no differences, got %v", diffs)
+	}
+}
+
+func TestDiffManifestsNamespaces(t *testing.T) {
+	const from = `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: blue
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: green
+`
+	const to = `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: blue
+`
+	expect := []ManifestDiff{
+		{
+			Name: "ConfigMap/green/settings",
+			Type: ManifestRemoved,
+			Diff: "-apiVersion: v1\n-kind: ConfigMap\n-metadata:\n-  name: settings\n-  namespace: green\n",
+		},
+	}
+
+	if diffs := DiffManifests(from, to); !reflect.DeepEqual(diffs, expect) {
+		t.Errorf("Expected %v, got %v", expect, diffs)
+	}
+}
@@ -18,6 +18,7 @@ package releaseutil // import "k8s.io/helm/pkg/releaseutil"
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -59,3 +60,65 @@ func TestSplitManifest(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, manifests)
 	}
 }
+
+const sourcedManifestFile = `
+---
+# Source: pequod/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: pequod
+---
+# Source: pequod/charts/whaleboat/templates/configmap.yaml
+# A whaleboat for every mate.
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: whaleboat
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: whaleboat-spare
+`
+
+func TestSplitManifestsWithSource(t *testing.T) {
+	manifests := SplitManifestsWithSource(sourcedManifestFile)
+	expected := []SourcedManifest{
+		{
+			Source:  "pequod/templates/service.yaml",
+			Content: "# Source: pequod/templates/service.yaml\napiVersion: v1\nkind: Service\nmetadata:\n  name: pequod",
+		},
+		{
+			Source:  "pequod/charts/whaleboat/templates/configmap.yaml",
+			Content: "# Source: pequod/charts/whaleboat/templates/configmap.yaml\n# A whaleboat for every mate.\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: whaleboat",
+		},
+		{
+			Source:  "",
+			Content: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: whaleboat-spare",
+		},
+	}
+	if !reflect.DeepEqual(manifests, expected) {
+		t.Errorf("Expected %v, got %v", expected, manifests)
+	}
+}
+
+func TestFilterManifestsByKind(t *testing.T) {
+	tests := []struct {
+		kinds  []string
+		expect []string
+	}{
+		{[]string{"ConfigMap"}, []string{"whaleboat", "whaleboat-spare"}},
+		{[]string{"service", "configmap"}, []string{"pequod", "whaleboat", "whaleboat-spare"}},
+		{[]string{"Deployment"}, nil},
+	}
+	for _, tt := range tests {
+		var names []string
+		for _, m := range FilterManifestsByKind(sourcedManifestFile, tt.kinds...) {
+			names = append(names, m.Content[strings.LastIndex(m.Content, " ")+1:])
+		}
+		if !reflect.DeepEqual(names, tt.expect) {
+			t.Errorf("%v: expected %v, got %v", tt.kinds, tt.expect, names)
+		}
+	}
+}
@@ -36,9 +36,15 @@ type Entry struct {
 	Name     string `json:"name"`
 	Cache    string `json:"cache"`
 	URL      string `json:"url"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
 	CertFile string `json:"certFile"`
 	KeyFile  string `json:"keyFile"`
 	CAFile   string `json:"caFile"`
+	// PassCredentialsAll sends the credentials to chart URLs on any host.
+	// By default they are only sent to the scheme and host of URL.
+	PassCredentialsAll bool `json:"passCredentialsAll,omitempty"`
 }
 
 // ChartRepository represents a chart repository
@@ -65,6 +71,13 @@ func NewChartRepository(cfg *Entry, getters getter.Providers) (*ChartRepository,
 		return nil, fmt.Errorf("Could not construct protocol handler for: %s error: %v", u.Scheme, err)
 	}
 
+	// Apply the stored credentials so that both the index and the chart
+	// downloads for this repository are authenticated.
+	if a, ok := client.(getter.Authenticator); ok {
+		a.SetCredentials(cfg.Username, cfg.Password)
+		a.SetBearerToken(cfg.Token)
+	}
+
 	return &ChartRepository{
 		Config:    cfg,
 		IndexFile: NewIndexFile(),
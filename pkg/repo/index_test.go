@@ -18,8 +18,10 @@ package repo
 
 import (
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/getter"
@@ -173,6 +175,91 @@ func TestDownloadIndexFile(t *testing.T) {
 	verifyLocalIndex(t, i)
 }
 
+func TestDownloadUserAgent(t *testing.T) {
+	fileBytes, err := ioutil.ReadFile(testfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agents := map[string]string{}
+	srv, err := startLocalServerForTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents[r.URL.Path] = r.UserAgent()
+		w.Write(fileBytes)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	dirName, err := ioutil.TempDir("", "tmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dirName)
+
+	r, err := NewChartRepository(&Entry{
+		Name:  testRepo,
+		URL:   srv.URL,
+		Cache: filepath.Join(dirName, testRepo+"-index.yaml"),
+	}, getter.All(environment.EnvSettings{UserAgentSuffix: "my-ci/1.0"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.DownloadIndexFile(""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Client.Get(srv.URL + "/charts/alpine-0.1.0.tgz"); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := getter.UserAgent("my-ci/1.0")
+	if !strings.HasPrefix(expect, "Helm/") || !strings.HasSuffix(expect, " my-ci/1.0") {
+		t.Fatalf("Unexpected User-Agent %q", expect)
+	}
+	for _, p := range []string{"/index.yaml", "/charts/alpine-0.1.0.tgz"} {
+		if agents[p] != expect {
+			t.Errorf("Expected User-Agent %q for %s, got %q", expect, p, agents[p])
+		}
+	}
+}
+
+func TestDownloadIndexFileThroughProxy(t *testing.T) {
+	var proxied []string
+	fileBytes, err := ioutil.ReadFile(testfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy, err := startLocalServerForTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.Write(fileBytes)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proxy.Close()
+
+	dirName, err := ioutil.TempDir("", "tmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dirName)
+
+	r, err := NewChartRepository(&Entry{
+		Name:  testRepo,
+		URL:   "http://charts.example.com/stable",
+		Cache: filepath.Join(dirName, testRepo+"-index.yaml"),
+	}, getter.All(environment.EnvSettings{HTTPProxy: proxy.URL}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.DownloadIndexFile(""); err != nil {
+		t.Fatal(err)
+	}
+	if len(proxied) != 1 || proxied[0] != "http://charts.example.com/stable/index.yaml" {
+		t.Errorf("Expected index to be fetched through the proxy, got %v", proxied)
+	}
+}
+
 func verifyLocalIndex(t *testing.T, i *IndexFile) {
 	numEntries := len(i.Entries)
 	if numEntries != 3 {
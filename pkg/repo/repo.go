@@ -124,6 +124,24 @@ func (r *RepoFile) Has(name string) bool {
 	return false
 }
 
+// FindChart returns the names of the repositories whose index has a chart
+// with the given name, in the order they are listed. indexPath maps a
+// repository name to the path of its cached index. Repositories whose index
+// cannot be loaded are skipped.
+func (r *RepoFile) FindChart(name string, indexPath func(repo string) string) []string {
+	var repos []string
+	for _, re := range r.Repositories {
+		i, err := LoadIndexFile(indexPath(re.Name))
+		if err != nil {
+			continue
+		}
+		if _, ok := i.Entries[name]; ok {
+			repos = append(repos, re.Name)
+		}
+	}
+	return repos
+}
+
 // Remove removes the entry from the list of repositories.
 func (r *RepoFile) Remove(name string) bool {
 	cp := []*Entry{}
@@ -140,10 +158,23 @@ func (r *RepoFile) Remove(name string) bool {
 }
 
 // WriteFile writes a repositories file to the given path.
+//
+// As the file may hold repository credentials, an existing file loses the
+// group and other permissions that perm does not grant.
 func (r *RepoFile) WriteFile(path string, perm os.FileMode) error {
 	data, err := yaml.Marshal(r)
 	if err != nil {
 		return err
 	}
-	return ioutil.WriteFile(path, data, perm)
+	if err := ioutil.WriteFile(path, data, perm); err != nil {
+		return err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if mode := fi.Mode().Perm() &^ (^perm & 0077); mode != fi.Mode().Perm() {
+		return os.Chmod(path, mode)
+	}
+	return nil
 }
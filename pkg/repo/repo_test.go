@@ -217,6 +217,30 @@ func TestWriteFile(t *testing.T) {
 	}
 }
 
+func TestWriteFilePermissions(t *testing.T) {
+	repoFile, err := ioutil.TempFile("", "helm-repo")
+	if err != nil {
+		t.Fatalf("failed to create test-file (%v)", err)
+	}
+	defer os.Remove(repoFile.Name())
+	if err := os.Chmod(repoFile.Name(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRepoFile()
+	r.Add(&Entry{Name: "private", URL: "https://example.com/private", Password: "secret"})
+	if err := r.WriteFile(repoFile.Name(), 0600); err != nil {
+		t.Fatalf("failed to write file (%v)", err)
+	}
+	fi, err := os.Stat(repoFile.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Errorf("expected mode 0600, got %o", fi.Mode().Perm())
+	}
+}
+
 func TestRepoNotExists(t *testing.T) {
 	_, err := LoadRepositoriesFile("/this/path/does/not/exist.yaml")
 	if err == nil {
@@ -225,3 +249,32 @@ func TestRepoNotExists(t *testing.T) {
 		t.Errorf("expected prompt to run `helm init` when repositories file does not exist")
 	}
 }
+
+func TestFindChart(t *testing.T) {
+	rf := NewRepoFile()
+	rf.Add(
+		&Entry{Name: "stable"},
+		&Entry{Name: "incubator"},
+		&Entry{Name: "old"},
+		&Entry{Name: "missing"},
+	)
+	indexes := map[string]string{
+		"stable":    "testdata/local-index.yaml",
+		"incubator": "testdata/server/index.yaml",
+		"old":       "testdata/unversioned-index.yaml",
+		"missing":   "testdata/no-such-index.yaml",
+	}
+	indexPath := func(repo string) string { return indexes[repo] }
+
+	tests := map[string]string{
+		"nginx":          "stable,incubator",
+		"chartWithNoURL": "stable",
+		"memcached":      "old",
+		"nope":           "",
+	}
+	for name, expect := range tests {
+		if got := strings.Join(rf.FindChart(name, indexPath), ","); got != expect {
+			t.Errorf("%s: expected repositories %q, got %q", name, expect, got)
+		}
+	}
+}
@@ -128,7 +128,8 @@ func HashReq(req *chartutil.Requirements) (string, error) {
 }
 
 // GetLocalPath generates absolute local path when use
-// "file://" in repository of requirements
+// "file://" in repository of requirements. The path must point at an
+// unpacked chart directory.
 func GetLocalPath(repo string, chartpath string) (string, error) {
 	var depPath string
 	var err error
@@ -149,5 +150,9 @@ func GetLocalPath(repo string, chartpath string) (string, error) {
 		return "", err
 	}
 
+	if ok, err := chartutil.IsChartDir(depPath); !ok {
+		return "", fmt.Errorf("%s is not a valid chart directory: %s", depPath, err)
+	}
+
 	return depPath, nil
 }
@@ -33,6 +33,7 @@ import (
 )
 
 var _ Driver = (*ConfigMaps)(nil)
+var _ Locker = (*ConfigMaps)(nil)
 
 // ConfigMapsDriverName is the string name of the driver.
 const ConfigMapsDriverName = "ConfigMap"
@@ -58,6 +59,19 @@ func (cfgmaps *ConfigMaps) Name() string {
 	return ConfigMapsDriverName
 }
 
+// Ping lists a single release configmap to check that the configmaps of the
+// namespace can be read.
+func (cfgmaps *ConfigMaps) Ping() error {
+	lsel := kblabels.Set{"OWNER": "TILLER"}.AsSelector()
+	opts := metav1.ListOptions{LabelSelector: lsel.String(), Limit: 1}
+
+	if _, err := cfgmaps.impl.List(opts); err != nil {
+		cfgmaps.Log("ping: failed to list: %s", err)
+		return err
+	}
+	return nil
+}
+
 // Get fetches the release named by key. The corresponding release is returned
 // or error if not found.
 func (cfgmaps *ConfigMaps) Get(key string) (*rspb.Release, error) {
@@ -215,6 +229,68 @@ func (cfgmaps *ConfigMaps) Delete(key string) (rls *rspb.Release, err error) {
 	return rls, nil
 }
 
+// Lock takes the lock of the release name, which is held in a ConfigMap
+// named after the release. A lock that has expired is taken over.
+func (cfgmaps *ConfigMaps) Lock(name, holder string, expires time.Time) error {
+	key := lockKey(name)
+	obj, err := cfgmaps.impl.Get(key, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lock := &core.ConfigMap{
+			ObjectMeta: lockObjectMeta(name),
+			Data:       lockData(holder, expires),
+		}
+		if _, err := cfgmaps.impl.Create(lock); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return ErrReleaseLocked(name)
+			}
+			cfgmaps.Log("lock: failed to create lock %q: %s", key, err)
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		cfgmaps.Log("lock: failed to get lock %q: %s", key, err)
+		return err
+	}
+	if !lockAvailable(obj.Data, holder, time.Now()) {
+		return ErrReleaseLocked(name)
+	}
+	if obj.Data["holder"] != holder {
+		cfgmaps.Log("lock: reclaiming expired lock %q of %s", key, obj.Data["holder"])
+	}
+	// The update carries the resource version that was read, so only one of
+	// several operations reclaiming the same lock succeeds.
+	obj.Data = lockData(holder, expires)
+	if _, err := cfgmaps.impl.Update(obj); err != nil {
+		if apierrors.IsConflict(err) {
+			return ErrReleaseLocked(name)
+		}
+		cfgmaps.Log("lock: failed to update lock %q: %s", key, err)
+		return err
+	}
+	return nil
+}
+
+// Unlock releases the lock of the release name if holder still holds it.
+func (cfgmaps *ConfigMaps) Unlock(name, holder string) error {
+	key := lockKey(name)
+	obj, err := cfgmaps.impl.Get(key, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if obj.Data["holder"] != holder {
+		return nil
+	}
+	opts := &metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &obj.UID}}
+	if err := cfgmaps.impl.Delete(key, opts); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 // newConfigMapsObject constructs a kubernetes ConfigMap object
 // to store a release. Each configmap data entry is the base64
 // encoded string of a release's binary protobuf encoding.
@@ -227,6 +303,7 @@ func (cfgmaps *ConfigMaps) Delete(key string) (rls *rspb.Release, err error) {
 //    "STATUS"         - status of the release (see proto/hapi/release.status.pb.go for variants)
 //    "OWNER"          - owner of the configmap, currently "TILLER".
 //    "NAME"           - name of the release.
+//    "COMMIT"         - commit the release was built from, if it is recorded.
 //
 func newConfigMapsObject(key string, rls *rspb.Release, lbs labels) (*core.ConfigMap, error) {
 	const owner = "TILLER"
@@ -246,6 +323,7 @@ func newConfigMapsObject(key string, rls *rspb.Release, lbs labels) (*core.Confi
 	lbs.set("OWNER", owner)
 	lbs.set("STATUS", rspb.Status_Code_name[int32(rls.Info.Status.Code)])
 	lbs.set("VERSION", strconv.Itoa(int(rls.Version)))
+	lbs.setBuildCommit(rls)
 
 	// create and return configmap object
 	return &core.ConfigMap{
@@ -15,6 +15,7 @@ package driver
 
 import (
 	"encoding/base64"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -31,6 +32,18 @@ func TestConfigMapName(t *testing.T) {
 	}
 }
 
+func TestConfigMapPing(t *testing.T) {
+	cfgmaps := newTestFixtureCfgMaps(t)
+	if err := cfgmaps.Ping(); err != nil {
+		t.Fatalf("Expected a healthy backend, got %s", err)
+	}
+
+	cfgmaps.impl.(*MockConfigMapsInterface).listErr = errors.New("connection refused")
+	if err := cfgmaps.Ping(); err == nil || err.Error() != "connection refused" {
+		t.Errorf("Expected an unreachable backend to fail, got %v", err)
+	}
+}
+
 func TestConfigMapGet(t *testing.T) {
 	vers := int32(1)
 	name := "smug-pigeon"
@@ -18,6 +18,7 @@ package driver // import "k8s.io/helm/pkg/storage/driver"
 
 import (
 	"fmt"
+	"time"
 
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
 )
@@ -29,6 +30,8 @@ var (
 	ErrReleaseExists = func(release string) error { return fmt.Errorf("release: %q already exists", release) }
 	// ErrInvalidKey indicates that a release key could not be parsed.
 	ErrInvalidKey = func(release string) error { return fmt.Errorf("release: %q invalid key", release) }
+	// ErrReleaseLocked indicates that another operation holds the lock of a release.
+	ErrReleaseLocked = func(release string) error { return fmt.Errorf("release: %q is locked by another operation", release) }
 )
 
 // Creator is the interface that wraps the Create method.
@@ -69,14 +72,36 @@ type Queryor interface {
 	Query(labels map[string]string) ([]*rspb.Release, error)
 }
 
-// Driver is the interface composed of Creator, Updator, Deletor, and Queryor
-// interfaces. It defines the behavior for storing, updating, deleted,
-// and retrieving Tiller releases from some underlying storage mechanism,
-// e.g. memory, configmaps.
+// Locker is the interface that wraps the Lock and Unlock methods.
+//
+// Lock takes the lock of the release name for holder until expires. It
+// returns ErrReleaseLocked if another holder has a lock that has not expired
+// yet. An expired lock is taken over, so that the lock of an operation that
+// died is eventually reclaimed.
+//
+// Unlock releases the lock of the release name if it is still held by holder.
+type Locker interface {
+	Lock(name, holder string, expires time.Time) error
+	Unlock(name, holder string) error
+}
+
+// Pinger is the interface that wraps the Ping method.
+//
+// Ping checks that the underlying storage can be reached, returning an error
+// if the releases cannot be read from it.
+type Pinger interface {
+	Ping() error
+}
+
+// Driver is the interface composed of Creator, Updator, Deletor, Queryor,
+// and Pinger interfaces. It defines the behavior for storing, updating,
+// deleted, and retrieving Tiller releases from some underlying storage
+// mechanism, e.g. memory, configmaps.
 type Driver interface {
 	Creator
 	Updator
 	Deletor
 	Queryor
+	Pinger
 	Name() string
 }
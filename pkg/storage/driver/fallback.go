@@ -0,0 +1,181 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"fmt"
+	"time"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+var _ Driver = (*Fallback)(nil)
+var _ Locker = (*Fallback)(nil)
+
+// Fallback is a driver that stores releases with a primary driver, but still
+// finds the releases stored with a legacy driver.
+//
+// It is used to move the release storage, e.g. to another namespace, without
+// losing track of the releases stored before. New releases are only ever
+// created with the primary driver. A release found with the legacy driver is
+// moved to the primary driver when it is updated.
+type Fallback struct {
+	primary Driver
+	legacy  Driver
+	Log     func(string, ...interface{})
+}
+
+// NewFallback initializes a new Fallback storing releases with primary and
+// falling back to legacy for the releases it does not know.
+func NewFallback(primary, legacy Driver) *Fallback {
+	return &Fallback{
+		primary: primary,
+		legacy:  legacy,
+		Log:     func(_ string, _ ...interface{}) {},
+	}
+}
+
+// Name returns the name of the primary driver.
+func (f *Fallback) Name() string {
+	return f.primary.Name()
+}
+
+// Ping checks that both the primary and the legacy driver can be reached.
+func (f *Fallback) Ping() error {
+	if err := f.primary.Ping(); err != nil {
+		return err
+	}
+	if err := f.legacy.Ping(); err != nil {
+		return fmt.Errorf("legacy storage: %s", err)
+	}
+	return nil
+}
+
+// Get fetches the release named by key, first with the primary driver and
+// then with the legacy driver.
+func (f *Fallback) Get(key string) (*rspb.Release, error) {
+	rls, err := f.primary.Get(key)
+	if err == nil {
+		return rls, nil
+	}
+	if rls, lerr := f.legacy.Get(key); lerr == nil {
+		return rls, nil
+	}
+	return nil, err
+}
+
+// List returns the releases of both drivers that satisfy filter. Where both
+// drivers hold the same release, the one of the primary driver is returned.
+func (f *Fallback) List(filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
+	results, err := f.primary.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	legacy, err := f.legacy.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	return mergeReleases(results, legacy), nil
+}
+
+// Query returns the releases of both drivers that match labels, or
+// ErrReleaseNotFound if neither driver has any.
+func (f *Fallback) Query(labels map[string]string) ([]*rspb.Release, error) {
+	results, err := f.primary.Query(labels)
+	legacy, lerr := f.legacy.Query(labels)
+	if err != nil && lerr != nil {
+		return nil, err
+	}
+	if err != nil {
+		f.Log("query: using legacy results only: %s", err)
+	}
+	return mergeReleases(results, legacy), nil
+}
+
+// Create stores the release with the primary driver.
+func (f *Fallback) Create(key string, rls *rspb.Release) error {
+	return f.primary.Create(key, rls)
+}
+
+// Update updates the release with the primary driver. A release that is only
+// stored with the legacy driver is moved to the primary driver.
+func (f *Fallback) Update(key string, rls *rspb.Release) error {
+	err := f.primary.Update(key, rls)
+	if err == nil {
+		return nil
+	}
+	if _, lerr := f.legacy.Get(key); lerr != nil {
+		return err
+	}
+	if err := f.primary.Create(key, rls); err != nil {
+		return err
+	}
+	if _, err := f.legacy.Delete(key); err != nil {
+		f.Log("update: failed to delete legacy release %q: %s", key, err)
+	}
+	return nil
+}
+
+// Delete deletes the release named by key from both drivers.
+func (f *Fallback) Delete(key string) (*rspb.Release, error) {
+	rls, err := f.primary.Delete(key)
+	lrls, lerr := f.legacy.Delete(key)
+	if err == nil {
+		return rls, nil
+	}
+	if lerr == nil {
+		return lrls, nil
+	}
+	return nil, err
+}
+
+// Lock takes the lock of the release name with the primary driver, if it
+// supports locking.
+func (f *Fallback) Lock(name, holder string, expires time.Time) error {
+	if l, ok := f.primary.(Locker); ok {
+		return l.Lock(name, holder, expires)
+	}
+	return nil
+}
+
+// Unlock releases the lock of the release name with the primary driver, if it
+// supports locking.
+func (f *Fallback) Unlock(name, holder string) error {
+	if l, ok := f.primary.(Locker); ok {
+		return l.Unlock(name, holder)
+	}
+	return nil
+}
+
+// mergeReleases appends the releases of legacy to primary, leaving out those
+// that primary already holds.
+func mergeReleases(primary, legacy []*rspb.Release) []*rspb.Release {
+	seen := make(map[string]bool, len(primary))
+	for _, rls := range primary {
+		seen[releaseKey(rls)] = true
+	}
+	for _, rls := range legacy {
+		if !seen[releaseKey(rls)] {
+			primary = append(primary, rls)
+		}
+	}
+	return primary
+}
+
+func releaseKey(rls *rspb.Release) string {
+	return fmt.Sprintf("%s.v%d", rls.Name, rls.Version)
+}
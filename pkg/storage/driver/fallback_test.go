@@ -0,0 +1,170 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// newFallbackFixture returns a Fallback storing releases in a ConfigMaps
+// driver of its own, and falling back to a ConfigMaps driver holding the
+// legacy releases.
+func newFallbackFixture(t *testing.T, legacy ...*rspb.Release) (*Fallback, *ConfigMaps, *ConfigMaps) {
+	primary := newTestFixtureCfgMaps(t)
+	old := newTestFixtureCfgMaps(t, legacy...)
+	return NewFallback(primary, old), primary, old
+}
+
+func releaseKeys(rels []*rspb.Release) []string {
+	var keys []string
+	for _, rls := range rels {
+		keys = append(keys, releaseKey(rls))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestFallbackName(t *testing.T) {
+	f, _, _ := newFallbackFixture(t)
+	if f.Name() != ConfigMapsDriverName {
+		t.Errorf("Expected name to be %q, got %q", ConfigMapsDriverName, f.Name())
+	}
+}
+
+func TestFallbackPing(t *testing.T) {
+	f, _, legacy := newFallbackFixture(t)
+	if err := f.Ping(); err != nil {
+		t.Fatalf("Expected a healthy backend, got %s", err)
+	}
+
+	legacy.impl.(*MockConfigMapsInterface).listErr = errors.New("connection refused")
+	if err := f.Ping(); err == nil || err.Error() != "legacy storage: connection refused" {
+		t.Errorf("Expected an unreachable legacy backend to fail, got %v", err)
+	}
+}
+
+func TestFallbackCreateAndList(t *testing.T) {
+	// The releases are deployed to the "apps" namespace wherever they are
+	// stored.
+	f, primary, legacy := newFallbackFixture(t, releaseStub("rls-a", 1, "apps", rspb.Status_SUPERSEDED))
+
+	rls := releaseStub("rls-a", 2, "apps", rspb.Status_DEPLOYED)
+	if err := f.Create(testKey(rls.Name, rls.Version), rls); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+	if _, err := primary.Get("rls-a.v2"); err != nil {
+		t.Errorf("Expected the release to be stored with the primary driver: %s", err)
+	}
+	if _, err := legacy.Get("rls-a.v2"); err == nil {
+		t.Error("Expected the release not to be stored with the legacy driver")
+	}
+
+	rels, err := f.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatalf("Failed to list releases: %s", err)
+	}
+	if keys, expect := releaseKeys(rels), []string{"rls-a.v1", "rls-a.v2"}; !reflect.DeepEqual(keys, expect) {
+		t.Errorf("Expected releases %v, got %v", expect, keys)
+	}
+	for _, rls := range rels {
+		if rls.Namespace != "apps" {
+			t.Errorf("Expected release %s in namespace apps, got %s", rls.Name, rls.Namespace)
+		}
+	}
+
+	rels, err = f.Query(map[string]string{"NAME": "rls-a", "OWNER": "TILLER"})
+	if err != nil {
+		t.Fatalf("Failed to query releases: %s", err)
+	}
+	if keys, expect := releaseKeys(rels), []string{"rls-a.v1", "rls-a.v2"}; !reflect.DeepEqual(keys, expect) {
+		t.Errorf("Expected releases %v, got %v", expect, keys)
+	}
+}
+
+func TestFallbackListPrefersPrimary(t *testing.T) {
+	f, primary, _ := newFallbackFixture(t, releaseStub("rls-a", 1, "apps", rspb.Status_SUPERSEDED))
+	if err := primary.Create("rls-a.v1", releaseStub("rls-a", 1, "apps", rspb.Status_DEPLOYED)); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+
+	rels, err := f.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatalf("Failed to list releases: %s", err)
+	}
+	if len(rels) != 1 || rels[0].Info.Status.Code != rspb.Status_DEPLOYED {
+		t.Errorf("Expected only the primary release, got %v", rels)
+	}
+}
+
+func TestFallbackGet(t *testing.T) {
+	f, _, _ := newFallbackFixture(t, releaseStub("rls-a", 1, "apps", rspb.Status_DEPLOYED))
+
+	if _, err := f.Get("rls-a.v1"); err != nil {
+		t.Errorf("Expected to get the legacy release: %s", err)
+	}
+	if _, err := f.Get("rls-a.v2"); err == nil {
+		t.Error("Expected an error for a missing release")
+	}
+}
+
+func TestFallbackUpdateMovesRelease(t *testing.T) {
+	f, primary, legacy := newFallbackFixture(t, releaseStub("rls-a", 1, "apps", rspb.Status_DEPLOYED))
+
+	rls := releaseStub("rls-a", 1, "apps", rspb.Status_SUPERSEDED)
+	if err := f.Update("rls-a.v1", rls); err != nil {
+		t.Fatalf("Failed to update release: %s", err)
+	}
+
+	got, err := primary.Get("rls-a.v1")
+	if err != nil {
+		t.Fatalf("Expected the release to be moved to the primary driver: %s", err)
+	}
+	if got.Info.Status.Code != rspb.Status_SUPERSEDED {
+		t.Errorf("Expected status %s, got %s", rspb.Status_SUPERSEDED, got.Info.Status.Code)
+	}
+	if _, err := legacy.Get("rls-a.v1"); err == nil {
+		t.Error("Expected the release to be removed from the legacy driver")
+	}
+
+	if err := f.Update("rls-b.v1", releaseStub("rls-b", 1, "apps", rspb.Status_DEPLOYED)); err == nil {
+		t.Error("Expected an error updating a missing release")
+	}
+}
+
+func TestFallbackDelete(t *testing.T) {
+	f, primary, _ := newFallbackFixture(t, releaseStub("rls-a", 1, "apps", rspb.Status_SUPERSEDED))
+	if err := primary.Create("rls-a.v2", releaseStub("rls-a", 2, "apps", rspb.Status_DEPLOYED)); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+
+	for _, key := range []string{"rls-a.v1", "rls-a.v2"} {
+		if _, err := f.Delete(key); err != nil {
+			t.Errorf("Failed to delete %s: %s", key, err)
+		}
+		if _, err := f.Get(key); err == nil {
+			t.Errorf("Expected %s to be deleted", key)
+		}
+	}
+	if _, err := f.Delete("rls-a.v3"); err == nil {
+		t.Error("Expected an error deleting a missing release")
+	}
+}
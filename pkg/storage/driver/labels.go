@@ -16,6 +16,12 @@ limitations under the License.
 
 package driver
 
+import (
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
 // labels is a map of key value pairs to be included as metadata in a configmap object.
 type labels map[string]string
 
@@ -46,3 +52,14 @@ func (lbs *labels) fromMap(kvs map[string]string) {
 		lbs.set(k, v)
 	}
 }
+
+// setBuildCommit sets the "COMMIT" label to the commit the release was built
+// from, so that releases can be selected by it. A commit that is not a valid
+// label value is not set.
+func (lbs labels) setBuildCommit(rls *rspb.Release) {
+	commit := rls.GetInfo().GetBuildInfo().GetCommit()
+	if commit == "" || len(validation.IsValidLabelValue(commit)) != 0 {
+		return
+	}
+	lbs.set("COMMIT", commit)
+}
@@ -18,6 +18,8 @@ package driver // import "k8s.io/helm/pkg/storage/driver"
 
 import (
 	"testing"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
 )
 
 func TestLabelsMatch(t *testing.T) {
@@ -47,3 +49,24 @@ func TestLabelsMatch(t *testing.T) {
 		}
 	}
 }
+
+func TestLabelsSetBuildCommit(t *testing.T) {
+	var tests = []struct {
+		desc   string
+		build  *rspb.BuildInfo
+		expect string
+	}{
+		{"no build info", nil, ""},
+		{"commit", &rspb.BuildInfo{Commit: "0a1b2c3d"}, "0a1b2c3d"},
+		{"invalid label value", &rspb.BuildInfo{Commit: "refs/heads/master"}, ""},
+	}
+
+	for _, tt := range tests {
+		var lbs labels
+		lbs.init()
+		lbs.setBuildCommit(&rspb.Release{Info: &rspb.Info{BuildInfo: tt.build}})
+		if got := lbs.get("COMMIT"); got != tt.expect {
+			t.Errorf("%s: expected COMMIT label %q, got %q", tt.desc, tt.expect, got)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// lockOwner is the OWNER label of the objects holding release locks. It
+// differs from the owner of release records so that locks are never listed
+// as releases.
+const lockOwner = "TILLER-LOCK"
+
+// lockKey returns the name of the object holding the lock of a release.
+func lockKey(name string) string {
+	return name + ".lock"
+}
+
+// lockObjectMeta returns the object meta data of the lock of a release.
+func lockObjectMeta(name string) metav1.ObjectMeta {
+	var lbs labels
+
+	lbs.init()
+	lbs.set("NAME", name)
+	lbs.set("OWNER", lockOwner)
+
+	return metav1.ObjectMeta{
+		Name:   lockKey(name),
+		Labels: lbs.toMap(),
+	}
+}
+
+// lockData returns the data stored in the lock of a release.
+func lockData(holder string, expires time.Time) map[string]string {
+	return map[string]string{
+		"holder":  holder,
+		"expires": expires.UTC().Format(time.RFC3339),
+	}
+}
+
+// lockAvailable reports whether a lock with the given data can be taken by
+// holder, either because holder already has it or because it has expired.
+// A lock with an unreadable expiry is treated as expired.
+func lockAvailable(data map[string]string, holder string, now time.Time) bool {
+	if data["holder"] == holder {
+		return true
+	}
+	expires, err := time.Parse(time.RFC3339, data["expires"])
+	return err != nil || !now.Before(expires)
+}
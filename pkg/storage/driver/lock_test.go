@@ -0,0 +1,69 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLock(t *testing.T) {
+	lockers := map[string]Locker{
+		MemoryDriverName:     NewMemory(),
+		ConfigMapsDriverName: newTestFixtureCfgMaps(t),
+		SecretsDriverName:    newTestFixtureSecrets(t),
+	}
+
+	const name = "smug-pigeon"
+	later := time.Now().Add(time.Minute)
+	earlier := time.Now().Add(-time.Minute)
+
+	for driverName, l := range lockers {
+		if err := l.Lock(name, "first", later); err != nil {
+			t.Fatalf("%s: failed to lock: %s", driverName, err)
+		}
+		if err := l.Lock(name, "second", later); err == nil {
+			t.Errorf("%s: expected a second holder to be rejected", driverName)
+		}
+		if err := l.Lock(name, "first", later); err != nil {
+			t.Errorf("%s: expected the holder to renew its lock, got %s", driverName, err)
+		}
+
+		// Unlocking on behalf of another holder leaves the lock alone.
+		if err := l.Unlock(name, "second"); err != nil {
+			t.Fatalf("%s: failed to unlock: %s", driverName, err)
+		}
+		if err := l.Lock(name, "second", later); err == nil {
+			t.Errorf("%s: expected the lock to still be held", driverName)
+		}
+
+		if err := l.Unlock(name, "first"); err != nil {
+			t.Fatalf("%s: failed to unlock: %s", driverName, err)
+		}
+		if err := l.Lock(name, "second", earlier); err != nil {
+			t.Fatalf("%s: failed to lock after unlock: %s", driverName, err)
+		}
+
+		// The lock of "second" has expired, as if its holder had died.
+		if err := l.Lock(name, "third", later); err != nil {
+			t.Errorf("%s: expected an expired lock to be reclaimed, got %s", driverName, err)
+		}
+		if err := l.Lock(name, "second", later); err == nil {
+			t.Errorf("%s: expected the reclaimed lock to be held", driverName)
+		}
+	}
+}
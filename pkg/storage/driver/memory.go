@@ -20,24 +20,33 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
 )
 
 var _ Driver = (*Memory)(nil)
+var _ Locker = (*Memory)(nil)
 
 // MemoryDriverName is the string name of this driver.
 const MemoryDriverName = "Memory"
 
 // Memory is the in-memory storage driver implementation.
 type Memory struct {
-	sync.RWMutex
+	mu    sync.RWMutex
 	cache map[string]records
+	locks map[string]memoryLock
+}
+
+// memoryLock is the lock of a release held by the memory driver.
+type memoryLock struct {
+	holder  string
+	expires time.Time
 }
 
 // NewMemory initializes a new memory driver.
 func NewMemory() *Memory {
-	return &Memory{cache: map[string]records{}}
+	return &Memory{cache: map[string]records{}, locks: map[string]memoryLock{}}
 }
 
 // Name returns the name of the driver.
@@ -45,6 +54,11 @@ func (mem *Memory) Name() string {
 	return MemoryDriverName
 }
 
+// Ping always succeeds, the memory driver cannot be unreachable.
+func (mem *Memory) Ping() error {
+	return nil
+}
+
 // Get returns the release named by key or returns ErrReleaseNotFound.
 func (mem *Memory) Get(key string) (*rspb.Release, error) {
 	defer unlock(mem.rlock())
@@ -158,16 +172,40 @@ func (mem *Memory) Delete(key string) (*rspb.Release, error) {
 	return nil, ErrReleaseNotFound(key)
 }
 
+// Lock takes the lock of the release name. A lock that has expired is taken over.
+func (mem *Memory) Lock(name, holder string, expires time.Time) error {
+	defer unlock(mem.wlock())
+
+	if lock, ok := mem.locks[name]; ok && lock.holder != holder && time.Now().Before(lock.expires) {
+		return ErrReleaseLocked(name)
+	}
+	if mem.locks == nil {
+		mem.locks = map[string]memoryLock{}
+	}
+	mem.locks[name] = memoryLock{holder: holder, expires: expires}
+	return nil
+}
+
+// Unlock releases the lock of the release name if holder still holds it.
+func (mem *Memory) Unlock(name, holder string) error {
+	defer unlock(mem.wlock())
+
+	if lock, ok := mem.locks[name]; ok && lock.holder == holder {
+		delete(mem.locks, name)
+	}
+	return nil
+}
+
 // wlock locks mem for writing
 func (mem *Memory) wlock() func() {
-	mem.Lock()
-	return func() { mem.Unlock() }
+	mem.mu.Lock()
+	return func() { mem.mu.Unlock() }
 }
 
 // rlock locks mem for reading
 func (mem *Memory) rlock() func() {
-	mem.RLock()
-	return func() { mem.RUnlock() }
+	mem.mu.RLock()
+	return func() { mem.mu.RUnlock() }
 }
 
 // unlock calls fn which reverses a mem.rlock or mem.wlock. e.g:
@@ -79,6 +79,9 @@ type MockConfigMapsInterface struct {
 	internalversion.ConfigMapInterface
 
 	objects map[string]*core.ConfigMap
+	// listErr is returned by List when set, e.g. to mock an unreachable
+	// API server.
+	listErr error
 }
 
 // Init initializes the MockConfigMapsInterface with the set of releases.
@@ -107,6 +110,9 @@ func (mock *MockConfigMapsInterface) Get(name string, options metav1.GetOptions)
 
 // List returns the a of ConfigMaps.
 func (mock *MockConfigMapsInterface) List(opts metav1.ListOptions) (*core.ConfigMapList, error) {
+	if mock.listErr != nil {
+		return nil, mock.listErr
+	}
 	var list core.ConfigMapList
 	for _, cfgmap := range mock.objects {
 		list.Items = append(list.Items, *cfgmap)
@@ -157,6 +163,9 @@ type MockSecretsInterface struct {
 	internalversion.SecretInterface
 
 	objects map[string]*core.Secret
+	// listErr is returned by List when set, e.g. to mock an unreachable
+	// API server.
+	listErr error
 }
 
 // Init initializes the MockSecretsInterface with the set of releases.
@@ -185,6 +194,9 @@ func (mock *MockSecretsInterface) Get(name string, options metav1.GetOptions) (*
 
 // List returns the a of Secret.
 func (mock *MockSecretsInterface) List(opts metav1.ListOptions) (*core.SecretList, error) {
+	if mock.listErr != nil {
+		return nil, mock.listErr
+	}
 	var list core.SecretList
 	for _, secret := range mock.objects {
 		list.Items = append(list.Items, *secret)
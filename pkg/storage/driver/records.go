@@ -130,6 +130,7 @@ func newRecord(key string, rls *rspb.Release) *record {
 	lbs.set("OWNER", "TILLER")
 	lbs.set("STATUS", rspb.Status_Code_name[int32(rls.Info.Status.Code)])
 	lbs.set("VERSION", strconv.Itoa(int(rls.Version)))
+	lbs.setBuildCommit(rls)
 
 	return &record{key: key, lbs: lbs, rls: proto.Clone(rls).(*rspb.Release)}
 }
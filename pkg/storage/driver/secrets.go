@@ -33,6 +33,7 @@ import (
 )
 
 var _ Driver = (*Secrets)(nil)
+var _ Locker = (*Secrets)(nil)
 
 // SecretsDriverName is the string name of the driver.
 const SecretsDriverName = "Secret"
@@ -58,6 +59,19 @@ func (secrets *Secrets) Name() string {
 	return SecretsDriverName
 }
 
+// Ping lists a single release secret to check that the secrets of the
+// namespace can be read.
+func (secrets *Secrets) Ping() error {
+	lsel := kblabels.Set{"OWNER": "TILLER"}.AsSelector()
+	opts := metav1.ListOptions{LabelSelector: lsel.String(), Limit: 1}
+
+	if _, err := secrets.impl.List(opts); err != nil {
+		secrets.Log("ping: failed to list: %s", err)
+		return err
+	}
+	return nil
+}
+
 // Get fetches the release named by key. The corresponding release is returned
 // or error if not found.
 func (secrets *Secrets) Get(key string) (*rspb.Release, error) {
@@ -215,6 +229,80 @@ func (secrets *Secrets) Delete(key string) (rls *rspb.Release, err error) {
 	return rls, nil
 }
 
+// Lock takes the lock of the release name, which is held in a Secret
+// named after the release. A lock that has expired is taken over.
+func (secrets *Secrets) Lock(name, holder string, expires time.Time) error {
+	key := lockKey(name)
+	obj, err := secrets.impl.Get(key, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lock := &core.Secret{
+			ObjectMeta: lockObjectMeta(name),
+			Data:       secretLockData(holder, expires),
+		}
+		if _, err := secrets.impl.Create(lock); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return ErrReleaseLocked(name)
+			}
+			secrets.Log("lock: failed to create lock %q: %s", key, err)
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		secrets.Log("lock: failed to get lock %q: %s", key, err)
+		return err
+	}
+	current := map[string]string{
+		"holder":  string(obj.Data["holder"]),
+		"expires": string(obj.Data["expires"]),
+	}
+	if !lockAvailable(current, holder, time.Now()) {
+		return ErrReleaseLocked(name)
+	}
+	if current["holder"] != holder {
+		secrets.Log("lock: reclaiming expired lock %q of %s", key, current["holder"])
+	}
+	// The update carries the resource version that was read, so only one of
+	// several operations reclaiming the same lock succeeds.
+	obj.Data = secretLockData(holder, expires)
+	if _, err := secrets.impl.Update(obj); err != nil {
+		if apierrors.IsConflict(err) {
+			return ErrReleaseLocked(name)
+		}
+		secrets.Log("lock: failed to update lock %q: %s", key, err)
+		return err
+	}
+	return nil
+}
+
+// Unlock releases the lock of the release name if holder still holds it.
+func (secrets *Secrets) Unlock(name, holder string) error {
+	key := lockKey(name)
+	obj, err := secrets.impl.Get(key, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if string(obj.Data["holder"]) != holder {
+		return nil
+	}
+	opts := &metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &obj.UID}}
+	if err := secrets.impl.Delete(key, opts); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func secretLockData(holder string, expires time.Time) map[string][]byte {
+	data := map[string][]byte{}
+	for k, v := range lockData(holder, expires) {
+		data[k] = []byte(v)
+	}
+	return data
+}
+
 // newSecretsObject constructs a kubernetes Secret object
 // to store a release. Each secret data entry is the base64
 // encoded string of a release's binary protobuf encoding.
@@ -227,6 +315,7 @@ func (secrets *Secrets) Delete(key string) (rls *rspb.Release, err error) {
 //    "STATUS"         - status of the release (see proto/hapi/release.status.pb.go for variants)
 //    "OWNER"          - owner of the secret, currently "TILLER".
 //    "NAME"           - name of the release.
+//    "COMMIT"         - commit the release was built from, if it is recorded.
 //
 func newSecretsObject(key string, rls *rspb.Release, lbs labels) (*core.Secret, error) {
 	const owner = "TILLER"
@@ -246,6 +335,7 @@ func newSecretsObject(key string, rls *rspb.Release, lbs labels) (*core.Secret,
 	lbs.set("OWNER", owner)
 	lbs.set("STATUS", rspb.Status_Code_name[int32(rls.Info.Status.Code)])
 	lbs.set("VERSION", strconv.Itoa(int(rls.Version)))
+	lbs.setBuildCommit(rls)
 
 	// create and return secret object
 	return &core.Secret{
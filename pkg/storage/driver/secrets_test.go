@@ -15,6 +15,7 @@ package driver
 
 import (
 	"encoding/base64"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -31,6 +32,18 @@ func TestSecretName(t *testing.T) {
 	}
 }
 
+func TestSecretPing(t *testing.T) {
+	secrets := newTestFixtureSecrets(t)
+	if err := secrets.Ping(); err != nil {
+		t.Fatalf("Expected a healthy backend, got %s", err)
+	}
+
+	secrets.impl.(*MockSecretsInterface).listErr = errors.New("connection refused")
+	if err := secrets.Ping(); err == nil || err.Error() != "connection refused" {
+		t.Errorf("Expected an unreachable backend to fail, got %v", err)
+	}
+}
+
 func TestSecretGet(t *testing.T) {
 	vers := int32(1)
 	name := "smug-pigeon"
@@ -18,7 +18,10 @@ package storage // import "k8s.io/helm/pkg/storage"
 
 import (
 	"fmt"
+	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
 	relutil "k8s.io/helm/pkg/releaseutil"
@@ -137,6 +140,25 @@ func (s *Storage) Deployed(name string) (*rspb.Release, error) {
 	return nil, err
 }
 
+// Query returns the revisions of releases stored with all of the given labels,
+// such as STATUS or VERSION. Only release records are matched, and no match is
+// not an error.
+func (s *Storage) Query(labels map[string]string) ([]*rspb.Release, error) {
+	s.Log("querying releases with labels %v", labels)
+
+	query := map[string]string{"OWNER": "TILLER"}
+	for k, v := range labels {
+		if k != "OWNER" {
+			query[k] = v
+		}
+	}
+	ls, err := s.Driver.Query(query)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		return nil, nil
+	}
+	return ls, err
+}
+
 // History returns the revision history for the release with the provided name, or
 // returns ErrReleaseNotFound if no such release name exists.
 func (s *Storage) History(name string) ([]*rspb.Release, error) {
@@ -205,6 +227,37 @@ func (s *Storage) Last(name string) (*rspb.Release, error) {
 	return h[0], nil
 }
 
+// lockSeq numbers the locks taken by this process, so that every operation
+// holds its lock under a distinct holder name.
+var lockSeq uint64
+
+// Lock takes the lock of the named release for a mutating operation. The
+// lock expires after ttl, after which another operation may reclaim it in case
+// the holder died without releasing it. The returned function releases the
+// lock.
+//
+// If the storage driver does not support locking, no lock is taken.
+func (s *Storage) Lock(name string, ttl time.Duration) (func(), error) {
+	l, ok := s.Driver.(driver.Locker)
+	if !ok {
+		s.Log("driver %s does not support locking releases", s.Name())
+		return func() {}, nil
+	}
+
+	host, _ := os.Hostname()
+	holder := fmt.Sprintf("%s-%d-%d", host, os.Getpid(), atomic.AddUint64(&lockSeq, 1))
+	s.Log("locking release %q", name)
+	if err := l.Lock(name, holder, time.Now().Add(ttl)); err != nil {
+		return nil, err
+	}
+	return func() {
+		s.Log("unlocking release %q", name)
+		if err := l.Unlock(name, holder); err != nil {
+			s.Log("failed to unlock release %q: %s", name, err)
+		}
+	}, nil
+}
+
 // makeKey concatenates a release name and version into
 // a string with format ```<release_name>#v<version>```.
 // This key is used to uniquely identify storage objects.
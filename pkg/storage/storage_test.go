@@ -19,7 +19,9 @@ package storage // import "k8s.io/helm/pkg/storage"
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/storage/driver"
@@ -236,6 +238,36 @@ func TestStorageHistory(t *testing.T) {
 	}
 }
 
+func TestStorageQuery(t *testing.T) {
+	storage := Init(driver.NewMemory())
+
+	rls0 := ReleaseTestData{Name: "angry-bird", Version: 1, Status: rspb.Status_SUPERSEDED}.ToRelease()
+	rls1 := ReleaseTestData{Name: "angry-bird", Version: 2, Status: rspb.Status_FAILED}.ToRelease()
+	rls2 := ReleaseTestData{Name: "happy-cats", Version: 1, Status: rspb.Status_FAILED}.ToRelease()
+	rls3 := ReleaseTestData{Name: "livid-human", Version: 1, Status: rspb.Status_DEPLOYED}.ToRelease()
+
+	assertErrNil(t.Fatal, storage.Create(rls0), "Storing release 'angry-bird' (v1)")
+	assertErrNil(t.Fatal, storage.Create(rls1), "Storing release 'angry-bird' (v2)")
+	assertErrNil(t.Fatal, storage.Create(rls2), "Storing release 'happy-cats' (v1)")
+	assertErrNil(t.Fatal, storage.Create(rls3), "Storing release 'livid-human' (v1)")
+
+	ls, err := storage.Query(map[string]string{"STATUS": "FAILED"})
+	if err != nil {
+		t.Fatalf("Failed to query releases: %s", err)
+	}
+	if len(ls) != 2 {
+		t.Fatalf("Expected 2 failed releases, got %d", len(ls))
+	}
+
+	ls, err = storage.Query(map[string]string{"STATUS": "DELETED"})
+	if err != nil {
+		t.Fatalf("Expected no error for a query without match, got %s", err)
+	}
+	if len(ls) != 0 {
+		t.Fatalf("Expected no deleted releases, got %d", len(ls))
+	}
+}
+
 func TestStorageRemoveLeastRecent(t *testing.T) {
 	storage := Init(driver.NewMemory())
 	storage.Log = t.Logf
@@ -325,6 +357,44 @@ func TestStorageLast(t *testing.T) {
 	}
 }
 
+func TestStorageLock(t *testing.T) {
+	storage := Init(driver.NewMemory())
+
+	const name = "angry-bird"
+
+	unlock, err := storage.Lock(name, time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to lock release %q: %s", name, err)
+	}
+	if _, err := storage.Lock(name, time.Minute); err == nil || !strings.Contains(err.Error(), "is locked") {
+		t.Errorf("Expected a concurrent lock to be rejected, got %v", err)
+	}
+
+	unlock()
+	unlock, err = storage.Lock(name, time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to lock release %q after unlocking: %s", name, err)
+	}
+	unlock()
+}
+
+func TestStorageLock_stale(t *testing.T) {
+	storage := Init(driver.NewMemory())
+
+	const name = "angry-bird"
+
+	// A lock left behind by an operation that died.
+	if err := storage.Driver.(driver.Locker).Lock(name, "dead-tiller", time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	unlock, err := storage.Lock(name, time.Minute)
+	if err != nil {
+		t.Fatalf("Expected the stale lock to be reclaimed, got %s", err)
+	}
+	unlock()
+}
+
 type ReleaseTestData struct {
 	Name      string
 	Version   int32
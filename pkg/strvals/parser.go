@@ -17,11 +17,14 @@ package strvals
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/ghodss/yaml"
 )
@@ -60,11 +63,41 @@ func ParseInto(s string, dest map[string]interface{}) error {
 	return t.parse()
 }
 
+// TypeFunc returns the JSON Schema type (string, integer, number or boolean)
+// declared for the value at path, or "" if no type is declared. The path holds
+// the keys that lead to the value, with "[]" standing for an element of a list.
+type TypeFunc func(path []string) string
+
+// ParseIntoTyped parses a strvals line and merges the result into dest, like
+// ParseInto, but converts each value to the type that types returns for its
+// path. Values without a declared type are converted as ParseInto does.
+func ParseIntoTyped(s string, dest map[string]interface{}, types TypeFunc) error {
+	scanner := bytes.NewBufferString(s)
+	t := newParser(scanner, dest)
+	t.types = types
+	return t.parse()
+}
+
+// ParseJSON parses a set-json line and merges the result into dest.
+//
+// A set-json line is of the form name1=json1,name2=json2, where each value is
+// a JSON document. If a key exists in dest, it overwrites the dest version.
+func ParseJSON(s string, dest map[string]interface{}) error {
+	scanner := bytes.NewBufferString(s)
+	t := newParser(scanner, dest)
+	t.isjson = true
+	return t.parse()
+}
+
 // parser is a simple parser that takes a strvals line and parses it into a
 // map representation.
 type parser struct {
 	sc   *bytes.Buffer
 	data map[string]interface{}
+	// isjson indicates that values are JSON documents.
+	isjson bool
+	// types, if set, decides the types of the values.
+	types TypeFunc
 }
 
 func newParser(sc *bytes.Buffer, data map[string]interface{}) *parser {
@@ -73,7 +106,7 @@ func newParser(sc *bytes.Buffer, data map[string]interface{}) *parser {
 
 func (t *parser) parse() error {
 	for {
-		err := t.key(t.data)
+		err := t.key(t.data, nil)
 		if err == nil {
 			continue
 		}
@@ -92,7 +125,7 @@ func runeSet(r []rune) map[rune]bool {
 	return s
 }
 
-func (t *parser) key(data map[string]interface{}) error {
+func (t *parser) key(data map[string]interface{}, path []string) error {
 	stop := runeSet([]rune{'=', '[', ',', '.'})
 	for {
 		switch k, last, err := runesUntil(t.sc, stop); {
@@ -117,13 +150,22 @@ func (t *parser) key(data map[string]interface{}) error {
 			}
 
 			// Now we need to get the value after the ].
-			list, err = t.listItem(list, i)
+			list, err = t.listItem(list, i, appendPath(path, kk, "[]"))
 			set(data, kk, list)
 			return err
 		case last == '=':
+			if t.isjson {
+				v, e := t.jsonVal()
+				if e != nil && e != io.EOF {
+					return fmt.Errorf("key %q: %s", string(k), e)
+				}
+				set(data, string(k), v)
+				return e
+			}
 			//End of key. Consume =, Get value.
 			// FIXME: Get value list first
-			vl, e := t.valList()
+			kpath := appendPath(path, string(k))
+			vl, e := t.valList(kpath)
 			switch e {
 			case nil:
 				set(data, string(k), vl)
@@ -133,7 +175,14 @@ func (t *parser) key(data map[string]interface{}) error {
 				return e
 			case ErrNotList:
 				v, e := t.val()
-				set(data, string(k), typedVal(v))
+				if e != nil && e != io.EOF {
+					return e
+				}
+				tv, err := t.typedVal(kpath, v)
+				if err != nil {
+					return err
+				}
+				set(data, string(k), tv)
 				return e
 			default:
 				return e
@@ -151,7 +200,7 @@ func (t *parser) key(data map[string]interface{}) error {
 			}
 
 			// Recurse
-			e := t.key(inner)
+			e := t.key(inner, appendPath(path, string(k)))
 			if len(inner) == 0 {
 				return fmt.Errorf("key map %q has no value", string(k))
 			}
@@ -190,7 +239,7 @@ func (t *parser) keyIndex() (int, error) {
 	return strconv.Atoi(string(v))
 
 }
-func (t *parser) listItem(list []interface{}, i int) ([]interface{}, error) {
+func (t *parser) listItem(list []interface{}, i int, path []string) ([]interface{}, error) {
 	stop := runeSet([]rune{'[', '.', '='})
 	switch k, last, err := runesUntil(t.sc, stop); {
 	case len(k) > 0:
@@ -198,7 +247,14 @@ func (t *parser) listItem(list []interface{}, i int) ([]interface{}, error) {
 	case err != nil:
 		return list, err
 	case last == '=':
-		vl, e := t.valList()
+		if t.isjson {
+			v, e := t.jsonVal()
+			if e != nil && e != io.EOF {
+				return list, e
+			}
+			return setIndex(list, i, v), e
+		}
+		vl, e := t.valList(path)
 		switch e {
 		case nil:
 			return setIndex(list, i, vl), nil
@@ -206,7 +262,14 @@ func (t *parser) listItem(list []interface{}, i int) ([]interface{}, error) {
 			return setIndex(list, i, ""), err
 		case ErrNotList:
 			v, e := t.val()
-			return setIndex(list, i, typedVal(v)), e
+			if e != nil && e != io.EOF {
+				return list, e
+			}
+			tv, err := t.typedVal(path, v)
+			if err != nil {
+				return list, err
+			}
+			return setIndex(list, i, tv), e
 		default:
 			return list, e
 		}
@@ -217,7 +280,7 @@ func (t *parser) listItem(list []interface{}, i int) ([]interface{}, error) {
 			return list, fmt.Errorf("error parsing index: %s", err)
 		}
 		// Now we need to get the value after the ].
-		list2, err := t.listItem(list, i)
+		list2, err := t.listItem(list, i, appendPath(path, "[]"))
 		return setIndex(list, i, list2), err
 	case last == '.':
 		// We have a nested object. Send to t.key
@@ -227,7 +290,7 @@ func (t *parser) listItem(list []interface{}, i int) ([]interface{}, error) {
 		}
 
 		// Recurse
-		e := t.key(inner)
+		e := t.key(inner, path)
 		return setIndex(list, i, inner), e
 	default:
 		return nil, fmt.Errorf("parse error: unexpected token %v", last)
@@ -240,7 +303,41 @@ func (t *parser) val() ([]rune, error) {
 	return v, err
 }
 
-func (t *parser) valList() ([]interface{}, error) {
+// jsonVal reads a JSON value and the ',' that may follow it. Syntax errors
+// report the character of the value at which they were found.
+func (t *parser) jsonVal() (interface{}, error) {
+	in := bytes.NewReader(t.sc.Bytes())
+	dec := json.NewDecoder(in)
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		if e, ok := err.(*json.SyntaxError); ok {
+			return nil, fmt.Errorf("invalid JSON at character %d: %s", e.Offset+1, e)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, errors.New("invalid JSON: unexpected end of value")
+		}
+		return nil, fmt.Errorf("invalid JSON: %s", err)
+	}
+
+	// The decoder reads ahead, so count what it has buffered as unread.
+	buffered, _ := ioutil.ReadAll(dec.Buffered())
+	t.sc.Next(t.sc.Len() - in.Len() - len(buffered))
+
+	r, _, e := t.sc.ReadRune()
+	for e == nil && unicode.IsSpace(r) {
+		r, _, e = t.sc.ReadRune()
+	}
+	if e != nil {
+		return v, e
+	}
+	if r != ',' {
+		return v, fmt.Errorf("unexpected data after JSON value: %q", string(r)+t.sc.String())
+	}
+	return v, nil
+}
+
+func (t *parser) valList(path []string) ([]interface{}, error) {
 	r, _, e := t.sc.ReadRune()
 	if e != nil {
 		return []interface{}{}, e
@@ -253,26 +350,81 @@ func (t *parser) valList() ([]interface{}, error) {
 
 	list := []interface{}{}
 	stop := runeSet([]rune{',', '}'})
+	item := appendPath(path, "[]")
 	for {
-		switch v, last, err := runesUntil(t.sc, stop); {
-		case err != nil:
+		v, last, err := runesUntil(t.sc, stop)
+		if err != nil {
 			if err == io.EOF {
 				err = errors.New("list must terminate with '}'")
 			}
 			return list, err
-		case last == '}':
+		}
+		tv, err := t.typedVal(item, v)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, tv)
+		if last == '}' {
 			// If this is followed by ',', consume it.
 			if r, _, e := t.sc.ReadRune(); e == nil && r != ',' {
 				t.sc.UnreadRune()
 			}
-			list = append(list, typedVal(v))
 			return list, nil
-		case last == ',':
-			list = append(list, typedVal(v))
 		}
 	}
 }
 
+// appendPath returns a new path made of path followed by keys.
+func appendPath(path []string, keys ...string) []string {
+	p := make([]string, 0, len(path)+len(keys))
+	return append(append(p, path...), keys...)
+}
+
+// typedVal converts v to the type declared for path, falling back to the
+// type guessed from the value when no type is declared.
+func (t *parser) typedVal(path []string, v []rune) (interface{}, error) {
+	if t.types == nil {
+		return typedVal(v), nil
+	}
+	val := string(v)
+	typ := t.types(path)
+	switch typ {
+	case "string":
+		return val, nil
+	case "integer":
+		if iv, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return iv, nil
+		}
+	case "number":
+		if iv, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return iv, nil
+		}
+		if fv, err := strconv.ParseFloat(val, 64); err == nil {
+			return fv, nil
+		}
+	case "boolean":
+		if strings.EqualFold(val, "true") || strings.EqualFold(val, "false") {
+			return strings.EqualFold(val, "true"), nil
+		}
+	default:
+		return typedVal(v), nil
+	}
+	return nil, fmt.Errorf("key %q: value %q is not of type %s", pathString(path), val, typ)
+}
+
+// pathString formats a path the way it is written in a set line, with the
+// elements of lists as "[]".
+func pathString(path []string) string {
+	var b bytes.Buffer
+	for i, k := range path {
+		if i > 0 && k != "[]" {
+			b.WriteByte('.')
+		}
+		b.WriteString(k)
+	}
+	return b.String()
+}
+
 func runesUntil(in io.RuneReader, stop map[rune]bool) ([]rune, rune, error) {
 	v := []rune{}
 	for {
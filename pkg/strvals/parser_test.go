@@ -16,6 +16,8 @@ limitations under the License.
 package strvals
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ghodss/yaml"
@@ -318,6 +320,100 @@ func TestParseInto(t *testing.T) {
 	}
 }
 
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		str    string
+		expect map[string]interface{}
+		err    string
+	}{
+		{
+			str: `ingress={"enabled":true,"hosts":["a","b"],"port":8080}`,
+			expect: map[string]interface{}{"ingress": map[string]interface{}{
+				"enabled": true,
+				"hosts":   []interface{}{"a", "b"},
+				"port":    8080,
+			}},
+		},
+		{
+			str:    `outer.inner=["a",{"b":null}],name="x,y"`,
+			expect: map[string]interface{}{"outer": map[string]interface{}{"inner": []interface{}{"a", map[string]interface{}{"b": nil}}}, "name": "x,y"},
+		},
+		{
+			str:    `list[1]={"a":1}`,
+			expect: map[string]interface{}{"list": []interface{}{nil, map[string]interface{}{"a": 1}}},
+		},
+		{
+			str: `ingress={"enabled":tru}`,
+			err: `key "ingress": invalid JSON at character 16`,
+		},
+		{
+			str: `ingress={"enabled":true`,
+			err: `key "ingress": invalid JSON: unexpected end of value`,
+		},
+		{
+			str: `ingress={} x`,
+			err: `key "ingress": unexpected data after JSON value`,
+		},
+	}
+
+	for _, tt := range tests {
+		got := map[string]interface{}{}
+		err := ParseJSON(tt.str, got)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: Expected error containing %q, got %v", tt.str, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tt.str, err)
+		}
+
+		y1, err := yaml.Marshal(tt.expect)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y2, err := yaml.Marshal(got)
+		if err != nil {
+			t.Fatalf("Error serializing parsed value: %s", err)
+		}
+
+		if string(y1) != string(y2) {
+			t.Errorf("%s: Expected:\n%s\nGot:\n%s", tt.str, y1, y2)
+		}
+	}
+}
+
+func TestParseJSONMerge(t *testing.T) {
+	got := map[string]interface{}{
+		"ingress": map[string]interface{}{"enabled": false},
+		"name":    "value",
+	}
+	if err := ParseJSON(`ingress={"hosts":["a"]}`, got); err != nil {
+		t.Fatal(err)
+	}
+	if err := ParseInto("ingress.enabled=true", got); err != nil {
+		t.Fatal(err)
+	}
+	expect := map[string]interface{}{
+		"ingress": map[string]interface{}{"enabled": true, "hosts": []interface{}{"a"}},
+		"name":    "value",
+	}
+
+	y1, err := yaml.Marshal(expect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y2, err := yaml.Marshal(got)
+	if err != nil {
+		t.Fatalf("Error serializing parsed value: %s", err)
+	}
+
+	if string(y1) != string(y2) {
+		t.Errorf("Expected:\n%s\nGot:\n%s", y1, y2)
+	}
+}
+
 func TestToYAML(t *testing.T) {
 	// The TestParse does the hard part. We just verify that YAML formatting is
 	// happening.
@@ -330,3 +426,104 @@ func TestToYAML(t *testing.T) {
 		t.Errorf("Expected %q, got %q", expect, o)
 	}
 }
+
+func TestParseIntoTyped(t *testing.T) {
+	types := map[string]string{
+		"replicas":        "integer",
+		"tag":             "string",
+		"enabled":         "boolean",
+		"ratio":           "number",
+		"image.tag":       "string",
+		"ports[]":         "integer",
+		"hosts[].name":    "string",
+		"hosts[].enabled": "boolean",
+	}
+	typeOf := func(path []string) string {
+		return types[pathString(path)]
+	}
+
+	tests := []struct {
+		str    string
+		expect map[string]interface{}
+		err    string
+	}{
+		{
+			str:    "replicas=3,tag=1234,enabled=false,ratio=0.5",
+			expect: map[string]interface{}{"replicas": 3, "tag": "1234", "enabled": false, "ratio": 0.5},
+		},
+		{
+			str:    "image.tag=1.10,image.pullPolicy=true",
+			expect: map[string]interface{}{"image": map[string]interface{}{"tag": "1.10", "pullPolicy": true}},
+		},
+		{
+			str:    "replicas=007,ratio=2",
+			expect: map[string]interface{}{"replicas": 7, "ratio": 2},
+		},
+		{
+			str:    "ports={80,0443},name=1234",
+			expect: map[string]interface{}{"ports": []interface{}{80, 443}, "name": 1234},
+		},
+		{
+			str: "hosts[0].name=true,hosts[0].enabled=TRUE,hosts[1].name=10",
+			expect: map[string]interface{}{"hosts": []interface{}{
+				map[string]interface{}{"name": "true", "enabled": true},
+				map[string]interface{}{"name": "10"},
+			}},
+		},
+		{
+			str: "replicas=three",
+			err: `key "replicas": value "three" is not of type integer`,
+		},
+		{
+			str: "hosts[0].enabled=yes",
+			err: `key "hosts[].enabled": value "yes" is not of type boolean`,
+		},
+		{
+			str: "ports={80,http}",
+			err: `key "ports[]": value "http" is not of type integer`,
+		},
+	}
+
+	for _, tt := range tests {
+		got := map[string]interface{}{}
+		err := ParseIntoTyped(tt.str, got, typeOf)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("%s: Expected error %q, got %v", tt.str, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tt.str, err)
+		}
+
+		y1, err := yaml.Marshal(tt.expect)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y2, err := yaml.Marshal(got)
+		if err != nil {
+			t.Fatalf("Error serializing parsed value: %s", err)
+		}
+		if string(y1) != string(y2) {
+			t.Errorf("%s: Expected:\n%s\nGot:\n%s", tt.str, y1, y2)
+		}
+	}
+}
+
+func TestParseIntoTypedWithoutSchema(t *testing.T) {
+	str := "replicas=3,tag=1234,enabled=false,version=0.1,list={1,a}"
+	untyped := map[string]interface{}{}
+	if err := ParseInto(str, untyped); err != nil {
+		t.Fatal(err)
+	}
+	for _, types := range []TypeFunc{nil, func([]string) string { return "" }} {
+		got := map[string]interface{}{}
+		if err := ParseIntoTyped(str, got, types); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, untyped) {
+			t.Errorf("Expected %v, got %v", untyped, got)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/version"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	relutil "k8s.io/helm/pkg/releaseutil"
+)
+
+const (
+	// apiCheckWarn reports uses of deprecated APIs as warnings.
+	apiCheckWarn = "warn"
+	// apiCheckFail fails the install on uses of deprecated APIs.
+	apiCheckFail = "fail"
+)
+
+// apiDeprecation describes an API that is deprecated, and later removed, in a
+// minor version of Kubernetes 1.x.
+type apiDeprecation struct {
+	apiVersion string
+	// kind is the deprecated kind of the API, or empty if all kinds are.
+	kind        string
+	deprecated  int
+	removed     int
+	replacement string
+}
+
+// apiDeprecations are the deprecated APIs known to the API check.
+var apiDeprecations = []apiDeprecation{
+	{"extensions/v1beta1", "Deployment", 9, 16, "apps/v1"},
+	{"extensions/v1beta1", "DaemonSet", 9, 16, "apps/v1"},
+	{"extensions/v1beta1", "ReplicaSet", 9, 16, "apps/v1"},
+	{"extensions/v1beta1", "NetworkPolicy", 9, 16, "networking.k8s.io/v1"},
+	{"extensions/v1beta1", "PodSecurityPolicy", 10, 16, "policy/v1beta1"},
+	{"extensions/v1beta1", "Ingress", 14, 22, "networking.k8s.io/v1"},
+	{"apps/v1beta1", "", 9, 16, "apps/v1"},
+	{"apps/v1beta2", "", 9, 16, "apps/v1"},
+	{"rbac.authorization.k8s.io/v1alpha1", "", 8, 22, "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "", 8, 22, "rbac.authorization.k8s.io/v1"},
+	{"scheduling.k8s.io/v1alpha1", "", 14, 17, "scheduling.k8s.io/v1"},
+	{"scheduling.k8s.io/v1beta1", "", 14, 22, "scheduling.k8s.io/v1"},
+	{"apiextensions.k8s.io/v1beta1", "", 16, 22, "apiextensions.k8s.io/v1"},
+	{"admissionregistration.k8s.io/v1beta1", "", 16, 22, "admissionregistration.k8s.io/v1"},
+	{"networking.k8s.io/v1beta1", "", 19, 22, "networking.k8s.io/v1"},
+	{"batch/v1beta1", "CronJob", 21, 25, "batch/v1"},
+	{"policy/v1beta1", "PodDisruptionBudget", 21, 25, "policy/v1"},
+}
+
+// apiResource is the part of a rendered resource the API check looks at.
+type apiResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+}
+
+// kubeMinor returns the minor version of a Kubernetes 1.x cluster. Providers
+// often append a "+" to the minor version, which is ignored.
+func kubeMinor(v *version.Info) (int, error) {
+	if v == nil || v.Major != "1" {
+		return 0, fmt.Errorf("unsupported Kubernetes version %v", v)
+	}
+	minor, err := strconv.Atoi(strings.TrimRight(v.Minor, "+"))
+	if err != nil {
+		return 0, fmt.Errorf("unsupported Kubernetes version %s.%s", v.Major, v.Minor)
+	}
+	return minor, nil
+}
+
+// deprecatedAPIs describes each resource of a manifest that uses an API which
+// is deprecated or removed in Kubernetes 1.minor, naming the API to use
+// instead.
+func deprecatedAPIs(manifest string, minor int) ([]string, error) {
+	var found []string
+	for _, doc := range relutil.SplitManifestsWithSource(manifest) {
+		var r apiResource
+		if err := yaml.Unmarshal([]byte(doc.Content), &r); err != nil {
+			return nil, err
+		}
+		for _, d := range apiDeprecations {
+			if d.apiVersion != r.APIVersion || (d.kind != "" && d.kind != r.Kind) || minor < d.deprecated {
+				continue
+			}
+			state := fmt.Sprintf("deprecated since Kubernetes 1.%d", d.deprecated)
+			if minor >= d.removed {
+				state = fmt.Sprintf("removed in Kubernetes 1.%d", d.removed)
+			}
+			found = append(found, fmt.Sprintf("%s %q uses %s, which is %s: use %s instead", r.Kind, r.Metadata.Name, r.APIVersion, state, d.replacement))
+			break
+		}
+	}
+	return found, nil
+}
+
+// checkAPIs compares the apiVersions of the resources of a release with the
+// APIs deprecated in the Kubernetes version of the cluster. Uses of deprecated
+// APIs are returned as warnings, or as an error if mode is "fail".
+func (s *ReleaseServer) checkAPIs(mode string, r *release.Release) ([]string, error) {
+	if mode != apiCheckWarn && mode != apiCheckFail {
+		return nil, fmt.Errorf("invalid API check %q: must be %q or %q", mode, apiCheckWarn, apiCheckFail)
+	}
+	sv, err := s.clientset.Discovery().ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("API check: %s", err)
+	}
+	minor, err := kubeMinor(sv)
+	if err != nil {
+		return nil, fmt.Errorf("API check: %s", err)
+	}
+	found, err := deprecatedAPIs(r.Manifest, minor)
+	if err != nil {
+		return nil, fmt.Errorf("API check: %s", err)
+	}
+	if len(found) > 0 && mode == apiCheckFail {
+		return nil, fmt.Errorf("deprecated APIs in release %q: %s", r.Name, strings.Join(found, "; "))
+	}
+	return found, nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/version"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+var apiCheckManifest = `apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: old
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: current
+---
+apiVersion: rbac.authorization.k8s.io/v1beta1
+kind: Role
+metadata:
+  name: reader
+`
+
+func TestDeprecatedAPIs(t *testing.T) {
+	tests := []struct {
+		minor  int
+		expect []string
+	}{
+		{8, []string{
+			`Role "reader" uses rbac.authorization.k8s.io/v1beta1, which is deprecated since Kubernetes 1.8: use rbac.authorization.k8s.io/v1 instead`,
+		}},
+		{10, []string{
+			`Deployment "old" uses extensions/v1beta1, which is deprecated since Kubernetes 1.9: use apps/v1 instead`,
+			`Role "reader" uses rbac.authorization.k8s.io/v1beta1, which is deprecated since Kubernetes 1.8: use rbac.authorization.k8s.io/v1 instead`,
+		}},
+		{16, []string{
+			`Deployment "old" uses extensions/v1beta1, which is removed in Kubernetes 1.16: use apps/v1 instead`,
+			`Role "reader" uses rbac.authorization.k8s.io/v1beta1, which is deprecated since Kubernetes 1.8: use rbac.authorization.k8s.io/v1 instead`,
+		}},
+	}
+	for _, tt := range tests {
+		found, err := deprecatedAPIs(apiCheckManifest, tt.minor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(found, tt.expect) {
+			t.Errorf("1.%d: expected %v, got %v", tt.minor, tt.expect, found)
+		}
+	}
+}
+
+func TestDeprecatedAPIsCurrent(t *testing.T) {
+	found, err := deprecatedAPIs("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n---\napiVersion: v1\nkind: Service\nmetadata:\n  name: web\n", 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 0 {
+		t.Errorf("Expected no deprecated APIs, got %v", found)
+	}
+}
+
+func TestKubeMinor(t *testing.T) {
+	for _, v := range []*version.Info{{Major: "1", Minor: "16"}, {Major: "1", Minor: "16+"}} {
+		minor, err := kubeMinor(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if minor != 16 {
+			t.Errorf("Expected minor version 16 for %s.%s, got %d", v.Major, v.Minor, minor)
+		}
+	}
+	for _, v := range []*version.Info{nil, {Major: "2", Minor: "0"}, {Major: "1", Minor: "x"}} {
+		if _, err := kubeMinor(v); err == nil {
+			t.Errorf("Expected an error for version %v", v)
+		}
+	}
+}
+
+func TestInstallRelease_InvalidAPICheck(t *testing.T) {
+	rs := rsFixture()
+
+	req := &services.InstallReleaseRequest{
+		Name:      "bogus",
+		Namespace: "spaced",
+		Chart: &chart.Chart{
+			Metadata:  &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{{Name: "templates/web.yaml", Data: []byte(apiCheckManifest)}},
+		},
+		ApiCheck: "maybe",
+	}
+	if _, err := rs.InstallRelease(helm.NewContext(), req); err == nil {
+		t.Error("Expected an invalid API check to fail")
+	}
+	if _, err := rs.env.Releases.Get("bogus", 1); err == nil {
+		t.Error("Expected no release to be stored")
+	}
+}
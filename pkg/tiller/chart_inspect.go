@@ -0,0 +1,82 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/any"
+	ctx "golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// binarySniffLen is the number of leading bytes examined to decide whether a
+// chart file is binary.
+const binarySniffLen = 512
+
+// InspectChart describes the contents of a chart without installing it.
+//
+// Only the metadata, template names, default values and README content are
+// returned in full. Other files are summarized by name and size.
+func (s *ReleaseServer) InspectChart(c ctx.Context, req *services.InspectChartRequest) (*services.InspectChartResponse, error) {
+	ch := req.Chart
+	if ch == nil || ch.Metadata == nil {
+		return nil, errMissingChart
+	}
+
+	res := &services.InspectChartResponse{
+		Metadata:  ch.Metadata,
+		Values:    ch.Values,
+		Templates: make([]string, 0, len(ch.Templates)),
+	}
+	for _, t := range ch.Templates {
+		res.Templates = append(res.Templates, t.Name)
+	}
+
+	for _, f := range ch.Files {
+		if f == nil {
+			continue
+		}
+		binary := isBinary(f.Value)
+		if res.Readme == "" && !binary && isReadme(f) {
+			res.Readme = string(f.Value)
+		}
+		res.Files = append(res.Files, &services.ChartFile{
+			Name:   f.TypeUrl,
+			Size:   int64(len(f.Value)),
+			Binary: binary,
+		})
+	}
+	return res, nil
+}
+
+// isReadme reports whether a chart file is the chart's top-level README.
+func isReadme(f *any.Any) bool {
+	name := strings.ToLower(f.TypeUrl)
+	return name == "readme" || strings.HasPrefix(name, "readme.")
+}
+
+// isBinary reports whether data looks binary. Like git, it treats data with a
+// NUL byte near the start as binary.
+func isBinary(data []byte) bool {
+	if len(data) > binarySniffLen {
+		data = data[:binarySniffLen]
+	}
+	return bytes.IndexByte(data, 0) != -1
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func TestInspectChart(t *testing.T) {
+	ch, err := chartutil.LoadDir("../chartutil/testdata/frobnitz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch.Files = append(ch.Files, &any.Any{TypeUrl: "images/logo.png", Value: []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")})
+
+	rs := rsFixture()
+	res, err := rs.InspectChart(helm.NewContext(), &services.InspectChartRequest{Chart: ch})
+	if err != nil {
+		t.Fatalf("Failed inspect: %s", err)
+	}
+
+	if res.Metadata.Name != "frobnitz" {
+		t.Errorf("Expected chart name frobnitz, got %q", res.Metadata.Name)
+	}
+
+	if expect := []string{"templates/template.tpl"}; !reflect.DeepEqual(res.Templates, expect) {
+		t.Errorf("Expected templates %v, got %v", expect, res.Templates)
+	}
+
+	if !strings.Contains(res.Values.Raw, `name: "Some Name"`) {
+		t.Errorf("Unexpected values: %s", res.Values.Raw)
+	}
+
+	if !strings.HasPrefix(res.Readme, "# Frobnitz") {
+		t.Errorf("Unexpected readme: %s", res.Readme)
+	}
+
+	files := map[string]*services.ChartFile{}
+	for _, f := range res.Files {
+		files[f.Name] = f
+	}
+	for _, name := range []string{"README.md", "LICENSE", "INSTALL.txt", "docs/README.md", "icon.svg"} {
+		f, ok := files[name]
+		if !ok {
+			t.Errorf("Expected file %s in %v", name, res.Files)
+			continue
+		}
+		if f.Binary || f.Size == 0 {
+			t.Errorf("Expected %s to be a non-empty text file, got %v", name, f)
+		}
+	}
+	if f, ok := files["images/logo.png"]; !ok || !f.Binary || f.Size != 16 {
+		t.Errorf("Expected images/logo.png to be summarized as binary, got %v", f)
+	}
+}
+
+func TestInspectChart_MissingChart(t *testing.T) {
+	rs := rsFixture()
+	if _, err := rs.InspectChart(helm.NewContext(), &services.InspectChartRequest{}); err != errMissingChart {
+		t.Errorf("Expected %q, got %v", errMissingChart, err)
+	}
+}
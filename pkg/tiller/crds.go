@@ -0,0 +1,58 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	util "k8s.io/helm/pkg/releaseutil"
+)
+
+const crdKind = "CustomResourceDefinition"
+
+// manifestSeparator separates the documents of a rendered release manifest.
+const manifestSeparator = "\n---\n"
+
+// splitCRDs splits a rendered release manifest into the documents that define
+// CustomResourceDefinitions and all other documents, keeping their order.
+//
+// Custom resources cannot be created before their definitions are
+// established, so the two are installed in separate phases.
+func splitCRDs(manifest string) (crds, rest string) {
+	var c, r []string
+	for _, doc := range strings.Split(manifest, manifestSeparator) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		var head util.SimpleHead
+		if err := yaml.Unmarshal([]byte(doc), &head); err == nil && head.Kind == crdKind {
+			c = append(c, doc)
+		} else {
+			r = append(r, doc)
+		}
+	}
+	return joinManifests(c), joinManifests(r)
+}
+
+func joinManifests(docs []string) string {
+	if len(docs) == 0 {
+		return ""
+	}
+	return manifestSeparator + strings.Join(docs, manifestSeparator)
+}
@@ -23,6 +23,7 @@ These dependencies are expressed as interfaces so that alternate implementations
 package environment
 
 import (
+	"context"
 	"io"
 	"time"
 
@@ -104,6 +105,19 @@ type KubeClient interface {
 	// by "\n---\n").
 	Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error
 
+	// CreateAll creates one or more resources like Create, but attempts every
+	// resource even if some of them fail. The failures are reported together.
+	//
+	// namespace must contain a valid existing namespace.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated
+	// by "\n---\n").
+	CreateAll(namespace string, reader io.Reader, timeout int64, shouldWait bool) error
+
+	// CreateWithOptions creates one or more resources like Create, with the
+	// given options.
+	CreateWithOptions(namespace string, reader io.Reader, opts kube.CreateOptions) error
+
 	// Get gets one or more resources. Returned string hsa the format like kubectl
 	// provides with the column headers separating the resource types.
 	//
@@ -121,6 +135,15 @@ type KubeClient interface {
 	// by "\n---\n").
 	Delete(namespace string, reader io.Reader) error
 
+	// DeleteWithOptions destroys one or more resources using the given
+	// propagation policy and grace period.
+	//
+	// namespace must contain a valid existing namespace.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated
+	// by "\n---\n").
+	DeleteWithOptions(namespace string, reader io.Reader, opts *kube.DeleteOptions) error
+
 	// Watch the resource in reader until it is "ready".
 	//
 	// For Jobs, "ready" means the job ran to completion (excited without error).
@@ -137,12 +160,25 @@ type KubeClient interface {
 	// by "\n---\n").
 	Update(namespace string, originalReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error
 
+	// UpdateWithOptions updates resources like Update, with the given
+	// options.
+	UpdateWithOptions(namespace string, originalReader, modifiedReader io.Reader, opts kube.UpdateOptions) error
+
 	Build(namespace string, reader io.Reader) (kube.Result, error)
 	BuildUnstructured(namespace string, reader io.Reader) (kube.Result, error)
 
 	// WaitAndGetCompletedPodPhase waits up to a timeout until a pod enters a completed phase
 	// and returns said phase (PodSucceeded or PodFailed qualify).
 	WaitAndGetCompletedPodPhase(namespace string, reader io.Reader, timeout time.Duration) (core.PodPhase, error)
+
+	// WaitUntilCRDEstablished waits up to a timeout until every
+	// CustomResourceDefinition in the reader is established.
+	WaitUntilCRDEstablished(reader io.Reader, timeout time.Duration) error
+
+	// WaitForSettle waits for settle and then checks that the resources in
+	// the reader are still ready, and that their pods did not restart. It
+	// returns early with an error when ctx is done.
+	WaitForSettle(ctx context.Context, namespace string, reader io.Reader, settle time.Duration) error
 }
 
 // PrintingKubeClient implements KubeClient, but simply prints the reader to
@@ -157,6 +193,18 @@ func (p *PrintingKubeClient) Create(ns string, r io.Reader, timeout int64, shoul
 	return err
 }
 
+// CreateAll prints the values of what would be created with a real KubeClient.
+func (p *PrintingKubeClient) CreateAll(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	_, err := io.Copy(p.Out, r)
+	return err
+}
+
+// CreateWithOptions prints the values of what would be created with a real KubeClient.
+func (p *PrintingKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	_, err := io.Copy(p.Out, r)
+	return err
+}
+
 // Get prints the values of what would be created with a real KubeClient.
 func (p *PrintingKubeClient) Get(ns string, r io.Reader) (string, error) {
 	_, err := io.Copy(p.Out, r)
@@ -171,6 +219,14 @@ func (p *PrintingKubeClient) Delete(ns string, r io.Reader) error {
 	return err
 }
 
+// DeleteWithOptions implements KubeClient DeleteWithOptions.
+//
+// It only prints out the content to be deleted.
+func (p *PrintingKubeClient) DeleteWithOptions(ns string, r io.Reader, opts *kube.DeleteOptions) error {
+	_, err := io.Copy(p.Out, r)
+	return err
+}
+
 // WatchUntilReady implements KubeClient WatchUntilReady.
 func (p *PrintingKubeClient) WatchUntilReady(ns string, r io.Reader, timeout int64, shouldWait bool) error {
 	_, err := io.Copy(p.Out, r)
@@ -183,6 +239,12 @@ func (p *PrintingKubeClient) Update(ns string, currentReader, modifiedReader io.
 	return err
 }
 
+// UpdateWithOptions implements KubeClient UpdateWithOptions.
+func (p *PrintingKubeClient) UpdateWithOptions(ns string, currentReader, modifiedReader io.Reader, opts kube.UpdateOptions) error {
+	_, err := io.Copy(p.Out, modifiedReader)
+	return err
+}
+
 // Build implements KubeClient Build.
 func (p *PrintingKubeClient) Build(ns string, reader io.Reader) (kube.Result, error) {
 	return []*resource.Info{}, nil
@@ -199,6 +261,18 @@ func (p *PrintingKubeClient) WaitAndGetCompletedPodPhase(namespace string, reade
 	return core.PodUnknown, err
 }
 
+// WaitUntilCRDEstablished implements KubeClient WaitUntilCRDEstablished.
+func (p *PrintingKubeClient) WaitUntilCRDEstablished(reader io.Reader, timeout time.Duration) error {
+	_, err := io.Copy(p.Out, reader)
+	return err
+}
+
+// WaitForSettle implements KubeClient WaitForSettle.
+func (p *PrintingKubeClient) WaitForSettle(ctx context.Context, namespace string, reader io.Reader, settle time.Duration) error {
+	_, err := io.Copy(p.Out, reader)
+	return err
+}
+
 // Environment provides the context for executing a client request.
 //
 // All services in a context are concurrency safe.
@@ -18,6 +18,7 @@ package environment
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"testing"
 	"time"
@@ -43,15 +44,27 @@ type mockKubeClient struct{}
 func (k *mockKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
 	return nil
 }
+func (k *mockKubeClient) CreateAll(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	return nil
+}
+func (k *mockKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	return nil
+}
 func (k *mockKubeClient) Get(ns string, r io.Reader) (string, error) {
 	return "", nil
 }
 func (k *mockKubeClient) Delete(ns string, r io.Reader) error {
 	return nil
 }
+func (k *mockKubeClient) DeleteWithOptions(ns string, r io.Reader, opts *kube.DeleteOptions) error {
+	return nil
+}
 func (k *mockKubeClient) Update(ns string, currentReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
 	return nil
 }
+func (k *mockKubeClient) UpdateWithOptions(ns string, currentReader, modifiedReader io.Reader, opts kube.UpdateOptions) error {
+	return nil
+}
 func (k *mockKubeClient) WatchUntilReady(ns string, r io.Reader, timeout int64, shouldWait bool) error {
 	return nil
 }
@@ -64,6 +77,12 @@ func (k *mockKubeClient) BuildUnstructured(ns string, reader io.Reader) (kube.Re
 func (k *mockKubeClient) WaitAndGetCompletedPodPhase(namespace string, reader io.Reader, timeout time.Duration) (core.PodPhase, error) {
 	return core.PodUnknown, nil
 }
+func (k *mockKubeClient) WaitUntilCRDEstablished(reader io.Reader, timeout time.Duration) error {
+	return nil
+}
+func (k *mockKubeClient) WaitForSettle(ctx context.Context, namespace string, reader io.Reader, settle time.Duration) error {
+	return nil
+}
 
 func (k *mockKubeClient) WaitAndGetCompletedPodStatus(namespace string, reader io.Reader, timeout time.Duration) (core.PodPhase, error) {
 	return "", nil
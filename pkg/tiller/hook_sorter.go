@@ -17,37 +17,12 @@ limitations under the License.
 package tiller
 
 import (
-	"sort"
-
+	"k8s.io/helm/pkg/hooks"
 	"k8s.io/helm/pkg/proto/hapi/release"
 )
 
 // sortByHookWeight does an in-place sort of hooks by their supplied weight.
-func sortByHookWeight(hooks []*release.Hook) []*release.Hook {
-	hs := newHookWeightSorter(hooks)
-	sort.Sort(hs)
-	return hs.hooks
-}
-
-type hookWeightSorter struct {
-	hooks []*release.Hook
-}
-
-func newHookWeightSorter(h []*release.Hook) *hookWeightSorter {
-	return &hookWeightSorter{
-		hooks: h,
-	}
-}
-
-func (hs *hookWeightSorter) Len() int { return len(hs.hooks) }
-
-func (hs *hookWeightSorter) Swap(i, j int) {
-	hs.hooks[i], hs.hooks[j] = hs.hooks[j], hs.hooks[i]
-}
-
-func (hs *hookWeightSorter) Less(i, j int) bool {
-	if hs.hooks[i].Weight == hs.hooks[j].Weight {
-		return hs.hooks[i].Name < hs.hooks[j].Name
-	}
-	return hs.hooks[i].Weight < hs.hooks[j].Weight
+func sortByHookWeight(h []*release.Hook) []*release.Hook {
+	hooks.SortByWeight(h)
+	return h
 }
@@ -20,7 +20,7 @@ import (
 	"fmt"
 	"log"
 	"path"
-	"strconv"
+	"sort"
 	"strings"
 
 	"github.com/ghodss/yaml"
@@ -28,6 +28,7 @@ import (
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/hooks"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
 	util "k8s.io/helm/pkg/releaseutil"
 )
 
@@ -46,8 +47,9 @@ var events = map[string]release.Hook_Event{
 
 // deletePolices represents a mapping between the key in the annotation for label deleting policy and its real meaning
 var deletePolices = map[string]release.Hook_DeletePolicy{
-	hooks.HookSucceeded: release.Hook_SUCCEEDED,
-	hooks.HookFailed:    release.Hook_FAILED,
+	hooks.HookSucceeded:      release.Hook_SUCCEEDED,
+	hooks.HookFailed:         release.Hook_FAILED,
+	hooks.BeforeHookCreation: release.Hook_BEFORE_HOOK_CREATION,
 }
 
 // Manifest represents a manifest file, which has a name and some content.
@@ -58,12 +60,13 @@ type Manifest struct {
 }
 
 type result struct {
-	hooks   []*release.Hook
-	generic []Manifest
+	hooks    []*release.Hook
+	generic  []Manifest
+	warnings []string
 }
 
 type manifestFile struct {
-	entries map[string]string
+	entries []util.SourcedManifest
 	path    string
 	apis    chartutil.VersionSet
 }
@@ -71,13 +74,31 @@ type manifestFile struct {
 // sortManifests takes a map of filename/YAML contents, splits the file
 // by manifest entries, and sorts the entries into hook types.
 //
+// Each document of a file is sorted on its own, so a file that renders several
+// resources has each of them ordered by its kind. Documents are kept in file
+// order among resources of the same kind, and empty documents are skipped.
+//
 // The resulting hooks struct will be populated with all of the generated hooks.
 // Any file that does not declare one of the hook types will be placed in the
 // 'generic' bucket.
 //
 // Files that do not parse into the expected format are simply placed into a map and
 // returned.
-func sortManifests(files map[string]string, apis chartutil.VersionSet, sort SortOrder) ([]*release.Hook, []Manifest, error) {
+//
+// A warning, prefixed with the file path, is returned for each malformed hook
+// annotation.
+func sortManifests(files map[string]string, apis chartutil.VersionSet, ordering SortOrder) ([]*release.Hook, []Manifest, []string, error) {
+	hs, generic, warnings, err := splitManifests(files, apis)
+	if err != nil {
+		return hs, generic, warnings, err
+	}
+	return hs, sortByKind(generic, ordering), warnings, nil
+}
+
+// splitManifests splits files into hooks and generic manifests like
+// sortManifests, leaving the documents of each file in order and the files in
+// no particular order.
+func splitManifests(files map[string]string, apis chartutil.VersionSet) ([]*release.Hook, []Manifest, []string, error) {
 	result := &result{}
 
 	for filePath, c := range files {
@@ -94,17 +115,18 @@ func sortManifests(files map[string]string, apis chartutil.VersionSet, sort Sort
 		}
 
 		manifestFile := &manifestFile{
-			entries: util.SplitManifests(c),
+			entries: util.SplitManifestsWithSource(c),
 			path:    filePath,
 			apis:    apis,
 		}
 
 		if err := manifestFile.sort(result); err != nil {
-			return result.hooks, result.generic, err
+			return result.hooks, result.generic, result.warnings, err
 		}
 	}
 
-	return result.hooks, sortByKind(result.generic, sort), nil
+	sort.Strings(result.warnings)
+	return result.hooks, result.generic, result.warnings, nil
 }
 
 // sort takes a manifestFile object which may contain multiple resource definition
@@ -127,7 +149,12 @@ func sortManifests(files map[string]string, apis chartutil.VersionSet, sort Sort
 // 		annotations:
 // 			helm.sh/hook-delete-policy: hook-succeeded
 func (file *manifestFile) sort(result *result) error {
-	for _, m := range file.entries {
+	for _, doc := range file.entries {
+		m := doc.Content
+		if emptyDocument(m) {
+			continue
+		}
+
 		var entry util.SimpleHead
 		err := yaml.Unmarshal([]byte(m), &entry)
 
@@ -159,6 +186,10 @@ func (file *manifestFile) sort(result *result) error {
 			continue
 		}
 
+		for _, w := range hooks.Warnings(entry.Metadata.Annotations) {
+			result.warnings = append(result.warnings, fmt.Sprintf("%s: %s", file.path, w))
+		}
+
 		hw := calculateHookWeight(entry)
 
 		h := &release.Hook{
@@ -209,6 +240,25 @@ func (file *manifestFile) sort(result *result) error {
 	return nil
 }
 
+// newHookPlan lists the hooks of hs that run on each of events, event by event
+// and in the order they run on each event.
+func newHookPlan(hs []*release.Hook, warnings []string, events ...release.Hook_Event) *services.HookPlan {
+	plan := &services.HookPlan{Warnings: warnings}
+	for _, e := range events {
+		for _, h := range hooks.ForEvent(hs, e) {
+			plan.Steps = append(plan.Steps, &services.HookPlanStep{
+				Event:          e,
+				Name:           h.Name,
+				Kind:           h.Kind,
+				Path:           h.Path,
+				Weight:         h.Weight,
+				DeletePolicies: h.DeletePolicies,
+			})
+		}
+	}
+	return plan
+}
+
 func hasAnyAnnotation(entry util.SimpleHead) bool {
 	if entry.Metadata == nil ||
 		entry.Metadata.Annotations == nil ||
@@ -220,11 +270,18 @@ func hasAnyAnnotation(entry util.SimpleHead) bool {
 }
 
 func calculateHookWeight(entry util.SimpleHead) int32 {
-	hws := entry.Metadata.Annotations[hooks.HookWeightAnno]
-	hw, err := strconv.Atoi(hws)
-	if err != nil {
-		hw = 0
-	}
+	return hooks.ParseWeight(entry.Metadata.Annotations[hooks.HookWeightAnno])
+}
 
-	return int32(hw)
+// emptyDocument reports whether a manifest document holds nothing but comments
+// and document separators, as a template that renders no resource after a "---"
+// leaves behind.
+func emptyDocument(doc string) bool {
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && line != "---" && !strings.HasPrefix(line, "#") {
+			return false
+		}
+	}
+	return true
 }
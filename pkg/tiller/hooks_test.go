@@ -140,7 +140,7 @@ metadata:
 		manifests[o.path] = o.manifest
 	}
 
-	hs, generic, err := sortManifests(manifests, chartutil.NewVersionSet("v1", "v1beta1"), InstallOrder)
+	hs, generic, _, err := sortManifests(manifests, chartutil.NewVersionSet("v1", "v1beta1"), InstallOrder)
 	if err != nil {
 		t.Fatalf("Unexpected error: %s", err)
 	}
@@ -229,6 +229,69 @@ metadata:
 	}
 }
 
+func TestSortManifestsMultiDocument(t *testing.T) {
+	files := map[string]string{
+		"templates/app.yaml": `---
+apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: app
+---
+---
+# Source: templates/app.yaml
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: app-namespace
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: zeta
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: alpha
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  annotations:
+    "helm.sh/hook": pre-install
+`,
+		"templates/service.yaml": `apiVersion: v1
+kind: Service
+metadata:
+  name: app
+`,
+	}
+	vs := chartutil.NewVersionSet("v1", "extensions/v1beta1", "batch/v1")
+	expect := []string{"Namespace/app-namespace", "ConfigMap/zeta", "ConfigMap/alpha", "Service/app", "Deployment/app"}
+
+	// Documents are split out of a map, so sort a few times to be sure their
+	// order does not depend on map iteration.
+	for n := 0; n < 10; n++ {
+		hs, generic, _, err := sortManifests(files, vs, InstallOrder)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(hs) != 1 || hs[0].Name != "migrate" || hs[0].Path != "templates/app.yaml" {
+			t.Fatalf("Expected the migrate hook from templates/app.yaml, got %v", hs)
+		}
+
+		var got []string
+		for _, m := range generic {
+			got = append(got, m.Head.Kind+"/"+m.Head.Metadata.Name)
+		}
+		if !reflect.DeepEqual(got, expect) {
+			t.Fatalf("Expected manifests in order %v, got %v", expect, got)
+		}
+	}
+}
+
 func TestVersionSet(t *testing.T) {
 	vs := chartutil.NewVersionSet("v1", "v1beta1", "extensions/alpha5", "batch/v1")
 
@@ -90,7 +90,7 @@ var UninstallOrder SortOrder = []string{
 // Results are sorted by 'ordering'
 func sortByKind(manifests []Manifest, ordering SortOrder) []Manifest {
 	ks := newKindSorter(manifests, ordering)
-	sort.Sort(ks)
+	sort.Stable(ks)
 	return ks.manifests
 }
 
@@ -121,7 +121,7 @@ func (k *kindSorter) Less(i, j int) bool {
 	first, aok := k.ordering[a.Head.Kind]
 	second, bok := k.ordering[b.Head.Kind]
 	// if same kind (including unknown) sub sort alphanumeric
-	if first == second {
+	if first == second && aok == bok {
 		// if both are unknown and of different kind sort by kind alphabetically
 		if !aok && !bok && a.Head.Kind != b.Head.Kind {
 			return a.Head.Kind < b.Head.Kind
@@ -143,6 +143,31 @@ func (k *kindSorter) Less(i, j int) bool {
 func SortByKind(manifests []Manifest) []Manifest {
 	ordering := InstallOrder
 	ks := newKindSorter(manifests, ordering)
-	sort.Sort(ks)
+	sort.Stable(ks)
 	return ks.manifests
 }
+
+// sortByFile sorts manifests by the path of their template file, keeping the
+// documents of a file in order. Namespaces and then CustomResourceDefinitions
+// are still sorted ahead of everything else, as the rest may depend on them.
+func sortByFile(manifests []Manifest) []Manifest {
+	sort.SliceStable(manifests, func(i, j int) bool {
+		a, b := fileOrderRank(manifests[i]), fileOrderRank(manifests[j])
+		if a != b {
+			return a < b
+		}
+		return manifests[i].Name < manifests[j].Name
+	})
+	return manifests
+}
+
+// fileOrderRank is the rank of a manifest for sortByFile.
+func fileOrderRank(m Manifest) int {
+	switch m.Head.Kind {
+	case "Namespace":
+		return 0
+	case "CustomResourceDefinition":
+		return 1
+	}
+	return 2
+}
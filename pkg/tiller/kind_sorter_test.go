@@ -215,3 +215,24 @@ func TestKindSorterSubSort(t *testing.T) {
 		})
 	}
 }
+
+func TestSortByFile(t *testing.T) {
+	manifests := []Manifest{
+		{Name: "templates/b.yaml", Content: "1", Head: &util.SimpleHead{Kind: "Deployment"}},
+		{Name: "templates/b.yaml", Content: "2", Head: &util.SimpleHead{Kind: "ConfigMap"}},
+		{Name: "templates/a.yaml", Content: "3", Head: &util.SimpleHead{Kind: "Service"}},
+		{Name: "templates/c.yaml", Content: "4", Head: &util.SimpleHead{Kind: "CustomResourceDefinition"}},
+		{Name: "templates/a.yaml", Content: "5", Head: &util.SimpleHead{Kind: "Secret"}},
+		{Name: "templates/d.yaml", Content: "6", Head: &util.SimpleHead{Kind: "Namespace"}},
+	}
+
+	// Namespaces and CRDs first, then by file, keeping the documents of a
+	// file in order.
+	var buf bytes.Buffer
+	for _, m := range sortByFile(manifests) {
+		buf.WriteString(m.Content)
+	}
+	if got, expected := buf.String(), "643512"; got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
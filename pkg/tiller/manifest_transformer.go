@@ -0,0 +1,353 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"k8s.io/helm/pkg/hooks"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// ManifestTransformer changes a rendered resource before it is sent to Kubernetes.
+//
+// Transform may mutate obj in place, for example to add labels or inject
+// containers. Returning an error rejects the resource, which fails the release
+// operation.
+type ManifestTransformer interface {
+	Transform(obj *unstructured.Unstructured) error
+}
+
+// ManifestTransformerFunc adapts an ordinary function to a ManifestTransformer.
+type ManifestTransformerFunc func(obj *unstructured.Unstructured) error
+
+// Transform calls f(obj).
+func (f ManifestTransformerFunc) Transform(obj *unstructured.Unstructured) error {
+	return f(obj)
+}
+
+// AddManifestTransformer registers transformers that are applied to every
+// rendered resource, including hooks. Transformers run in the order they were
+// registered.
+//
+// Transformers must be registered before the server starts handling requests.
+func (s *ReleaseServer) AddManifestTransformer(transformers ...ManifestTransformer) {
+	s.transformers = append(s.transformers, transformers...)
+}
+
+// transformManifests applies transformers to rendered hooks and manifests.
+func transformManifests(hs []*release.Hook, ms []Manifest, transformers []ManifestTransformer) error {
+	if len(transformers) == 0 {
+		return nil
+	}
+	for _, h := range hs {
+		out, err := transformManifest(h.Manifest, transformers)
+		if err != nil {
+			return fmt.Errorf("%s: %s", h.Path, err)
+		}
+		h.Manifest = out
+	}
+	for i, m := range ms {
+		out, err := transformManifest(m.Content, transformers)
+		if err != nil {
+			return fmt.Errorf("%s: %s", m.Name, err)
+		}
+		ms[i].Content = out
+	}
+	return nil
+}
+
+// transformManifest runs transformers over a single rendered resource and
+// returns the resulting YAML.
+func transformManifest(content string, transformers []ManifestTransformer) (string, error) {
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content), &obj); err != nil {
+		return "", err
+	}
+	if len(obj) == 0 {
+		return content, nil
+	}
+
+	u := &unstructured.Unstructured{Object: obj}
+	orig := u.DeepCopy()
+	for _, t := range transformers {
+		if err := t.Transform(u); err != nil {
+			return "", fmt.Errorf("%s %q rejected: %s", u.GetKind(), u.GetName(), err)
+		}
+	}
+	// Resources the transformers leave alone keep their formatting.
+	if reflect.DeepEqual(orig.Object, u.Object) {
+		return content, nil
+	}
+
+	out, err := yaml.Marshal(u.Object)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// commonLabels returns a transformer that adds the recommended labels to every
+// resource. Labels that a resource already sets are left untouched.
+func commonLabels(releaseName string, md *chart.Metadata) ManifestTransformer {
+	labels := map[string]string{
+		"app.kubernetes.io/managed-by": "Helm",
+		"app.kubernetes.io/instance":   releaseName,
+		"helm.sh/chart":                labelValue(md.Name + "-" + md.Version),
+	}
+	if md.AppVersion != "" {
+		labels["app.kubernetes.io/version"] = labelValue(md.AppVersion)
+	}
+	return addLabels(labels)
+}
+
+// Labels identifying the release and revision that manage a resource.
+const (
+	releaseLabel  = "helm.sh/release"
+	revisionLabel = "helm.sh/revision"
+)
+
+// releaseLabels returns a transformer that adds the release name and revision
+// to every resource the release manages. Hooks, which are run rather than
+// managed, and documents without a kind are skipped. Labels that a resource
+// already sets are left untouched.
+func releaseLabels(releaseName string, revision int) ManifestTransformer {
+	add := addLabels(map[string]string{
+		releaseLabel:  releaseName,
+		revisionLabel: strconv.Itoa(revision),
+	})
+	return ManifestTransformerFunc(func(obj *unstructured.Unstructured) error {
+		if _, ok := obj.GetAnnotations()[hooks.HookAnno]; ok || obj.GetKind() == "" {
+			return nil
+		}
+		return add.Transform(obj)
+	})
+}
+
+// addLabels returns a transformer that adds labels to every resource that does
+// not already set them.
+func addLabels(labels map[string]string) ManifestTransformer {
+	return ManifestTransformerFunc(func(obj *unstructured.Unstructured) error {
+		l := obj.GetLabels()
+		if l == nil {
+			l = map[string]string{}
+		}
+		for k, v := range labels {
+			if _, ok := l[k]; !ok {
+				l[k] = v
+			}
+		}
+		obj.SetLabels(l)
+		return nil
+	})
+}
+
+// relabelRevision updates the revision label that Tiller set on the resources
+// of a release from revision from to revision to, as a rollback reapplies the
+// manifest of an earlier revision. Resources whose label was set by the chart
+// are left untouched, and so is the formatting of the others.
+func relabelRevision(hooks []*release.Hook, manifest string, from, to int32) ([]*release.Hook, string, error) {
+	relabel := ManifestTransformerFunc(func(obj *unstructured.Unstructured) error {
+		l := obj.GetLabels()
+		if l[revisionLabel] == strconv.Itoa(int(from)) {
+			l[revisionLabel] = strconv.Itoa(int(to))
+			obj.SetLabels(l)
+		}
+		return nil
+	})
+	transformers := []ManifestTransformer{relabel}
+
+	relabelled := make([]*release.Hook, len(hooks))
+	for i, h := range hooks {
+		c := *h
+		out, err := transformManifest(h.Manifest, transformers)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %s", h.Path, err)
+		}
+		c.Manifest = out
+		relabelled[i] = &c
+	}
+
+	// Each document of the manifest starts with the name of its source file.
+	docs := strings.Split(manifest, sourceSeparator)
+	for i := 1; i < len(docs); i++ {
+		nl := strings.Index(docs[i], "\n")
+		if nl < 0 {
+			continue
+		}
+		out, err := transformManifest(docs[i][nl+1:], transformers)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %s", docs[i][:nl], err)
+		}
+		docs[i] = docs[i][:nl+1] + out
+	}
+	return relabelled, strings.Join(docs, sourceSeparator), nil
+}
+
+// labelValue makes s usable as a label value, the same way the chart label
+// helper generated by 'helm create' does.
+func labelValue(s string) string {
+	s = strings.Replace(s, "+", "_", -1)
+	if len(s) > 63 {
+		s = s[:63]
+	}
+	return strings.TrimRight(s, "-_.")
+}
+
+// resourcePatch sets one field of the rendered resource with the given kind
+// and name.
+type resourcePatch struct {
+	spec    string
+	kind    string
+	name    string
+	path    []string
+	value   interface{}
+	matched bool
+}
+
+// resourcePatches is a transformer that applies patches to the resources they
+// target.
+type resourcePatches []*resourcePatch
+
+// parseResourcePatches parses patches of the form KIND/NAME:PATH=VALUE, where
+// PATH is a dot separated list of fields. VALUE is a JSON object or list, an
+// integer, a boolean, null or a string.
+func parseResourcePatches(specs []string) (resourcePatches, error) {
+	var patches resourcePatches
+	for _, spec := range specs {
+		p, err := parseResourcePatch(spec)
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, p)
+	}
+	return patches, nil
+}
+
+func parseResourcePatch(spec string) (*resourcePatch, error) {
+	invalid := fmt.Errorf("invalid patch %q: expected KIND/NAME:PATH=VALUE", spec)
+
+	target, field := split2(spec, ":")
+	path, value := split2(field, "=")
+	kind, name := split2(target, "/")
+	if kind == "" || name == "" || path == "" || !strings.Contains(field, "=") {
+		return nil, invalid
+	}
+	p := &resourcePatch{spec: spec, kind: kind, name: name, path: strings.Split(path, ".")}
+	for _, f := range p.path {
+		if f == "" {
+			return nil, invalid
+		}
+	}
+
+	v, err := patchValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch %q: %s", spec, err)
+	}
+	p.value = v
+	return p, nil
+}
+
+// split2 splits s around the first sep. If sep is not found, the second
+// string is empty.
+func split2(s, sep string) (string, string) {
+	parts := strings.SplitN(s, sep, 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// patchValue converts the value of a patch the same way --set converts values,
+// also accepting a JSON object or list.
+func patchValue(s string) (interface{}, error) {
+	if strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[") {
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	switch strings.ToLower(s) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, nil
+	}
+	return s, nil
+}
+
+// Transform applies the patches that target obj.
+func (patches resourcePatches) Transform(obj *unstructured.Unstructured) error {
+	for _, p := range patches {
+		if !strings.EqualFold(p.kind, obj.GetKind()) || p.name != obj.GetName() {
+			continue
+		}
+		if err := setField(obj.Object, p.value, p.path); err != nil {
+			return fmt.Errorf("patch %q: %s", p.spec, err)
+		}
+		p.matched = true
+	}
+	return nil
+}
+
+// unmatched returns an error naming the patches that targeted no resource.
+func (patches resourcePatches) unmatched() error {
+	var specs []string
+	for _, p := range patches {
+		if !p.matched {
+			specs = append(specs, p.spec)
+		}
+	}
+	if len(specs) > 0 {
+		return fmt.Errorf("patches matched no rendered resource: %s", strings.Join(specs, ", "))
+	}
+	return nil
+}
+
+// setField sets the field at path in obj, creating intermediate objects.
+func setField(obj map[string]interface{}, value interface{}, path []string) error {
+	for i, f := range path[:len(path)-1] {
+		next, ok := obj[f]
+		if !ok || next == nil {
+			m := map[string]interface{}{}
+			obj[f] = m
+			obj = m
+			continue
+		}
+		m, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s is not an object", strings.Join(path[:i+1], "."))
+		}
+		obj = m
+	}
+	obj[path[len(path)-1]] = value
+	return nil
+}
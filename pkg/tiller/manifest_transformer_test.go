@@ -0,0 +1,367 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	relutil "k8s.io/helm/pkg/releaseutil"
+)
+
+func transformerChartStub() *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{Name: "hello"},
+		Templates: []*chart.Template{
+			{Name: "templates/deployment", Data: []byte("kind: Deployment\nmetadata:\n  name: web\n")},
+			{Name: "templates/service", Data: []byte("kind: Service\nmetadata:\n  name: web\n  labels:\n    tier: frontend\n")},
+			{Name: "templates/hooks", Data: []byte(manifestWithHook)},
+		},
+	}
+}
+
+func addLabel(key, value string) ManifestTransformer {
+	return ManifestTransformerFunc(func(obj *unstructured.Unstructured) error {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[key] = value
+		obj.SetLabels(labels)
+		return nil
+	})
+}
+
+func TestInstallRelease_ManifestTransformer(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	var seen []string
+	rs.AddManifestTransformer(
+		addLabel("team", "platform"),
+		ManifestTransformerFunc(func(obj *unstructured.Unstructured) error {
+			// Transformers run in registration order.
+			if obj.GetLabels()["team"] != "platform" {
+				t.Errorf("Expected %s %s to be labelled by the first transformer", obj.GetKind(), obj.GetName())
+			}
+			seen = append(seen, obj.GetKind())
+			return nil
+		}),
+	)
+
+	req := &services.InstallReleaseRequest{
+		Name:  "transformed",
+		Chart: transformerChartStub(),
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	if len(seen) != 3 {
+		t.Errorf("Expected 3 resources to be transformed, got %v", seen)
+	}
+
+	if n := strings.Count(res.Release.Manifest, "team: platform"); n != 2 {
+		t.Errorf("Expected label on both resources, found %d in:\n%s", n, res.Release.Manifest)
+	}
+	if !strings.Contains(res.Release.Manifest, "tier: frontend") {
+		t.Errorf("Expected existing labels to be kept:\n%s", res.Release.Manifest)
+	}
+	if !strings.Contains(res.Release.Hooks[0].Manifest, "team: platform") {
+		t.Errorf("Expected label on hook:\n%s", res.Release.Hooks[0].Manifest)
+	}
+}
+
+func TestInstallRelease_ManifestTransformerRejects(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	rs.AddManifestTransformer(ManifestTransformerFunc(func(obj *unstructured.Unstructured) error {
+		if obj.GetKind() == "Service" {
+			return errors.New("services are not allowed")
+		}
+		return nil
+	}))
+
+	req := &services.InstallReleaseRequest{
+		Name:  "rejected",
+		Chart: transformerChartStub(),
+	}
+	_, err := rs.InstallRelease(c, req)
+	if err == nil {
+		t.Fatal("Expected install to fail")
+	}
+	if !strings.Contains(err.Error(), "services are not allowed") || !strings.Contains(err.Error(), `Service "web"`) {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if _, err := rs.env.Releases.Get(req.Name, 1); err == nil {
+		t.Error("Expected no release to be recorded")
+	}
+}
+
+func TestCommonLabels(t *testing.T) {
+	md := &chart.Metadata{Name: "hello", Version: "0.1.0+build.1", AppVersion: "1.13"}
+	transformers := []ManifestTransformer{commonLabels("angry-panda", md)}
+
+	tests := []struct {
+		name     string
+		manifest string
+		expect   map[string]string
+	}{
+		{
+			name:     "bare resource",
+			manifest: "kind: ConfigMap\nmetadata:\n  name: bare\n",
+			expect: map[string]string{
+				"app.kubernetes.io/managed-by": "Helm",
+				"app.kubernetes.io/instance":   "angry-panda",
+				"app.kubernetes.io/version":    "1.13",
+				"helm.sh/chart":                "hello-0.1.0_build.1",
+			},
+		},
+		{
+			name: "author-set labels",
+			manifest: `kind: ConfigMap
+metadata:
+  name: labelled
+  labels:
+    app.kubernetes.io/version: "2.0"
+    helm.sh/chart: custom
+    tier: backend
+`,
+			expect: map[string]string{
+				"app.kubernetes.io/managed-by": "Helm",
+				"app.kubernetes.io/instance":   "angry-panda",
+				"app.kubernetes.io/version":    "2.0",
+				"helm.sh/chart":                "custom",
+				"tier":                         "backend",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		out, err := transformManifest(tt.manifest, transformers)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal([]byte(out), &obj); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		u := &unstructured.Unstructured{Object: obj}
+		if got := u.GetLabels(); !reflect.DeepEqual(got, tt.expect) {
+			t.Errorf("%s: expected labels %v, got %v", tt.name, tt.expect, got)
+		}
+	}
+}
+
+func TestInstallRelease_CommonLabels(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := &services.InstallReleaseRequest{
+		Name:         "labelled",
+		Chart:        transformerChartStub(),
+		CommonLabels: true,
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if n := strings.Count(res.Release.Manifest, "app.kubernetes.io/instance: labelled"); n != 2 {
+		t.Errorf("Expected instance label on both resources, found %d in:\n%s", n, res.Release.Manifest)
+	}
+
+	req = &services.InstallReleaseRequest{
+		Name:  "unlabelled",
+		Chart: transformerChartStub(),
+	}
+	if res, err = rs.InstallRelease(c, req); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if strings.Contains(res.Release.Manifest, "app.kubernetes.io") {
+		t.Errorf("Expected no common labels unless requested:\n%s", res.Release.Manifest)
+	}
+}
+
+func TestReleaseLabels(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	ch := transformerChartStub()
+	ch.Templates = append(ch.Templates, &chart.Template{
+		Name: "templates/pinned",
+		Data: []byte("kind: ConfigMap\nmetadata:\n  name: pinned\n  labels:\n    helm.sh/revision: pinned\n"),
+	})
+	// expectRevision checks the labels of the resources of rel, and that its
+	// hooks are left as rendered.
+	expectRevision := func(rel *release.Release, revision string) {
+		for _, m := range relutil.SplitManifests(rel.Manifest) {
+			var obj map[string]interface{}
+			if err := yaml.Unmarshal([]byte(m), &obj); err != nil {
+				t.Fatal(err)
+			}
+			u := &unstructured.Unstructured{Object: obj}
+			labels := u.GetLabels()
+			expect := revision
+			if u.GetName() == "pinned" {
+				expect = "pinned"
+			}
+			if labels[releaseLabel] != "labelled" || labels[revisionLabel] != expect {
+				t.Errorf("Expected %s %q to be labelled with revision %s of the release, got %v", u.GetKind(), u.GetName(), expect, labels)
+			}
+			if u.GetKind() == "Service" && labels["tier"] != "frontend" {
+				t.Errorf("Expected the author-set tier label to be kept, got %v", labels)
+			}
+		}
+		if rel.Hooks[0].Manifest != manifestWithHook {
+			t.Errorf("Expected the hook to be left as rendered:\n%s", rel.Hooks[0].Manifest)
+		}
+	}
+
+	res, err := rs.InstallRelease(c, &services.InstallReleaseRequest{Name: "labelled", Chart: ch})
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	expectRevision(res.Release, "1")
+
+	up, err := rs.UpdateRelease(c, &services.UpdateReleaseRequest{Name: "labelled", Chart: ch})
+	if err != nil {
+		t.Fatalf("Failed upgrade: %s", err)
+	}
+	expectRevision(up.Release, "2")
+
+	rb, err := rs.RollbackRelease(c, &services.RollbackReleaseRequest{Name: "labelled", Version: 1})
+	if err != nil {
+		t.Fatalf("Failed rollback: %s", err)
+	}
+	expectRevision(rb.Release, "3")
+	if !strings.HasPrefix(rb.Release.Manifest, sourceSeparator) {
+		t.Errorf("Expected the manifest to keep its source comments:\n%s", rb.Release.Manifest)
+	}
+
+	// The stored revision the rollback went back to is unchanged.
+	first, err := rs.env.Releases.Get("labelled", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectRevision(first, "1")
+}
+
+func TestInstallRelease_Patches(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	ch := transformerChartStub()
+	ch.Templates = append(ch.Templates,
+		&chart.Template{Name: "templates/my-app", Data: []byte("kind: Deployment\nmetadata:\n  name: my-app\nspec:\n  replicas: 1\n")},
+		&chart.Template{Name: "templates/worker", Data: []byte("kind: Deployment\nmetadata:\n  name: worker\nspec:\n  replicas: 1\n")},
+	)
+	req := &services.InstallReleaseRequest{
+		Name:    "patched",
+		Chart:   ch,
+		Patches: []string{"deployment/my-app:spec.replicas=5", "Deployment/my-app:spec.template.metadata.labels={\"tier\":\"web\"}"},
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	replicas := map[string]interface{}{}
+	for _, m := range relutil.SplitManifests(res.Release.Manifest) {
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal([]byte(m), &obj); err != nil {
+			t.Fatal(err)
+		}
+		u := &unstructured.Unstructured{Object: obj}
+		if u.GetKind() != "Deployment" {
+			continue
+		}
+		spec, _ := obj["spec"].(map[string]interface{})
+		replicas[u.GetName()] = spec["replicas"]
+		if u.GetName() == "my-app" {
+			tmpl, _ := spec["template"].(map[string]interface{})
+			if !reflect.DeepEqual(tmpl, map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"tier": "web"}}}) {
+				t.Errorf("Expected the template labels to be patched, got %v", tmpl)
+			}
+		}
+	}
+	expect := map[string]interface{}{"my-app": float64(5), "worker": float64(1), "web": nil}
+	if !reflect.DeepEqual(replicas, expect) {
+		t.Errorf("Expected replicas %v, got %v", expect, replicas)
+	}
+}
+
+func TestInstallRelease_PatchesUnmatched(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := &services.InstallReleaseRequest{
+		Name:    "patched",
+		Chart:   transformerChartStub(),
+		Patches: []string{"deployment/web:spec.replicas=5", "deployment/nemo:spec.replicas=5"},
+	}
+	_, err := rs.InstallRelease(c, req)
+	if err == nil {
+		t.Fatal("Expected a patch that targets no resource to fail the install")
+	}
+	if !strings.Contains(err.Error(), "deployment/nemo:spec.replicas=5") || strings.Contains(err.Error(), "deployment/web") {
+		t.Errorf("Expected only the unmatched patch to be reported, got %q", err)
+	}
+}
+
+func TestParseResourcePatch(t *testing.T) {
+	tests := []struct {
+		spec  string
+		path  []string
+		value interface{}
+		err   bool
+	}{
+		{spec: "deployment/web:spec.replicas=5", path: []string{"spec", "replicas"}, value: int64(5)},
+		{spec: "deployment/web:spec.paused=true", path: []string{"spec", "paused"}, value: true},
+		{spec: "configmap/web:data.url=http://example.com/a=b", path: []string{"data", "url"}, value: "http://example.com/a=b"},
+		{spec: "deployment/web:spec.selector={\"app\":\"web\"}", path: []string{"spec", "selector"}, value: map[string]interface{}{"app": "web"}},
+		{spec: "deployment/web:spec.replicas", err: true},
+		{spec: "deployment:spec.replicas=5", err: true},
+		{spec: "deployment/web:spec..replicas=5", err: true},
+		{spec: "deployment/web:spec.selector={", err: true},
+	}
+
+	for _, tt := range tests {
+		p, err := parseResourcePatch(tt.spec)
+		if (err != nil) != tt.err {
+			t.Errorf("%s: expected error %t, got %v", tt.spec, tt.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(p.path, tt.path) || !reflect.DeepEqual(p.value, tt.value) {
+			t.Errorf("%s: expected %v=%#v, got %v=%#v", tt.spec, tt.path, tt.value, p.path, p.value)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/apis/core"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	relutil "k8s.io/helm/pkg/releaseutil"
+)
+
+const (
+	// quotaCheckWarn reports insufficient quota as a warning.
+	quotaCheckWarn = "warn"
+	// quotaCheckFail fails the install on insufficient quota.
+	quotaCheckFail = "fail"
+)
+
+// quotaPodSpec is the part of a pod spec that counts against a ResourceQuota.
+type quotaPodSpec struct {
+	Containers     []quotaContainer `json:"containers"`
+	InitContainers []quotaContainer `json:"initContainers"`
+}
+
+type quotaContainer struct {
+	Resources struct {
+		Requests core.ResourceList `json:"requests"`
+	} `json:"resources"`
+}
+
+// quotaWorkload is a rendered resource that creates pods.
+type quotaWorkload struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		quotaPodSpec
+		Replicas    *int32 `json:"replicas"`
+		Parallelism *int32 `json:"parallelism"`
+		Template    struct {
+			Spec quotaPodSpec `json:"spec"`
+		} `json:"template"`
+	} `json:"spec"`
+}
+
+// pods returns the spec and the number of the pods the workload creates.
+//
+// DaemonSets and CronJobs are not counted, as how many pods they create is not
+// known from the manifest alone.
+func (w *quotaWorkload) pods() (quotaPodSpec, int32) {
+	count := func(n *int32) int32 {
+		if n == nil {
+			return 1
+		}
+		return *n
+	}
+	switch w.Kind {
+	case "Pod":
+		return w.Spec.quotaPodSpec, 1
+	case "Deployment", "ReplicaSet", "ReplicationController", "StatefulSet":
+		return w.Spec.Template.Spec, count(w.Spec.Replicas)
+	case "Job":
+		return w.Spec.Template.Spec, count(w.Spec.Parallelism)
+	}
+	return quotaPodSpec{}, 0
+}
+
+// requests returns the resource requests of a pod. As init containers run one
+// at a time before the other containers, a pod requests the larger of the sum
+// of its containers and the largest of its init containers.
+func (p quotaPodSpec) requests() core.ResourceList {
+	total := core.ResourceList{}
+	for _, c := range p.Containers {
+		addResources(total, c.Resources.Requests)
+	}
+	for _, c := range p.InitContainers {
+		for name, q := range c.Resources.Requests {
+			if cur, ok := total[name]; !ok || q.Cmp(cur) > 0 {
+				total[name] = q.DeepCopy()
+			}
+		}
+	}
+	return total
+}
+
+func addResources(total, add core.ResourceList) {
+	for name, q := range add {
+		sum := total[name]
+		sum.Add(q)
+		total[name] = sum
+	}
+}
+
+// manifestRequests sums the resource requests of the pods created by the
+// workloads of a manifest. The number of pods is returned as the "pods"
+// resource.
+func manifestRequests(manifest string) (core.ResourceList, error) {
+	total := core.ResourceList{}
+	var pods int64
+	for _, doc := range relutil.SplitManifests(manifest) {
+		var w quotaWorkload
+		if err := yaml.Unmarshal([]byte(doc), &w); err != nil {
+			return nil, err
+		}
+		spec, n := w.pods()
+		reqs := spec.requests()
+		for i := int32(0); i < n; i++ {
+			addResources(total, reqs)
+		}
+		pods += int64(n)
+	}
+	if pods > 0 {
+		total[core.ResourcePods] = *resource.NewQuantity(pods, resource.DecimalSI)
+	}
+	return total, nil
+}
+
+// quotaShortfalls describes each resource of a ResourceQuota that has less
+// left than requested. Quotas with scopes are skipped, as whether they apply
+// depends on the pods.
+func quotaShortfalls(quota core.ResourceQuota, requested core.ResourceList) []string {
+	if len(quota.Spec.Scopes) > 0 {
+		return nil
+	}
+	hard := quota.Status.Hard
+	if len(hard) == 0 {
+		hard = quota.Spec.Hard
+	}
+	names := make([]string, 0, len(hard))
+	for name := range hard {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	var shortfalls []string
+	for _, name := range names {
+		want, ok := requested[core.ResourceName(strings.TrimPrefix(name, "requests."))]
+		if !ok {
+			continue
+		}
+		left := hard[core.ResourceName(name)]
+		left.Sub(quota.Status.Used[core.ResourceName(name)])
+		if want.Cmp(left) > 0 {
+			shortfalls = append(shortfalls, fmt.Sprintf("quota %q allows %s more %s, but the release requests %s", quota.Name, left.String(), name, want.String()))
+		}
+	}
+	return shortfalls
+}
+
+// checkQuota compares the resource requests of the workloads of a release with
+// the remaining ResourceQuota of its namespace. Insufficient quota is returned
+// as warnings, or as an error if mode is "fail". Namespaces without a quota
+// are not checked.
+//
+// The quotas are read with Tiller's clientset on purpose. A ResourceQuota
+// applies to everything created in its namespace, whoever creates it, and the
+// resources of the release are created with that same clientset, so it is
+// the quota the API server will hold them to. If Tiller may not list quotas,
+// the check is skipped with a warning, or fails if mode is "fail".
+func (s *ReleaseServer) checkQuota(mode string, r *release.Release) ([]string, error) {
+	if mode != quotaCheckWarn && mode != quotaCheckFail {
+		return nil, fmt.Errorf("invalid quota check %q: must be %q or %q", mode, quotaCheckWarn, quotaCheckFail)
+	}
+	quotas, err := s.clientset.Core().ResourceQuotas(r.Namespace).List(metav1.ListOptions{})
+	if apierrors.IsForbidden(err) && mode == quotaCheckWarn {
+		return []string{fmt.Sprintf("quota check skipped: %s", err)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("quota check: %s", err)
+	}
+	if len(quotas.Items) == 0 {
+		return nil, nil
+	}
+	requested, err := manifestRequests(r.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("quota check: %s", err)
+	}
+
+	var shortfalls []string
+	for _, q := range quotas.Items {
+		shortfalls = append(shortfalls, quotaShortfalls(q, requested)...)
+	}
+	if len(shortfalls) > 0 && mode == quotaCheckFail {
+		return nil, fmt.Errorf("insufficient quota in namespace %q: %s", r.Namespace, strings.Join(shortfalls, "; "))
+	}
+	return shortfalls, nil
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	testcore "k8s.io/client-go/testing"
+	"k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/fake"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+var quotaManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 3
+  template:
+    spec:
+      initContainers:
+      - name: migrate
+        resources:
+          requests:
+            memory: 1Gi
+      containers:
+      - name: web
+        resources:
+          requests:
+            cpu: 500m
+            memory: 128Mi
+      - name: proxy
+        resources:
+          requests:
+            cpu: 100m
+`
+
+// quotaFixture returns a release server whose "quota" namespace has a
+// ResourceQuota of hard resources, of which used are taken.
+func quotaFixture(t *testing.T, hard, used core.ResourceList) *ReleaseServer {
+	rs := rsFixture()
+	_, err := rs.clientset.Core().ResourceQuotas("quota").Create(&core.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "quota"},
+		Spec:       core.ResourceQuotaSpec{Hard: hard},
+		Status:     core.ResourceQuotaStatus{Hard: hard, Used: used},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rs
+}
+
+func quotaRequest(name, mode string) *services.InstallReleaseRequest {
+	return &services.InstallReleaseRequest{
+		Name:      name,
+		Namespace: "quota",
+		Chart: &chart.Chart{
+			Metadata:  &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{{Name: "templates/web.yaml", Data: []byte(quotaManifest)}},
+		},
+		QuotaCheck: mode,
+	}
+}
+
+func TestManifestRequests(t *testing.T) {
+	reqs, err := manifestRequests(quotaManifest + "---\nkind: Pod\nmetadata:\n  name: one\nspec:\n  containers:\n  - resources:\n      requests:\n        cpu: 200m\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each replica requests the 1Gi of its init container, which is more
+	// than the 128Mi of its containers.
+	for name, expect := range map[core.ResourceName]string{
+		core.ResourceCPU:    "2",
+		core.ResourceMemory: "3Gi",
+		core.ResourcePods:   "4",
+	} {
+		got := reqs[name]
+		if got.Cmp(resource.MustParse(expect)) != 0 {
+			t.Errorf("Expected %s request of %s, got %s", name, expect, got.String())
+		}
+	}
+}
+
+func TestInstallRelease_QuotaExceeded(t *testing.T) {
+	rs := quotaFixture(t, core.ResourceList{
+		core.ResourceRequestsCPU: resource.MustParse("4"),
+		core.ResourcePods:        resource.MustParse("10"),
+	}, core.ResourceList{
+		core.ResourceRequestsCPU: resource.MustParse("3"),
+	})
+
+	_, err := rs.InstallRelease(helm.NewContext(), quotaRequest("over-quota", quotaCheckFail))
+	if err == nil {
+		t.Fatal("Expected the install to fail on insufficient quota")
+	}
+	expect := `quota "compute" allows 1 more requests.cpu, but the release requests 1800m`
+	if !strings.Contains(err.Error(), expect) {
+		t.Errorf("Expected %q to contain %q", err, expect)
+	}
+	if _, err := rs.env.Releases.Get("over-quota", 1); err == nil {
+		t.Error("Expected no release to be stored")
+	}
+
+	res, err := rs.InstallRelease(helm.NewContext(), quotaRequest("warned", quotaCheckWarn))
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if len(res.Warnings) != 1 || res.Warnings[0] != expect {
+		t.Errorf("Expected warning %q, got %v", expect, res.Warnings)
+	}
+}
+
+func TestInstallRelease_QuotaHeadroom(t *testing.T) {
+	rs := quotaFixture(t, core.ResourceList{
+		core.ResourceRequestsCPU: resource.MustParse("4"),
+		core.ResourceMemory:      resource.MustParse("8Gi"),
+		core.ResourcePods:        resource.MustParse("10"),
+	}, core.ResourceList{
+		core.ResourceRequestsCPU: resource.MustParse("1"),
+		core.ResourceMemory:      resource.MustParse("2Gi"),
+	})
+
+	res, err := rs.InstallRelease(helm.NewContext(), quotaRequest("fits", quotaCheckFail))
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if len(res.Warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", res.Warnings)
+	}
+}
+
+func TestInstallRelease_NoQuota(t *testing.T) {
+	rs := rsFixture()
+
+	res, err := rs.InstallRelease(helm.NewContext(), quotaRequest("unlimited", quotaCheckFail))
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if len(res.Warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", res.Warnings)
+	}
+
+	if _, err := rs.InstallRelease(helm.NewContext(), quotaRequest("bogus", "maybe")); err == nil {
+		t.Error("Expected an invalid quota check to fail")
+	}
+}
+
+func TestInstallRelease_QuotaForbidden(t *testing.T) {
+	rs := rsFixture()
+	var listed []string
+	rs.clientset.(*fake.Clientset).PrependReactor("list", "resourcequotas", func(action testcore.Action) (bool, runtime.Object, error) {
+		listed = append(listed, action.GetNamespace())
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "resourcequotas"}, "", errors.New("denied"))
+	})
+
+	// Tiller's own clientset, which creates the resources, reads the quota
+	// of the namespace of the release.
+	res, err := rs.InstallRelease(helm.NewContext(), quotaRequest("unknown", quotaCheckWarn))
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if len(listed) != 1 || listed[0] != "quota" {
+		t.Errorf("Expected the quotas of namespace quota to be listed, got %v", listed)
+	}
+	if len(res.Warnings) != 1 || !strings.HasPrefix(res.Warnings[0], "quota check skipped: ") {
+		t.Errorf("Expected a warning that the check was skipped, got %v", res.Warnings)
+	}
+
+	if _, err := rs.InstallRelease(helm.NewContext(), quotaRequest("refused", quotaCheckFail)); err == nil || !strings.Contains(err.Error(), "forbidden") {
+		t.Errorf("Expected the install to fail when the quota cannot be read, got %v", err)
+	}
+}
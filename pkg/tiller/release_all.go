@@ -0,0 +1,78 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"github.com/golang/protobuf/proto"
+	ctx "golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// GetReleaseAll gets the metadata, values, manifest, hooks and notes of a
+// release in one call.
+//
+// The release is read as GetReleaseContent reads it. The values supplied by
+// the user are also coalesced with the defaults of the chart, as
+// 'helm get values --all' does.
+func (s *ReleaseServer) GetReleaseAll(c ctx.Context, req *services.GetReleaseAllRequest) (*services.GetReleaseAllResponse, error) {
+	content, err := s.GetReleaseContent(c, &services.GetReleaseContentRequest{Name: req.Name, Version: req.Version})
+	if err != nil {
+		return nil, err
+	}
+	rel := content.Release
+
+	vals, err := chartutil.CoalesceValues(rel.Chart, rel.Config)
+	if err != nil {
+		return nil, err
+	}
+	computed, err := vals.YAML()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &services.GetReleaseAllResponse{
+		Name:           rel.Name,
+		Namespace:      rel.Namespace,
+		Version:        rel.Version,
+		Chart:          rel.GetChart().GetMetadata(),
+		Config:         rel.Config,
+		ComputedValues: &chart.Config{Raw: computed},
+	}
+	if rel.Info != nil {
+		// The notes are moved out of the info, so work on a copy to leave
+		// the stored release untouched.
+		res.Info = proto.Clone(rel.Info).(*release.Info)
+		if res.Info.Status != nil {
+			res.Notes = res.Info.Status.Notes
+			res.Info.Status.Notes = ""
+		}
+	}
+	if !req.SkipManifest {
+		res.Manifest = rel.Manifest
+	}
+	if !req.SkipHooks {
+		res.Hooks = rel.Hooks
+	}
+	if req.SkipNotes {
+		res.Notes = ""
+	}
+	return res, nil
+}
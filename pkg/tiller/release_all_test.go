@@ -0,0 +1,148 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func TestGetReleaseAll(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Manifest = "kind: ConfigMap"
+	rel.Info.Status.Notes = "Release notes"
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+	rel2 := releaseStub()
+	rel2.Version = 2
+	rel2.Manifest = "kind: Secret"
+	if err := rs.env.Releases.Create(rel2); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	res, err := rs.GetReleaseAll(c, &services.GetReleaseAllRequest{Name: rel.Name, Version: 1})
+	if err != nil {
+		t.Fatalf("Error getting release: %s", err)
+	}
+
+	content, err := rs.GetReleaseContent(c, &services.GetReleaseContentRequest{Name: rel.Name, Version: 1})
+	if err != nil {
+		t.Fatalf("Error getting release content: %s", err)
+	}
+	status, err := rs.GetReleaseStatus(c, &services.GetReleaseStatusRequest{Name: rel.Name, Version: 1})
+	if err != nil {
+		t.Fatalf("Error getting release status: %s", err)
+	}
+
+	if res.Name != content.Release.Name || res.Version != 1 || res.Namespace != content.Release.Namespace {
+		t.Errorf("Expected release %s revision 1, got %s revision %d", content.Release.Name, res.Name, res.Version)
+	}
+	if !reflect.DeepEqual(res.Chart, content.Release.Chart.Metadata) {
+		t.Errorf("Expected chart %v, got %v", content.Release.Chart.Metadata, res.Chart)
+	}
+	if !reflect.DeepEqual(res.Config, content.Release.Config) {
+		t.Errorf("Expected values %v, got %v", content.Release.Config, res.Config)
+	}
+	if res.Manifest != content.Release.Manifest {
+		t.Errorf("Expected manifest %q, got %q", content.Release.Manifest, res.Manifest)
+	}
+	if !reflect.DeepEqual(res.Hooks, content.Release.Hooks) {
+		t.Errorf("Expected hooks %v, got %v", content.Release.Hooks, res.Hooks)
+	}
+	if res.Notes != status.Info.Status.Notes {
+		t.Errorf("Expected notes %q, got %q", status.Info.Status.Notes, res.Notes)
+	}
+	if res.Info.Status.Code != status.Info.Status.Code {
+		t.Errorf("Expected status %s, got %s", status.Info.Status.Code, res.Info.Status.Code)
+	}
+
+	vals, err := chartutil.CoalesceValues(content.Release.Chart, content.Release.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	computed, err := vals.YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ComputedValues.Raw != computed {
+		t.Errorf("Expected computed values %q, got %q", computed, res.ComputedValues.Raw)
+	}
+
+	// Moving the notes out of the info must not change the stored release.
+	if stored, _ := rs.env.Releases.Get(rel.Name, 1); stored.Info.Status.Notes != "Release notes" {
+		t.Errorf("Expected stored notes to be kept, got %q", stored.Info.Status.Notes)
+	}
+}
+
+func TestGetReleaseAll_Latest(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Info.Status.Code = release.Status_SUPERSEDED
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+	rel2 := releaseStub()
+	rel2.Version = 2
+	rel2.Manifest = "kind: Secret"
+	if err := rs.env.Releases.Create(rel2); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	res, err := rs.GetReleaseAll(c, &services.GetReleaseAllRequest{Name: rel.Name})
+	if err != nil {
+		t.Fatalf("Error getting release: %s", err)
+	}
+	if res.Version != 2 || res.Manifest != rel2.Manifest {
+		t.Errorf("Expected revision 2 with manifest %q, got revision %d with %q", rel2.Manifest, res.Version, res.Manifest)
+	}
+}
+
+func TestGetReleaseAll_Skip(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Manifest = "kind: ConfigMap"
+	rel.Info.Status.Notes = "Release notes"
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	res, err := rs.GetReleaseAll(c, &services.GetReleaseAllRequest{
+		Name:         rel.Name,
+		SkipManifest: true,
+		SkipHooks:    true,
+		SkipNotes:    true,
+	})
+	if err != nil {
+		t.Fatalf("Error getting release: %s", err)
+	}
+	if res.Manifest != "" || len(res.Hooks) != 0 || res.Notes != "" {
+		t.Errorf("Expected manifest, hooks and notes to be skipped, got %v", res)
+	}
+	if res.Config.Raw != rel.Config.Raw {
+		t.Errorf("Expected values %q, got %q", rel.Config.Raw, res.Config.Raw)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package tiller
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -32,8 +33,20 @@ import (
 
 // InstallRelease installs a release and stores the release record.
 func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallReleaseRequest) (*services.InstallReleaseResponse, error) {
+	return s.installRelease(c, req, nil)
+}
+
+// installRelease installs a release, reporting the steps of the install to
+// progress.
+func (s *ReleaseServer) installRelease(c ctx.Context, req *services.InstallReleaseRequest, progress installProgress) (*services.InstallReleaseResponse, error) {
+	unlock, err := s.lockRelease(req.Name, req.Timeout, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	s.Log("preparing install for %s", req.Name)
-	rel, err := s.prepareRelease(req)
+	rel, warnings, err := s.prepareRelease(req)
 	if err != nil {
 		s.Log("failed install prepare step: %s", err)
 		res := &services.InstallReleaseResponse{Release: rel}
@@ -46,31 +59,77 @@ func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallRelea
 		return res, err
 	}
 
+	progress.report(progressRendered, "rendered %d resource(s) and %d hook(s)", len(manifestResources(rel.Manifest)), len(rel.Hooks))
+
+	var preflight []string
+	if req.QuotaCheck != "" {
+		if preflight, err = s.checkQuota(req.QuotaCheck, rel); err != nil {
+			s.Log("failed install quota check: %s", err)
+			return &services.InstallReleaseResponse{Release: rel}, err
+		}
+		for _, w := range preflight {
+			s.Log("warning: %s", w)
+		}
+	}
+	if req.ApiCheck != "" {
+		deprecated, err := s.checkAPIs(req.ApiCheck, rel)
+		if err != nil {
+			s.Log("failed install API check: %s", err)
+			return &services.InstallReleaseResponse{Release: rel}, err
+		}
+		for _, w := range deprecated {
+			s.Log("warning: %s", w)
+		}
+		preflight = append(preflight, deprecated...)
+	}
+
 	s.Log("performing install for %s", req.Name)
-	res, err := s.performRelease(rel, req)
+	res, err := s.performRelease(c, rel, req, progress)
 	if err != nil {
 		s.Log("failed install perform step: %s", err)
 	}
+	if res != nil {
+		res.Warnings = preflight
+	}
+	if req.DryRun && res != nil {
+		res.HookPlan = newHookPlan(rel.Hooks, warnings, release.Hook_PRE_INSTALL, release.Hook_POST_INSTALL)
+	}
 	return res, err
 }
 
-// prepareRelease builds a release for an install operation.
-func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*release.Release, error) {
+// prepareRelease builds a release for an install operation. It also returns
+// warnings about malformed hook annotations of the chart.
+func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*release.Release, []string, error) {
 	if req.Chart == nil {
-		return nil, errMissingChart
+		return nil, nil, errMissingChart
+	}
+
+	if req.ValuesFrom != "" {
+		vals, err := s.mergeValuesFrom(req.ValuesFrom, req.Namespace, req.Values)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Values = vals
 	}
 
 	name, err := s.uniqName(req.Name, req.ReuseName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	caps, err := capabilities(s.clientset.Discovery())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	// A reused name continues the revision history of the release it replaces.
 	revision := 1
+	if req.ReuseName {
+		if h, err := s.env.Releases.History(name); err == nil && len(h) > 0 {
+			relutil.Reverse(h, relutil.SortByRevision)
+			revision = int(h[0].Version) + 1
+		}
+	}
 	ts := timeconv.Now()
 	options := chartutil.ReleaseOptions{
 		Name:      name,
@@ -81,10 +140,30 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 	}
 	valuesToRender, err := chartutil.ToRenderValuesCaps(req.Chart, req.Values, options, caps)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	hooks, manifestDoc, notesTxt, err := s.renderResources(req.Chart, valuesToRender, caps.APIVersions)
+	transformers := []ManifestTransformer{releaseLabels(name, revision)}
+	if req.CommonLabels {
+		transformers = append(transformers, commonLabels(name, req.Chart.Metadata))
+	}
+	patches, err := parseResourcePatches(req.Patches)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(patches) > 0 {
+		transformers = append(transformers, patches)
+	}
+
+	opts := renderOptions{
+		subchartNotes: req.RenderSubchartNotes,
+		failOnEmpty:   req.FailOnEmpty,
+		orderedApply:  req.OrderedApply,
+	}
+	hooks, manifestDoc, notesTxt, warnings, err := s.renderResources(req.Chart, valuesToRender, opts, caps.APIVersions, transformers...)
+	if err == nil {
+		err = patches.unmatched()
+	}
 	if err != nil {
 		// Return a release with partial data so that client can show debugging
 		// information.
@@ -104,7 +183,14 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 		if manifestDoc != nil {
 			rel.Manifest = manifestDoc.String()
 		}
-		return rel, err
+		return rel, nil, err
+	}
+
+	manifest := manifestDoc.String()
+	crds, rest := splitCRDs(manifest)
+	if req.SkipCrds && crds != "" {
+		s.Log("skipping CustomResourceDefinitions of %s", name)
+		manifest, crds = rest, ""
 	}
 
 	// Store a release.
@@ -118,8 +204,9 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 			LastDeployed:  ts,
 			Status:        &release.Status{Code: release.Status_PENDING_INSTALL},
 			Description:   "Initial install underway", // Will be overwritten.
+			BuildInfo:     req.BuildInfo,
 		},
-		Manifest: manifestDoc.String(),
+		Manifest: manifest,
 		Hooks:    hooks,
 		Version:  int32(revision),
 	}
@@ -127,12 +214,18 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 		rel.Info.Status.Notes = notesTxt
 	}
 
-	err = validateManifest(s.env.KubeClient, req.Namespace, manifestDoc.Bytes())
-	return rel, err
+	// Custom resources cannot be built before their definitions are
+	// established, so when the chart defines CRDs only those are validated
+	// here. The rest of the manifest is validated as it is created.
+	if crds != "" {
+		manifest = crds
+	}
+	err = validateManifest(s.env.KubeClient, req.Namespace, []byte(manifest))
+	return rel, warnings, err
 }
 
 // performRelease runs a release.
-func (s *ReleaseServer) performRelease(r *release.Release, req *services.InstallReleaseRequest) (*services.InstallReleaseResponse, error) {
+func (s *ReleaseServer) performRelease(c ctx.Context, r *release.Release, req *services.InstallReleaseRequest, progress installProgress) (*services.InstallReleaseResponse, error) {
 	res := &services.InstallReleaseResponse{Release: r}
 
 	if req.DryRun {
@@ -141,9 +234,20 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 		return res, nil
 	}
 
+	// When a name is reused, the release being replaced and its original
+	// status are kept so that a failed atomic install can restore them.
+	var (
+		replaced       *release.Release
+		replacedStatus release.Status_Code
+	)
+
 	// pre-install hooks
 	if !req.DisableHooks {
-		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.PreInstall, req.Timeout); err != nil {
+		progress.reportHooks(progressPreInstall, r.Hooks, release.Hook_PRE_INSTALL)
+		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.PreInstall, req.Timeout, false); err != nil {
+			if req.Atomic {
+				s.cleanupFailedInstall(r, req, replaced, replacedStatus)
+			}
 			return res, err
 		}
 	} else {
@@ -159,6 +263,7 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 
 		// old release
 		old := h[0]
+		replaced, replacedStatus = old, old.Info.Status.Code
 
 		// update old release status
 		old.Info.Status.Code = release.Status_SUPERSEDED
@@ -173,7 +278,11 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 			Timeout:  req.Timeout,
 		}
 		s.recordRelease(r, false)
-		if err := s.ReleaseModule.Update(old, r, updateReq, s.env); err != nil {
+		err := s.ReleaseModule.Update(old, r, updateReq, progress.applyEnvironment(s.env))
+		if err == nil {
+			err = s.settle(c, r.Namespace, r.Manifest, req.Wait, req.PostWaitSettle)
+		}
+		if err != nil {
 			msg := fmt.Sprintf("Release replace %q failed: %s", r.Name, err)
 			s.Log("warning: %s", msg)
 			old.Info.Status.Code = release.Status_SUPERSEDED
@@ -181,6 +290,9 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 			r.Info.Description = msg
 			s.recordRelease(old, true)
 			s.recordRelease(r, true)
+			if req.Atomic {
+				s.cleanupFailedInstall(r, req, replaced, replacedStatus)
+			}
 			return res, err
 		}
 
@@ -188,30 +300,44 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 		// nothing to replace, create as normal
 		// regular manifests
 		s.recordRelease(r, false)
-		if err := s.ReleaseModule.Create(r, req, s.env); err != nil {
+		err := s.ReleaseModule.Create(r, req, progress.applyEnvironment(s.env))
+		if err == nil {
+			err = s.settle(c, r.Namespace, r.Manifest, req.Wait, req.PostWaitSettle)
+		}
+		if err != nil {
 			msg := fmt.Sprintf("Release %q failed: %s", r.Name, err)
 			s.Log("warning: %s", msg)
 			r.Info.Status.Code = release.Status_FAILED
 			r.Info.Description = msg
 			s.recordRelease(r, true)
+			if req.Atomic {
+				s.cleanupFailedInstall(r, req, replaced, replacedStatus)
+			}
 			return res, fmt.Errorf("release %s failed: %s", r.Name, err)
 		}
 	}
 
 	// post-install hooks
 	if !req.DisableHooks {
-		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.PostInstall, req.Timeout); err != nil {
+		progress.reportHooks(progressPostInstall, r.Hooks, release.Hook_POST_INSTALL)
+		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.PostInstall, req.Timeout, false); err != nil {
 			msg := fmt.Sprintf("Release %q failed post-install: %s", r.Name, err)
 			s.Log("warning: %s", msg)
 			r.Info.Status.Code = release.Status_FAILED
 			r.Info.Description = msg
 			s.recordRelease(r, true)
+			if req.Atomic {
+				s.cleanupFailedInstall(r, req, replaced, replacedStatus)
+			}
 			return res, err
 		}
 	}
 
 	r.Info.Status.Code = release.Status_DEPLOYED
 	r.Info.Description = "Install complete"
+	if req.Description != "" {
+		r.Info.Description = req.Description
+	}
 	// This is a tricky case. The release has been created, but the result
 	// cannot be recorded. The truest thing to tell the user is that the
 	// release was created. However, the user will not be able to do anything
@@ -223,3 +349,53 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 
 	return res, nil
 }
+
+// cleanupFailedInstall undoes a failed atomic install.
+//
+// The release's resources are deleted, along with the resources of any install
+// hooks whose delete policy is hook-failed or before-hook-creation. The release
+// record is then purged. If the install replaced an earlier release, that
+// release's status is restored.
+func (s *ReleaseServer) cleanupFailedInstall(r *release.Release, req *services.InstallReleaseRequest, replaced *release.Release, replacedStatus release.Status_Code) {
+	s.Log("atomic install of %s failed, cleaning up", r.Name)
+	kubeCli := s.env.KubeClient
+
+	if err := kubeCli.Delete(r.Namespace, bytes.NewBufferString(r.Manifest)); err != nil {
+		s.Log("warning: failed to delete resources of release %s: %s", r.Name, err)
+	}
+
+	if !req.DisableHooks {
+		for _, h := range r.Hooks {
+			if !isInstallHook(h) {
+				continue
+			}
+			for _, policy := range []string{hooks.HookFailed, hooks.BeforeHookCreation} {
+				if hookShouldBeDeleted(h, policy) {
+					s.deleteHookByPolicy(h, policy, r.Name, r.Namespace, "install", kubeCli)
+					break
+				}
+			}
+		}
+	}
+
+	if _, err := s.env.Releases.Get(r.Name, r.Version); err == nil {
+		if err := s.purgeReleases(r); err != nil {
+			s.Log("warning: failed to purge release %s: %s", r.Name, err)
+		}
+	}
+
+	if replaced != nil {
+		replaced.Info.Status.Code = replacedStatus
+		s.recordRelease(replaced, true)
+	}
+}
+
+// isInstallHook reports whether a hook runs before or after an install.
+func isInstallHook(h *release.Hook) bool {
+	for _, e := range h.Events {
+		if e == release.Hook_PRE_INSTALL || e == release.Hook_POST_INSTALL {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	relutil "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+// The stages of an install reported by InstallReleaseStream.
+const (
+	progressRendered    = "rendered"
+	progressPreInstall  = "pre-install"
+	progressApplying    = "applying"
+	progressWaiting     = "waiting"
+	progressPostInstall = "post-install"
+	progressDone        = "done"
+)
+
+// installProgress receives the steps of an install as they start. A nil
+// installProgress ignores them.
+type installProgress func(stage, message string)
+
+func (p installProgress) report(stage, format string, args ...interface{}) {
+	if p != nil {
+		p(stage, fmt.Sprintf(format, args...))
+	}
+}
+
+// reportHooks reports the hooks of a release run for event.
+func (p installProgress) reportHooks(stage string, hooks []*release.Hook, event release.Hook_Event) {
+	n := 0
+	for _, h := range hooks {
+		for _, e := range h.Events {
+			if e == event {
+				n++
+				break
+			}
+		}
+	}
+	p.report(stage, "running %d %s hook(s)", n, stage)
+}
+
+// applyEnvironment returns env with a KubeClient that reports each resource
+// as it is applied and, if the install waits, the wait for them to be ready.
+func (p installProgress) applyEnvironment(env *environment.Environment) *environment.Environment {
+	if p == nil {
+		return env
+	}
+	e := *env
+	e.KubeClient = &progressKubeClient{KubeClient: env.KubeClient, progress: p}
+	return &e
+}
+
+// progressKubeClient is a KubeClient that reports the resources it creates
+// and updates to progress. Resources applied by Rudder are not reported.
+type progressKubeClient struct {
+	environment.KubeClient
+	progress installProgress
+}
+
+func (k *progressKubeClient) applying(kind, name string) {
+	k.progress.report(progressApplying, "%s %q", kind, name)
+}
+
+func (k *progressKubeClient) waiting(timeout int64) func() {
+	return func() {
+		k.progress.report(progressWaiting, "waiting up to %ds for resources to be ready", timeout)
+	}
+}
+
+func (k *progressKubeClient) Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	return k.CreateWithOptions(namespace, reader, kube.CreateOptions{Timeout: timeout, ShouldWait: shouldWait})
+}
+
+func (k *progressKubeClient) CreateAll(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	return k.CreateWithOptions(namespace, reader, kube.CreateOptions{Timeout: timeout, ShouldWait: shouldWait, ContinueOnError: true})
+}
+
+func (k *progressKubeClient) CreateWithOptions(namespace string, reader io.Reader, opts kube.CreateOptions) error {
+	opts.Applying = k.applying
+	opts.Waiting = k.waiting(opts.Timeout)
+	return k.KubeClient.CreateWithOptions(namespace, reader, opts)
+}
+
+func (k *progressKubeClient) Update(namespace string, originalReader, targetReader io.Reader, force, recreate bool, timeout int64, shouldWait bool) error {
+	return k.UpdateWithOptions(namespace, originalReader, targetReader, kube.UpdateOptions{
+		Force:      force,
+		Recreate:   recreate,
+		Timeout:    timeout,
+		ShouldWait: shouldWait,
+	})
+}
+
+func (k *progressKubeClient) UpdateWithOptions(namespace string, originalReader, targetReader io.Reader, opts kube.UpdateOptions) error {
+	opts.Applying = k.applying
+	opts.Waiting = k.waiting(opts.Timeout)
+	return k.KubeClient.UpdateWithOptions(namespace, originalReader, targetReader, opts)
+}
+
+// manifestResources returns the resources of a manifest in the order they
+// appear in it. Documents that are not resources are skipped.
+func manifestResources(manifest string) []apiResource {
+	var resources []apiResource
+	for _, doc := range relutil.SplitManifestsWithSource(manifest) {
+		var r apiResource
+		if err := yaml.Unmarshal([]byte(doc.Content), &r); err != nil || r.Kind == "" {
+			continue
+		}
+		resources = append(resources, r)
+	}
+	return resources
+}
+
+// InstallReleaseStream installs a release like InstallRelease, sending an
+// event to the stream as each step of the install starts. The last event
+// carries the response. When the release is applied by Rudder, there are no
+// events for the resources being applied or waited for.
+func (s *ReleaseServer) InstallReleaseStream(req *services.InstallReleaseRequest, stream services.ReleaseService_InstallReleaseStreamServer) error {
+	var sendErr error
+	progress := func(stage, message string) {
+		if sendErr == nil {
+			sendErr = stream.Send(&services.InstallReleaseEvent{Stage: stage, Message: message})
+		}
+	}
+	res, err := s.installRelease(stream.Context(), req, progress)
+	if res != nil && res.Release != nil {
+		done := &services.InstallReleaseEvent{Stage: progressDone, Response: res}
+		if res.Release.Info != nil {
+			done.Message = res.Release.Info.Description
+		}
+		if serr := stream.Send(done); serr != nil && err == nil {
+			err = serr
+		}
+	}
+	if err == nil && sendErr != nil {
+		s.Log("warning: sending install progress: %s", sendErr)
+	}
+	return err
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+// applyingKubeClient reports each resource of a manifest to the callbacks of
+// CreateWithOptions as it creates it. It fails to create the resource named
+// fail.
+type applyingKubeClient struct {
+	environment.PrintingKubeClient
+	fail string
+}
+
+func (k *applyingKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	for _, res := range manifestResources(string(b)) {
+		if opts.Applying != nil {
+			opts.Applying(res.Kind, res.Metadata.Name)
+		}
+		if res.Metadata.Name == k.fail {
+			return fmt.Errorf("failed to create %s %q", res.Kind, res.Metadata.Name)
+		}
+	}
+	if opts.ShouldWait && opts.Waiting != nil {
+		opts.Waiting()
+	}
+	return nil
+}
+
+func TestInstallReleaseStream(t *testing.T) {
+	rs := rsFixture()
+	rs.env.KubeClient = &applyingKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+	stream := &mockInstallStreamServer{}
+
+	req := &services.InstallReleaseRequest{
+		Namespace: "spaced",
+		Wait:      true,
+		Timeout:   30,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/configmap", Data: []byte("kind: ConfigMap\nmetadata:\n  name: first\n")},
+				{Name: "templates/secret", Data: []byte("kind: Secret\nmetadata:\n  name: second\n")},
+				{Name: "templates/hooks", Data: []byte(manifestWithHook)},
+			},
+		},
+	}
+	if err := rs.InstallReleaseStream(req, stream); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	var stages, messages []string
+	for _, e := range stream.events {
+		stages = append(stages, e.Stage)
+		messages = append(messages, e.Message)
+	}
+	expectStages := []string{"rendered", "pre-install", "applying", "applying", "waiting", "post-install", "done"}
+	if !reflect.DeepEqual(stages, expectStages) {
+		t.Fatalf("Expected stages %v, got %v", expectStages, stages)
+	}
+	expectMessages := []string{
+		"rendered 2 resource(s) and 1 hook(s)",
+		"running 0 pre-install hook(s)",
+		`Secret "second"`,
+		`ConfigMap "first"`,
+		"waiting up to 30s for resources to be ready",
+		"running 1 post-install hook(s)",
+		"Install complete",
+	}
+	if !reflect.DeepEqual(messages, expectMessages) {
+		t.Errorf("Expected messages %q, got %q", expectMessages, messages)
+	}
+
+	for _, e := range stream.events[:len(stream.events)-1] {
+		if e.Response != nil {
+			t.Errorf("Expected no response with %q event", e.Stage)
+		}
+	}
+	done := stream.events[len(stream.events)-1]
+	if done.Response == nil || done.Response.Release == nil {
+		t.Fatal("Expected the release with the done event")
+	}
+	if _, err := rs.env.Releases.Get(done.Response.Release.Name, done.Response.Release.Version); err != nil {
+		t.Errorf("Expected release %s to be stored: %s", done.Response.Release.Name, err)
+	}
+}
+
+func TestInstallReleaseStream_Failure(t *testing.T) {
+	rs := rsFixture()
+	rs.env.KubeClient = newHookFailingKubeClient()
+	stream := &mockInstallStreamServer{}
+
+	req := &services.InstallReleaseRequest{Chart: chartStub()}
+	if err := rs.InstallReleaseStream(req, stream); err == nil {
+		t.Fatal("Expected failed install")
+	}
+	last := stream.events[len(stream.events)-1]
+	if last.Stage != "done" || last.Response == nil {
+		t.Fatalf("Expected a done event with the failed release, got %v", last)
+	}
+	if code := last.Response.Release.Info.Status.Code; code != release.Status_FAILED {
+		t.Errorf("Expected FAILED release, got %s", code)
+	}
+}
+
+func TestInstallReleaseStream_ApplyFailure(t *testing.T) {
+	rs := rsFixture()
+	rs.env.KubeClient = &applyingKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}, fail: "second"}
+	stream := &mockInstallStreamServer{}
+
+	req := &services.InstallReleaseRequest{
+		Namespace: "spaced",
+		Wait:      true,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/configmap", Data: []byte("kind: ConfigMap\nmetadata:\n  name: first\n")},
+				{Name: "templates/secret", Data: []byte("kind: Secret\nmetadata:\n  name: second\n")},
+			},
+		},
+	}
+	if err := rs.InstallReleaseStream(req, stream); err == nil {
+		t.Fatal("Expected failed install")
+	}
+
+	// The Secret is applied first, and the ConfigMap after it never is.
+	var stages []string
+	for _, e := range stream.events {
+		stages = append(stages, e.Stage)
+	}
+	expectStages := []string{"rendered", "pre-install", "applying", "done"}
+	if !reflect.DeepEqual(stages, expectStages) {
+		t.Fatalf("Expected stages %v, got %v", expectStages, stages)
+	}
+	if msg := stream.events[2].Message; msg != `Secret "second"` {
+		t.Errorf("Expected the failed resource to be reported, got %q", msg)
+	}
+}
@@ -18,13 +18,20 @@ package tiller
 
 import (
 	"fmt"
+	"io/ioutil"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
 	"k8s.io/helm/pkg/version"
 )
 
@@ -331,6 +338,52 @@ func TestInstallRelease_WithChartAndDependencyNotes(t *testing.T) {
 	}
 }
 
+func TestInstallRelease_RenderSubchartNotes(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	subchart := func(name string) *chart.Chart {
+		return &chart.Chart{
+			Metadata: &chart.Metadata{Name: name},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+				{Name: "templates/NOTES.txt", Data: []byte(name + " notes for {{ .Release.Name }}")},
+			},
+		}
+	}
+	req := &services.InstallReleaseRequest{
+		Namespace: "spaced",
+		Name:      "subnotes",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+				{Name: "templates/NOTES.txt", Data: []byte(notesText + "\n")},
+			},
+			Files: []*any.Any{
+				{TypeUrl: "requirements.yaml", Value: []byte("dependencies:\n- name: first\n  condition: first.enabled\n- name: second\n  condition: second.enabled\n")},
+			},
+			Dependencies: []*chart.Chart{subchart("first"), subchart("second")},
+		},
+		Values:              &chart.Config{Raw: "second:\n  enabled: false\n"},
+		RenderSubchartNotes: true,
+	}
+	// The client leaves disabled subcharts out of the request.
+	if err := chartutil.ProcessRequirementsEnabled(req.Chart, req.Values); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	expect := notesText + "\n\nfirst NOTES:\nfirst notes for subnotes"
+	if got := res.Release.Info.Status.Notes; got != expect {
+		t.Errorf("Expected notes %q, got %q", expect, got)
+	}
+}
+
 func TestInstallRelease_DryRun(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -384,6 +437,87 @@ func TestInstallRelease_DryRun(t *testing.T) {
 	}
 }
 
+func TestInstallRelease_DryRunHookPlan(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	hook := func(name, events, weight string) []byte {
+		return []byte(fmt.Sprintf(`kind: Job
+metadata:
+  name: %s
+  annotations:
+    "helm.sh/hook": %s
+    "helm.sh/hook-weight": %q
+    "helm.sh/hook-delete-policy": hook-succeeded
+`, name, events, weight))
+	}
+	ch := chartStub()
+	ch.Templates = []*chart.Template{
+		{Name: "templates/hello", Data: []byte("hello: world")},
+		{Name: "templates/migrate", Data: hook("migrate", "pre-install", "5")},
+		{Name: "templates/backup", Data: hook("backup", "pre-install", "-5")},
+		{Name: "templates/notify", Data: hook("notify", "post-install", "heavy")},
+		{Name: "templates/smoke", Data: hook("smoke", "post-install", "-1")},
+		{Name: "templates/typo", Data: hook("typo", "pre-instal", "0")},
+	}
+
+	req := &services.InstallReleaseRequest{
+		Chart:  ch,
+		DryRun: true,
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	plan := res.HookPlan
+	if plan == nil {
+		t.Fatal("Expected a hook plan")
+	}
+
+	expect := []struct {
+		event release.Hook_Event
+		name  string
+	}{
+		{release.Hook_PRE_INSTALL, "backup"},
+		{release.Hook_PRE_INSTALL, "migrate"},
+		{release.Hook_POST_INSTALL, "smoke"},
+		{release.Hook_POST_INSTALL, "notify"},
+	}
+	if len(plan.Steps) != len(expect) {
+		t.Fatalf("Expected %d steps, got %v", len(expect), plan.Steps)
+	}
+	for i, e := range expect {
+		step := plan.Steps[i]
+		if step.Event != e.event || step.Name != e.name {
+			t.Errorf("Expected %s %s at %d, got %s %s", e.event, e.name, i, step.Event, step.Name)
+		}
+		if len(step.DeletePolicies) != 1 || step.DeletePolicies[0] != release.Hook_SUCCEEDED {
+			t.Errorf("Expected %s to be deleted on success, got %v", step.Name, step.DeletePolicies)
+		}
+	}
+
+	warnings := []string{
+		`hello/templates/notify: invalid hook weight "heavy", using 0`,
+		`hello/templates/typo: unknown hook "pre-instal", the resource is skipped`,
+	}
+	if !reflect.DeepEqual(plan.Warnings, warnings) {
+		t.Errorf("Expected warnings %q, got %q", warnings, plan.Warnings)
+	}
+}
+
+func TestInstallRelease_HookPlanOnlyOnDryRun(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	res, err := rs.InstallRelease(c, &services.InstallReleaseRequest{Chart: chartStub()})
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if res.HookPlan != nil {
+		t.Errorf("Expected no hook plan, got %v", res.HookPlan)
+	}
+}
+
 func TestInstallRelease_NoHooks(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -422,6 +556,256 @@ func TestInstallRelease_FailedHooks(t *testing.T) {
 	}
 }
 
+func TestInstallRelease_AtomicCleansUpHooks(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := newResourceTrackingKubeClient("post-job")
+	rs.env.KubeClient = kubeClient
+
+	ch := chartStub()
+	ch.Templates = []*chart.Template{
+		{Name: "templates/hello", Data: []byte("hello: world")},
+		{Name: "templates/pre-failed", Data: []byte(`kind: ConfigMap
+metadata:
+  name: pre-cm-failed
+  annotations:
+    "helm.sh/hook": pre-install
+    "helm.sh/hook-delete-policy": hook-failed
+`)},
+		{Name: "templates/pre-before", Data: []byte(`kind: ConfigMap
+metadata:
+  name: pre-cm-before
+  annotations:
+    "helm.sh/hook": pre-install
+    "helm.sh/hook-delete-policy": before-hook-creation
+`)},
+		{Name: "templates/post-job", Data: []byte(`kind: Job
+metadata:
+  name: post-job
+  annotations:
+    "helm.sh/hook": post-install
+    "helm.sh/hook-delete-policy": hook-failed
+`)},
+	}
+
+	req := &services.InstallReleaseRequest{
+		Chart:  ch,
+		Name:   "atomic-install",
+		Atomic: true,
+	}
+	if _, err := rs.InstallRelease(c, req); err == nil {
+		t.Fatal("Expected failed install")
+	}
+
+	if len(kubeClient.resources) != 0 {
+		t.Errorf("Expected no resources after atomic install, got %d:\n%v", len(kubeClient.resources), kubeClient.resources)
+	}
+
+	if h, err := rs.env.Releases.History(req.Name); err == nil && len(h) > 0 {
+		t.Errorf("Expected release to be purged, got %d revisions", len(h))
+	}
+}
+
+func crdChart() *chart.Chart {
+	ch := chartStub()
+	ch.Templates = []*chart.Template{
+		{Name: "templates/crontab", Data: []byte("apiVersion: stable.example.com/v1\nkind: CronTab\nmetadata:\n  name: my-crontab\n")},
+		{Name: "templates/crd", Data: []byte("apiVersion: apiextensions.k8s.io/v1beta1\nkind: CustomResourceDefinition\nmetadata:\n  name: crontabs.stable.example.com\n")},
+		{Name: "templates/configmap", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n")},
+	}
+	return ch
+}
+
+func TestInstallRelease_CRDsFirst(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := &crdTrackingKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+	rs.env.KubeClient = kubeClient
+
+	req := &services.InstallReleaseRequest{
+		Chart: crdChart(),
+		Name:  "crd-install",
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	expect := []string{
+		"create CustomResourceDefinition",
+		"wait CustomResourceDefinition",
+		"create ConfigMap,CronTab",
+	}
+	if !reflect.DeepEqual(kubeClient.calls, expect) {
+		t.Errorf("Expected calls %v, got %v", expect, kubeClient.calls)
+	}
+	// The CronTab is retried while its definition is not served yet.
+	if len(kubeClient.crds) != 2 || !strings.Contains(kubeClient.crds[1], "name: crontabs.stable.example.com") {
+		t.Errorf("Expected the CRDs of the release with the rest of it, got %q", kubeClient.crds)
+	}
+	if !strings.Contains(res.Release.Manifest, "kind: CustomResourceDefinition") {
+		t.Errorf("Expected CRD in release manifest, got %q", res.Release.Manifest)
+	}
+}
+
+func TestInstallRelease_ContinueOnError(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := &crdTrackingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+		failOn:             "kind: CronTab",
+	}
+	rs.env.KubeClient = kubeClient
+
+	req := &services.InstallReleaseRequest{
+		Chart:           crdChart(),
+		Name:            "crd-continue",
+		ContinueOnError: true,
+	}
+	_, err := rs.InstallRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), `CronTab "my-crontab": admission denied`) {
+		t.Fatalf("Expected the failed resource to be reported, got %v", err)
+	}
+
+	expect := []string{
+		"create all CustomResourceDefinition",
+		"wait CustomResourceDefinition",
+		"create all ConfigMap,CronTab",
+	}
+	if !reflect.DeepEqual(kubeClient.calls, expect) {
+		t.Errorf("Expected calls %v, got %v", expect, kubeClient.calls)
+	}
+
+	rel, err := rs.env.Releases.Get(req.Name, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Info.Status.Code != release.Status_FAILED {
+		t.Errorf("Expected release to be FAILED, got %s", rel.Info.Status.Code)
+	}
+}
+
+func TestInstallRelease_ContinueOnErrorCRDFailure(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := &crdTrackingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+		failOn:             "kind: CustomResourceDefinition",
+	}
+	rs.env.KubeClient = kubeClient
+
+	req := &services.InstallReleaseRequest{
+		Chart:           crdChart(),
+		Name:            "crd-continue",
+		ContinueOnError: true,
+	}
+	if _, err := rs.InstallRelease(c, req); err == nil {
+		t.Fatal("Expected failed install")
+	}
+
+	expect := []string{"create all CustomResourceDefinition"}
+	if !reflect.DeepEqual(kubeClient.calls, expect) {
+		t.Errorf("Expected a failed CRD to stop the rest of the release, got calls %v", kubeClient.calls)
+	}
+}
+
+func TestInstallRelease_TakeOwnership(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.KubeClient = &ownershipKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+
+	req := &services.InstallReleaseRequest{
+		Chart:        chartStub(),
+		Name:         "mine",
+		DisableHooks: true,
+	}
+	_, err := rs.InstallRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), `ConfigMap "shared" is owned by release "other"`) {
+		t.Fatalf("Expected an ownership conflict, got %v", err)
+	}
+
+	req.ReuseName = true
+	req.TakeOwnership = true
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Expected the install to take ownership, got %s", err)
+	}
+	if res.Release.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("Expected release to be DEPLOYED, got %s", res.Release.Info.Status.Code)
+	}
+}
+
+func TestInstallRelease_SkipCRDs(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := &crdTrackingKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+	rs.env.KubeClient = kubeClient
+
+	req := &services.InstallReleaseRequest{
+		Chart:    crdChart(),
+		Name:     "crd-skip",
+		SkipCrds: true,
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	expect := []string{"create ConfigMap,CronTab"}
+	if !reflect.DeepEqual(kubeClient.calls, expect) {
+		t.Errorf("Expected calls %v, got %v", expect, kubeClient.calls)
+	}
+	if strings.Contains(res.Release.Manifest, "CustomResourceDefinition") {
+		t.Errorf("Expected no CRD in release manifest, got %q", res.Release.Manifest)
+	}
+}
+
+func TestInstallRelease_CRDsDir(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := &crdTrackingKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+	rs.env.KubeClient = kubeClient
+
+	ch := chartStub()
+	ch.Templates = []*chart.Template{
+		{Name: "templates/crontab", Data: []byte("apiVersion: stable.example.com/v1\nkind: CronTab\nmetadata:\n  name: my-crontab\n")},
+	}
+	ch.Files = []*any.Any{
+		{TypeUrl: "crds/crontab.yaml", Value: []byte("apiVersion: apiextensions.k8s.io/v1beta1\nkind: CustomResourceDefinition\nmetadata:\n  name: crontabs.stable.example.com\n  annotations:\n    note: \"{{ .Release.Name }}\"\n")},
+	}
+	sub := &chart.Chart{Metadata: &chart.Metadata{Name: "sub"}}
+	sub.Files = []*any.Any{
+		{TypeUrl: "crds/shell.yaml", Value: []byte("apiVersion: apiextensions.k8s.io/v1beta1\nkind: CustomResourceDefinition\nmetadata:\n  name: shells.stable.example.com\n")},
+	}
+	ch.Dependencies = []*chart.Chart{sub}
+
+	req := &services.InstallReleaseRequest{
+		Chart: ch,
+		Name:  "crd-dir",
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	expect := []string{
+		"create CustomResourceDefinition,CustomResourceDefinition",
+		"wait CustomResourceDefinition,CustomResourceDefinition",
+		"create CronTab",
+	}
+	if !reflect.DeepEqual(kubeClient.calls, expect) {
+		t.Errorf("Expected calls %v, got %v", expect, kubeClient.calls)
+	}
+	for _, source := range []string{"hello/crds/crontab.yaml", "hello/charts/sub/crds/shell.yaml"} {
+		if !strings.Contains(res.Release.Manifest, "# Source: "+source) {
+			t.Errorf("Expected %s in release manifest, got %q", source, res.Release.Manifest)
+		}
+	}
+	if !strings.Contains(res.Release.Manifest, `note: "{{ .Release.Name }}"`) {
+		t.Errorf("Expected CRD to be unrendered, got %q", res.Release.Manifest)
+	}
+}
+
 func TestInstallRelease_ReuseName(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -453,6 +837,76 @@ func TestInstallRelease_ReuseName(t *testing.T) {
 	}
 }
 
+func TestInstallRelease_ReuseNameRevision(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Info.Status.Code = release.Status_DELETED
+	rs.env.Releases.Create(rel)
+
+	ch := chartStub()
+	ch.Templates = append(ch.Templates, &chart.Template{
+		Name: "templates/revision",
+		Data: []byte("kind: ConfigMap\nmetadata:\n  name: revision\ndata:\n  revision: \"{{ .Release.Revision }}\"\n"),
+	})
+	req := &services.InstallReleaseRequest{
+		Chart:     ch,
+		ReuseName: true,
+		Name:      rel.Name,
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	if res.Release.Version != rel.Version+1 {
+		t.Errorf("Expected revision %d, got %d", rel.Version+1, res.Release.Version)
+	}
+	if !strings.Contains(res.Release.Manifest, `revision: "2"`) {
+		t.Errorf("Expected the manifest to be rendered with revision 2, got %q", res.Release.Manifest)
+	}
+
+	h, err := rs.env.Releases.History(rel.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h) != 2 {
+		t.Fatalf("Expected 2 revisions in the history, got %d", len(h))
+	}
+	old, err := rs.env.Releases.Get(rel.Name, rel.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if old.Info.Status.Code != release.Status_SUPERSEDED {
+		t.Errorf("Expected the deleted release to be superseded, got %s", old.Info.Status.Code)
+	}
+}
+
+func TestInstallRelease_ReuseNameDeployed(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	req := &services.InstallReleaseRequest{
+		Chart:     chartStub(),
+		ReuseName: true,
+		Name:      rel.Name,
+	}
+	_, err := rs.InstallRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), "cannot re-use a name that is still in use") {
+		t.Fatalf("Expected an error reusing the name of a deployed release, got %v", err)
+	}
+
+	h, err := rs.env.Releases.History(rel.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h) != 1 || h[0].Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("Expected the deployed release to be left alone, got %v", h)
+	}
+}
+
 func TestInstallRelease_KubeVersion(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -498,3 +952,149 @@ func TestInstallRelease_WrongKubeVersion(t *testing.T) {
 		t.Errorf("Expected %q to contain %q", err.Error(), expect)
 	}
 }
+
+func TestInstallRelease_Description(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	for _, tt := range []struct {
+		name, description, expect string
+	}{
+		{"default-description", "", "Install complete"},
+		{"custom-description", "first deploy of the frontend", "first deploy of the frontend"},
+	} {
+		req := &services.InstallReleaseRequest{
+			Chart:       chartStub(),
+			Name:        tt.name,
+			Description: tt.description,
+		}
+		if _, err := rs.InstallRelease(c, req); err != nil {
+			t.Fatalf("Failed install: %s", err)
+		}
+		if got := latestDescription(t, rs, tt.name); got != tt.expect {
+			t.Errorf("Expected description %q, got %q", tt.expect, got)
+		}
+	}
+}
+
+func TestInstallRelease_FailOnEmpty(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	emptyChart := func(marker string) *chart.Chart {
+		return &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+				{Name: "templates/_helpers.tpl", Data: []byte("")},
+				{Name: "templates/ingress.yaml", Data: []byte(marker + "{{ if .Values.ingress }}\nkind: Ingress\n{{ end }}\n")},
+			},
+		}
+	}
+
+	req := &services.InstallReleaseRequest{
+		Chart:       emptyChart("# ingress\n"),
+		Name:        "empty-template",
+		FailOnEmpty: true,
+	}
+	_, err := rs.InstallRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), "templates rendered no resources: hello/templates/ingress.yaml") {
+		t.Fatalf("Expected the empty template to fail the install, got %v", err)
+	}
+
+	req = &services.InstallReleaseRequest{
+		Chart:       emptyChart("# helm.sh/allow-empty\n"),
+		Name:        "allowed-empty-template",
+		FailOnEmpty: true,
+	}
+	if _, err := rs.InstallRelease(c, req); err != nil {
+		t.Fatalf("Expected the marked template to be allowed to render empty, got %s", err)
+	}
+
+	req = &services.InstallReleaseRequest{
+		Chart: emptyChart(""),
+		Name:  "unchecked-empty-template",
+	}
+	if _, err := rs.InstallRelease(c, req); err != nil {
+		t.Fatalf("Expected empty templates to be allowed by default, got %s", err)
+	}
+}
+
+func TestInstallRelease_PostWaitSettle(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := &settleFailingKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+	rs.env.KubeClient = kubeClient
+
+	req := &services.InstallReleaseRequest{
+		Chart:          chartStub(),
+		Name:           "no-wait",
+		Namespace:      "spaced",
+		PostWaitSettle: 30,
+	}
+	if _, err := rs.InstallRelease(c, req); err != nil {
+		t.Fatalf("Expected no settle period without a wait, got %s", err)
+	}
+	if len(kubeClient.settles) != 0 {
+		t.Errorf("Expected no settle period without a wait, got %v", kubeClient.settles)
+	}
+
+	req = &services.InstallReleaseRequest{
+		Chart:          chartStub(),
+		Name:           "crash-loop",
+		Namespace:      "spaced",
+		Wait:           true,
+		PostWaitSettle: 30,
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), "pod spaced/web is no longer ready") {
+		t.Fatalf("Expected the settle check to fail the install, got %v", err)
+	}
+	if !reflect.DeepEqual(kubeClient.settles, []time.Duration{30 * time.Second}) {
+		t.Errorf("Expected a settle period of 30s, got %v", kubeClient.settles)
+	}
+	if code := res.Release.Info.Status.Code; code != release.Status_FAILED {
+		t.Errorf("Expected FAILED release, got %s", code)
+	}
+}
+
+func TestInstallRelease_OrderedApply(t *testing.T) {
+	c := helm.NewContext()
+	templates := []*chart.Template{
+		{Name: "templates/a-deployment", Data: []byte("kind: Deployment\nmetadata:\n  name: web\n")},
+		{Name: "templates/b-configmap", Data: []byte("kind: ConfigMap\nmetadata:\n  name: web-config\n")},
+		{Name: "templates/c-namespace", Data: []byte("kind: Namespace\nmetadata:\n  name: web-ns\n")},
+	}
+
+	for _, tt := range []struct {
+		orderedApply bool
+		expect       []string
+	}{
+		{false, []string{"kind: Namespace", "kind: ConfigMap", "kind: Deployment"}},
+		{true, []string{"kind: Namespace", "kind: Deployment", "kind: ConfigMap"}},
+	} {
+		rs := rsFixture()
+		req := &services.InstallReleaseRequest{
+			Namespace:    "spaced",
+			OrderedApply: tt.orderedApply,
+			Chart: &chart.Chart{
+				Metadata:  &chart.Metadata{Name: "hello"},
+				Templates: templates,
+			},
+		}
+		res, err := rs.InstallRelease(c, req)
+		if err != nil {
+			t.Fatalf("Failed install: %s", err)
+		}
+
+		last := -1
+		for _, kind := range tt.expect {
+			i := strings.Index(res.Release.Manifest, kind)
+			if i <= last {
+				t.Errorf("ordered apply %t: expected resources in the order %v, got:\n%s", tt.orderedApply, tt.expect, res.Release.Manifest)
+				break
+			}
+			last = i
+		}
+	}
+}
@@ -17,12 +17,19 @@ limitations under the License.
 package tiller
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
-	relutil "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/timeconv"
 )
 
 // ListReleases lists the releases found by the server.
@@ -33,12 +40,9 @@ func (s *ReleaseServer) ListReleases(req *services.ListReleasesRequest, stream s
 
 	//rels, err := s.env.Releases.ListDeployed()
 	rels, err := s.env.Releases.ListFilterAll(func(r *release.Release) bool {
-		for _, sc := range req.StatusCodes {
-			if sc == r.Info.Status.Code {
-				return true
-			}
-		}
-		return false
+		return matchesStatus(r, req.StatusCodes) && matchesChart(r, req.ChartName) &&
+			deployedBetween(r, req.DeployedAfter, req.DeployedBefore) &&
+			builtFrom(r, req.BuildCommit)
 	})
 	if err != nil {
 		return err
@@ -60,24 +64,26 @@ func (s *ReleaseServer) ListReleases(req *services.ListReleasesRequest, stream s
 
 	total := int64(len(rels))
 
-	switch req.SortBy {
-	case services.ListSort_NAME:
-		relutil.SortByName(rels)
-	case services.ListSort_LAST_RELEASED:
-		relutil.SortByDate(rels)
-	}
-
-	if req.SortOrder == services.ListSort_DESC {
-		ll := len(rels)
-		rr := make([]*release.Release, ll)
-		for i, item := range rels {
-			rr[ll-i-1] = item
-		}
-		rels = rr
+	cmp, err := listComparator(req)
+	if err != nil {
+		return err
 	}
+	sort.SliceStable(rels, func(i, j int) bool {
+		return cmp(rels[i], rels[j]) < 0
+	})
 
 	l := int64(len(rels))
-	if req.Offset != "" {
+	if req.Cursor != "" {
+		last, err := decodeListCursor(req.Cursor)
+		if err != nil {
+			return err
+		}
+		i := sort.Search(len(rels), func(i int) bool {
+			return cmp(rels[i], last) > 0
+		})
+		rels = rels[i:]
+		l = int64(len(rels))
+	} else if req.Offset != "" {
 
 		i := -1
 		for ii, cur := range rels {
@@ -101,22 +107,196 @@ func (s *ReleaseServer) ListReleases(req *services.ListReleasesRequest, stream s
 		req.Limit = ListDefaultLimit
 	}
 
-	next := ""
+	next, nextCursor := "", ""
 	if l > req.Limit {
 		next = rels[req.Limit].Name
 		rels = rels[0:req.Limit]
 		l = int64(len(rels))
+		if nextCursor, err = encodeListCursor(rels[l-1]); err != nil {
+			return err
+		}
 	}
 
 	res := &services.ListReleasesResponse{
-		Next:     next,
-		Count:    l,
-		Total:    total,
-		Releases: rels,
+		Next:       next,
+		NextCursor: nextCursor,
+		Count:      l,
+		Total:      total,
+		Releases:   rels,
 	}
 	return stream.Send(res)
 }
 
+// releaseComparators compare two releases by a sort key, returning a negative
+// number, zero or a positive number if a sorts before, with or after b.
+var releaseComparators = map[services.ListSort_SortBy]func(a, b *release.Release) int{
+	services.ListSort_NAME: func(a, b *release.Release) int {
+		return strings.Compare(a.Name, b.Name)
+	},
+	services.ListSort_LAST_RELEASED: func(a, b *release.Release) int {
+		return compareInt64(a.Info.LastDeployed.Seconds, b.Info.LastDeployed.Seconds)
+	},
+	services.ListSort_NAMESPACE: func(a, b *release.Release) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	},
+	services.ListSort_CHART_NAME: func(a, b *release.Release) int {
+		return strings.Compare(a.GetChart().GetMetadata().GetName(), b.GetChart().GetMetadata().GetName())
+	},
+	services.ListSort_REVISION: func(a, b *release.Release) int {
+		return compareInt64(int64(a.Version), int64(b.Version))
+	},
+}
+
+// listComparator returns the comparator ordering the releases of req. The
+// sort_by and sort_order fields are used if no sort keys are given. A DESC
+// sort_order then reverses the whole order, tie-breaks included, so that it
+// also reverses the order of releases when sort_by is not set.
+func listComparator(req *services.ListReleasesRequest) (func(a, b *release.Release) int, error) {
+	if len(req.SortKeys) > 0 {
+		return releaseComparator(req.SortKeys)
+	}
+	var keys []*services.ListSortKey
+	if req.SortBy != services.ListSort_UNKNOWN {
+		keys = []*services.ListSortKey{{SortBy: req.SortBy}}
+	}
+	cmp, err := releaseComparator(keys)
+	if err != nil || req.SortOrder != services.ListSort_DESC {
+		return cmp, err
+	}
+	return func(a, b *release.Release) int { return cmp(b, a) }, nil
+}
+
+// releaseComparator returns a comparator ordering releases by each of the
+// keys in turn, so that later keys only order releases which are equal by all
+// of the previous keys. Releases equal by all keys are ordered by name and
+// revision, so the order is total and list cursors are stable.
+func releaseComparator(keys []*services.ListSortKey) (func(a, b *release.Release) int, error) {
+	cmps := make([]func(a, b *release.Release) int, 0, len(keys)+2)
+	for _, key := range keys {
+		cmp, ok := releaseComparators[key.SortBy]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort key %s", key.SortBy)
+		}
+		if key.SortOrder == services.ListSort_DESC {
+			asc := cmp
+			cmp = func(a, b *release.Release) int { return asc(b, a) }
+		}
+		cmps = append(cmps, cmp)
+	}
+	cmps = append(cmps,
+		releaseComparators[services.ListSort_NAME],
+		releaseComparators[services.ListSort_REVISION],
+	)
+	return func(a, b *release.Release) int {
+		for _, cmp := range cmps {
+			if c := cmp(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}, nil
+}
+
+// listCursor is the position of a release in a release list. It holds every
+// field a release list can be sorted by, so the position stays valid when
+// releases are added or removed between list calls.
+type listCursor struct {
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace"`
+	Version      int32  `json:"version"`
+	Chart        string `json:"chart"`
+	LastDeployed int64  `json:"lastDeployed"`
+}
+
+// encodeListCursor returns an opaque cursor for the position of r.
+func encodeListCursor(r *release.Release) (string, error) {
+	c := listCursor{
+		Name:      r.Name,
+		Namespace: r.Namespace,
+		Version:   r.Version,
+		Chart:     r.GetChart().GetMetadata().GetName(),
+	}
+	if last := r.GetInfo().GetLastDeployed(); last != nil {
+		c.LastDeployed = last.Seconds
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeListCursor returns a release at the position encoded in cursor, to
+// compare listed releases against.
+func decodeListCursor(cursor string) (*release.Release, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	var c listCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return &release.Release{
+		Name:      c.Name,
+		Namespace: c.Namespace,
+		Version:   c.Version,
+		Chart:     &chart.Chart{Metadata: &chart.Metadata{Name: c.Chart}},
+		Info:      &release.Info{LastDeployed: &timestamp.Timestamp{Seconds: c.LastDeployed}},
+	}, nil
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func matchesStatus(r *release.Release, codes []release.Status_Code) bool {
+	for _, sc := range codes {
+		if sc == r.Info.Status.Code {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesChart reports whether r is a release of the chart name. An empty name
+// matches every release.
+func matchesChart(r *release.Release, name string) bool {
+	return name == "" || r.GetChart().GetMetadata().GetName() == name
+}
+
+// builtFrom reports whether r was built from the commit. An empty commit matches
+// every release.
+func builtFrom(r *release.Release, commit string) bool {
+	return commit == "" || r.GetInfo().GetBuildInfo().GetCommit() == commit
+}
+
+// deployedBetween reports whether r was last deployed after the time after and
+// before the time before. Either bound may be nil.
+func deployedBetween(r *release.Release, after, before *timestamp.Timestamp) bool {
+	if after == nil && before == nil {
+		return true
+	}
+	last := r.GetInfo().GetLastDeployed()
+	if last == nil {
+		return false
+	}
+	t := timeconv.Time(last)
+	if after != nil && !t.After(timeconv.Time(after)) {
+		return false
+	}
+	if before != nil && !t.Before(timeconv.Time(before)) {
+		return false
+	}
+	return true
+}
+
 func filterByNamespace(namespace string, rels []*release.Release) ([]*release.Release, error) {
 	matches := []*release.Release{}
 	for _, r := range rels {
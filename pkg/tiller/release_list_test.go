@@ -18,8 +18,10 @@ package tiller
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
 )
@@ -147,6 +149,273 @@ func TestListReleasesSort(t *testing.T) {
 	}
 }
 
+func TestListReleasesSortDesc(t *testing.T) {
+	rs := rsFixture()
+
+	fixtures := []struct {
+		name     string
+		deployed int64
+	}{
+		{"ahab", 100},
+		{"bildad", 200},
+		{"peleg", 100},
+		{"starbuck", 300},
+	}
+	for _, f := range fixtures {
+		rel := releaseStub()
+		rel.Name = f.name
+		rel.Info.LastDeployed = &timestamp.Timestamp{Seconds: f.deployed}
+		if err := rs.env.Releases.Create(rel); err != nil {
+			t.Fatalf("Could not store mock release: %s", err)
+		}
+	}
+
+	tests := []struct {
+		sortBy services.ListSort_SortBy
+		expect []string
+	}{
+		// Without sort_by, DESC reverses the order by name.
+		{services.ListSort_UNKNOWN, []string{"starbuck", "peleg", "bildad", "ahab"}},
+		// Releases deployed at the same time are reversed as well.
+		{services.ListSort_LAST_RELEASED, []string{"starbuck", "bildad", "peleg", "ahab"}},
+	}
+	for _, tt := range tests {
+		mrs := &mockListServer{}
+		req := &services.ListReleasesRequest{
+			SortBy:    tt.sortBy,
+			SortOrder: services.ListSort_DESC,
+		}
+		if err := rs.ListReleases(req, mrs); err != nil {
+			t.Fatalf("Failed listing: %s", err)
+		}
+		var got []string
+		for _, r := range mrs.val.Releases {
+			got = append(got, r.Name)
+		}
+		if !reflect.DeepEqual(got, tt.expect) {
+			t.Errorf("sort by %s: expected %v, got %v", tt.sortBy, tt.expect, got)
+		}
+	}
+}
+
+func TestListReleasesSortKeys(t *testing.T) {
+	rs := rsFixture()
+
+	fixtures := []struct {
+		name      string
+		namespace string
+		deployed  int64
+	}{
+		{"ahab", "pequod", 300},
+		{"starbuck", "pequod", 100},
+		{"stubb", "pequod", 200},
+		{"bildad", "nantucket", 100},
+		{"peleg", "nantucket", 400},
+	}
+	for _, f := range fixtures {
+		rel := releaseStub()
+		rel.Name = f.name
+		rel.Namespace = f.namespace
+		rel.Info.LastDeployed = &timestamp.Timestamp{Seconds: f.deployed}
+		if err := rs.env.Releases.Create(rel); err != nil {
+			t.Fatalf("Could not store mock release: %s", err)
+		}
+	}
+
+	mrs := &mockListServer{}
+	req := &services.ListReleasesRequest{
+		Limit: 3,
+		SortKeys: []*services.ListSortKey{
+			{SortBy: services.ListSort_NAMESPACE, SortOrder: services.ListSort_ASC},
+			{SortBy: services.ListSort_LAST_RELEASED, SortOrder: services.ListSort_DESC},
+		},
+	}
+	if err := rs.ListReleases(req, mrs); err != nil {
+		t.Fatalf("Failed listing: %s", err)
+	}
+
+	// Sorting happens before pagination.
+	expect := []string{"peleg", "bildad", "ahab"}
+	if len(mrs.val.Releases) != len(expect) {
+		t.Fatalf("Expected %d releases, got %d", len(expect), len(mrs.val.Releases))
+	}
+	for i, n := range expect {
+		if mrs.val.Releases[i].Name != n {
+			t.Errorf("Expected %q at %d, got %q", n, i, mrs.val.Releases[i].Name)
+		}
+	}
+	if mrs.val.Next != "stubb" {
+		t.Errorf("Expected next release %q, got %q", "stubb", mrs.val.Next)
+	}
+
+	req.SortKeys = append(req.SortKeys, &services.ListSortKey{SortBy: services.ListSort_UNKNOWN})
+	if err := rs.ListReleases(req, mrs); err == nil {
+		t.Error("Expected an error for an unknown sort key")
+	}
+}
+
+func TestListReleasesCursor(t *testing.T) {
+	rs := rsFixture()
+	for _, name := range []string{"echo", "delta", "charlie", "bravo", "alpha"} {
+		rel := releaseStub()
+		rel.Name = name
+		if err := rs.env.Releases.Create(rel); err != nil {
+			t.Fatalf("Could not store mock release: %s", err)
+		}
+	}
+
+	mrs := &mockListServer{}
+	req := &services.ListReleasesRequest{
+		Limit:  2,
+		SortBy: services.ListSort_NAME,
+	}
+	if err := rs.ListReleases(req, mrs); err != nil {
+		t.Fatalf("Failed listing: %s", err)
+	}
+	var listed []string
+	for _, r := range mrs.val.Releases {
+		listed = append(listed, r.Name)
+	}
+	if mrs.val.NextCursor == "" {
+		t.Fatal("Expected a cursor for the next page")
+	}
+
+	// Releases added before the cursor, or removed at it, must not shift
+	// the next page.
+	rel := releaseStub()
+	rel.Name = "able"
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+	if _, err := rs.env.Releases.Delete("bravo", 1); err != nil {
+		t.Fatalf("Could not delete mock release: %s", err)
+	}
+
+	req.Cursor = mrs.val.NextCursor
+	if err := rs.ListReleases(req, mrs); err != nil {
+		t.Fatalf("Failed listing: %s", err)
+	}
+	for _, r := range mrs.val.Releases {
+		listed = append(listed, r.Name)
+	}
+
+	expect := []string{"alpha", "bravo", "charlie", "delta"}
+	if len(listed) != len(expect) {
+		t.Fatalf("Expected %v, got %v", expect, listed)
+	}
+	for i := range expect {
+		if listed[i] != expect[i] {
+			t.Errorf("Expected %v, got %v", expect, listed)
+			break
+		}
+	}
+
+	req.Cursor = mrs.val.NextCursor
+	if err := rs.ListReleases(req, mrs); err != nil {
+		t.Fatalf("Failed listing: %s", err)
+	}
+	if len(mrs.val.Releases) != 1 || mrs.val.Releases[0].Name != "echo" {
+		t.Errorf("Expected only echo on the last page, got %v", mrs.val.Releases)
+	}
+	if mrs.val.NextCursor != "" {
+		t.Errorf("Expected no cursor after the last page, got %q", mrs.val.NextCursor)
+	}
+
+	req.Cursor = "not a cursor"
+	if err := rs.ListReleases(req, mrs); err == nil {
+		t.Error("Expected an error for an invalid cursor")
+	}
+}
+
+func TestListReleasesByChartAndDate(t *testing.T) {
+	rs := rsFixture()
+
+	fixtures := []struct {
+		name     string
+		chart    string
+		deployed int64
+		status   release.Status_Code
+	}{
+		{"old-hello", "hello", 100, release.Status_DEPLOYED},
+		{"older-hello", "hello", 50, release.Status_DEPLOYED},
+		{"ancient-hello", "hello", 10, release.Status_DEPLOYED},
+		{"new-hello", "hello", 300, release.Status_DEPLOYED},
+		{"old-failed-hello", "hello", 100, release.Status_FAILED},
+		{"old-goodbye", "goodbye", 100, release.Status_DEPLOYED},
+	}
+	for _, f := range fixtures {
+		rel := namedReleaseStub(f.name, f.status)
+		rel.Chart.Metadata.Name = f.chart
+		rel.Info.LastDeployed = &timestamp.Timestamp{Seconds: f.deployed}
+		if err := rs.env.Releases.Create(rel); err != nil {
+			t.Fatalf("Could not store mock release: %s", err)
+		}
+	}
+
+	mrs := &mockListServer{}
+	req := &services.ListReleasesRequest{
+		SortBy:         services.ListSort_NAME,
+		ChartName:      "hello",
+		DeployedBefore: &timestamp.Timestamp{Seconds: 200},
+		DeployedAfter:  &timestamp.Timestamp{Seconds: 10},
+	}
+	if err := rs.ListReleases(req, mrs); err != nil {
+		t.Fatalf("Failed listing: %s", err)
+	}
+
+	expect := []string{"old-hello", "older-hello"}
+	if len(mrs.val.Releases) != len(expect) {
+		t.Fatalf("Expected %d releases, got %v", len(expect), mrs.val.Releases)
+	}
+	for i, n := range expect {
+		if mrs.val.Releases[i].Name != n {
+			t.Errorf("Expected %q at %d, got %q", n, i, mrs.val.Releases[i].Name)
+		}
+	}
+}
+
+func TestListReleasesByBuildCommit(t *testing.T) {
+	rs := rsFixture()
+
+	fixtures := []struct {
+		name   string
+		commit string
+	}{
+		{"alpha", "abc123"},
+		{"beta", "def456"},
+		{"gamma", "abc123"},
+		{"delta", ""},
+	}
+	for _, f := range fixtures {
+		rel := namedReleaseStub(f.name, release.Status_DEPLOYED)
+		if f.commit != "" {
+			rel.Info.BuildInfo = &release.BuildInfo{Commit: f.commit}
+		}
+		if err := rs.env.Releases.Create(rel); err != nil {
+			t.Fatalf("Could not store mock release: %s", err)
+		}
+	}
+
+	mrs := &mockListServer{}
+	req := &services.ListReleasesRequest{
+		SortBy:      services.ListSort_NAME,
+		BuildCommit: "abc123",
+	}
+	if err := rs.ListReleases(req, mrs); err != nil {
+		t.Fatalf("Failed listing: %s", err)
+	}
+
+	expect := []string{"alpha", "gamma"}
+	if len(mrs.val.Releases) != len(expect) {
+		t.Fatalf("Expected %d releases, got %v", len(expect), mrs.val.Releases)
+	}
+	for i, n := range expect {
+		if mrs.val.Releases[i].Name != n {
+			t.Errorf("Expected %q at %d, got %q", n, i, mrs.val.Releases[i].Name)
+		}
+	}
+}
+
 func TestListReleasesFilter(t *testing.T) {
 	rs := rsFixture()
 	names := []string{
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
 
@@ -51,14 +52,31 @@ type LocalReleaseModule struct {
 
 // Create creates a release via kubeclient from provided environment
 func (m *LocalReleaseModule) Create(r *release.Release, req *services.InstallReleaseRequest, env *environment.Environment) error {
-	b := bytes.NewBufferString(r.Manifest)
-	return env.KubeClient.Create(r.Namespace, b, req.Timeout, req.Wait)
+	return CreateRelease(r, env.KubeClient, kube.CreateOptions{
+		Timeout:         req.Timeout,
+		ShouldWait:      req.Wait,
+		ContinueOnError: req.ContinueOnError,
+		TakeOwnership:   req.TakeOwnership,
+	})
 }
 
 // Update performs an update from current to target release
+//
+// If the request asks to clean up on failure, the resources created by a
+// failed update are deleted again. Taking ownership is handled as for Create.
 func (m *LocalReleaseModule) Update(current, target *release.Release, req *services.UpdateReleaseRequest, env *environment.Environment) error {
 	c := bytes.NewBufferString(current.Manifest)
 	t := bytes.NewBufferString(target.Manifest)
+	if req.CleanupOnFail || req.TakeOwnership {
+		return env.KubeClient.UpdateWithOptions(target.Namespace, c, t, kube.UpdateOptions{
+			Force:         req.Force,
+			Recreate:      req.Recreate,
+			Timeout:       req.Timeout,
+			ShouldWait:    req.Wait,
+			CleanupOnFail: req.CleanupOnFail,
+			TakeOwnership: req.TakeOwnership,
+		})
+	}
 	return env.KubeClient.Update(target.Namespace, c, t, req.Force, req.Recreate, req.Timeout, req.Wait)
 }
 
@@ -80,7 +98,7 @@ func (m *LocalReleaseModule) Delete(rel *release.Release, req *services.Uninstal
 	if err != nil {
 		return rel.Manifest, []error{fmt.Errorf("Could not get apiVersions from Kubernetes: %v", err)}
 	}
-	return DeleteRelease(rel, vs, env.KubeClient)
+	return DeleteRelease(rel, vs, env.KubeClient, DeleteOptions(req.Cascade, req.GracePeriod))
 }
 
 // RemoteReleaseModule is a ReleaseModule which calls Rudder service to operate on a release
@@ -88,7 +106,11 @@ type RemoteReleaseModule struct{}
 
 // Create calls rudder.InstallRelease
 func (m *RemoteReleaseModule) Create(r *release.Release, req *services.InstallReleaseRequest, env *environment.Environment) error {
-	request := &rudderAPI.InstallReleaseRequest{Release: r}
+	request := &rudderAPI.InstallReleaseRequest{
+		Release:         r,
+		TakeOwnership:   req.TakeOwnership,
+		ContinueOnError: req.ContinueOnError,
+	}
 	_, err := rudder.InstallRelease(request)
 	return err
 }
@@ -96,12 +118,14 @@ func (m *RemoteReleaseModule) Create(r *release.Release, req *services.InstallRe
 // Update calls rudder.UpgradeRelease
 func (m *RemoteReleaseModule) Update(current, target *release.Release, req *services.UpdateReleaseRequest, env *environment.Environment) error {
 	upgrade := &rudderAPI.UpgradeReleaseRequest{
-		Current:  current,
-		Target:   target,
-		Recreate: req.Recreate,
-		Timeout:  req.Timeout,
-		Wait:     req.Wait,
-		Force:    req.Force,
+		Current:       current,
+		Target:        target,
+		Recreate:      req.Recreate,
+		Timeout:       req.Timeout,
+		Wait:          req.Wait,
+		Force:         req.Force,
+		TakeOwnership: req.TakeOwnership,
+		CleanupOnFail: req.CleanupOnFail,
 	}
 	_, err := rudder.UpgradeRelease(upgrade)
 	return err
@@ -132,7 +156,11 @@ func (m *RemoteReleaseModule) Status(r *release.Release, req *services.GetReleas
 
 // Delete calls rudder.DeleteRelease
 func (m *RemoteReleaseModule) Delete(r *release.Release, req *services.UninstallReleaseRequest, env *environment.Environment) (string, []error) {
-	deleteRequest := &rudderAPI.DeleteReleaseRequest{Release: r}
+	deleteRequest := &rudderAPI.DeleteReleaseRequest{
+		Release:     r,
+		Cascade:     req.Cascade,
+		GracePeriod: req.GracePeriod,
+	}
 	resp, err := rudder.DeleteRelease(deleteRequest)
 
 	errs := make([]error, 0)
@@ -147,10 +175,51 @@ func (m *RemoteReleaseModule) Delete(r *release.Release, req *services.Uninstall
 	return result, errs
 }
 
-// DeleteRelease is a helper that allows Rudder to delete a release without exposing most of Tiller inner functions
-func DeleteRelease(rel *release.Release, vs chartutil.VersionSet, kubeClient environment.KubeClient) (kept string, errs []error) {
+// DeleteOptions returns the deletion options for the given propagation policy
+// and grace period, or nil when both are left to the default behaviour.
+func DeleteOptions(cascade string, gracePeriod int64) *kube.DeleteOptions {
+	if cascade == "" && gracePeriod == 0 {
+		return nil
+	}
+	return &kube.DeleteOptions{Cascade: cascade, GracePeriod: gracePeriod}
+}
+
+// CreateRelease is a helper that allows Rudder to create a release the way
+// Tiller does.
+//
+// CustomResourceDefinitions in the release are created first. The rest of the
+// release is only created once all of them are established, and only the rest
+// is waited for if opts asks to wait. If opts asks to continue on error, every
+// resource is attempted and the failures are reported together, but a failed
+// CustomResourceDefinition still stops the rest of the release from being
+// created.
+func CreateRelease(r *release.Release, kubeClient environment.KubeClient, opts kube.CreateOptions) error {
+	crds, rest := splitCRDs(r.Manifest)
+	opts.CRDs = crds
+	create := func(manifest string, wait bool) error {
+		o := opts
+		o.ShouldWait = wait
+		return kubeClient.CreateWithOptions(r.Namespace, bytes.NewBufferString(manifest), o)
+	}
+	if crds != "" {
+		if err := create(crds, false); err != nil {
+			return err
+		}
+		if err := kubeClient.WaitUntilCRDEstablished(bytes.NewBufferString(crds), time.Duration(opts.Timeout)*time.Second); err != nil {
+			return err
+		}
+		if rest == "" {
+			return nil
+		}
+	}
+	return create(rest, opts.ShouldWait)
+}
+
+// DeleteRelease is a helper that allows Rudder to delete a release without exposing most of Tiller inner functions.
+// A nil opts deletes the resources with the default behaviour.
+func DeleteRelease(rel *release.Release, vs chartutil.VersionSet, kubeClient environment.KubeClient, opts *kube.DeleteOptions) (kept string, errs []error) {
 	manifests := relutil.SplitManifests(rel.Manifest)
-	_, files, err := sortManifests(manifests, vs, UninstallOrder)
+	_, files, _, err := sortManifests(manifests, vs, UninstallOrder)
 	if err != nil {
 		// We could instead just delete everything in no particular order.
 		// FIXME: One way to delete at this point would be to try a label-based
@@ -170,7 +239,13 @@ func DeleteRelease(rel *release.Release, vs chartutil.VersionSet, kubeClient env
 		if b.Len() == 0 {
 			continue
 		}
-		if err := kubeClient.Delete(rel.Namespace, b); err != nil {
+		var err error
+		if opts != nil {
+			err = kubeClient.DeleteWithOptions(rel.Namespace, b, opts)
+		} else {
+			err = kubeClient.Delete(rel.Namespace, b)
+		}
+		if err != nil {
 			log.Printf("uninstall: Failed deletion of %q: %s", rel.Name, err)
 			if err == kube.ErrNoObjectsVisited {
 				// Rewrite the message from "no objects visited"
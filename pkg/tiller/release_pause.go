@@ -0,0 +1,59 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	ctx "golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// SetReleasePaused pauses or resumes a release by flagging its latest revision
+// and, if that is not the deployed one, its deployed revision. An upgrade reads
+// the flag from both.
+func (s *ReleaseServer) SetReleasePaused(c ctx.Context, req *services.SetReleasePausedRequest) (*services.SetReleasePausedResponse, error) {
+	if err := validateReleaseName(req.Name); err != nil {
+		s.Log("setReleasePaused: Release name is invalid: %s", req.Name)
+		return nil, err
+	}
+
+	unlock, err := s.lockRelease(req.Name, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	rel, err := s.env.Releases.Last(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []*release.Release{rel}
+	if deployed, err := s.env.Releases.Deployed(req.Name); err == nil && deployed.Version != rel.Version {
+		revisions = append(revisions, deployed)
+	}
+
+	s.Log("setting paused to %t for %s", req.Paused, req.Name)
+	for _, r := range revisions {
+		r.Info.Paused = req.Paused
+		if err := s.env.Releases.Update(r); err != nil {
+			return nil, err
+		}
+	}
+	return &services.SetReleasePausedResponse{Release: rel}, nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func TestSetReleasePaused(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	upgrade := func(force bool) (*services.UpdateReleaseResponse, error) {
+		return rs.UpdateRelease(c, &services.UpdateReleaseRequest{
+			Name: rel.Name,
+			Chart: &chart.Chart{
+				Metadata:  &chart.Metadata{Name: "hello"},
+				Templates: []*chart.Template{{Name: "templates/hello", Data: []byte("hello: world")}},
+			},
+			ForcePaused: force,
+		})
+	}
+
+	res, err := rs.SetReleasePaused(c, &services.SetReleasePausedRequest{Name: rel.Name, Paused: true})
+	if err != nil {
+		t.Fatalf("Failed pausing: %s", err)
+	}
+	if !res.Release.Info.Paused {
+		t.Error("Expected the returned release to be paused")
+	}
+	stored, err := rs.env.Releases.Get(rel.Name, rel.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stored.Info.Paused {
+		t.Error("Expected the stored release to be paused")
+	}
+
+	if _, err := upgrade(false); err == nil || !strings.Contains(err.Error(), "is paused") {
+		t.Fatalf("Expected the paused release to reject the upgrade, got %v", err)
+	}
+	if last, _ := rs.env.Releases.Last(rel.Name); last.Version != rel.Version {
+		t.Errorf("Expected no new revision, got revision %d", last.Version)
+	}
+
+	// A forced upgrade goes through, and the release stays paused.
+	forced, err := upgrade(true)
+	if err != nil {
+		t.Fatalf("Failed forced upgrade: %s", err)
+	}
+	if !forced.Release.Info.Paused {
+		t.Error("Expected the release to stay paused after a forced upgrade")
+	}
+
+	if _, err := rs.SetReleasePaused(c, &services.SetReleasePausedRequest{Name: rel.Name, Paused: false}); err != nil {
+		t.Fatalf("Failed resuming: %s", err)
+	}
+	resumed, err := upgrade(false)
+	if err != nil {
+		t.Fatalf("Failed upgrading the resumed release: %s", err)
+	}
+	if resumed.Release.Info.Paused {
+		t.Error("Expected the upgraded release not to be paused")
+	}
+}
+
+func TestSetReleasePaused_failedLastRevision(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	failed := upgradeReleaseVersion(rel)
+	failed.Info.Status.Code = release.Status_FAILED
+	rel.Info.Status.Code = release.Status_DEPLOYED
+	rs.env.Releases.Update(rel)
+	rs.env.Releases.Create(failed)
+
+	paused := func() (deployed, last bool) {
+		d, err := rs.env.Releases.Get(rel.Name, rel.Version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		l, err := rs.env.Releases.Get(rel.Name, failed.Version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d.Info.Paused, l.Info.Paused
+	}
+
+	if _, err := rs.SetReleasePaused(c, &services.SetReleasePausedRequest{Name: rel.Name, Paused: true}); err != nil {
+		t.Fatalf("Failed pausing: %s", err)
+	}
+	if d, l := paused(); !d || !l {
+		t.Errorf("Expected the deployed and the last revision to be paused, got %t and %t", d, l)
+	}
+
+	if _, err := rs.SetReleasePaused(c, &services.SetReleasePausedRequest{Name: rel.Name, Paused: false}); err != nil {
+		t.Fatalf("Failed resuming: %s", err)
+	}
+	if d, l := paused(); d || l {
+		t.Errorf("Expected the deployed and the last revision to be resumed, got %t and %t", d, l)
+	}
+
+	_, err := rs.UpdateRelease(c, &services.UpdateReleaseRequest{
+		Name: rel.Name,
+		Chart: &chart.Chart{
+			Metadata:  &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{{Name: "templates/hello", Data: []byte("hello: world")}},
+		},
+	})
+	if err != nil {
+		t.Errorf("Failed upgrading the resumed release: %s", err)
+	}
+}
+
+func TestRollbackRelease_paused(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	upgradedRel := upgradeReleaseVersion(rel)
+	rs.env.Releases.Update(rel)
+	rs.env.Releases.Create(upgradedRel)
+
+	if _, err := rs.SetReleasePaused(c, &services.SetReleasePausedRequest{Name: rel.Name, Paused: true}); err != nil {
+		t.Fatalf("Failed pausing: %s", err)
+	}
+	res, err := rs.RollbackRelease(c, &services.RollbackReleaseRequest{Name: rel.Name, DisableHooks: true, Version: 1})
+	if err != nil {
+		t.Fatalf("Failed rollback: %s", err)
+	}
+	if !res.Release.Info.Paused {
+		t.Error("Expected the release to stay paused after a rollback")
+	}
+	stored, err := rs.env.Releases.Deployed(rel.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stored.Info.Paused {
+		t.Error("Expected the deployed revision to stay paused after a rollback")
+	}
+}
+
+func TestSetReleasePaused_missingRelease(t *testing.T) {
+	rs := rsFixture()
+	if _, err := rs.SetReleasePaused(helm.NewContext(), &services.SetReleasePausedRequest{Name: "angry-panda", Paused: true}); err == nil {
+		t.Error("Expected an error for a release that does not exist")
+	}
+}
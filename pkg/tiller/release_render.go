@@ -0,0 +1,97 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"github.com/golang/protobuf/proto"
+	ctx "golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+// RenderRelease renders the chart stored in a release with new values, as an
+// upgrade to the next revision would render it, without installing anything.
+//
+// This lets clients check the values they are about to upgrade a release
+// with, without sending its chart again.
+func (s *ReleaseServer) RenderRelease(c ctx.Context, req *services.RenderReleaseRequest) (*services.RenderReleaseResponse, error) {
+	if err := validateReleaseName(req.Name); err != nil {
+		s.Log("renderRelease: Release name is invalid: %s", req.Name)
+		return nil, err
+	}
+
+	var rel *release.Release
+	var err error
+	if req.Version <= 0 {
+		rel, err = s.env.Releases.Deployed(req.Name)
+	} else {
+		rel, err = s.env.Releases.Get(req.Name, req.Version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rel.Chart == nil {
+		return nil, errMissingChart
+	}
+	last, err := s.env.Releases.Last(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reusing the values changes the values of the chart, so work on a copy
+	// to leave the stored release untouched.
+	update := &services.UpdateReleaseRequest{
+		Name:        req.Name,
+		Chart:       proto.Clone(rel.Chart).(*chart.Chart),
+		Values:      req.Values,
+		ReuseValues: req.ReuseValues,
+	}
+	if err := s.reuseValues(update, rel); err != nil {
+		return nil, err
+	}
+
+	options := chartutil.ReleaseOptions{
+		Name:      req.Name,
+		Time:      timeconv.Now(),
+		Namespace: rel.Namespace,
+		IsUpgrade: true,
+		Revision:  int(last.Version + 1),
+	}
+	caps, err := capabilities(s.clientset.Discovery())
+	if err != nil {
+		return nil, err
+	}
+	valuesToRender, err := chartutil.ToRenderValuesCaps(update.Chart, update.Values, options, caps)
+	if err != nil {
+		return nil, err
+	}
+
+	hooks, manifestDoc, notesTxt, _, err := s.renderResources(update.Chart, valuesToRender, renderOptions{}, caps.APIVersions)
+	if err != nil {
+		return nil, err
+	}
+	return &services.RenderReleaseResponse{
+		Manifest: manifestDoc.String(),
+		Hooks:    hooks,
+		Notes:    notesTxt,
+		Config:   update.Values,
+	}, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func renderReleaseStub() *release.Release {
+	rel := releaseStub()
+	rel.Chart.Values = &chart.Config{Raw: "replicas: 1\nimage: nginx\n"}
+	rel.Chart.Templates = append(rel.Chart.Templates,
+		&chart.Template{Name: "templates/deployment", Data: []byte("replicas: {{ .Values.replicas }}\nimage: {{ .Values.image }}\nrevision: {{ .Release.Revision }}")},
+		&chart.Template{Name: "templates/NOTES.txt", Data: []byte("running {{ .Values.replicas }} replicas")},
+	)
+	rel.Config = &chart.Config{Raw: "replicas: 2\n"}
+	return rel
+}
+
+func TestRenderRelease(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := renderReleaseStub()
+	rs.env.Releases.Create(rel)
+
+	res, err := rs.RenderRelease(c, &services.RenderReleaseRequest{
+		Name:   rel.Name,
+		Values: &chart.Config{Raw: "replicas: 5\n"},
+	})
+	if err != nil {
+		t.Fatalf("Failed render: %s", err)
+	}
+	for _, want := range []string{"replicas: 5", "image: nginx", "revision: 2", "hello: world"} {
+		if !strings.Contains(res.Manifest, want) {
+			t.Errorf("Expected %q in the manifest, got:\n%s", want, res.Manifest)
+		}
+	}
+	if res.Notes != "running 5 replicas" {
+		t.Errorf("Expected notes rendered with the new values, got %q", res.Notes)
+	}
+	if len(res.Hooks) != 1 || res.Hooks[0].Name != "test-cm" {
+		t.Errorf("Expected the hook of the chart, got %v", res.Hooks)
+	}
+	if res.Config.Raw != "replicas: 5\n" {
+		t.Errorf("Expected the new values, got %q", res.Config.Raw)
+	}
+
+	stored, err := rs.env.Releases.Get(rel.Name, rel.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Manifest != rel.Manifest || stored.Config.Raw != "replicas: 2\n" {
+		t.Errorf("Expected the stored release to be unchanged, got %v", stored)
+	}
+	if h, _ := rs.env.Releases.History(rel.Name); len(h) != 1 {
+		t.Errorf("Expected no new revision, got %d revisions", len(h))
+	}
+}
+
+func TestRenderRelease_Values(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := renderReleaseStub()
+	rs.env.Releases.Create(rel)
+
+	tests := []struct {
+		name   string
+		req    *services.RenderReleaseRequest
+		expect []string
+	}{
+		{
+			name:   "release values",
+			req:    &services.RenderReleaseRequest{Name: rel.Name},
+			expect: []string{"replicas: 2", "image: nginx"},
+		},
+		{
+			name: "new values replace the release values",
+			req: &services.RenderReleaseRequest{
+				Name:   rel.Name,
+				Values: &chart.Config{Raw: "image: httpd\n"},
+			},
+			expect: []string{"replicas: 1", "image: httpd"},
+		},
+		{
+			name: "new values merged over the release values",
+			req: &services.RenderReleaseRequest{
+				Name:        rel.Name,
+				Values:      &chart.Config{Raw: "image: httpd\n"},
+				ReuseValues: true,
+			},
+			expect: []string{"replicas: 2", "image: httpd"},
+		},
+	}
+	for _, tt := range tests {
+		res, err := rs.RenderRelease(c, tt.req)
+		if err != nil {
+			t.Fatalf("%s: Failed render: %s", tt.name, err)
+		}
+		for _, want := range tt.expect {
+			if !strings.Contains(res.Manifest, want) {
+				t.Errorf("%s: Expected %q in the manifest, got:\n%s", tt.name, want, res.Manifest)
+			}
+		}
+	}
+
+	if _, err := rs.RenderRelease(c, &services.RenderReleaseRequest{Name: "no-such-release"}); err == nil {
+		t.Error("Expected an error for a missing release")
+	}
+}
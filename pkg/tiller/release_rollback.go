@@ -29,6 +29,12 @@ import (
 
 // RollbackRelease rolls back to a previous version of the given release.
 func (s *ReleaseServer) RollbackRelease(c ctx.Context, req *services.RollbackReleaseRequest) (*services.RollbackReleaseResponse, error) {
+	unlock, err := s.lockRelease(req.Name, req.Timeout, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	s.Log("preparing rollback of %s", req.Name)
 	currentRelease, targetRelease, err := s.prepareRollback(req)
 	if err != nil {
@@ -86,6 +92,11 @@ func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*
 		return nil, nil, err
 	}
 
+	hooks, manifest, err := relabelRevision(prls.Hooks, prls.Manifest, prls.Version, crls.Version+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Store a new release object with previous release's configuration
 	target := &release.Release{
 		Name:      req.Name,
@@ -102,10 +113,15 @@ func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*
 			// Because we lose the reference to rbv elsewhere, we set the
 			// message here, and only override it later if we experience failure.
 			Description: fmt.Sprintf("Rollback to %d", rbv),
+			BuildInfo:   prls.Info.BuildInfo,
+			Paused:      crls.Info.Paused,
 		},
 		Version:  crls.Version + 1,
-		Manifest: prls.Manifest,
-		Hooks:    prls.Hooks,
+		Manifest: manifest,
+		Hooks:    hooks,
+	}
+	if req.Description != "" {
+		target.Info.Description = req.Description
 	}
 
 	return crls, target, nil
@@ -121,7 +137,7 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 
 	// pre-rollback hooks
 	if !req.DisableHooks {
-		if err := s.execHook(targetRelease.Hooks, targetRelease.Name, targetRelease.Namespace, hooks.PreRollback, req.Timeout); err != nil {
+		if err := s.execHook(targetRelease.Hooks, targetRelease.Name, targetRelease.Namespace, hooks.PreRollback, req.Timeout, false); err != nil {
 			return res, err
 		}
 	} else {
@@ -141,7 +157,7 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 
 	// post-rollback hooks
 	if !req.DisableHooks {
-		if err := s.execHook(targetRelease.Hooks, targetRelease.Name, targetRelease.Namespace, hooks.PostRollback, req.Timeout); err != nil {
+		if err := s.execHook(targetRelease.Hooks, targetRelease.Name, targetRelease.Namespace, hooks.PostRollback, req.Timeout, false); err != nil {
 			return res, err
 		}
 	}
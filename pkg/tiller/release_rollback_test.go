@@ -225,3 +225,33 @@ func TestRollbackReleaseFailure(t *testing.T) {
 		t.Errorf("Expected SUPERSEDED status on previous Release version. Got %v", oldStatus)
 	}
 }
+
+func TestRollbackRelease_Description(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	upgradedRel := upgradeReleaseVersion(rel)
+	rs.env.Releases.Update(rel)
+	rs.env.Releases.Create(upgradedRel)
+
+	for _, tt := range []struct {
+		description, expect string
+	}{
+		{"revert broken config", "revert broken config"},
+		{"", "Rollback to 1"},
+	} {
+		req := &services.RollbackReleaseRequest{
+			Name:         rel.Name,
+			DisableHooks: true,
+			Version:      1,
+			Description:  tt.description,
+		}
+		if _, err := rs.RollbackRelease(c, req); err != nil {
+			t.Fatalf("Failed rollback: %s", err)
+		}
+		if got := latestDescription(t, rs, rel.Name); got != tt.expect {
+			t.Errorf("Expected description %q, got %q", tt.expect, got)
+		}
+	}
+}
@@ -22,9 +22,12 @@ import (
 	"fmt"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/technosophos/moniker"
+	ctx "golang.org/x/net/context"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
@@ -47,6 +50,10 @@ import (
 // See https://github.com/kubernetes/helm/issues/1528
 const releaseNameMaxLen = 53
 
+// releaseLockGrace is added to the timeout of an operation to get how long it
+// holds the lock of its release before another operation may reclaim it.
+const releaseLockGrace = 5 * time.Minute
+
 // NOTESFILE_SUFFIX that we want to treat special. It goes through the templating engine
 // but it's not a yaml file (resource) hence can't have hooks, etc. And the user actually
 // wants to see this file after rendering in the status command. However, it must be a suffix
@@ -81,9 +88,10 @@ var ValidName = regexp.MustCompile("^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])+
 // ReleaseServer implements the server-side gRPC endpoint for the HAPI services.
 type ReleaseServer struct {
 	ReleaseModule
-	env       *environment.Environment
-	clientset internalclientset.Interface
-	Log       func(string, ...interface{})
+	env          *environment.Environment
+	clientset    internalclientset.Interface
+	Log          func(string, ...interface{})
+	transformers []ManifestTransformer
 }
 
 // NewReleaseServer creates a new release server.
@@ -105,6 +113,24 @@ func NewReleaseServer(env *environment.Environment, clientset internalclientset.
 	}
 }
 
+// lockRelease takes the lock of the named release for a mutating operation
+// that runs for up to timeout seconds, so that concurrent operations on the
+// same release are rejected. The returned function releases the lock.
+//
+// Dry runs and invalid names take no lock; the latter are rejected by the
+// operation itself.
+func (s *ReleaseServer) lockRelease(name string, timeout int64, dryRun bool) (func(), error) {
+	if dryRun || validateReleaseName(name) != nil {
+		return func() {}, nil
+	}
+	unlock, err := s.env.Releases.Lock(name, time.Duration(timeout)*time.Second+releaseLockGrace)
+	if err != nil {
+		s.Log("failed to lock release %s: %s", name, err)
+		return nil, err
+	}
+	return unlock, nil
+}
+
 // reuseValues copies values from the current release to a new release if the
 // new release does not have any values.
 //
@@ -244,12 +270,28 @@ func GetVersionSet(client discovery.ServerGroupsInterface) (chartutil.VersionSet
 	return chartutil.NewVersionSet(versions...), nil
 }
 
-func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values, vs chartutil.VersionSet) ([]*release.Hook, *bytes.Buffer, string, error) {
+// renderOptions are the options of renderResources.
+type renderOptions struct {
+	// subchartNotes adds the notes of subcharts to the notes of the chart.
+	subchartNotes bool
+	// failOnEmpty makes templates that render to nothing but whitespace and
+	// comments an error, unless they carry the allowEmptyMarker comment.
+	failOnEmpty bool
+	// orderedApply sorts the resources by template file instead of by kind.
+	orderedApply bool
+}
+
+// renderResources renders a chart into its hooks, manifest and notes, along
+// with warnings about malformed hook annotations.
+//
+// Rendered resources are passed through the server's manifest transformers,
+// followed by any extra transformers given for this render.
+func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values, opts renderOptions, vs chartutil.VersionSet, extra ...ManifestTransformer) ([]*release.Hook, *bytes.Buffer, string, []string, error) {
 	// Guard to make sure Tiller is at the right version to handle this chart.
 	sver := version.GetVersion()
 	if ch.Metadata.TillerVersion != "" &&
 		!version.IsCompatibleRange(ch.Metadata.TillerVersion, sver) {
-		return nil, nil, "", fmt.Errorf("Chart incompatible with Tiller %s", sver)
+		return nil, nil, "", nil, fmt.Errorf("Chart incompatible with Tiller %s", sver)
 	}
 
 	if ch.Metadata.KubeVersion != "" {
@@ -257,7 +299,7 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 		gitVersion := cap.KubeVersion.String()
 		k8sVersion := strings.Split(gitVersion, "+")[0]
 		if !version.IsCompatibleRange(ch.Metadata.KubeVersion, k8sVersion) {
-			return nil, nil, "", fmt.Errorf("Chart requires kubernetesVersion: %s which is incompatible with Kubernetes %s", ch.Metadata.KubeVersion, k8sVersion)
+			return nil, nil, "", nil, fmt.Errorf("Chart requires kubernetesVersion: %s which is incompatible with Kubernetes %s", ch.Metadata.KubeVersion, k8sVersion)
 		}
 	}
 
@@ -265,7 +307,7 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 	renderer := s.engine(ch)
 	files, err := renderer.Render(ch, values)
 	if err != nil {
-		return nil, nil, "", err
+		return nil, nil, "", nil, err
 	}
 
 	// NOTES.txt gets rendered like all the other files, but because it's not a hook nor a resource,
@@ -274,21 +316,33 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 	// look for terminating NOTES.txt. We also remove it from the files so that we don't have to skip
 	// it in the sortHooks.
 	notes := ""
+	subNotes := map[string]string{}
 	for k, v := range files {
 		if strings.HasSuffix(k, notesFileSuffix) {
-			// Only apply the notes if it belongs to the parent chart
+			// Only apply the notes if it belongs to the parent chart, unless
+			// the notes of subcharts were asked for as well.
 			// Note: Do not use filePath.Join since it creates a path with \ which is not expected
 			if k == path.Join(ch.Metadata.Name, "templates", notesFileSuffix) {
 				notes = v
+			} else if opts.subchartNotes && strings.TrimSpace(v) != "" {
+				subNotes[k] = v
 			}
 			delete(files, k)
 		}
 	}
+	notes = appendSubchartNotes(notes, subNotes)
+
+	if opts.failOnEmpty {
+		if empty := emptyTemplates(files); len(empty) > 0 {
+			return nil, nil, "", nil, fmt.Errorf("templates rendered no resources: %s (mark templates that may render empty with the comment %q)", strings.Join(empty, ", "), "# "+allowEmptyMarker)
+		}
+	}
 
 	// Sort hooks, manifests, and partials. Only hooks and manifests are returned,
 	// as partials are not used after renderer.Render. Empty manifests are also
-	// removed here.
-	hooks, manifests, err := sortManifests(files, vs, InstallOrder)
+	// removed here. Manifests are sorted by kind, or by template file if the
+	// resources are to be applied in file order.
+	hooks, manifests, warnings, err := splitManifests(files, vs)
 	if err != nil {
 		// By catching parse errors here, we can prevent bogus releases from going
 		// to Kubernetes.
@@ -300,20 +354,94 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 			if len(strings.TrimSpace(content)) == 0 {
 				continue
 			}
-			b.WriteString("\n---\n# Source: " + name + "\n")
+			b.WriteString(sourceSeparator + name + "\n")
 			b.WriteString(content)
 		}
-		return nil, b, "", err
+		return nil, b, "", nil, err
+	}
+	if opts.orderedApply {
+		manifests = sortByFile(manifests)
+	} else {
+		manifests = sortByKind(manifests, InstallOrder)
+	}
+
+	transformers := append(append([]ManifestTransformer(nil), s.transformers...), extra...)
+	if err := transformManifests(hooks, manifests, transformers); err != nil {
+		return nil, nil, "", nil, err
 	}
 
-	// Aggregate all valid manifests into one big doc.
+	// Aggregate all valid manifests into one big doc. The files in the crds/
+	// directories of the charts are not templates, so they are added as-is,
+	// ahead of the rendered manifests.
 	b := bytes.NewBuffer(nil)
+	for _, crd := range chartutil.CRDs(ch) {
+		b.WriteString(sourceSeparator + crd.Name + "\n")
+		b.Write(crd.Data)
+	}
 	for _, m := range manifests {
-		b.WriteString("\n---\n# Source: " + m.Name + "\n")
+		b.WriteString(sourceSeparator + m.Name + "\n")
 		b.WriteString(m.Content)
 	}
 
-	return hooks, b, notes, nil
+	return hooks, b, notes, warnings, nil
+}
+
+// sourceSeparator starts each document of the manifest of a release, and is
+// followed by the name of the file the document was rendered from.
+const sourceSeparator = "\n---\n# Source: "
+
+// allowEmptyMarker, as a comment in a template, exempts the template from the
+// check for templates that render empty.
+const allowEmptyMarker = "helm.sh/allow-empty"
+
+// emptyTemplates returns the sorted names of the rendered files that contain
+// nothing but whitespace, comments and document separators, skipping partials
+// and files marked with allowEmptyMarker.
+func emptyTemplates(files map[string]string) []string {
+	var empty []string
+	for name, content := range files {
+		if strings.HasPrefix(path.Base(name), "_") {
+			continue
+		}
+		isEmpty, allowed := true, false
+		for _, line := range strings.Split(content, "\n") {
+			line = strings.TrimSpace(line)
+			switch {
+			case line == "" || line == "---":
+			case strings.HasPrefix(line, "#"):
+				if strings.TrimSpace(strings.TrimPrefix(line, "#")) == allowEmptyMarker {
+					allowed = true
+				}
+			default:
+				isEmpty = false
+			}
+		}
+		if isEmpty && !allowed {
+			empty = append(empty, name)
+		}
+	}
+	sort.Strings(empty)
+	return empty
+}
+
+// appendSubchartNotes appends the notes rendered for subcharts, keyed by the
+// path of their NOTES.txt, to the notes of the chart. They are appended in
+// path order, each headed by the name of its subchart.
+func appendSubchartNotes(notes string, subNotes map[string]string) string {
+	paths := make([]string, 0, len(subNotes))
+	for p := range subNotes {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		if notes != "" {
+			notes = strings.TrimRight(notes, "\n") + "\n\n"
+		}
+		name := path.Base(path.Dir(path.Dir(p)))
+		notes += fmt.Sprintf("%s NOTES:\n%s", name, subNotes[p])
+	}
+	return notes
 }
 
 func (s *ReleaseServer) recordRelease(r *release.Release, reuse bool) {
@@ -326,7 +454,18 @@ func (s *ReleaseServer) recordRelease(r *release.Release, reuse bool) {
 	}
 }
 
-func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook string, timeout int64) error {
+// settle waits for the resources of a manifest to settle after they were
+// waited for, failing if they did not stay ready. It does nothing unless the
+// request waits and asks for a settle period of secs seconds. The wait ends
+// early if the request is cancelled.
+func (s *ReleaseServer) settle(c ctx.Context, namespace, manifest string, wait bool, secs int64) error {
+	if !wait || secs <= 0 {
+		return nil
+	}
+	return s.env.KubeClient.WaitForSettle(c, namespace, bytes.NewBufferString(manifest), time.Duration(secs)*time.Second)
+}
+
+func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook string, timeout int64, shouldWait bool) error {
 	kubeCli := s.env.KubeClient
 	code, ok := events[hook]
 	if !ok {
@@ -334,18 +473,12 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 	}
 
 	s.Log("executing %d %s hooks for %s", len(hs), hook, name)
-	executingHooks := []*release.Hook{}
-	for _, h := range hs {
-		for _, e := range h.Events {
-			if e == code {
-				executingHooks = append(executingHooks, h)
-			}
-		}
-	}
-
-	executingHooks = sortByHookWeight(executingHooks)
+	executingHooks := hooks.ForEvent(hs, code)
 
 	for _, h := range executingHooks {
+		if err := s.deleteHookByPolicy(h, hooks.BeforeHookCreation, name, namespace, hook, kubeCli); err != nil {
+			return err
+		}
 
 		b := bytes.NewBufferString(h.Manifest)
 		if err := kubeCli.Create(namespace, b, timeout, false); err != nil {
@@ -355,18 +488,12 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 		// No way to rewind a bytes.Buffer()?
 		b.Reset()
 		b.WriteString(h.Manifest)
-		if err := kubeCli.WatchUntilReady(namespace, b, timeout, false); err != nil {
+		if err := kubeCli.WatchUntilReady(namespace, b, timeout, shouldWait); err != nil {
 			s.Log("warning: Release %s %s %s could not complete: %s", name, hook, h.Path, err)
 			// If a hook is failed, checkout the annotation of the hook to determine whether the hook should be deleted
 			// under failed condition. If so, then clear the corresponding resource object in the hook
-			if hookShouldBeDeleted(h, hooks.HookFailed) {
-				b.Reset()
-				b.WriteString(h.Manifest)
-				s.Log("deleting %s hook %s for release %s due to %q policy", hook, h.Name, name, hooks.HookFailed)
-				if errHookDelete := kubeCli.Delete(namespace, b); errHookDelete != nil {
-					s.Log("warning: Release %s %s %S could not be deleted: %s", name, hook, h.Path, errHookDelete)
-					return errHookDelete
-				}
+			if errHookDelete := s.deleteHookByPolicy(h, hooks.HookFailed, name, namespace, hook, kubeCli); errHookDelete != nil {
+				return errHookDelete
 			}
 			return err
 		}
@@ -376,13 +503,8 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 	// If all hooks are succeeded, checkout the annotation of each hook to determine whether the hook should be deleted
 	// under succeeded condition. If so, then clear the corresponding resource object in each hook
 	for _, h := range executingHooks {
-		b := bytes.NewBufferString(h.Manifest)
-		if hookShouldBeDeleted(h, hooks.HookSucceeded) {
-			s.Log("deleting %s hook %s for release %s due to %q policy", hook, h.Name, name, hooks.HookSucceeded)
-			if errHookDelete := kubeCli.Delete(namespace, b); errHookDelete != nil {
-				s.Log("warning: Release %s %s %S could not be deleted: %s", name, hook, h.Path, errHookDelete)
-				return errHookDelete
-			}
+		if err := s.deleteHookByPolicy(h, hooks.HookSucceeded, name, namespace, hook, kubeCli); err != nil {
+			return err
 		}
 		h.LastRun = timeconv.Now()
 	}
@@ -390,6 +512,21 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 	return nil
 }
 
+// deleteHookByPolicy deletes the resources of a hook if its delete policies
+// include the given policy.
+func (s *ReleaseServer) deleteHookByPolicy(h *release.Hook, policy, name, namespace, hook string, kubeCli environment.KubeClient) error {
+	if !hookShouldBeDeleted(h, policy) {
+		return nil
+	}
+	s.Log("deleting %s hook %s for release %s due to %q policy", hook, h.Name, name, policy)
+	b := bytes.NewBufferString(h.Manifest)
+	if err := kubeCli.Delete(namespace, b); err != nil {
+		s.Log("warning: Release %s %s %s could not be deleted: %s", name, hook, h.Path, err)
+		return err
+	}
+	return nil
+}
+
 func validateManifest(c environment.KubeClient, ns string, manifest []byte) error {
 	r := bytes.NewReader(manifest)
 	_, err := c.BuildUnstructured(ns, r)
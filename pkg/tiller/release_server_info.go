@@ -0,0 +1,75 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"sort"
+
+	ctx "golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/version"
+)
+
+// serverFeatures are the optional request features every Tiller of this
+// version supports.
+var serverFeatures = []string{
+	"api-check",
+	"atomic",
+	"cleanup-on-fail",
+	"common-labels",
+	"fail-on-empty",
+	"get-release-all",
+	"install-stream",
+	"patches",
+	"pause",
+	"quota-check",
+	"render-release",
+	"skip-crds",
+	"subchart-notes",
+	"values-from",
+}
+
+// GetServerInfo sends the server version, the storage driver and the features
+// the server supports.
+//
+// Only the names of features are sent, never the configuration behind them.
+func (s *ReleaseServer) GetServerInfo(c ctx.Context, req *services.GetServerInfoRequest) (*services.GetServerInfoResponse, error) {
+	return &services.GetServerInfoResponse{
+		Version:       version.GetVersionProto(),
+		StorageDriver: s.env.Releases.Name(),
+		Features:      s.features(),
+	}, nil
+}
+
+// features returns the sorted names of the features the server supports,
+// including those that depend on how the server is configured.
+func (s *ReleaseServer) features() []string {
+	features := append([]string(nil), serverFeatures...)
+	if _, ok := s.ReleaseModule.(*RemoteReleaseModule); ok {
+		features = append(features, "experimental-release")
+	}
+	if _, ok := s.env.Releases.Driver.(driver.Locker); ok {
+		features = append(features, "release-locks")
+	}
+	if s.env.Releases.MaxHistory > 0 {
+		features = append(features, "history-max")
+	}
+	sort.Strings(features)
+	return features
+}
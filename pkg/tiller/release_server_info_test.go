@@ -0,0 +1,86 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/version"
+)
+
+func TestGetServerInfo(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	res, err := rs.GetServerInfo(c, &services.GetServerInfoRequest{})
+	if err != nil {
+		t.Fatalf("Failed to get server info: %s", err)
+	}
+	if res.Version.SemVer != version.GetVersion() {
+		t.Errorf("Expected version %q, got %q", version.GetVersion(), res.Version.SemVer)
+	}
+	if res.Version.GitCommit != version.GitCommit {
+		t.Errorf("Expected git commit %q, got %q", version.GitCommit, res.Version.GitCommit)
+	}
+	if res.StorageDriver != "Memory" {
+		t.Errorf("Expected storage driver Memory, got %q", res.StorageDriver)
+	}
+	expect := []string{
+		"api-check",
+		"atomic",
+		"cleanup-on-fail",
+		"common-labels",
+		"fail-on-empty",
+		"get-release-all",
+		"install-stream",
+		"patches",
+		"pause",
+		"quota-check",
+		"release-locks",
+		"render-release",
+		"skip-crds",
+		"subchart-notes",
+		"values-from",
+	}
+	if !reflect.DeepEqual(res.Features, expect) {
+		t.Errorf("Expected features %v, got %v", expect, res.Features)
+	}
+}
+
+func TestGetServerInfo_Config(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.ReleaseModule = &RemoteReleaseModule{}
+	rs.env.Releases.MaxHistory = 10
+
+	res, err := rs.GetServerInfo(c, &services.GetServerInfoRequest{})
+	if err != nil {
+		t.Fatalf("Failed to get server info: %s", err)
+	}
+	features := map[string]bool{}
+	for _, f := range res.Features {
+		features[f] = true
+	}
+	for _, f := range []string{"experimental-release", "history-max", "release-locks"} {
+		if !features[f] {
+			t.Errorf("Expected feature %q to be enabled, got %v", f, res.Features)
+		}
+	}
+}
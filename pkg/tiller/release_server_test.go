@@ -18,10 +18,14 @@ package tiller
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"golang.org/x/net/context"
@@ -29,6 +33,7 @@ import (
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/fake"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -297,12 +302,19 @@ func newUpdateFailingKubeClient() *updateFailingKubeClient {
 
 type updateFailingKubeClient struct {
 	environment.PrintingKubeClient
+	// opts are the options of the last UpdateWithOptions call.
+	opts *kube.UpdateOptions
 }
 
 func (u *updateFailingKubeClient) Update(namespace string, originalReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
 	return errors.New("Failed update in kube client")
 }
 
+func (u *updateFailingKubeClient) UpdateWithOptions(namespace string, originalReader, modifiedReader io.Reader, opts kube.UpdateOptions) error {
+	u.opts = &opts
+	return errors.New("Failed update in kube client")
+}
+
 func newHookFailingKubeClient() *hookFailingKubeClient {
 	return &hookFailingKubeClient{
 		PrintingKubeClient: environment.PrintingKubeClient{Out: os.Stdout},
@@ -317,6 +329,160 @@ func (h *hookFailingKubeClient) WatchUntilReady(ns string, r io.Reader, timeout
 	return errors.New("Failed watch")
 }
 
+// resourceTrackingKubeClient records the manifests that are currently created.
+// Watching a manifest that contains failOn fails.
+type resourceTrackingKubeClient struct {
+	environment.PrintingKubeClient
+	failOn    string
+	resources map[string]bool
+}
+
+func newResourceTrackingKubeClient(failOn string) *resourceTrackingKubeClient {
+	return &resourceTrackingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+		failOn:             failOn,
+		resources:          map[string]bool{},
+	}
+}
+
+func (k *resourceTrackingKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	k.resources[string(b)] = true
+	return nil
+}
+
+func (k *resourceTrackingKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	return k.Create(ns, r, opts.Timeout, opts.ShouldWait)
+}
+
+func (k *resourceTrackingKubeClient) Delete(ns string, r io.Reader) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	delete(k.resources, string(b))
+	return nil
+}
+
+func (k *resourceTrackingKubeClient) WatchUntilReady(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if strings.Contains(string(b), k.failOn) {
+		return errors.New("Failed watch")
+	}
+	return nil
+}
+
+// crdTrackingKubeClient records, in order, the kinds of the resources it is
+// asked to create and the CustomResourceDefinitions it waits on, and the
+// CustomResourceDefinitions passed along with each create. Creating with
+// ContinueOnError fails when the manifest contains failOn.
+type crdTrackingKubeClient struct {
+	environment.PrintingKubeClient
+	calls  []string
+	crds   []string
+	failOn string
+}
+
+var kindPattern = regexp.MustCompile(`(?m)^kind: (\w+)`)
+
+func (k *crdTrackingKubeClient) record(op string, r io.Reader) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var kinds []string
+	for _, m := range kindPattern.FindAllStringSubmatch(string(b), -1) {
+		kinds = append(kinds, m[1])
+	}
+	k.calls = append(k.calls, op+" "+strings.Join(kinds, ","))
+	return nil
+}
+
+func (k *crdTrackingKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	k.crds = append(k.crds, opts.CRDs)
+	if !opts.ContinueOnError {
+		return k.record("create", r)
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err := k.record("create all", strings.NewReader(string(b))); err != nil {
+		return err
+	}
+	if k.failOn != "" && strings.Contains(string(b), k.failOn) {
+		return errors.New("1 of 2 resource(s) failed:\nCronTab \"my-crontab\": admission denied")
+	}
+	return nil
+}
+
+func (k *crdTrackingKubeClient) WaitUntilCRDEstablished(r io.Reader, timeout time.Duration) error {
+	return k.record("wait", r)
+}
+
+// ownershipKubeClient fails to create or update resources owned by another
+// release, unless it is asked to take ownership of them.
+type ownershipKubeClient struct {
+	environment.PrintingKubeClient
+}
+
+func (k *ownershipKubeClient) conflict() error {
+	return &kube.OwnershipConflictError{Kind: "ConfigMap", Name: "shared", Owner: "other", Release: "mine"}
+}
+
+func (k *ownershipKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	return k.conflict()
+}
+
+func (k *ownershipKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	if !opts.TakeOwnership {
+		return k.conflict()
+	}
+	return nil
+}
+
+func (k *ownershipKubeClient) Update(ns string, currentReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
+	return k.conflict()
+}
+
+func (k *ownershipKubeClient) UpdateWithOptions(ns string, currentReader, modifiedReader io.Reader, opts kube.UpdateOptions) error {
+	if !opts.TakeOwnership {
+		return k.conflict()
+	}
+	return nil
+}
+
+// settleFailingKubeClient records the settle periods it is asked for, and
+// fails them as if a pod started crash-looping after the wait.
+type settleFailingKubeClient struct {
+	environment.PrintingKubeClient
+	settles []time.Duration
+}
+
+func (k *settleFailingKubeClient) WaitForSettle(c context.Context, ns string, r io.Reader, settle time.Duration) error {
+	k.settles = append(k.settles, settle)
+	return fmt.Errorf("resources did not stay ready for %v: pod %s/web is no longer ready", settle, ns)
+}
+
+// latestDescription returns the description of the newest revision of a
+// release as reported by GetHistory.
+func latestDescription(t *testing.T, rs *ReleaseServer, name string) string {
+	res, err := rs.GetHistory(helm.NewContext(), &services.GetHistoryRequest{Name: name, Max: 1})
+	if err != nil {
+		t.Fatalf("Failed to get history of %s: %s", name, err)
+	}
+	if len(res.Releases) != 1 {
+		t.Fatalf("Expected 1 revision of %s, got %d", name, len(res.Releases))
+	}
+	return res.Releases[0].Info.Description
+}
+
 type mockListServer struct {
 	val *services.ListReleasesResponse
 }
@@ -344,3 +510,18 @@ func (rs mockRunReleaseTestServer) SetTrailer(m metadata.MD)       {}
 func (rs mockRunReleaseTestServer) SendMsg(v interface{}) error    { return nil }
 func (rs mockRunReleaseTestServer) RecvMsg(v interface{}) error    { return nil }
 func (rs mockRunReleaseTestServer) Context() context.Context       { return helm.NewContext() }
+
+type mockInstallStreamServer struct {
+	events []*services.InstallReleaseEvent
+}
+
+func (s *mockInstallStreamServer) Send(e *services.InstallReleaseEvent) error {
+	s.events = append(s.events, e)
+	return nil
+}
+func (s *mockInstallStreamServer) SetHeader(m metadata.MD) error  { return nil }
+func (s *mockInstallStreamServer) SendHeader(m metadata.MD) error { return nil }
+func (s *mockInstallStreamServer) SetTrailer(m metadata.MD)       {}
+func (s *mockInstallStreamServer) SendMsg(v interface{}) error    { return nil }
+func (s *mockInstallStreamServer) RecvMsg(v interface{}) error    { return nil }
+func (s *mockInstallStreamServer) Context() context.Context       { return helm.NewContext() }
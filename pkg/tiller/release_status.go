@@ -21,6 +21,8 @@ import (
 	"fmt"
 
 	ctx "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -44,7 +46,7 @@ func (s *ReleaseServer) GetReleaseStatus(c ctx.Context, req *services.GetRelease
 	} else {
 		var err error
 		if rel, err = s.env.Releases.Get(req.Name, req.Version); err != nil {
-			return nil, fmt.Errorf("getting release '%s' (v%d): %s", req.Name, req.Version, err)
+			return nil, grpc.Errorf(codes.NotFound, "release %q has no revision %d: %s", req.Name, req.Version, err)
 		}
 	}
 
@@ -60,6 +62,18 @@ func (s *ReleaseServer) GetReleaseStatus(c ctx.Context, req *services.GetRelease
 		Name:      rel.Name,
 		Namespace: rel.Namespace,
 		Info:      rel.Info,
+		Version:   rel.Version,
+	}
+
+	if req.Version > 0 {
+		statusResp.Config = rel.Config
+		statusResp.Manifest = rel.Manifest
+
+		// The resources in the cluster belong to the latest revision, so an
+		// older revision is described as it was stored.
+		if last, err := s.env.Releases.Last(req.Name); err == nil && last.Version != rel.Version {
+			return statusResp, nil
+		}
 	}
 
 	// Ok, we got the status of the release as we had jotted down, now we need to match the
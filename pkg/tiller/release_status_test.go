@@ -17,9 +17,14 @@ limitations under the License.
 package tiller
 
 import (
+	"strings"
 	"testing"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
 )
@@ -63,3 +68,57 @@ func TestGetReleaseStatusDeleted(t *testing.T) {
 		t.Errorf("Expected %d, got %d", release.Status_DELETED, res.Info.Status.Code)
 	}
 }
+
+func TestGetReleaseStatusRevision(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Info.Status.Code = release.Status_SUPERSEDED
+	rel.Manifest = "kind: ConfigMap"
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+	rel2 := releaseStub()
+	rel2.Version = 2
+	rel2.Config = &chart.Config{Raw: "name: newer"}
+	if err := rs.env.Releases.Create(rel2); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	res, err := rs.GetReleaseStatus(c, &services.GetReleaseStatusRequest{Name: rel.Name, Version: 1})
+	if err != nil {
+		t.Fatalf("Error getting release status: %s", err)
+	}
+	if res.Version != 1 {
+		t.Errorf("Expected revision 1, got %d", res.Version)
+	}
+	if res.Info.Status.Code != release.Status_SUPERSEDED {
+		t.Errorf("Expected %s, got %s", release.Status_SUPERSEDED, res.Info.Status.Code)
+	}
+	if res.Config.Raw != rel.Config.Raw {
+		t.Errorf("Expected values %q, got %q", rel.Config.Raw, res.Config.Raw)
+	}
+	if res.Manifest != rel.Manifest {
+		t.Errorf("Expected manifest %q, got %q", rel.Manifest, res.Manifest)
+	}
+}
+
+func TestGetReleaseStatusMissingRevision(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	_, err := rs.GetReleaseStatus(c, &services.GetReleaseStatusRequest{Name: rel.Name, Version: 5})
+	if err == nil {
+		t.Fatal("Expected an error for a missing revision")
+	}
+	if code := grpc.Code(err); code != codes.NotFound {
+		t.Errorf("Expected code %s, got %s", codes.NotFound, code)
+	}
+	if !strings.Contains(err.Error(), "has no revision 5") {
+		t.Errorf("Expected error to name the revision, got %q", err)
+	}
+}
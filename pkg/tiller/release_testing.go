@@ -49,6 +49,11 @@ func (s *ReleaseServer) RunReleaseTest(req *services.TestReleaseRequest, stream
 		return err
 	}
 
+	if err := tSuite.Filter(req.Filters); err != nil {
+		s.Log("error filtering tests for %s: %s", rel.Name, err)
+		return err
+	}
+
 	if err := tSuite.Run(testEnv); err != nil {
 		s.Log("error running test suite for %s: %s", rel.Name, err)
 		return err
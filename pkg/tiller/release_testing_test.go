@@ -17,6 +17,7 @@ limitations under the License.
 package tiller
 
 import (
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/proto/hapi/release"
@@ -34,3 +35,15 @@ func TestRunReleaseTest(t *testing.T) {
 		t.Fatalf("failed to run release tests on %s: %s", rel.Name, err)
 	}
 }
+
+func TestRunReleaseTest_NoMatchingTests(t *testing.T) {
+	rs := rsFixture()
+	rel := namedReleaseStub("nemo", release.Status_DEPLOYED)
+	rs.env.Releases.Create(rel)
+
+	req := &services.TestReleaseRequest{Name: "nemo", Timeout: 2, Filters: []string{"name=~smoke"}}
+	err := rs.RunReleaseTest(req, mockRunReleaseTestServer{})
+	if err == nil || !strings.Contains(err.Error(), "no tests match") {
+		t.Errorf("expected an error for filters matching no tests, got %v", err)
+	}
+}
@@ -18,6 +18,7 @@ package tiller
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	ctx "golang.org/x/net/context"
@@ -35,6 +36,18 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 		s.Log("uninstallRelease: Release name is invalid: %s", req.Name)
 		return nil, err
 	}
+	if opts := DeleteOptions(req.Cascade, req.GracePeriod); opts != nil {
+		if err := opts.Validate(); err != nil {
+			s.Log("uninstallRelease: %s", err)
+			return nil, err
+		}
+	}
+
+	unlock, err := s.lockRelease(req.Name, req.Timeout, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
 	rels, err := s.env.Releases.History(req.Name)
 	if err != nil {
@@ -62,19 +75,26 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 	}
 
 	s.Log("uninstall: Deleting %s", req.Name)
-	rel.Info.Status.Code = release.Status_DELETING
-	rel.Info.Deleted = timeconv.Now()
-	rel.Info.Description = "Deletion in progress (or silently failed)"
 	res := &services.UninstallReleaseResponse{Release: rel}
+	es := []string{}
 
 	if !req.DisableHooks {
-		if err := s.execHook(rel.Hooks, rel.Name, rel.Namespace, hooks.PreDelete, req.Timeout); err != nil {
-			return res, err
+		if err := s.execHook(rel.Hooks, rel.Name, rel.Namespace, hooks.PreDelete, req.Timeout, req.Wait); err != nil {
+			if !req.ContinueOnHookFailure {
+				s.Log("uninstall: pre-delete hook failed, aborting deletion of %s: %s", req.Name, err)
+				return res, fmt.Errorf("pre-delete hook failed, release %q was not deleted: %s", req.Name, err)
+			}
+			s.Log("uninstall: pre-delete hook failed, continuing deletion of %s: %s", req.Name, err)
+			es = append(es, err.Error())
 		}
 	} else {
 		s.Log("delete hooks disabled for %s", req.Name)
 	}
 
+	rel.Info.Status.Code = release.Status_DELETING
+	rel.Info.Deleted = timeconv.Now()
+	rel.Info.Description = "Deletion in progress (or silently failed)"
+
 	// From here on out, the release is currently considered to be in Status_DELETING
 	// state.
 	if err := s.env.Releases.Update(rel); err != nil {
@@ -84,14 +104,13 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 	kept, errs := s.ReleaseModule.Delete(rel, req, s.env)
 	res.Info = kept
 
-	es := make([]string, 0, len(errs))
 	for _, e := range errs {
 		s.Log("error: %v", e)
 		es = append(es, e.Error())
 	}
 
 	if !req.DisableHooks {
-		if err := s.execHook(rel.Hooks, rel.Name, rel.Namespace, hooks.PostDelete, req.Timeout); err != nil {
+		if err := s.execHook(rel.Hooks, rel.Name, rel.Namespace, hooks.PostDelete, req.Timeout, req.Wait); err != nil {
 			es = append(es, err.Error())
 		}
 	}
@@ -126,3 +145,198 @@ func (s *ReleaseServer) purgeReleases(rels ...*release.Release) error {
 	}
 	return nil
 }
+
+// DeleteReleases deletes the releases named in the request and those matched
+// by its filter, one after the other. A failure to delete a release is
+// reported in its result and does not stop the others from being deleted.
+func (s *ReleaseServer) DeleteReleases(c ctx.Context, req *services.DeleteReleasesRequest) (*services.DeleteReleasesResponse, error) {
+	names, err := s.releasesToDelete(req)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := services.UninstallReleaseRequest{}
+	if req.Options != nil {
+		opts = *req.Options
+	}
+
+	res := &services.DeleteReleasesResponse{}
+	for _, name := range names {
+		result := &services.DeleteReleasesResult{Name: name}
+		var (
+			r   *services.UninstallReleaseResponse
+			err error
+		)
+		if req.DryRun {
+			r, err = s.lastRelease(name, opts.Purge)
+		} else {
+			ureq := opts
+			ureq.Name = name
+			r, err = s.UninstallRelease(c, &ureq)
+		}
+		if err != nil {
+			s.Log("delete releases: failed to delete %s: %s", name, err)
+			result.Error = err.Error()
+		} else {
+			result.Response = r
+		}
+		res.Results = append(res.Results, result)
+	}
+	return res, nil
+}
+
+// releasesToDelete returns the names of the releases a DeleteReleasesRequest
+// deletes: the names it lists, followed by the sorted names of the releases
+// its filter matches and then of those its selector matches, without
+// duplicates.
+func (s *ReleaseServer) releasesToDelete(req *services.DeleteReleasesRequest) ([]string, error) {
+	if len(req.Names) == 0 && req.Filter == "" && req.Selector == "" {
+		return nil, errMissingRelease
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	add := func(matched []string) {
+		sort.Strings(matched)
+		for _, name := range matched {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	for _, name := range req.Names {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	if req.Filter != "" {
+		matched, err := s.filteredReleases(req.Filter, req.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		add(matched)
+	}
+	if req.Selector != "" {
+		matched, err := s.selectedReleases(req.Selector, req.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		add(matched)
+	}
+	return names, nil
+}
+
+// filteredReleases returns the names of the releases that are not deleted and
+// whose names match filter.
+func (s *ReleaseServer) filteredReleases(filter, namespace string) ([]string, error) {
+	rels, err := s.env.Releases.ListFilterAll(func(r *release.Release) bool {
+		return r.Info.Status.Code != release.Status_DELETED
+	})
+	if err != nil {
+		return nil, err
+	}
+	if namespace != "" {
+		if rels, err = filterByNamespace(namespace, rels); err != nil {
+			return nil, err
+		}
+	}
+	if rels, err = filterReleases(filter, rels); err != nil {
+		return nil, err
+	}
+	matched := make([]string, 0, len(rels))
+	for _, r := range rels {
+		matched = append(matched, r.Name)
+	}
+	return matched, nil
+}
+
+// selectedReleases returns the names of the releases whose last revision is
+// stored with all of the labels of selector. Deleted releases are only
+// matched if the selector sets STATUS.
+func (s *ReleaseServer) selectedReleases(selector, namespace string) ([]string, error) {
+	labels, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	rels, err := s.env.Releases.Query(labels)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []string
+	seen := map[string]bool{}
+	for _, r := range rels {
+		if seen[r.Name] {
+			continue
+		}
+		seen[r.Name] = true
+
+		last, err := s.env.Releases.Last(r.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !revisionMatched(rels, last) {
+			continue
+		}
+		if _, ok := labels["STATUS"]; !ok && last.Info.Status.Code == release.Status_DELETED {
+			continue
+		}
+		if namespace != "" && last.Namespace != namespace {
+			continue
+		}
+		matched = append(matched, last.Name)
+	}
+	return matched, nil
+}
+
+// revisionMatched reports whether rel is among the revisions rels.
+func revisionMatched(rels []*release.Release, rel *release.Release) bool {
+	for _, r := range rels {
+		if r.Name == rel.Name && r.Version == rel.Version {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSelector parses a comma-separated list of key=value storage labels.
+// Keys, and the value of STATUS, are not case sensitive.
+func parseSelector(selector string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, pair := range strings.Split(selector, ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("invalid selector %q: expected key=value pairs", selector)
+		}
+		key, value := strings.ToUpper(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1])
+		if key == "STATUS" {
+			value = strings.ToUpper(value)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+// lastRelease returns the latest revision of the named release the way
+// UninstallRelease would, without deleting it.
+func (s *ReleaseServer) lastRelease(name string, purge bool) (*services.UninstallReleaseResponse, error) {
+	if err := validateReleaseName(name); err != nil {
+		return nil, err
+	}
+	rels, err := s.env.Releases.History(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(rels) < 1 {
+		return nil, errMissingRelease
+	}
+	relutil.SortByRevision(rels)
+	rel := rels[len(rels)-1]
+	if rel.Info.Status.Code == release.Status_DELETED && !purge {
+		return nil, fmt.Errorf("the release named %q is already deleted", name)
+	}
+	return &services.UninstallReleaseResponse{Release: rel}, nil
+}
@@ -17,12 +17,18 @@ limitations under the License.
 package tiller
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
 )
 
 func TestUninstallRelease(t *testing.T) {
@@ -176,3 +182,326 @@ func TestUninstallReleaseNoHooks(t *testing.T) {
 		t.Errorf("Expected LastRun to be zero, got %d.", res.Release.Hooks[0].LastRun.Seconds)
 	}
 }
+
+type deleteOptionsKubeClient struct {
+	environment.PrintingKubeClient
+	opts []*kube.DeleteOptions
+}
+
+func (k *deleteOptionsKubeClient) DeleteWithOptions(ns string, r io.Reader, opts *kube.DeleteOptions) error {
+	k.opts = append(k.opts, opts)
+	return nil
+}
+
+func TestUninstallReleaseDeleteOptions(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := &deleteOptionsKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+	rs.env.KubeClient = kubeClient
+	rs.env.Releases.Create(releaseStub())
+
+	req := &services.UninstallReleaseRequest{
+		Name:        "angry-panda",
+		Cascade:     "Orphan",
+		GracePeriod: 30,
+	}
+
+	if _, err := rs.UninstallRelease(c, req); err != nil {
+		t.Fatalf("Failed uninstall: %s", err)
+	}
+
+	if len(kubeClient.opts) == 0 {
+		t.Fatal("Expected resources to be deleted with options")
+	}
+	for _, opts := range kubeClient.opts {
+		if opts.Cascade != "Orphan" {
+			t.Errorf("Expected cascade Orphan, got %q", opts.Cascade)
+		}
+		if opts.GracePeriod != 30 {
+			t.Errorf("Expected grace period 30, got %d", opts.GracePeriod)
+		}
+	}
+}
+
+func TestUninstallReleaseInvalidCascade(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.Releases.Create(releaseStub())
+
+	req := &services.UninstallReleaseRequest{
+		Name:    "angry-panda",
+		Cascade: "sideways",
+	}
+
+	if _, err := rs.UninstallRelease(c, req); err == nil {
+		t.Fatal("Expected an invalid cascade to be rejected")
+	}
+
+	rel, err := rs.env.Releases.Get("angry-panda", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("Expected release to stay DEPLOYED, got %s", rel.Info.Status.Code)
+	}
+}
+
+// hookRecordingKubeClient records the hook watches and deletions it is asked
+// to perform, in order. Watches fail if failWatch is set.
+type hookRecordingKubeClient struct {
+	environment.PrintingKubeClient
+	failWatch bool
+	calls     []string
+}
+
+func (k *hookRecordingKubeClient) WatchUntilReady(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	k.calls = append(k.calls, fmt.Sprintf("watch(wait=%t)", shouldWait))
+	if k.failWatch {
+		return errors.New("Job failed: BackoffLimitExceeded")
+	}
+	return nil
+}
+
+func (k *hookRecordingKubeClient) Delete(ns string, r io.Reader) error {
+	k.calls = append(k.calls, "delete")
+	return nil
+}
+
+func TestUninstallReleaseWaitsForPreDeleteHook(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := &hookRecordingKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+	rs.env.KubeClient = kubeClient
+	rs.env.Releases.Create(releaseStub())
+
+	req := &services.UninstallReleaseRequest{
+		Name: "angry-panda",
+		Wait: true,
+	}
+
+	if _, err := rs.UninstallRelease(c, req); err != nil {
+		t.Fatalf("Failed uninstall: %s", err)
+	}
+
+	if len(kubeClient.calls) < 2 || kubeClient.calls[0] != "watch(wait=true)" {
+		t.Fatalf("Expected the pre-delete hook to be awaited first, got %v", kubeClient.calls)
+	}
+	for _, call := range kubeClient.calls[1:] {
+		if call != "delete" {
+			t.Errorf("Expected only deletions after the pre-delete hook, got %v", kubeClient.calls)
+		}
+	}
+}
+
+func TestUninstallReleasePreDeleteHookFailure(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := &hookRecordingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+		failWatch:          true,
+	}
+	rs.env.KubeClient = kubeClient
+	rs.env.Releases.Create(releaseStub())
+
+	req := &services.UninstallReleaseRequest{
+		Name: "angry-panda",
+		Wait: true,
+	}
+
+	_, err := rs.UninstallRelease(c, req)
+	if err == nil {
+		t.Fatal("Expected a failing pre-delete hook to abort the deletion")
+	}
+	if !strings.Contains(err.Error(), "was not deleted") {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	for _, call := range kubeClient.calls {
+		if call == "delete" {
+			t.Errorf("Expected no resources to be deleted, got %v", kubeClient.calls)
+		}
+	}
+
+	rel, err := rs.env.Releases.Get("angry-panda", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("Expected release to stay DEPLOYED, got %s", rel.Info.Status.Code)
+	}
+}
+
+func TestUninstallReleaseContinueOnHookFailure(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := &hookRecordingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+		failWatch:          true,
+	}
+	rs.env.KubeClient = kubeClient
+	rs.env.Releases.Create(releaseStub())
+
+	req := &services.UninstallReleaseRequest{
+		Name:                  "angry-panda",
+		ContinueOnHookFailure: true,
+	}
+
+	res, err := rs.UninstallRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), "BackoffLimitExceeded") {
+		t.Errorf("Expected the hook failure to be reported, got %v", err)
+	}
+
+	deleted := false
+	for _, call := range kubeClient.calls {
+		deleted = deleted || call == "delete"
+	}
+	if !deleted {
+		t.Errorf("Expected resources to be deleted, got %v", kubeClient.calls)
+	}
+
+	if res.Release.Info.Status.Code != release.Status_DELETED {
+		t.Errorf("Expected status code to be DELETED, got %s", res.Release.Info.Status.Code)
+	}
+}
+
+func TestDeleteReleases(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	for _, rel := range []*release.Release{
+		namedReleaseStub("ci-1", release.Status_DEPLOYED),
+		namedReleaseStub("ci-2", release.Status_FAILED),
+		namedReleaseStub("ci-3", release.Status_DELETED),
+		namedReleaseStub("keep-me", release.Status_DEPLOYED),
+	} {
+		rs.env.Releases.Create(rel)
+	}
+
+	req := &services.DeleteReleasesRequest{
+		Names:   []string{"ci-1", "missing"},
+		Filter:  "^ci-",
+		Options: &services.UninstallReleaseRequest{Name: "ignored", DisableHooks: true},
+	}
+	res, err := rs.DeleteReleases(c, req)
+	if err != nil {
+		t.Fatalf("Failed to delete releases: %s", err)
+	}
+
+	var names []string
+	for _, r := range res.Results {
+		names = append(names, r.Name)
+	}
+	if expect := "ci-1 missing ci-2"; strings.Join(names, " ") != expect {
+		t.Fatalf("Expected results for %q, got %q", expect, names)
+	}
+	for _, r := range []*services.DeleteReleasesResult{res.Results[0], res.Results[2]} {
+		if r.Error != "" || r.Response == nil {
+			t.Errorf("Expected %s to be deleted, got error %q", r.Name, r.Error)
+			continue
+		}
+		if r.Response.Release.Info.Status.Code != release.Status_DELETED {
+			t.Errorf("Expected %s to be DELETED, got %s", r.Name, r.Response.Release.Info.Status.Code)
+		}
+	}
+	if res.Results[1].Error == "" || res.Results[1].Response != nil {
+		t.Errorf("Expected deleting a missing release to fail, got %v", res.Results[1])
+	}
+
+	rel, err := rs.env.Releases.Get("keep-me", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("Expected keep-me to be left deployed, got %s", rel.Info.Status.Code)
+	}
+}
+
+func TestDeleteReleasesDryRun(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.Releases.Create(namedReleaseStub("ci-1", release.Status_DEPLOYED))
+	rs.env.Releases.Create(namedReleaseStub("ci-2", release.Status_DELETED))
+
+	req := &services.DeleteReleasesRequest{
+		Names:  []string{"ci-1", "ci-2"},
+		DryRun: true,
+	}
+	res, err := rs.DeleteReleases(c, req)
+	if err != nil {
+		t.Fatalf("Failed to delete releases: %s", err)
+	}
+	if len(res.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(res.Results))
+	}
+	if res.Results[0].Error != "" || res.Results[0].Response.Release.Name != "ci-1" {
+		t.Errorf("Expected ci-1 to be deletable, got %v", res.Results[0])
+	}
+	if !strings.Contains(res.Results[1].Error, "already deleted") {
+		t.Errorf("Expected ci-2 to be reported as already deleted, got %v", res.Results[1])
+	}
+
+	rel, err := rs.env.Releases.Get("ci-1", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("Expected a dry run to leave ci-1 deployed, got %s", rel.Info.Status.Code)
+	}
+}
+
+func TestDeleteReleasesSelector(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	recovered := namedReleaseStub("recovered", release.Status_FAILED)
+	rs.env.Releases.Create(recovered)
+	upgraded := namedReleaseStub("recovered", release.Status_DEPLOYED)
+	upgraded.Version = 2
+	for _, rel := range []*release.Release{
+		upgraded,
+		namedReleaseStub("broken-2", release.Status_FAILED),
+		namedReleaseStub("broken-1", release.Status_FAILED),
+		namedReleaseStub("healthy", release.Status_DEPLOYED),
+	} {
+		rs.env.Releases.Create(rel)
+	}
+
+	req := &services.DeleteReleasesRequest{
+		Selector: "status=failed",
+		Options:  &services.UninstallReleaseRequest{DisableHooks: true},
+	}
+	res, err := rs.DeleteReleases(c, req)
+	if err != nil {
+		t.Fatalf("Failed to delete releases: %s", err)
+	}
+
+	var names []string
+	for _, r := range res.Results {
+		if r.Error != "" {
+			t.Errorf("Expected %s to be deleted, got error %q", r.Name, r.Error)
+		}
+		names = append(names, r.Name)
+	}
+	if expect := "broken-1 broken-2"; strings.Join(names, " ") != expect {
+		t.Fatalf("Expected results for %q, got %q", expect, names)
+	}
+	for _, name := range []string{"recovered", "healthy"} {
+		rel, err := rs.env.Releases.Last(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rel.Info.Status.Code != release.Status_DEPLOYED {
+			t.Errorf("Expected %s to be kept, got %s", name, rel.Info.Status.Code)
+		}
+	}
+
+	if _, err := rs.DeleteReleases(c, &services.DeleteReleasesRequest{Selector: "STATUS"}); err == nil {
+		t.Error("Expected an error for a malformed selector")
+	}
+}
+
+func TestDeleteReleasesNoReleases(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	if _, err := rs.DeleteReleases(c, &services.DeleteReleasesRequest{}); err != errMissingRelease {
+		t.Errorf("Expected %v, got %v", errMissingRelease, err)
+	}
+}
@@ -34,12 +34,29 @@ func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateRelease
 		s.Log("updateRelease: Release name is invalid: %s", req.Name)
 		return nil, err
 	}
+	unlock, err := s.lockRelease(req.Name, req.Timeout, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	s.Log("preparing update for %s", req.Name)
 	currentRelease, updatedRelease, err := s.prepareUpdate(req)
 	if err != nil {
 		return nil, err
 	}
 
+	if req.SkipUnchanged && !req.Force && unchangedRelease(currentRelease, updatedRelease) {
+		s.Log("release %s is unchanged, keeping revision %d", req.Name, currentRelease.Version)
+		if !req.DryRun {
+			currentRelease.Info.LastDeployed = updatedRelease.Info.LastDeployed
+			if err := s.env.Releases.Update(currentRelease); err != nil {
+				return nil, err
+			}
+		}
+		return &services.UpdateReleaseResponse{Release: currentRelease}, nil
+	}
+
 	if !req.DryRun {
 		s.Log("creating updated release for %s", req.Name)
 		if err := s.env.Releases.Create(updatedRelease); err != nil {
@@ -48,7 +65,7 @@ func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateRelease
 	}
 
 	s.Log("performing update for %s", req.Name)
-	res, err := s.performUpdate(currentRelease, updatedRelease, req)
+	res, err := s.performUpdate(c, currentRelease, updatedRelease, req)
 	if err != nil {
 		return res, err
 	}
@@ -80,12 +97,27 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 		return nil, nil, err
 	}
 
+	// The ConfigMap goes beneath the values resolved above, whether given with
+	// the request or carried over from the current release.
+	if req.ValuesFrom != "" {
+		vals, err := s.mergeValuesFrom(req.ValuesFrom, currentRelease.Namespace, req.Values)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Values = vals
+	}
+
 	// finds the non-deleted release with the given name
 	lastRelease, err := s.env.Releases.Last(req.Name)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	paused := currentRelease.Info.Paused || lastRelease.Info.Paused
+	if paused && !req.ForcePaused {
+		return nil, nil, fmt.Errorf("release %q is paused", req.Name)
+	}
+
 	// Increment revision count. This is passed to templates, and also stored on
 	// the release object.
 	revision := lastRelease.Version + 1
@@ -108,10 +140,31 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 		return nil, nil, err
 	}
 
-	hooks, manifestDoc, notesTxt, err := s.renderResources(req.Chart, valuesToRender, caps.APIVersions)
+	transformers := []ManifestTransformer{releaseLabels(req.Name, int(revision))}
+	if req.CommonLabels {
+		transformers = append(transformers, commonLabels(req.Name, req.Chart.Metadata))
+	}
+	patches, err := parseResourcePatches(req.Patches)
 	if err != nil {
 		return nil, nil, err
 	}
+	if len(patches) > 0 {
+		transformers = append(transformers, patches)
+	}
+
+	opts := renderOptions{failOnEmpty: req.FailOnEmpty, orderedApply: req.OrderedApply}
+	hooks, manifestDoc, notesTxt, _, err := s.renderResources(req.Chart, valuesToRender, opts, caps.APIVersions, transformers...)
+	if err == nil {
+		err = patches.unmatched()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buildInfo := req.BuildInfo
+	if buildInfo == nil {
+		buildInfo = currentRelease.Info.BuildInfo
+	}
 
 	// Store an updated release.
 	updatedRelease := &release.Release{
@@ -124,6 +177,8 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 			LastDeployed:  ts,
 			Status:        &release.Status{Code: release.Status_PENDING_UPGRADE},
 			Description:   "Preparing upgrade", // This should be overwritten later.
+			Paused:        paused,
+			BuildInfo:     buildInfo,
 		},
 		Version:  revision,
 		Manifest: manifestDoc.String(),
@@ -137,7 +192,35 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 	return currentRelease, updatedRelease, err
 }
 
-func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.Release, req *services.UpdateReleaseRequest) (*services.UpdateReleaseResponse, error) {
+// unchangedRelease reports whether updated, built for the revision that
+// directly follows current, renders the same chart, values, manifest and hooks.
+// The revision labels of the resources are not compared.
+func unchangedRelease(current, updated *release.Release) bool {
+	if updated.Version != current.Version+1 {
+		return false
+	}
+	if _, manifest, err := relabelRevision(nil, current.Manifest, current.Version, updated.Version); err != nil || manifest != updated.Manifest {
+		return false
+	}
+	if current.Config.GetRaw() != updated.Config.GetRaw() {
+		return false
+	}
+	cm, um := current.Chart.GetMetadata(), updated.Chart.GetMetadata()
+	if cm.GetName() != um.GetName() || cm.GetVersion() != um.GetVersion() {
+		return false
+	}
+	if len(current.Hooks) != len(updated.Hooks) {
+		return false
+	}
+	for i, h := range current.Hooks {
+		if h.Path != updated.Hooks[i].Path || h.Manifest != updated.Hooks[i].Manifest {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *ReleaseServer) performUpdate(c ctx.Context, originalRelease, updatedRelease *release.Release, req *services.UpdateReleaseRequest) (*services.UpdateReleaseResponse, error) {
 	res := &services.UpdateReleaseResponse{Release: updatedRelease}
 
 	if req.DryRun {
@@ -148,13 +231,17 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 
 	// pre-upgrade hooks
 	if !req.DisableHooks {
-		if err := s.execHook(updatedRelease.Hooks, updatedRelease.Name, updatedRelease.Namespace, hooks.PreUpgrade, req.Timeout); err != nil {
+		if err := s.execHook(updatedRelease.Hooks, updatedRelease.Name, updatedRelease.Namespace, hooks.PreUpgrade, req.Timeout, false); err != nil {
 			return res, err
 		}
 	} else {
 		s.Log("update hooks disabled for %s", req.Name)
 	}
-	if err := s.ReleaseModule.Update(originalRelease, updatedRelease, req, s.env); err != nil {
+	err := s.ReleaseModule.Update(originalRelease, updatedRelease, req, s.env)
+	if err == nil {
+		err = s.settle(c, updatedRelease.Namespace, updatedRelease.Manifest, req.Wait, req.PostWaitSettle)
+	}
+	if err != nil {
 		msg := fmt.Sprintf("Upgrade %q failed: %s", updatedRelease.Name, err)
 		s.Log("warning: %s", msg)
 		updatedRelease.Info.Status.Code = release.Status_FAILED
@@ -166,7 +253,7 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 
 	// post-upgrade hooks
 	if !req.DisableHooks {
-		if err := s.execHook(updatedRelease.Hooks, updatedRelease.Name, updatedRelease.Namespace, hooks.PostUpgrade, req.Timeout); err != nil {
+		if err := s.execHook(updatedRelease.Hooks, updatedRelease.Name, updatedRelease.Namespace, hooks.PostUpgrade, req.Timeout, false); err != nil {
 			return res, err
 		}
 	}
@@ -176,6 +263,9 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 
 	updatedRelease.Info.Status.Code = release.Status_DEPLOYED
 	updatedRelease.Info.Description = "Upgrade complete"
+	if req.Description != "" {
+		updatedRelease.Info.Description = req.Description
+	}
 
 	return res, nil
 }
@@ -17,8 +17,11 @@ limitations under the License.
 package tiller
 
 import (
+	"io"
+	"io/ioutil"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 
@@ -26,6 +29,8 @@ import (
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/tiller/environment"
 )
 
 func TestUpdateRelease(t *testing.T) {
@@ -239,6 +244,103 @@ func TestUpdateReleaseFailure(t *testing.T) {
 	}
 }
 
+func TestUpdateReleaseFailure_CleanupOnFail(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	kubeClient := newUpdateFailingKubeClient()
+	rs.env.KubeClient = kubeClient
+
+	req := &services.UpdateReleaseRequest{
+		Name:          rel.Name,
+		DisableHooks:  true,
+		Chart:         rel.Chart,
+		Wait:          true,
+		CleanupOnFail: true,
+	}
+	res, err := rs.UpdateRelease(c, req)
+	if err == nil {
+		t.Fatal("Expected failed update")
+	}
+	if kubeClient.opts == nil || !kubeClient.opts.CleanupOnFail || !kubeClient.opts.ShouldWait {
+		t.Errorf("Expected the update to clean up on failure and wait, got options %+v", kubeClient.opts)
+	}
+	if status := res.Release.Info.Status.Code; status != release.Status_FAILED {
+		t.Errorf("Expected FAILED release. Got %s", status)
+	}
+}
+
+func TestUpdateRelease_TakeOwnership(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	rs.env.KubeClient = &ownershipKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+
+	req := &services.UpdateReleaseRequest{
+		Name:         rel.Name,
+		DisableHooks: true,
+		Chart:        rel.Chart,
+	}
+	if _, err := rs.UpdateRelease(c, req); err == nil || !strings.Contains(err.Error(), `is owned by release "other"`) {
+		t.Fatalf("Expected an ownership conflict, got %v", err)
+	}
+
+	req.TakeOwnership = true
+	res, err := rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Expected the upgrade to take ownership, got %s", err)
+	}
+	if status := res.Release.Info.Status.Code; status != release.Status_DEPLOYED {
+		t.Errorf("Expected DEPLOYED release, got %s", status)
+	}
+}
+
+func TestUpdateRelease_OrderedApply(t *testing.T) {
+	c := helm.NewContext()
+	templates := []*chart.Template{
+		{Name: "templates/a-deployment", Data: []byte("kind: Deployment\nmetadata:\n  name: web\n")},
+		{Name: "templates/b-configmap", Data: []byte("kind: ConfigMap\nmetadata:\n  name: web-config\n")},
+	}
+
+	for _, tt := range []struct {
+		orderedApply bool
+		expect       []string
+	}{
+		{false, []string{"kind: ConfigMap", "kind: Deployment"}},
+		{true, []string{"kind: Deployment", "kind: ConfigMap"}},
+	} {
+		rs := rsFixture()
+		rel := releaseStub()
+		rs.env.Releases.Create(rel)
+
+		req := &services.UpdateReleaseRequest{
+			Name:         rel.Name,
+			DisableHooks: true,
+			OrderedApply: tt.orderedApply,
+			Chart: &chart.Chart{
+				Metadata:  &chart.Metadata{Name: "hello"},
+				Templates: templates,
+			},
+		}
+		res, err := rs.UpdateRelease(c, req)
+		if err != nil {
+			t.Fatalf("Failed upgrade: %s", err)
+		}
+
+		last := -1
+		for _, kind := range tt.expect {
+			i := strings.Index(res.Release.Manifest, kind)
+			if i <= last {
+				t.Errorf("ordered apply %t: expected resources in the order %v, got:\n%s", tt.orderedApply, tt.expect, res.Release.Manifest)
+				break
+			}
+			last = i
+		}
+	}
+}
+
 func TestUpdateReleaseNoHooks(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -298,3 +400,289 @@ func compareStoredAndReturnedRelease(t *testing.T, rs ReleaseServer, res service
 
 	return storedRelease
 }
+
+func TestUpdateRelease_Description(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	for _, tt := range []struct {
+		description, expect string
+	}{
+		{"bump image to 1.2", "bump image to 1.2"},
+		{"", "Upgrade complete"},
+	} {
+		req := &services.UpdateReleaseRequest{
+			Name:        rel.Name,
+			Chart:       chartStub(),
+			Description: tt.description,
+		}
+		if _, err := rs.UpdateRelease(c, req); err != nil {
+			t.Fatalf("Failed update: %s", err)
+		}
+		if got := latestDescription(t, rs, rel.Name); got != tt.expect {
+			t.Errorf("Expected description %q, got %q", tt.expect, got)
+		}
+	}
+}
+
+func TestUpdateRelease_BuildInfo(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Info.BuildInfo = &release.BuildInfo{Pipeline: "deploy", Commit: "abc123", Actor: "ci-bot"}
+	rs.env.Releases.Create(rel)
+
+	build := &release.BuildInfo{Pipeline: "deploy", Commit: "def456", Actor: "jane"}
+	for _, tt := range []struct {
+		build, expect *release.BuildInfo
+	}{
+		{nil, rel.Info.BuildInfo},
+		{build, build},
+		{nil, build},
+	} {
+		req := &services.UpdateReleaseRequest{
+			Name:      rel.Name,
+			Chart:     chartStub(),
+			BuildInfo: tt.build,
+		}
+		res, err := rs.UpdateRelease(c, req)
+		if err != nil {
+			t.Fatalf("Failed update: %s", err)
+		}
+		if got := res.Release.Info.BuildInfo; !proto.Equal(got, tt.expect) {
+			t.Errorf("Expected build info %v, got %v", tt.expect, got)
+		}
+		stored, err := rs.env.Releases.Get(rel.Name, res.Release.Version)
+		if err != nil {
+			t.Fatalf("Failed to get release: %s", err)
+		}
+		if got := stored.Info.BuildInfo; !proto.Equal(got, tt.expect) {
+			t.Errorf("Expected stored build info %v, got %v", tt.expect, got)
+		}
+	}
+}
+
+// blockingKubeClient blocks updates until release is closed, signalling
+// started once an update is in progress.
+type blockingKubeClient struct {
+	environment.PrintingKubeClient
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingKubeClient) Update(namespace string, originalReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
+	close(b.started)
+	<-b.release
+	return nil
+}
+
+func TestUpdateRelease_Locked(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	kc := &blockingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+		started:            make(chan struct{}),
+		release:            make(chan struct{}),
+	}
+	rs.env.KubeClient = kc
+
+	req := &services.UpdateReleaseRequest{
+		Name:         rel.Name,
+		DisableHooks: true,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+			},
+		},
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := rs.UpdateRelease(c, req)
+		done <- err
+	}()
+	<-kc.started
+
+	// The first upgrade is still running, so a second one is rejected.
+	if _, err := rs.UpdateRelease(c, req); err == nil || !strings.Contains(err.Error(), "is locked") {
+		t.Errorf("Expected a concurrent upgrade to be rejected as locked, got %v", err)
+	}
+
+	close(kc.release)
+	if err := <-done; err != nil {
+		t.Fatalf("Failed first upgrade: %s", err)
+	}
+
+	// Once the first upgrade completed, its lock is released.
+	rs.env.KubeClient = &environment.PrintingKubeClient{Out: ioutil.Discard}
+	if _, err := rs.UpdateRelease(c, req); err != nil {
+		t.Errorf("Expected an upgrade after completion to succeed, got %s", err)
+	}
+}
+
+func TestUpdateRelease_StaleLock(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	// A lock left behind by an upgrade whose Tiller died.
+	locker := rs.env.Releases.Driver.(driver.Locker)
+	if err := locker.Lock(rel.Name, "dead-tiller", time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &services.UpdateReleaseRequest{
+		Name:         rel.Name,
+		DisableHooks: true,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+			},
+		},
+	}
+	if _, err := rs.UpdateRelease(c, req); err != nil {
+		t.Errorf("Expected the stale lock to be reclaimed, got %s", err)
+	}
+}
+
+func TestUpdateRelease_PostWaitSettle(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	kubeClient := &settleFailingKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+	rs.env.KubeClient = kubeClient
+
+	req := &services.UpdateReleaseRequest{
+		Name:           rel.Name,
+		Chart:          rel.Chart,
+		DisableHooks:   true,
+		Wait:           true,
+		PostWaitSettle: 10,
+	}
+	res, err := rs.UpdateRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), "is no longer ready") {
+		t.Fatalf("Expected the settle check to fail the upgrade, got %v", err)
+	}
+	if len(kubeClient.settles) != 1 || kubeClient.settles[0] != 10*time.Second {
+		t.Errorf("Expected a settle period of 10s, got %v", kubeClient.settles)
+	}
+	if code := res.Release.Info.Status.Code; code != release.Status_FAILED {
+		t.Errorf("Expected FAILED release, got %s", code)
+	}
+}
+
+func TestUpdateRelease_SkipUnchanged(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{
+		Name:  rel.Name,
+		Chart: rel.Chart,
+	}
+	res, err := rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	deployed := res.Release.Info.LastDeployed
+
+	for _, tt := range []struct {
+		name     string
+		skip     bool
+		force    bool
+		revision int32
+	}{
+		{"skip unchanged", true, false, 2},
+		{"force", true, true, 3},
+		{"no skip", false, false, 4},
+	} {
+		req := &services.UpdateReleaseRequest{
+			Name:          rel.Name,
+			Chart:         rel.Chart,
+			SkipUnchanged: tt.skip,
+			Force:         tt.force,
+		}
+		res, err := rs.UpdateRelease(c, req)
+		if err != nil {
+			t.Fatalf("%s: Failed updated: %s", tt.name, err)
+		}
+		if res.Release.Version != tt.revision {
+			t.Errorf("%s: Expected revision %d, got %d", tt.name, tt.revision, res.Release.Version)
+		}
+		if code := res.Release.Info.Status.Code; code != release.Status_DEPLOYED {
+			t.Errorf("%s: Expected DEPLOYED release, got %s", tt.name, code)
+		}
+		history, err := rs.env.Releases.History(rel.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(history) != int(tt.revision) {
+			t.Errorf("%s: Expected %d revisions, got %d", tt.name, tt.revision, len(history))
+		}
+	}
+
+	stored, err := rs.env.Releases.Get(rel.Name, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Info.LastDeployed.Seconds < deployed.Seconds {
+		t.Errorf("Expected the skipped upgrade to update the timestamp of revision 2")
+	}
+}
+
+func TestUpdateRelease_SkipUnchangedLabels(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	ch := transformerChartStub()
+	if _, err := rs.InstallRelease(c, &services.InstallReleaseRequest{Name: "labelled", Chart: ch}); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	// The revision labels differ, but the upgrade changes nothing else.
+	res, err := rs.UpdateRelease(c, &services.UpdateReleaseRequest{Name: "labelled", Chart: ch, SkipUnchanged: true})
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	if res.Release.Version != 1 {
+		t.Errorf("Expected revision 1 to be kept, got %d", res.Release.Version)
+	}
+}
+
+func TestUpdateRelease_SkipUnchangedValues(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{
+		Name:  rel.Name,
+		Chart: rel.Chart,
+	}
+	if _, err := rs.UpdateRelease(c, req); err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+
+	req = &services.UpdateReleaseRequest{
+		Name:          rel.Name,
+		Chart:         rel.Chart,
+		Values:        &chart.Config{Raw: "name: changed\n"},
+		SkipUnchanged: true,
+	}
+	res, err := rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	if res.Release.Version != 3 {
+		t.Errorf("Expected changed values to create revision 3, got %d", res.Release.Version)
+	}
+}
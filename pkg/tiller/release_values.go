@@ -0,0 +1,96 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// valuesFromDefaultKey is the ConfigMap key read when a values reference does
+// not name one.
+const valuesFromDefaultKey = "values.yaml"
+
+// mergeValuesFrom merges the values stored in the ConfigMap referenced by ref
+// beneath vals, so that the values of the request win. The ConfigMap must be
+// in namespace, the namespace of the release: Tiller reads it with its own
+// service account, which must not expose ConfigMaps of other namespaces.
+func (s *ReleaseServer) mergeValuesFrom(ref, namespace string, vals *chart.Config) (*chart.Config, error) {
+	base, err := s.valuesFromConfigMap(ref, namespace)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := chartutil.ReadValues([]byte(vals.GetRaw()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse values: %s", err)
+	}
+	raw, err := chartutil.Values(chartutil.DeepMerge(base, overrides)).YAML()
+	if err != nil {
+		return nil, err
+	}
+	return &chart.Config{Raw: raw}, nil
+}
+
+// valuesFromConfigMap reads the values stored under a key of a ConfigMap in
+// releaseNamespace.
+func (s *ReleaseServer) valuesFromConfigMap(ref, releaseNamespace string) (chartutil.Values, error) {
+	namespace, name, key, err := parseValuesFrom(ref)
+	if err != nil {
+		return nil, err
+	}
+	if namespace != releaseNamespace {
+		return nil, fmt.Errorf("values from %s: configmap must be in the namespace of the release, %q", ref, releaseNamespace)
+	}
+	cm, err := s.clientset.Core().ConfigMaps(namespace).Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("values from %s: configmap %q not found in namespace %q", ref, name, namespace)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("values from %s: %s", ref, err)
+	}
+	data, ok := cm.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("values from %s: configmap %q has no key %q", ref, name, key)
+	}
+	vals, err := chartutil.ReadValues([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("values from %s: failed to parse key %q: %s", ref, key, err)
+	}
+	return vals, nil
+}
+
+// parseValuesFrom splits a reference of the form configmap/NAMESPACE/NAME[:KEY].
+func parseValuesFrom(ref string) (namespace, name, key string, err error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) != 3 || parts[0] != "configmap" {
+		return "", "", "", fmt.Errorf("invalid values reference %q: expected configmap/NAMESPACE/NAME[:KEY]", ref)
+	}
+	namespace, name, key = parts[1], parts[2], valuesFromDefaultKey
+	if i := strings.Index(name, ":"); i >= 0 {
+		name, key = name[:i], name[i+1:]
+	}
+	if namespace == "" || name == "" || key == "" {
+		return "", "", "", fmt.Errorf("invalid values reference %q: expected configmap/NAMESPACE/NAME[:KEY]", ref)
+	}
+	return namespace, name, key, nil
+}
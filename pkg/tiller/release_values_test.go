@@ -0,0 +1,207 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/apis/core"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func valuesFromFixture(t *testing.T) *ReleaseServer {
+	rs := rsFixture()
+	_, err := rs.clientset.Core().ConfigMaps("config").Create(&core.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "nemo", Namespace: "config"},
+		Data: map[string]string{
+			"values.yaml": "image:\n  repository: clownfish\n  tag: \"1.0\"\nreplicas: 2\n",
+			"prod":        "replicas: 5\n",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rs
+}
+
+func TestInstallRelease_ValuesFrom(t *testing.T) {
+	c := helm.NewContext()
+	rs := valuesFromFixture(t)
+
+	req := &services.InstallReleaseRequest{
+		Namespace: "config",
+		Chart: &chart.Chart{
+			Metadata:  &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{{Name: "templates/hello", Data: []byte("hello: world")}},
+		},
+		Values:     &chart.Config{Raw: "image:\n  tag: \"2.0\"\n"},
+		ValuesFrom: "configmap/config/nemo",
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	vals, err := chartutil.ReadValues([]byte(res.Release.Config.Raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := chartutil.Values{
+		"image":    map[string]interface{}{"repository": "clownfish", "tag": "2.0"},
+		"replicas": float64(2),
+	}
+	if !reflect.DeepEqual(vals, expect) {
+		t.Errorf("Expected values %v, got %v", expect, vals)
+	}
+}
+
+func TestUpdateRelease_ValuesFrom(t *testing.T) {
+	c := helm.NewContext()
+	rs := valuesFromFixture(t)
+	rel := releaseStub()
+	rel.Namespace = "config"
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{
+		Name:       rel.Name,
+		Chart:      rel.Chart,
+		Values:     &chart.Config{Raw: "replicas: 3\n"},
+		ValuesFrom: "configmap/config/nemo:prod",
+	}
+	res, err := rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+
+	vals, err := chartutil.ReadValues([]byte(res.Release.Config.Raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vals["replicas"] != float64(3) {
+		t.Errorf("Expected the request to override the configmap, got %v", vals)
+	}
+}
+
+func TestUpdateRelease_ValuesFromOnly(t *testing.T) {
+	tests := []struct {
+		name        string
+		resetValues bool
+		expect      chartutil.Values
+	}{
+		{
+			name: "values of the current release are kept",
+			expect: chartutil.Values{
+				"image":    map[string]interface{}{"repository": "clownfish", "tag": "2.0"},
+				"replicas": float64(3),
+			},
+		},
+		{
+			name:        "reset values",
+			resetValues: true,
+			expect: chartutil.Values{
+				"image":    map[string]interface{}{"repository": "clownfish", "tag": "1.0"},
+				"replicas": float64(2),
+			},
+		},
+	}
+	for _, tt := range tests {
+		rs := valuesFromFixture(t)
+		rel := releaseStub()
+		rel.Namespace = "config"
+		rel.Config = &chart.Config{Raw: "image:\n  tag: \"2.0\"\nreplicas: 3\n"}
+		rs.env.Releases.Create(rel)
+
+		req := &services.UpdateReleaseRequest{
+			Name:        rel.Name,
+			Chart:       rel.Chart,
+			ResetValues: tt.resetValues,
+			ValuesFrom:  "configmap/config/nemo",
+		}
+		res, err := rs.UpdateRelease(helm.NewContext(), req)
+		if err != nil {
+			t.Fatalf("%s: failed update: %s", tt.name, err)
+		}
+
+		vals, err := chartutil.ReadValues([]byte(res.Release.Config.Raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(vals, tt.expect) {
+			t.Errorf("%s: expected values %v, got %v", tt.name, tt.expect, vals)
+		}
+	}
+}
+
+func TestInstallRelease_ValuesFromErrors(t *testing.T) {
+	tests := []struct {
+		ref    string
+		expect string
+	}{
+		{"configmap/config/dory", `configmap "dory" not found in namespace "config"`},
+		{"configmap/config/nemo:staging", `configmap "nemo" has no key "staging"`},
+		{"secret/config/nemo", "invalid values reference"},
+		{"configmap/kube-system/nemo", `configmap must be in the namespace of the release, "config"`},
+	}
+	for _, tt := range tests {
+		rs := valuesFromFixture(t)
+		req := &services.InstallReleaseRequest{
+			Namespace:  "config",
+			Chart:      chartStub(),
+			ValuesFrom: tt.ref,
+		}
+		_, err := rs.InstallRelease(helm.NewContext(), req)
+		if err == nil {
+			t.Errorf("%s: expected an error", tt.ref)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("%s: expected error containing %q, got %q", tt.ref, tt.expect, err)
+		}
+	}
+}
+
+func TestParseValuesFrom(t *testing.T) {
+	tests := []struct {
+		ref                  string
+		namespace, name, key string
+		ok                   bool
+	}{
+		{"configmap/config/nemo", "config", "nemo", "values.yaml", true},
+		{"configmap/config/nemo:prod", "config", "nemo", "prod", true},
+		{"configmap/nemo", "", "", "", false},
+		{"configmap/config/nemo:", "", "", "", false},
+		{"configmap//nemo", "", "", "", false},
+		{"secret/config/nemo", "", "", "", false},
+	}
+	for _, tt := range tests {
+		namespace, name, key, err := parseValuesFrom(tt.ref)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: expected ok=%t, got error %v", tt.ref, tt.ok, err)
+			continue
+		}
+		if namespace != tt.namespace || name != tt.name || key != tt.key {
+			t.Errorf("%s: expected %s/%s:%s, got %s/%s:%s", tt.ref, tt.namespace, tt.name, tt.key, namespace, name, key)
+		}
+	}
+}
@@ -20,12 +20,15 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	goprom "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
+	"k8s.io/helm/pkg/logging"
+	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/version"
 )
 
@@ -33,6 +36,10 @@ import (
 // grpc library default is 4MB
 var maxMsgSize = 1024 * 1024 * 20
 
+// Logger receives an entry for every RPC handled by servers created after it
+// is set. If it is nil, RPCs are not logged.
+var Logger *logging.Logger
+
 // DefaultServerOpts returns the set of default grpc ServerOption's that Tiller requires.
 func DefaultServerOpts() []grpc.ServerOption {
 	return []grpc.ServerOption{
@@ -48,6 +55,7 @@ func NewServer(opts ...grpc.ServerOption) *grpc.Server {
 }
 
 func newUnaryInterceptor() grpc.UnaryServerInterceptor {
+	logger := Logger
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		if err := checkClientVersion(ctx); err != nil {
 			// whitelist GetVersion() from the version check
@@ -56,18 +64,61 @@ func newUnaryInterceptor() grpc.UnaryServerInterceptor {
 				return nil, err
 			}
 		}
-		return goprom.UnaryServerInterceptor(ctx, req, info, handler)
+		start := time.Now()
+		resp, err = goprom.UnaryServerInterceptor(ctx, req, info, handler)
+		logRPC(logger, info.FullMethod, req, resp, time.Since(start), err)
+		return resp, err
 	}
 }
 
 func newStreamInterceptor() grpc.StreamServerInterceptor {
+	logger := Logger
 	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		if err := checkClientVersion(ss.Context()); err != nil {
 			log.Println(err)
 			return err
 		}
-		return goprom.StreamServerInterceptor(srv, ss, info, handler)
+		start := time.Now()
+		err := goprom.StreamServerInterceptor(srv, ss, info, handler)
+		logRPC(logger, info.FullMethod, nil, nil, time.Since(start), err)
+		return err
+	}
+}
+
+// logRPC logs a handled RPC with the name and namespace of the release it
+// concerns. Only these identifiers are taken from the request and response,
+// so that values and manifests never reach the log.
+func logRPC(logger *logging.Logger, fullMethod string, req, resp interface{}, d time.Duration, err error) {
+	if logger == nil {
+		return
+	}
+	_, op := splitMethod(fullMethod)
+	fields := logging.Fields{"op": op, "duration": d.String()}
+
+	if r, ok := req.(interface {
+		GetName() string
+	}); ok && r.GetName() != "" {
+		fields["release"] = r.GetName()
+	}
+	if r, ok := req.(interface {
+		GetNamespace() string
+	}); ok && r.GetNamespace() != "" {
+		fields["namespace"] = r.GetNamespace()
+	}
+	if r, ok := resp.(interface {
+		GetRelease() *release.Release
+	}); ok && r.GetRelease() != nil {
+		fields["release"] = r.GetRelease().Name
+		fields["namespace"] = r.GetRelease().Namespace
+	}
+
+	if err != nil {
+		// Errors may quote rendered manifests on following lines.
+		fields["error"] = strings.SplitN(err.Error(), "\n", 2)[0]
+		logger.Log(logging.ErrorLevel, "request failed", fields)
+		return
 	}
+	logger.Log(logging.InfoLevel, "request handled", fields)
 }
 
 func splitMethod(fullMethod string) (string, string) {
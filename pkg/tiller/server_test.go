@@ -0,0 +1,97 @@
+/*
+Copyright 2018 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"k8s.io/helm/pkg/logging"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/version"
+)
+
+func TestUnaryInterceptorLogging(t *testing.T) {
+	var buf bytes.Buffer
+	oldLogger := Logger
+	defer func() { Logger = oldLogger }()
+	Logger = logging.New(&buf, logging.JSONFormat, logging.InfoLevel)
+
+	interceptor := newUnaryInterceptor()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-helm-api-client", version.GetVersion()))
+	info := &grpc.UnaryServerInfo{FullMethod: "/hapi.services.tiller.ReleaseService/InstallRelease"}
+	req := &services.InstallReleaseRequest{
+		Name:      "angry-panda",
+		Namespace: "spaced",
+		Values:    &chart.Config{Raw: "password: hunter2"},
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		rel := releaseStub()
+		rel.Namespace = "spaced"
+		rel.Manifest = "kind: Secret\ndata:\n  password: aHVudGVyMg=="
+		return &services.InstallReleaseResponse{Release: rel}, nil
+	}
+	if _, err := interceptor(ctx, req, info, handler); err != nil {
+		t.Fatal(err)
+	}
+
+	failing := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("YAML parse error\npassword: hunter2")
+	}
+	if _, err := interceptor(ctx, req, info, failing); err == nil {
+		t.Fatal("expected the handler error to be returned")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log entries, got %q", buf.String())
+	}
+
+	expected := []map[string]string{
+		{"level": "info", "op": "InstallRelease", "release": "angry-panda", "namespace": "spaced"},
+		{"level": "error", "op": "InstallRelease", "release": "angry-panda", "namespace": "spaced", "error": "YAML parse error"},
+	}
+	for i, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("failed to decode log entry %q: %s", line, err)
+		}
+		for k, v := range expected[i] {
+			if entry[k] != v {
+				t.Errorf("entry %d: expected %s to be %q, got %v", i, k, v, entry[k])
+			}
+		}
+		if _, ok := entry["duration"]; !ok {
+			t.Errorf("entry %d: expected a duration, got %s", i, line)
+		}
+	}
+
+	for _, s := range []string{"hunter2", "aHVudGVyMg==", "kind: Secret"} {
+		if strings.Contains(buf.String(), s) {
+			t.Errorf("expected %q not to be logged, got %s", s, buf.String())
+		}
+	}
+}
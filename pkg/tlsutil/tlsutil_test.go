@@ -19,6 +19,7 @@ package tlsutil
 import (
 	"crypto/tls"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -54,6 +55,25 @@ func TestClientConfig(t *testing.T) {
 	}
 }
 
+func TestClientConfigWithoutCert(t *testing.T) {
+	opts := Options{
+		CaCertFile: testfile(t, testCaCertFile),
+		ServerName: "tiller-server",
+	}
+
+	cfg, err := ClientConfig(opts)
+	if err != nil {
+		t.Fatalf("error building tls client config: %v", err)
+	}
+
+	if got := len(cfg.Certificates); got != 0 {
+		t.Fatalf("expecting no client certificates, got %d", got)
+	}
+	if cfg.ServerName != "tiller-server" {
+		t.Fatalf("expecting server name %q, got %q", "tiller-server", cfg.ServerName)
+	}
+}
+
 func TestServerConfig(t *testing.T) {
 	opts := Options{
 		CaCertFile: testfile(t, testCaCertFile),
@@ -74,6 +94,84 @@ func TestServerConfig(t *testing.T) {
 	}
 }
 
+func TestServerConfigVersions(t *testing.T) {
+	opts := Options{
+		CertFile: testfile(t, testCertFile),
+		KeyFile:  testfile(t, testKeyFile),
+	}
+	cfg, err := ServerConfig(opts)
+	if err != nil {
+		t.Fatalf("error building tls server config: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.CipherSuites, DefaultCipherSuites) {
+		t.Errorf("expecting the default cipher suites, got %v", cfg.CipherSuites)
+	}
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	tests := []struct {
+		version uint16
+		ok      bool
+	}{
+		{tls.VersionTLS10, false},
+		{tls.VersionTLS11, false},
+		{tls.VersionTLS12, true},
+	}
+	for _, tt := range tests {
+		conn, err := tls.Dial("tcp", lis.Addr().String(), &tls.Config{
+			InsecureSkipVerify: true,
+			MinVersion:         tt.version,
+			MaxVersion:         tt.version,
+		})
+		if err == nil {
+			conn.Close()
+		}
+		if ok := err == nil; ok != tt.ok {
+			t.Errorf("TLS version %x: expecting the connection to succeed to be %t, got error %v", tt.version, tt.ok, err)
+		}
+	}
+}
+
+func TestParseCipherSuites(t *testing.T) {
+	suites, err := ParseCipherSuites("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384}
+	if !reflect.DeepEqual(suites, expect) {
+		t.Errorf("expecting %v, got %v", expect, suites)
+	}
+
+	for _, names := range []string{"TLS_RSA_WITH_RC4_128_SHA", "TLS_NOT_A_SUITE"} {
+		if _, err := ParseCipherSuites(names); err == nil {
+			t.Errorf("expecting an error for %q", names)
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	if v, err := ParseVersion("1.1"); err != nil || v != tls.VersionTLS11 {
+		t.Errorf("expecting TLS 1.1, got %x, %v", v, err)
+	}
+	if _, err := ParseVersion("1.4"); err == nil {
+		t.Error("expecting an error for TLS version 1.4")
+	}
+}
+
 func testfile(t *testing.T, file string) (path string) {
 	var err error
 	if path, err = filepath.Abs(filepath.Join(tlsTestDir, file)); err != nil {